@@ -20,6 +20,7 @@ func adminCommands() []*cobra.Command {
 		adminPlugins(),
 		adminBroadcasts(),
 		adminErrors(),
+		adminObjectStore(),
 	}
 }
 
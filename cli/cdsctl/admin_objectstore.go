@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var adminObjectStoreCmd = cli.Command{
+	Name:  "objectstore",
+	Short: "Manage CDS objectstore",
+}
+
+func adminObjectStore() *cobra.Command {
+	return cli.NewCommand(adminObjectStoreCmd, nil, []*cobra.Command{
+		cli.NewGetCommand(adminObjectStoreMigrateCmd, adminObjectStoreMigrateRun, nil),
+		cli.NewGetCommand(adminObjectStoreStatusCmd, adminObjectStoreStatusRun, nil),
+	})
+}
+
+var adminObjectStoreMigrateCmd = cli.Command{
+	Name:  "migrate",
+	Short: "Copy all artifacts and static files from an objectstore to another one",
+	Long: `Copy all artifacts and static files from an objectstore to another one.
+Objectstores are named primary or mirror. When the destination is the primary objectstore, artifacts and static files are updated to point to their new location.`,
+	Args: []cli.Arg{
+		{Name: "from"},
+		{Name: "to"},
+	},
+	Example: `cdsctl admin objectstore migrate mirror primary`,
+}
+
+func adminObjectStoreMigrateRun(v cli.Values) (interface{}, error) {
+	return client.ObjectStoreMigrationStart(v.GetString("from"), v.GetString("to"))
+}
+
+var adminObjectStoreStatusCmd = cli.Command{
+	Name:  "status",
+	Short: "Show the progress of the last objectstore migration",
+}
+
+func adminObjectStoreStatusRun(v cli.Values) (interface{}, error) {
+	return client.ObjectStoreMigrationStatus()
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/howeyc/gopass"
 	"github.com/spf13/cobra"
@@ -39,6 +40,10 @@ var loginCmd = cli.Command{
 			ShortHand: "p",
 			Usage:     "CDS Password",
 			Kind:      reflect.String,
+		}, {
+			Name:  "oidc",
+			Usage: "Login with the OpenID Connect provider of CDS",
+			Kind:  reflect.Bool,
 		}, {
 			Name:  "env",
 			Usage: "Display the commands to set up the environment for the cds client",
@@ -57,6 +62,16 @@ func loginRun(v cli.Values) error {
 	password := v.GetString("password")
 	env := v.GetBool("env")
 
+	if v.GetBool("oidc") {
+		if env && url == "" {
+			return fmt.Errorf("Please set flags to use --env option")
+		}
+		if !env {
+			fmt.Println("CDS API URL:", url)
+		}
+		return doLoginOIDC(url, env)
+	}
+
 	if env &&
 		(url == "" || username == "" || password == "") {
 		return fmt.Errorf("Please set flags to use --env option")
@@ -111,6 +126,54 @@ func doLogin(url, username, password string, env bool) error {
 		return fmt.Errorf("login failed")
 	}
 
+	return writeLogin(url, username, token, env)
+}
+
+func doLoginOIDC(url string, env bool) error {
+	conf := cdsclient.Config{
+		Host:    url,
+		Verbose: os.Getenv("CDS_VERBOSE") == "true",
+	}
+
+	client = cdsclient.New(conf)
+	device, err := client.UserLoginOIDCDevice()
+	if err != nil {
+		if conf.Verbose {
+			fmt.Fprintf(os.Stderr, "error:%s\n", err)
+		}
+		return fmt.Errorf("Unable to start OIDC login, please check CDS API URL")
+	}
+
+	// Instructions are written on stderr to keep the output of --env usable
+	fmt.Fprintf(os.Stderr, "Open %s and enter the code %s\n", device.VerificationURI, device.UserCode)
+	if device.VerificationURIComplete != "" {
+		fmt.Fprintf(os.Stderr, "or open %s\n", device.VerificationURIComplete)
+	}
+
+	interval := time.Duration(device.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	expiresIn := time.Duration(device.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = 10 * time.Minute
+	}
+
+	for deadline := time.Now().Add(expiresIn); time.Now().Before(deadline); {
+		time.Sleep(interval)
+		res, err := client.UserLoginOIDCDeviceToken(device.DeviceCode)
+		if sdk.ErrorIs(err, sdk.ErrOIDCAuthorizationPending) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("login failed: %v", err)
+		}
+		return writeLogin(url, res.User.Username, res.Token, env)
+	}
+	return fmt.Errorf("login failed: the code has expired")
+}
+
+func writeLogin(url, username, token string, env bool) error {
 	if env && sdk.GOOS == "windows" {
 		fmt.Println("env option is not supported on windows yet")
 		os.Exit(1)
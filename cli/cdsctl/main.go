@@ -35,6 +35,7 @@ func main() {
 		project(),
 		worker(),
 		workflow(),
+		template(),
 		update(),
 		usr(),
 		shell(),
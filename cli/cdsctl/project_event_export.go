@@ -0,0 +1,94 @@
+package main
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var projectEventExportCmd = cli.Command{
+	Name:  "event-export",
+	Short: "Manage CDS project event exports",
+	Long: `Export the events of a project to one of its Kafka, RabbitMQ or Webhook platforms.
+
+	# export workflow run events on the topic my-topic of the Kafka platform my-kafka
+	$ cdsctl project event-export add MY-PROJECT my-kafka --topic my-topic --event-type EventRunWorkflow --event-type EventRunWorkflowNode
+`,
+}
+
+func projectEventExport() *cobra.Command {
+	return cli.NewCommand(projectEventExportCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectEventExportListCmd, projectEventExportListFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectEventExportAddCmd, projectEventExportAddFunc, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(projectEventExportDeleteCmd, projectEventExportDeleteFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectEventExportListCmd = cli.Command{
+	Name:  "list",
+	Short: "List event exports of a project with their delivery status",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func projectEventExportListFunc(v cli.Values) (cli.ListResult, error) {
+	exports, err := client.ProjectEventExportList(v.GetString(_ProjectKey))
+	return cli.AsListResult(exports), err
+}
+
+var projectEventExportAddCmd = cli.Command{
+	Name:  "add",
+	Short: "Export the events of a project to a platform",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "platform"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "topic",
+			Usage: "Kafka topic or RabbitMQ exchange",
+			Kind:  reflect.String,
+		},
+		{
+			Name:  "event-type",
+			Usage: "Export only this type of event, ie. EventRunWorkflow. Can be repeated",
+			Kind:  reflect.Slice,
+		},
+	},
+}
+
+func projectEventExportAddFunc(v cli.Values) error {
+	export := sdk.ProjectEventExport{
+		PlatformName: v.GetString("platform"),
+		Topic:        v.GetString("topic"),
+		EventTypes:   v.GetStringSlice("event-type"),
+		Enabled:      true,
+	}
+	return client.ProjectEventExportAdd(v.GetString(_ProjectKey), &export)
+}
+
+var projectEventExportDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete an event export of a project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+}
+
+func projectEventExportDeleteFunc(v cli.Values) error {
+	id, err := strconv.ParseInt(v.GetString("id"), 10, 64)
+	if err != nil {
+		return err
+	}
+	return client.ProjectEventExportDelete(v.GetString(_ProjectKey), id)
+}
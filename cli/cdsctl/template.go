@@ -0,0 +1,312 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+var templateCmd = cli.Command{
+	Name:  "template",
+	Short: "Manage workflow templates",
+}
+
+func template() *cobra.Command {
+	return cli.NewCommand(templateCmd, nil, []*cobra.Command{
+		cli.NewListCommand(templateListCmd, templateListRun, nil),
+		cli.NewCommand(templatePushCmd, templatePushRun, nil),
+		cli.NewDeleteCommand(templateDeleteCmd, templateDeleteRun, nil),
+		cli.NewCommand(templateApplyCmd, templateApplyRun, nil),
+		cli.NewListCommand(templateInstancesCmd, templateInstancesRun, nil),
+		cli.NewCommand(templateBulkCmd, templateBulkRun, nil),
+	})
+}
+
+// splitTemplatePath returns the group and the name of a template given as group/name
+func splitTemplatePath(path string) (string, string, error) {
+	s := strings.Split(path, "/")
+	if len(s) != 2 || s[0] == "" || s[1] == "" {
+		return "", "", fmt.Errorf("Invalid template path %s, expected group/name", path)
+	}
+	return s[0], s[1], nil
+}
+
+var templateListCmd = cli.Command{
+	Name:  "list",
+	Short: "List workflow templates",
+}
+
+func templateListRun(v cli.Values) (cli.ListResult, error) {
+	wts, err := client.TemplateGetAll()
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(wts), nil
+}
+
+var templatePushCmd = cli.Command{
+	Name:  "push",
+	Short: "Create or update a workflow template from a yaml file",
+	Long: `
+Create or update a workflow template. You must be administrator of the group of the template.
+Each update of a template creates a new version, that can be applied on its instances with the bulk command.
+
+The workflow, pipelines, applications and environments are CDS yaml files where [[ .name ]] is the name
+of the generated workflow, [[ .project ]] the key of its project and [[ .params.key ]] the value of a parameter:
+
+	name: go-service
+	group: my-group
+	parameters:
+	- key: repo
+	  type: string
+	  required: true
+	- key: deploy
+	  type: boolean
+	workflow: |
+	  name: "[[ .name ]]"
+	  version: v1.0
+	  pipeline: build
+	  application: "[[ .name ]]"
+	pipelines:
+	- value: |
+	    version: v1.0
+	    name: build
+	    jobs:
+	    - job: build
+	      steps:
+	      - script: make VERSION={{.cds.version}}
+	`,
+	Example: "cdsctl template push my-template.yml",
+	Args: []cli.Arg{
+		{Name: "filepath"},
+	},
+}
+
+func templatePushRun(v cli.Values) error {
+	reader, format, err := exportentities.OpenFile(v.GetString("filepath"))
+	if err != nil {
+		return fmt.Errorf("Cannot read file %s: %v", v.GetString("filepath"), err)
+	}
+	defer reader.Close()
+	if format != exportentities.FormatYAML {
+		return fmt.Errorf("Invalid file format, yaml is expected")
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(reader); err != nil {
+		return fmt.Errorf("Cannot read file content %s: %v", v.GetString("filepath"), err)
+	}
+
+	var wt sdk.WorkflowTemplate
+	if err := yaml.Unmarshal(buf.Bytes(), &wt); err != nil {
+		return fmt.Errorf("Cannot unmarshal yaml file %s: %v", v.GetString("filepath"), err)
+	}
+	if wt.GroupName == "" {
+		return fmt.Errorf("Group of the template is required")
+	}
+
+	old, err := client.TemplateGet(wt.GroupName, wt.Name)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrWorkflowTemplateNotFound) {
+		return err
+	}
+	if old == nil {
+		if err := client.TemplatePost(&wt); err != nil {
+			return err
+		}
+		fmt.Printf("Template %s/%s created\n", wt.GroupName, wt.Name)
+		return nil
+	}
+
+	if err := client.TemplatePut(wt.GroupName, wt.Name, &wt); err != nil {
+		return err
+	}
+	fmt.Printf("Template %s/%s updated to version %d\n", wt.GroupName, wt.Name, wt.Version)
+	return nil
+}
+
+var templateDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Delete a workflow template",
+	Long:  "Delete a workflow template. The generated workflows are kept but are no longer instances of the template",
+	Args: []cli.Arg{
+		{Name: "template-path"},
+	},
+}
+
+func templateDeleteRun(v cli.Values) error {
+	groupName, templateName, err := splitTemplatePath(v.GetString("template-path"))
+	if err != nil {
+		return err
+	}
+	return client.TemplateDelete(groupName, templateName)
+}
+
+var templateApplyCmd = cli.Command{
+	Name:  "apply",
+	Short: "Generate a workflow from a template",
+	Long: `
+Generate a workflow, with its pipelines, applications and environments, from a template.
+If the workflow already exists, it is updated with the current version of the template.`,
+	Example: `cdsctl template apply MYPROJECT my-service my-group/go-service -p repo=my-org/my-service -p deploy=true`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: _WorkflowName},
+		{Name: "template-path"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:      "param",
+			ShortHand: "p",
+			Usage:     "Parameter of the template as key=value. Can be repeated",
+			Kind:      reflect.Slice,
+		},
+	},
+}
+
+func templateApplyRun(v cli.Values) error {
+	groupName, templateName, err := splitTemplatePath(v.GetString("template-path"))
+	if err != nil {
+		return err
+	}
+
+	req := sdk.WorkflowTemplateRequest{
+		ProjectKey:   v[_ProjectKey],
+		WorkflowName: v[_WorkflowName],
+		Parameters:   make(map[string]string),
+	}
+	for _, p := range v.GetStringSlice("param") {
+		if p == "" {
+			continue
+		}
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("Invalid parameter %s, expected key=value", p)
+		}
+		req.Parameters[kv[0]] = kv[1]
+	}
+
+	msgs, err := client.TemplateApply(groupName, templateName, req)
+	if err != nil {
+		return err
+	}
+	for _, m := range msgs {
+		fmt.Println(m)
+	}
+	fmt.Printf("Workflow %s generated from template %s/%s\n", req.WorkflowName, groupName, templateName)
+	return nil
+}
+
+var templateInstancesCmd = cli.Command{
+	Name:  "instances",
+	Short: "List the workflows generated from a template",
+	Args: []cli.Arg{
+		{Name: "template-path"},
+	},
+}
+
+func templateInstancesRun(v cli.Values) (cli.ListResult, error) {
+	groupName, templateName, err := splitTemplatePath(v.GetString("template-path"))
+	if err != nil {
+		return nil, err
+	}
+	is, err := client.TemplateInstances(groupName, templateName)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(is), nil
+}
+
+var templateBulkCmd = cli.Command{
+	Name:  "bulk",
+	Short: "Apply the last version of a template on its instances",
+	Long: `
+Apply the last version of a template on the workflows generated from it, with the parameters of each instance.
+Use --preview to display the changes without applying them.`,
+	Example: `cdsctl template bulk my-group/go-service --preview
+cdsctl template bulk my-group/go-service --instance 12 --instance 13`,
+	Args: []cli.Arg{
+		{Name: "template-path"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "preview",
+			Usage: "Display the diff of each workflow without applying the template",
+			Kind:  reflect.Bool,
+		},
+		{
+			Name:  "instance",
+			Usage: "ID of an instance to update, all if not given. Can be repeated",
+			Kind:  reflect.Slice,
+		},
+	},
+}
+
+func templateBulkRun(v cli.Values) error {
+	groupName, templateName, err := splitTemplatePath(v.GetString("template-path"))
+	if err != nil {
+		return err
+	}
+
+	var bulk sdk.WorkflowTemplateBulk
+	for _, s := range v.GetStringSlice("instance") {
+		if s == "" {
+			continue
+		}
+		var id int64
+		if _, err := fmt.Sscanf(s, "%d", &id); err != nil {
+			return fmt.Errorf("Invalid instance id %s", s)
+		}
+		bulk.InstanceIDs = append(bulk.InstanceIDs, id)
+	}
+
+	if v.GetBool("preview") {
+		diffs, err := client.TemplatePreview(groupName, templateName, bulk)
+		if err != nil {
+			return err
+		}
+		for _, d := range diffs {
+			fmt.Printf("# %s/%s (instance %d, version %d)\n", d.Instance.ProjectKey, d.Instance.WorkflowName, d.Instance.ID, d.Instance.WorkflowTemplateVersion)
+			switch {
+			case d.Error != "":
+				fmt.Printf("Error: %s\n", d.Error)
+			case d.Diff == "":
+				fmt.Println("No changes")
+			default:
+				fmt.Print(d.Diff)
+			}
+			if d.VersionDiff != "" {
+				fmt.Printf("# Changes of the template since version %d\n", d.Instance.WorkflowTemplateVersion)
+				fmt.Print(d.VersionDiff)
+			}
+		}
+		return nil
+	}
+
+	res, err := client.TemplateBulk(groupName, templateName, bulk)
+	if err != nil {
+		return err
+	}
+	var failed int
+	for _, r := range res {
+		if r.Error != "" {
+			failed++
+			fmt.Printf("%s/%s: %s\n", r.Instance.ProjectKey, r.Instance.WorkflowName, r.Error)
+			continue
+		}
+		fmt.Printf("%s/%s: updated to version %d\n", r.Instance.ProjectKey, r.Instance.WorkflowName, r.Instance.WorkflowTemplateVersion)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d workflows were not updated", failed, len(res))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var userTokenCmd = cli.Command{
+	Name:  "token",
+	Short: "Manage your personal access tokens",
+}
+
+func userToken() *cobra.Command {
+	return cli.NewCommand(userTokenCmd, nil, []*cobra.Command{
+		cli.NewListCommand(userTokenListCmd, userTokenListRun, nil),
+		cli.NewGetCommand(userTokenCreateCmd, userTokenCreateRun, nil),
+		cli.NewDeleteCommand(userTokenDeleteCmd, userTokenDeleteRun, nil),
+	})
+}
+
+var userTokenListCmd = cli.Command{
+	Name:  "list",
+	Short: "List your personal access tokens",
+}
+
+func userTokenListRun(v cli.Values) (cli.ListResult, error) {
+	tokens, err := client.UserAccessTokenList()
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(tokens), nil
+}
+
+var userTokenCreateCmd = cli.Command{
+	Name:  "create",
+	Short: "Create a personal access token",
+	Long: fmt.Sprintf(`
+Create a personal access token to use the API in scripts on your behalf.
+
+The scopes limit what the token can do: %s.
+The read scope only allows to read, run allows to start workflows, project allows to manage projects
+and admin keeps your administrator rights.
+
+The token value is only displayed once, it can be used as CDS_TOKEN or as a bearer token:
+
+	$ cdsctl user token create my-script --scope run --project MYPROJ --expire-in 30
+	`, strings.Join(sdk.AccessTokenScopes, ", ")),
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "scope",
+			Usage: "Scope of the token. Can be repeated",
+			Kind:  reflect.Slice,
+		},
+		{
+			Name:  "project",
+			Usage: "Restrict the token to this project. Can be repeated",
+			Kind:  reflect.Slice,
+		},
+		{
+			Name:    "expire-in",
+			Usage:   "Number of days before the expiration of the token",
+			Default: "30",
+			Kind:    reflect.String,
+		},
+	},
+}
+
+func userTokenCreateRun(v cli.Values) (interface{}, error) {
+	days, err := v.GetInt64("expire-in")
+	if err != nil {
+		return nil, err
+	}
+	t := sdk.AccessToken{
+		Name:     v.GetString("name"),
+		Scopes:   v.GetStringSlice("scope"),
+		Projects: v.GetStringSlice("project"),
+		ExpireAt: time.Now().Add(time.Duration(days) * 24 * time.Hour),
+	}
+	if err := client.UserAccessTokenCreate(&t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+var userTokenDeleteCmd = cli.Command{
+	Name:  "delete",
+	Short: "Revoke a personal access token",
+	Args: []cli.Arg{
+		{Name: "id"},
+	},
+}
+
+func userTokenDeleteRun(v cli.Values) error {
+	id, err := strconv.ParseInt(v.GetString("id"), 10, 64)
+	if err != nil {
+		return err
+	}
+	return client.UserAccessTokenDelete(id)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowApproveCmd = cli.Command{
+	Name:  "approve",
+	Short: "Approve or reject a workflow node run waiting for approval",
+	Long:  "Approve or reject a workflow node run waiting for approval. The node run is executed once it has enough approvals, or stopped if it's rejected",
+	Example: `cdsctl workflow approve MYPROJECT myworkflow 5 deploy-prod # To approve the node run deploy-prod of the workflow run 5
+cdsctl workflow approve MYPROJECT myworkflow 5 deploy-prod --reject --comment "not during the freeze"
+	`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "run-number"},
+		{Name: "node-name"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "reject",
+			Usage: "Reject the node run instead of approving it",
+			Kind:  reflect.Bool,
+		},
+		{
+			Name:      "comment",
+			ShortHand: "c",
+			Usage:     "Comment recorded with the approval",
+			Kind:      reflect.String,
+		},
+	},
+}
+
+func workflowApproveRun(v cli.Values) error {
+	runNumber, err := v.GetInt64("run-number")
+	if err != nil {
+		return err
+	}
+
+	wr, err := client.WorkflowRunGet(v[_ProjectKey], v[_WorkflowName], runNumber)
+	if err != nil {
+		return err
+	}
+
+	var nodeRunID int64
+	for _, wnrs := range wr.WorkflowNodeRuns {
+		if wnrs[0].WorkflowNodeName == v.GetString("node-name") {
+			nodeRunID = wnrs[0].ID
+			break
+		}
+	}
+	if nodeRunID == 0 {
+		return fmt.Errorf("Node not found")
+	}
+
+	req := sdk.WorkflowNodeRunApprovalRequest{
+		Approved: !v.GetBool("reject"),
+		Comment:  v.GetString("comment"),
+	}
+	nodeRun, err := client.WorkflowNodeApproval(v[_ProjectKey], v[_WorkflowName], runNumber, nodeRunID, req)
+	if err != nil {
+		return err
+	}
+
+	if req.Approved {
+		fmt.Printf("Workflow node %s from workflow %s #%d has been approved, status: %s\n", v.GetString("node-name"), v[_WorkflowName], nodeRun.Number, nodeRun.Status)
+	} else {
+		fmt.Printf("Workflow node %s from workflow %s #%d has been rejected\n", v.GetString("node-name"), v[_WorkflowName], nodeRun.Number)
+	}
+	return nil
+}
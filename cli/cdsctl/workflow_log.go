@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -18,7 +20,16 @@ var workflowLogCmd = cli.Command{
 	Name:    "logs",
 	Aliases: []string{"log"},
 	Short:   "Manage CDS Workflow Run Logs",
-	Long: `Download logs from a workflow run.
+	Long: `Display or download logs from a workflow run.
+
+	# display all logs of latest run
+	$ cdsctl workflow logs KEY WF
+
+	# follow logs of running jobs on latest run until the run is over
+	$ cdsctl workflow logs KEY WF --follow
+
+	# follow logs of jobs named MyJob on run number 1
+	$ cdsctl workflow logs KEY WF 1 --follow --pattern="MyJob"
 
 	# list all logs files on latest run
 	$ cdsctl workflow logs list KEY WF
@@ -34,13 +45,39 @@ var workflowLogCmd = cli.Command{
 	# this will download file WF-1.0-pipeline.myPipeline-stage.MyStage-job.MyJob-status.Success-step.0.log
 
 `,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	OptionalArgs: []cli.Arg{
+		{
+			Name: "run-number",
+			IsValid: func(s string) bool {
+				match, _ := regexp.MatchString(`[0-9]?`, s)
+				return match
+			},
+			Weight: 1,
+		},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "pattern",
+			Usage: "Filter on log filename",
+			Kind:  reflect.String,
+		},
+		{
+			Name:  "follow",
+			Usage: "Stream logs of running jobs until the run is over",
+			Kind:  reflect.Bool,
+		},
+	},
 }
 
 func workflowLog() *cobra.Command {
-	return cli.NewCommand(workflowLogCmd, nil, []*cobra.Command{
+	return cli.NewCommand(workflowLogCmd, workflowLogRun, []*cobra.Command{
 		cli.NewCommand(workflowLogListCmd, workflowLogListRun, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowLogDownloadCmd, workflowLogDownloadRun, nil, withAllCommandModifiers()...),
-	})
+	}, withAllCommandModifiers()...)
 }
 
 var workflowLogListCmd = cli.Command{
@@ -120,6 +157,7 @@ type workflowLogDetail struct {
 	pipelineName string
 	stageName    string
 	status       string
+	stepStatus   string
 	jobName      string
 	runID        int64
 	jobID        int64
@@ -141,6 +179,10 @@ func (w workflowLogDetail) getFilename() string {
 	)
 }
 
+func (w workflowLogDetail) getTitle() string {
+	return fmt.Sprintf("%s > %s > %s > step %d", w.pipelineName, w.stageName, w.jobName, w.stepOrder)
+}
+
 func workflowLogProcess(wr *sdk.WorkflowRun) []workflowLogDetail {
 	logs := []workflowLogDetail{}
 	for _, noderuns := range wr.WorkflowNodeRuns {
@@ -157,6 +199,7 @@ func workflowLogProcess(wr *sdk.WorkflowRun) []workflowLogDetail {
 								jobName:      job.Job.Job.Action.Name,
 								jobID:        job.ID,
 								status:       job.Status,
+								stepStatus:   step.Status,
 								stepOrder:    step.StepOrder,
 								runID:        node.ID,
 								number:       wr.Number,
@@ -262,3 +305,85 @@ func workflowLogDownloadRun(v cli.Values) error {
 	}
 	return nil
 }
+
+func workflowLogRun(v cli.Values) error {
+	runNumber, err := workflowLogSearchNumber(v)
+	if err != nil {
+		return err
+	}
+
+	var reg *regexp.Regexp
+	if v.GetString("pattern") != "" {
+		var errp error
+		reg, errp = regexp.Compile(v.GetString("pattern"))
+		if errp != nil {
+			return fmt.Errorf("Invalid pattern %s: %v", v.GetString("pattern"), errp)
+		}
+	}
+
+	follow := v.GetBool("follow")
+	displayed := map[string]bool{}
+	for {
+		wr, err := client.WorkflowRunGet(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber)
+		if err != nil {
+			return err
+		}
+
+		for _, log := range workflowLogProcess(wr) {
+			key := fmt.Sprintf("%d-%d-%d", log.runID, log.jobID, log.stepOrder)
+			if displayed[key] || (reg != nil && !reg.MatchString(log.getFilename())) {
+				continue
+			}
+
+			switch {
+			case !follow || sdk.StatusIsTerminated(log.stepStatus):
+				buildState, err := client.WorkflowNodeRunJobStep(v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber, log.runID, log.jobID, log.stepOrder)
+				if err != nil {
+					return err
+				}
+				if buildState.StepLogs.Val != "" {
+					fmt.Println(cli.Magenta(log.getTitle()))
+					fmt.Print(buildState.StepLogs.Val)
+				}
+			case log.stepStatus == sdk.StatusBuilding.String():
+				fmt.Println(cli.Magenta(log.getTitle()))
+				if err := workflowLogFollowStep(v, runNumber, log); err != nil {
+					return err
+				}
+			default:
+				// The step has not started yet
+				continue
+			}
+			displayed[key] = true
+		}
+
+		if !follow || sdk.StatusIsTerminated(wr.Status) {
+			return nil
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// workflowLogFollowStep prints logs of a running step until the step is over
+func workflowLogFollowStep(v cli.Values, runNumber int64, log workflowLogDetail) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msgs := make(chan sdk.StepLogsMessage)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- client.WorkflowNodeRunJobStepLogsStream(ctx, v.GetString(_ProjectKey), v.GetString(_WorkflowName), runNumber, log.runID, log.jobID, log.stepOrder, msgs)
+	}()
+
+	for {
+		select {
+		case msg := <-msgs:
+			fmt.Print(msg.Val)
+			if msg.Status != "" {
+				fmt.Println(cli.Magenta(fmt.Sprintf("%s: %s", log.getTitle(), msg.Status)))
+			}
+		case err := <-errs:
+			return err
+		}
+	}
+}
@@ -38,8 +38,8 @@ func InsertAction(tx gorp.SqlExecutor, a *sdk.Action, public bool) error {
 		return sdk.ErrActionLoop
 	}
 
-	query := `INSERT INTO action (name, description, type, enabled, deprecated, public) VALUES($1, $2, $3, $4, $5, $6) RETURNING id`
-	if err := tx.QueryRow(query, a.Name, a.Description, a.Type, a.Enabled, a.Deprecated, public).Scan(&a.ID); err != nil {
+	query := `INSERT INTO action (name, description, type, enabled, deprecated, public, timeout) VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	if err := tx.QueryRow(query, a.Name, a.Description, a.Type, a.Enabled, a.Deprecated, public, a.Timeout).Scan(&a.ID); err != nil {
 		return err
 	}
 
@@ -115,7 +115,7 @@ func InsertAction(tx gorp.SqlExecutor, a *sdk.Action, public bool) error {
 
 // LoadPublicAction load an action from database
 func LoadPublicAction(db gorp.SqlExecutor, name string) (*sdk.Action, error) {
-	query := `SELECT id, name, description, type, last_modified, enabled, deprecated FROM action WHERE lower(action.name) = lower($1) AND public = true`
+	query := `SELECT id, name, description, type, last_modified, enabled, deprecated, timeout FROM action WHERE lower(action.name) = lower($1) AND public = true`
 	a, err := loadActions(db, query, name)
 	if err != nil {
 		return nil, err
@@ -125,7 +125,7 @@ func LoadPublicAction(db gorp.SqlExecutor, name string) (*sdk.Action, error) {
 
 // LoadActionByID retrieves in database the action with given id
 func LoadActionByID(db gorp.SqlExecutor, actionID int64) (*sdk.Action, error) {
-	query := `SELECT id, name, description, type, last_modified, enabled, deprecated FROM action WHERE action.id = $1`
+	query := `SELECT id, name, description, type, last_modified, enabled, deprecated, timeout FROM action WHERE action.id = $1`
 	a, err := loadActions(db, query, actionID)
 	if err != nil {
 		return nil, err
@@ -135,7 +135,7 @@ func LoadActionByID(db gorp.SqlExecutor, actionID int64) (*sdk.Action, error) {
 
 // LoadActions load all actions from database
 func LoadActions(db gorp.SqlExecutor) ([]sdk.Action, error) {
-	query := `SELECT id, name, description, type, last_modified, enabled, deprecated FROM action WHERE public = true ORDER BY name`
+	query := `SELECT id, name, description, type, last_modified, enabled, deprecated, timeout FROM action WHERE public = true ORDER BY name`
 	return loadActions(db, query)
 }
 
@@ -153,13 +153,15 @@ func loadActions(db gorp.SqlExecutor, query string, args ...interface{}) ([]sdk.
 	for rows.Next() {
 		a := sdk.Action{}
 		var lastModified time.Time
-		if err := rows.Scan(&a.ID, &a.Name, &a.Description, &a.Type, &lastModified, &a.Enabled, &a.Deprecated); err != nil {
+		var timeout sql.NullInt64
+		if err := rows.Scan(&a.ID, &a.Name, &a.Description, &a.Type, &lastModified, &a.Enabled, &a.Deprecated, &timeout); err != nil {
 			if err == sql.ErrNoRows {
 				return nil, sdk.ErrNoAction
 			}
 			return nil, fmt.Errorf("cannot Scan> %s", err)
 		}
 		a.LastModified = lastModified.Unix()
+		a.Timeout = timeout.Int64
 		acts = append(acts, a)
 	}
 
@@ -265,8 +267,8 @@ func UpdateActionDB(db gorp.SqlExecutor, a *sdk.Action, userID int64) error {
 		}
 	}
 
-	query := `UPDATE action SET name=$1, description=$2, type=$3, enabled=$4, deprecated=$5 WHERE id=$6`
-	_, errdb := db.Exec(query, a.Name, a.Description, string(a.Type), a.Enabled, a.Deprecated, a.ID)
+	query := `UPDATE action SET name=$1, description=$2, type=$3, enabled=$4, deprecated=$5, timeout=$6 WHERE id=$7`
+	_, errdb := db.Exec(query, a.Name, a.Description, string(a.Type), a.Enabled, a.Deprecated, a.Timeout, a.ID)
 	return errdb
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/migrate"
 	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
@@ -161,3 +162,28 @@ func putPostAdminServiceCallHandler(api *API, method string) service.Handler {
 		return service.Write(w, btes, code, "application/json")
 	}
 }
+
+func (api *API) getAdminObjectStoreMigrationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		m, err := migrate.ObjectStoreMigrationStatus(api.Cache)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, m, http.StatusOK)
+	}
+}
+
+func (api *API) postAdminObjectStoreMigrationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var m sdk.ObjectStoreMigration
+		if err := service.UnmarshalBody(r, &m); err != nil {
+			return err
+		}
+
+		started, err := migrate.StartObjectStoreMigration(api.Router.Background, api.Cache, api.DBConnectionFactory.GetDBMap, m.From, m.To, api.PanicDump())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, started, http.StatusAccepted)
+	}
+}
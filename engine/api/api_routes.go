@@ -19,6 +19,10 @@ func (api *API) InitRouter() {
 
 	r := api.Router
 	r.Handle("/login", r.POST(api.loginUserHandler, Auth(false)))
+	r.Handle("/login/oidc", r.GET(api.getLoginOIDCHandler, Auth(false)))
+	r.Handle("/login/oidc/callback", r.POST(api.postLoginOIDCCallbackHandler, Auth(false)))
+	r.Handle("/login/oidc/device", r.POST(api.postLoginOIDCDeviceHandler, Auth(false)))
+	r.Handle("/login/oidc/device/token", r.POST(api.postLoginOIDCDeviceTokenHandler, Auth(false)))
 
 	log.Info("Initializing Events broker")
 	// Initialize event broker
@@ -44,6 +48,7 @@ func (api *API) InitRouter() {
 	// Admin
 	r.Handle("/admin/maintenance", r.POST(api.postMaintenanceHandler, NeedAdmin(true)))
 	r.Handle("/admin/warning", r.DELETE(api.adminTruncateWarningsHandler, NeedAdmin(true)))
+	r.Handle("/admin/objectstore/migration", r.GET(api.getAdminObjectStoreMigrationHandler, NeedAdmin(true)), r.POST(api.postAdminObjectStoreMigrationHandler, NeedAdmin(true)))
 	r.Handle("/admin/debug", r.GET(api.getProfileIndexHandler, Auth(false)))
 	r.Handle("/admin/debug/trace", r.POST(api.getTraceHandler, NeedAdmin(true)), r.GET(api.getTraceHandler, NeedAdmin(true)))
 	r.Handle("/admin/debug/cpu", r.POST(api.getCPUProfileHandler, NeedAdmin(true)), r.GET(api.getCPUProfileHandler, NeedAdmin(true)))
@@ -124,6 +129,8 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{permProjectKey}/applications", r.GET(api.getApplicationsHandler, AllowProvider(true)), r.POST(api.addApplicationHandler))
 	r.Handle("/project/{permProjectKey}/platforms", r.GET(api.getProjectPlatformsHandler), r.POST(api.postProjectPlatformHandler))
 	r.Handle("/project/{permProjectKey}/platforms/{platformName}", r.GET(api.getProjectPlatformHandler, AllowServices(true)), r.PUT(api.putProjectPlatformHandler), r.DELETE(api.deleteProjectPlatformHandler))
+	r.Handle("/project/{permProjectKey}/events/export", r.GET(api.getProjectEventExportsHandler), r.POST(api.postProjectEventExportHandler))
+	r.Handle("/project/{permProjectKey}/events/export/{id}", r.GET(api.getProjectEventExportHandler), r.PUT(api.putProjectEventExportHandler), r.DELETE(api.deleteProjectEventExportHandler))
 	r.Handle("/project/{permProjectKey}/notifications", r.GET(api.getProjectNotificationsHandler, DEPRECATED))
 	r.Handle("/project/{permProjectKey}/all/keys", r.GET(api.getAllKeysProjectHandler))
 	r.Handle("/project/{permProjectKey}/keys", r.GET(api.getKeysInProjectHandler), r.POST(api.addKeyInProjectHandler))
@@ -259,10 +266,12 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/artifacts", r.GET(api.getWorkflowRunArtifactsHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}", r.GET(api.getWorkflowNodeRunHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/stop", r.POSTEXECUTE(api.stopWorkflowNodeRunHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/approval", r.POSTEXECUTE(api.approveWorkflowNodeRunHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeID}/history", r.GET(api.getWorkflowNodeRunHistoryHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/{nodeName}/commits", r.GET(api.getWorkflowCommitsHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/log/service", r.GET(api.getWorkflowNodeRunJobServiceLogsHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/step/{stepOrder}", r.GET(api.getWorkflowNodeRunJobStepHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/step/{stepOrder}/stream", r.GET(api.getWorkflowNodeRunJobStepLogsStreamHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/artifact/{artifactId}", r.GET(api.getDownloadArtifactHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/node/{nodeID}/triggers/condition", r.GET(api.getWorkflowTriggerConditionHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/release", r.POST(api.releaseApplicationWorkflowHandler))
@@ -346,6 +355,11 @@ func (api *API) InitRouter() {
 	r.Handle("/queue/workflows/{permID}/artifact/{ref}", r.POSTEXECUTE(api.postWorkflowJobArtifactHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permID}/artifact/{ref}/url", r.POSTEXECUTE(api.postWorkflowJobArtifacWithTempURLHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permID}/artifact/{ref}/url/callback", r.POSTEXECUTE(api.postWorkflowJobArtifactWithTempURLCallbackHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permID}/artifact/{ref}/upload", r.POSTEXECUTE(api.postWorkflowJobArtifactUploadHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permID}/artifact/{ref}/upload/{uploadID}", r.POSTEXECUTE(api.postWorkflowJobArtifactUploadChunkHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permID}/artifact/{ref}/upload/{uploadID}/url", r.POSTEXECUTE(api.postWorkflowJobArtifactUploadChunkURLHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permID}/artifact/{ref}/upload/{uploadID}/url/callback", r.POSTEXECUTE(api.postWorkflowJobArtifactUploadChunkURLCallbackHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permID}/artifact/{ref}/upload/{uploadID}/complete", r.POSTEXECUTE(api.postWorkflowJobArtifactUploadCompleteHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permID}/staticfiles/{name}", r.POSTEXECUTE(api.postWorkflowJobStaticFilesHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permID}/staticfiles/{name}/url", r.POSTEXECUTE(api.postWorkflowJobStaticFilesWithTempURLHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permID}/staticfiles/{name}/url/callback", r.POSTEXECUTE(api.postWorkflowJobStaticFilesWithTempURLCallbackHandler, NeedWorker(), EnableTracing(), MaintenanceAware()))
@@ -386,6 +400,7 @@ func (api *API) InitRouter() {
 
 	// config
 	r.Handle("/config/user", r.GET(api.ConfigUserHandler, Auth(true)))
+	r.Handle("/config/vcsgerrit", r.GET(api.getVCSGerritConfigurationHandler, NeedService()))
 
 	// Users
 	r.Handle("/user", r.GET(api.getUsersHandler))
@@ -396,10 +411,13 @@ func (api *API) InitRouter() {
 	r.Handle("/user/timeline/filter", r.GET(api.getTimelineFilterHandler), r.POST(api.postTimelineFilterHandler))
 	r.Handle("/user/token", r.GET(api.getUserTokenListHandler))
 	r.Handle("/user/token/{token}", r.GET(api.getUserTokenHandler))
+	r.Handle("/user/accesstoken", r.GET(api.getUserAccessTokensHandler), r.POST(api.postUserAccessTokenHandler))
+	r.Handle("/user/accesstoken/{id}", r.DELETE(api.deleteUserAccessTokenHandler))
 	r.Handle("/user/signup", r.POST(api.addUserHandler, Auth(false)))
 	r.Handle("/user/import", r.POST(api.importUsersHandler, NeedAdmin(true)))
 	r.Handle("/user/{username}", r.GET(api.getUserHandler, NeedUsernameOrAdmin(true)), r.PUT(api.updateUserHandler, NeedUsernameOrAdmin(true)), r.DELETE(api.deleteUserHandler, NeedUsernameOrAdmin(true)))
 	r.Handle("/user/{username}/groups", r.GET(api.getUserGroupsHandler, NeedUsernameOrAdmin(true)))
+	r.Handle("/user/{username}/oidc", r.POST(api.postUserOIDCLinkHandler, NeedAdmin(true)), r.DELETE(api.deleteUserOIDCLinkHandler, NeedAdmin(true)))
 	r.Handle("/user/{username}/confirm/{token}", r.GET(api.confirmUserHandler, Auth(false)))
 	r.Handle("/user/{username}/reset", r.POST(api.resetUserHandler, Auth(false)))
 	r.Handle("/auth/mode", r.GET(api.authModeHandler, Auth(false)))
@@ -424,6 +442,15 @@ func (api *API) InitRouter() {
 	r.Handle("/worker/model/{permModelID}", r.PUT(api.updateWorkerModelHandler), r.DELETE(api.deleteWorkerModelHandler))
 	r.Handle("/worker/model/capability/type", r.GET(api.getRequirementTypesHandler))
 
+	// Workflow templates
+	r.Handle("/template", r.GET(api.getWorkflowTemplatesHandler), r.POST(api.postWorkflowTemplateHandler))
+	r.Handle("/template/{groupName}/{templateName}", r.GET(api.getWorkflowTemplateHandler), r.PUT(api.putWorkflowTemplateHandler), r.DELETE(api.deleteWorkflowTemplateHandler))
+	r.Handle("/template/{groupName}/{templateName}/apply", r.POST(api.postApplyWorkflowTemplateHandler))
+	r.Handle("/template/{groupName}/{templateName}/instance", r.GET(api.getWorkflowTemplateInstancesHandler))
+	r.Handle("/template/{groupName}/{templateName}/version/{version}", r.GET(api.getWorkflowTemplateVersionHandler))
+	r.Handle("/template/{groupName}/{templateName}/preview", r.POST(api.postWorkflowTemplatePreviewHandler))
+	r.Handle("/template/{groupName}/{templateName}/bulk", r.POST(api.postWorkflowTemplateBulkHandler))
+
 	// Workflows
 	r.Handle("/workflow/hook", r.GET(api.getWorkflowHooksHandler, NeedService()))
 	r.Handle("/workflow/hook/model/{model}", r.GET(api.getWorkflowHookModelHandler), r.POST(api.postWorkflowHookModelHandler, NeedAdmin(true)), r.PUT(api.putWorkflowHookModelHandler, NeedAdmin(true)))
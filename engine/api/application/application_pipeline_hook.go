@@ -66,7 +66,7 @@ func TriggerPipeline(tx gorp.SqlExecutor, store cache.Store, h sdk.Hook, branch
 	// Get commit message to check if we have to skip the build
 	if a.VCSServer != "" {
 		vcsServer := repositoriesmanager.GetProjectVCSServer(projectData, a.VCSServer)
-		client, _ := repositoriesmanager.AuthorizedClient(context.Background(), tx, store, vcsServer)
+		client, _ := repositoriesmanager.AuthorizedClient(context.Background(), tx, store, projectData.Key, vcsServer)
 		if client != nil {
 			commit, err := client.Commit(context.Background(), a.RepositoryFullname, hash)
 			if err != nil {
@@ -211,6 +211,10 @@ func InsertVariable(db gorp.SqlExecutor, store cache.Store, app *sdk.Application
 		return sdk.NewError(sdk.ErrInvalidName, fmt.Errorf("Invalid variable name. It should match %s", sdk.NamePattern))
 	}
 
+	if err := sdk.CheckVaultVariable(variable); err != nil {
+		return err
+	}
+
 	if sdk.NeedPlaceholder(variable.Type) && variable.Value == sdk.PasswordPlaceholder {
 		return fmt.Errorf("You try to insert a placeholder for new variable %s", variable.Name)
 	}
@@ -254,6 +258,10 @@ func UpdateVariable(db gorp.SqlExecutor, store cache.Store, app *sdk.Application
 		return sdk.NewError(sdk.ErrInvalidName, fmt.Errorf("Invalid variable name. It should match %s", sdk.NamePattern))
 	}
 
+	if err := sdk.CheckVaultVariable(*variable); err != nil {
+		return err
+	}
+
 	if sdk.NeedPlaceholder(variable.Type) && variable.Value == sdk.PasswordPlaceholder {
 		variable.Value = variableBefore.Value
 	}
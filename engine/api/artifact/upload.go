@@ -0,0 +1,170 @@
+package artifact
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// uploadChunk is a chunk of an artifact upload, stored next to the artifact in the objectstore
+type uploadChunk struct {
+	upload *sdk.WorkflowNodeRunArtifactUpload
+	index  int
+}
+
+func (c uploadChunk) GetName() string {
+	return fmt.Sprintf("%s.upload-%s.%d", c.upload.Artifact.GetName(), c.upload.ID, c.index)
+}
+
+func (c uploadChunk) GetPath() string {
+	return c.upload.Artifact.GetPath()
+}
+
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// StoreUploadChunk stores the chunk of an upload starting at offset and updates the state of the upload
+func StoreUploadChunk(u *sdk.WorkflowNodeRunArtifactUpload, offset int64, data io.Reader) error {
+	if offset != u.Offset {
+		return sdk.NewErrorFrom(sdk.ErrConflict, "invalid offset %d, expected %d", offset, u.Offset)
+	}
+
+	// Read one more byte than allowed to detect oversized chunks
+	r := &countingReader{Reader: io.LimitReader(data, sdk.ArtifactUploadChunkSize+1)}
+	chunk := uploadChunk{upload: u, index: len(u.Chunks)}
+	if _, err := objectstore.Store(chunk, ioutil.NopCloser(r)); err != nil {
+		return sdk.WrapError(err, "cannot store chunk %d of artifact %s", chunk.index, u.Artifact.Name)
+	}
+	if r.n > sdk.ArtifactUploadChunkSize || u.Offset+r.n > u.Artifact.Size {
+		_ = objectstore.Delete(chunk)
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "chunk %d of artifact %s is too large", chunk.index, u.Artifact.Name)
+	}
+
+	u.Chunks = append(u.Chunks, r.n)
+	u.Offset += r.n
+	return nil
+}
+
+// UploadChunkURL returns a temporary url to store the chunk of an upload starting at offset, for objectstores supporting temporary urls
+func UploadChunkURL(u *sdk.WorkflowNodeRunArtifactUpload, offset int64) (string, error) {
+	if offset != u.Offset {
+		return "", sdk.NewErrorFrom(sdk.ErrConflict, "invalid offset %d, expected %d", offset, u.Offset)
+	}
+	store, ok := objectstore.Storage().(objectstore.DriverWithRedirect)
+	if !ok || !objectstore.Instance().TemporaryURLSupported {
+		return "", sdk.NewErrorFrom(sdk.ErrForbidden, "objectstore does not support temporary urls")
+	}
+	url, _, err := store.StoreURL(uploadChunk{upload: u, index: len(u.Chunks)})
+	if err != nil {
+		return "", sdk.WrapError(err, "cannot get temporary url of chunk %d of artifact %s", len(u.Chunks), u.Artifact.Name)
+	}
+	return url, nil
+}
+
+// AddUploadChunk updates the state of an upload with a chunk stored through a temporary url.
+// The size of the chunk is the one given by the worker, the checksum of the reassembled artifact is checked at the end of the upload.
+func AddUploadChunk(u *sdk.WorkflowNodeRunArtifactUpload, offset, size int64) error {
+	if offset != u.Offset {
+		return sdk.NewErrorFrom(sdk.ErrConflict, "invalid offset %d, expected %d", offset, u.Offset)
+	}
+	if size <= 0 || size > sdk.ArtifactUploadChunkSize || u.Offset+size > u.Artifact.Size {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid size %d of chunk %d of artifact %s", size, len(u.Chunks), u.Artifact.Name)
+	}
+	u.Chunks = append(u.Chunks, size)
+	u.Offset += size
+	return nil
+}
+
+// chunksReader reads the chunks of an upload one after the other
+type chunksReader struct {
+	upload  *sdk.WorkflowNodeRunArtifactUpload
+	index   int
+	current io.ReadCloser
+}
+
+func (r *chunksReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if r.index >= len(r.upload.Chunks) {
+				return 0, io.EOF
+			}
+			c, err := objectstore.Fetch(uploadChunk{upload: r.upload, index: r.index})
+			if err != nil {
+				return 0, sdk.WrapError(err, "cannot fetch chunk %d of artifact %s", r.index, r.upload.Artifact.Name)
+			}
+			r.current = c
+		}
+
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close() // nolint
+			r.current = nil
+			r.index++
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (r *chunksReader) Close() error {
+	if r.current != nil {
+		return r.current.Close()
+	}
+	return nil
+}
+
+// CompleteUpload stores the artifact reassembled from the chunks of an upload and checks its size and SHA512sum,
+// as the sizes of the chunks stored through temporary urls are the ones given by the worker
+func CompleteUpload(u *sdk.WorkflowNodeRunArtifactUpload) error {
+	if u.Offset != u.Artifact.Size {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "artifact %s is incomplete: %d bytes received on %d", u.Artifact.Name, u.Offset, u.Artifact.Size)
+	}
+	if u.Artifact.SHA512sum == "" {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing checksum of artifact %s", u.Artifact.Name)
+	}
+
+	h := sha512.New()
+	chunks := &chunksReader{upload: u}
+	r := &countingReader{Reader: chunks}
+	err := SaveWorkflowFile(&u.Artifact, ioutil.NopCloser(io.TeeReader(r, h)))
+	chunks.Close() // nolint
+	if err != nil {
+		return err
+	}
+
+	if r.n != u.Artifact.Size {
+		_ = objectstore.Delete(&u.Artifact)
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid size of artifact %s: %d bytes stored on %d", u.Artifact.Name, r.n, u.Artifact.Size)
+	}
+	if hex.EncodeToString(h.Sum(nil)) != u.Artifact.SHA512sum {
+		_ = objectstore.Delete(&u.Artifact)
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid checksum of artifact %s", u.Artifact.Name)
+	}
+	return nil
+}
+
+// DeleteUploadChunks removes the chunks of an upload from the objectstore
+func DeleteUploadChunks(u *sdk.WorkflowNodeRunArtifactUpload) {
+	for i := range u.Chunks {
+		if err := objectstore.Delete(uploadChunk{upload: u, index: i}); err != nil {
+			log.Warning("DeleteUploadChunks> cannot delete chunk %d of artifact %s: %v", i, u.Artifact.Name, err)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestUpload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cds-artifact-")
+	if err != nil {
+		t.Fatalf("unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir) // nolint
+
+	if err := objectstore.Initialize(context.Background(), objectstore.Config{
+		Kind:    objectstore.Filesystem,
+		Options: objectstore.ConfigOptions{Filesystem: objectstore.ConfigOptionsFilesystem{Basedir: dir}},
+	}); err != nil {
+		t.Fatalf("unable to initialize objectstore: %v", err)
+	}
+
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	sum := sha512.Sum512(content)
+	u := &sdk.WorkflowNodeRunArtifactUpload{
+		ID: sdk.UUID(),
+		Artifact: sdk.WorkflowNodeRunArtifact{
+			Name:              "file.txt",
+			Tag:               "1",
+			WorkflowID:        1,
+			WorkflowNodeRunID: 1,
+			Size:              int64(len(content)),
+			SHA512sum:         hex.EncodeToString(sum[:]),
+		},
+	}
+
+	assert.NoError(t, StoreUploadChunk(u, 0, bytes.NewReader(content[:600])))
+	assert.Equal(t, int64(600), u.Offset)
+
+	// A chunk sent twice is rejected
+	err = StoreUploadChunk(u, 0, bytes.NewReader(content[:600]))
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrConflict))
+
+	assert.Error(t, CompleteUpload(u), "upload is incomplete")
+
+	assert.NoError(t, StoreUploadChunk(u, 600, bytes.NewReader(content[600:])))
+	assert.Equal(t, []int64{600, 400}, u.Chunks)
+
+	assert.NoError(t, CompleteUpload(u))
+	b, _ := ioutil.ReadFile(u.Artifact.ObjectPath)
+	assert.Equal(t, content, b)
+
+	// The checksum of the reassembled artifact is checked
+	u.Artifact.SHA512sum = "wrong"
+	assert.Error(t, CompleteUpload(u))
+	_, err = os.Stat(u.Artifact.ObjectPath)
+	assert.True(t, os.IsNotExist(err))
+
+	// and required
+	u.Artifact.SHA512sum = ""
+	assert.True(t, sdk.ErrorIs(CompleteUpload(u), sdk.ErrWrongRequest))
+
+	// The size of the reassembled artifact is checked, as chunk sizes may be given by the worker
+	u.Artifact.SHA512sum = hex.EncodeToString(sum[:])
+	u.Artifact.Size, u.Offset, u.Chunks = 1100, 1100, []int64{600, 500}
+	assert.True(t, sdk.ErrorIs(CompleteUpload(u), sdk.ErrWrongRequest))
+	_, err = os.Stat(u.Artifact.ObjectPath)
+	assert.True(t, os.IsNotExist(err))
+
+	DeleteUploadChunks(u)
+	files, _ := filepath.Glob(filepath.Join(dir, u.Artifact.GetPath(), "*"))
+	assert.Empty(t, files)
+}
+
+func TestAddUploadChunk(t *testing.T) {
+	u := &sdk.WorkflowNodeRunArtifactUpload{
+		ID:       sdk.UUID(),
+		Artifact: sdk.WorkflowNodeRunArtifact{Name: "file.txt", Size: 1000},
+	}
+
+	assert.NoError(t, AddUploadChunk(u, 0, 600))
+	assert.True(t, sdk.ErrorIs(AddUploadChunk(u, 0, 600), sdk.ErrConflict))
+	assert.True(t, sdk.ErrorIs(AddUploadChunk(u, 600, 600), sdk.ErrWrongRequest), "chunk exceeds the artifact size")
+	assert.True(t, sdk.ErrorIs(AddUploadChunk(u, 600, 0), sdk.ErrWrongRequest))
+	assert.NoError(t, AddUploadChunk(u, 600, 400))
+	assert.Equal(t, []int64{600, 400}, u.Chunks)
+	assert.Equal(t, int64(1000), u.Offset)
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// accessTokenLastUsedPrecision avoids updating the last use of an access token on each request
+const accessTokenLastUsedPrecision = time.Minute
+
+// GetAccessToken returns the personal access token sent as bearer token or as session token
+func GetAccessToken(headers http.Header) string {
+	if h := headers.Get("Authorization"); strings.HasPrefix(h, "Bearer "+sdk.AccessTokenPrefix) {
+		return strings.TrimPrefix(h, "Bearer ")
+	}
+	if h := headers.Get(sdk.SessionTokenHeader); strings.HasPrefix(h, sdk.AccessTokenPrefix) {
+		return h
+	}
+	return ""
+}
+
+// CheckAccessTokenAuth checks the personal access token of a user
+func CheckAccessTokenAuth(ctx context.Context, db gorp.SqlExecutor, headers http.Header) (context.Context, error) {
+	t, err := user.LoadAccessTokenByValue(db, GetAccessToken(headers))
+	if err != nil {
+		return ctx, err
+	}
+	if t.IsExpired() {
+		return ctx, fmt.Errorf("access token %d has expired", t.ID)
+	}
+
+	u, err := user.LoadUserWithoutAuthByID(db, t.UserID)
+	if err != nil {
+		return ctx, fmt.Errorf("cannot load user of access token %d: %v", t.ID, err)
+	}
+
+	if now := time.Now(); t.LastUsed == nil || now.Sub(*t.LastUsed) > accessTokenLastUsedPrecision {
+		if err := user.UpdateAccessTokenLastUsed(db, t.ID, now); err != nil {
+			log.Warning("CheckAccessTokenAuth> %v", err)
+		}
+		t.LastUsed = &now
+	}
+
+	ctx = context.WithValue(ctx, ContextUser, u)
+	ctx = context.WithValue(ctx, ContextAccessToken, t)
+	return ctx, nil
+}
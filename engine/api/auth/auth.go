@@ -28,9 +28,10 @@ const (
 	ContextService
 	ContextUserSession
 	ContextProvider
+	ContextAccessToken
 )
 
-//Driver is an interface to all auth method (local, ldap and beyond...)
+//Driver is an interface to all auth method (local, ldap, oidc and beyond...)
 type Driver interface {
 	Open(options interface{}, store sessionstore.Store) error
 	Store() sessionstore.Store
@@ -52,6 +53,10 @@ func GetDriver(c context.Context, mode string, options interface{}, storeOptions
 		d = &LDAPClient{
 			dbFunc: DBFunc,
 		}
+	case "oidc":
+		d = &OIDCClient{
+			dbFunc: DBFunc,
+		}
 	default:
 		d = &LocalClient{
 			dbFunc: DBFunc,
@@ -134,10 +139,11 @@ func GetService(db *gorp.DbMap, store cache.Store, hash string) (*sdk.Service, e
 // ContextValues retuns auth values of a context
 func ContextValues(ctx context.Context) map[interface{}]interface{} {
 	return map[interface{}]interface{}{
-		ContextHatchery: ctx.Value(ContextHatchery),
-		ContextService:  ctx.Value(ContextService),
-		ContextWorker:   ctx.Value(ContextWorker),
-		ContextUser:     ctx.Value(ContextUser),
+		ContextHatchery:    ctx.Value(ContextHatchery),
+		ContextService:     ctx.Value(ContextService),
+		ContextWorker:      ctx.Value(ContextWorker),
+		ContextUser:        ctx.Value(ContextUser),
+		ContextAccessToken: ctx.Value(ContextAccessToken),
 	}
 }
 
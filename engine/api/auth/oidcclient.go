@@ -0,0 +1,681 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // register SHA256 for token signatures
+	_ "crypto/sha512" // register SHA384 and SHA512 for token signatures
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/sessionstore"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	oidcGrantTypeAuthorizationCode = "authorization_code"
+	oidcGrantTypeDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+	// oidcClockSkew is the tolerance when checking the expiration of tokens
+	oidcClockSkew = time.Minute
+)
+
+//OIDCConfig handles all config to connect to an OpenID Connect provider
+type OIDCConfig struct {
+	Issuer        string
+	ClientID      string
+	ClientSecret  string
+	RedirectURL   string
+	Scopes        []string
+	UsernameClaim string
+	GroupsClaim   string
+	GroupsPrefix  string
+}
+
+// oidcProvider is the metadata published by the provider on its discovery endpoint
+type oidcProvider struct {
+	Issuer                      string `json:"issuer"`
+	AuthorizationEndpoint       string `json:"authorization_endpoint"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	JWKSURI                     string `json:"jwks_uri"`
+}
+
+type oidcToken struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type oidcJSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+//OIDCClient is an auth driver which delegates authentication to an OpenID Connect provider
+type OIDCClient struct {
+	store      sessionstore.Store
+	conf       OIDCConfig
+	provider   oidcProvider
+	local      *LocalClient
+	dbFunc     func() *gorp.DbMap
+	httpClient *http.Client
+	keysMutex  sync.Mutex
+	keys       map[string]crypto.PublicKey
+}
+
+//Open discovers the OpenID Connect provider
+func (c *OIDCClient) Open(options interface{}, store sessionstore.Store) error {
+	log.Info("Auth> Connecting to session store")
+	c.store = store
+	//OIDC Client needs a local client to check local users and sessions
+	c.local = &LocalClient{
+		dbFunc: c.dbFunc,
+	}
+	c.local.Open(options, store)
+
+	conf, ok := options.(OIDCConfig)
+	if !ok {
+		return fmt.Errorf("invalid OIDC configuration")
+	}
+	if conf.Issuer == "" || conf.ClientID == "" {
+		return fmt.Errorf("OIDC issuer and client id are mandatory")
+	}
+	scopes := make([]string, 0, len(conf.Scopes))
+	for _, s := range conf.Scopes {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "profile", "email"}
+	}
+	conf.Scopes = scopes
+	if conf.UsernameClaim == "" {
+		conf.UsernameClaim = "preferred_username"
+	}
+	c.conf = conf
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	log.Info("Auth> Discovering OIDC provider %s", conf.Issuer)
+	u := strings.TrimSuffix(conf.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := c.getJSON(u, &c.provider); err != nil {
+		return sdk.WrapError(err, "cannot discover OIDC provider %s", conf.Issuer)
+	}
+	if c.provider.Issuer != conf.Issuer {
+		return fmt.Errorf("OIDC issuer mismatch: %s is published by %s", c.provider.Issuer, conf.Issuer)
+	}
+	return c.refreshKeys()
+}
+
+//Store returns store
+func (c *OIDCClient) Store() sessionstore.Store {
+	return c.store
+}
+
+//CheckAuth checks the session created after an OpenID Connect login
+func (c *OIDCClient) CheckAuth(ctx context.Context, w http.ResponseWriter, req *http.Request) (context.Context, error) {
+	return c.local.CheckAuth(ctx, w, req)
+}
+
+//Authentify check username and password of local users, OIDC users have no password
+func (c *OIDCClient) Authentify(username, password string) (bool, error) {
+	return c.local.Authentify(username, password)
+}
+
+//Issuer returns the issuer of the OpenID Connect provider
+func (c *OIDCClient) Issuer() string {
+	return c.conf.Issuer
+}
+
+//AuthCodeURL returns the URL of the provider to start an authorization code login
+func (c *OIDCClient) AuthCodeURL(state, nonce string) string {
+	v := url.Values{
+		"response_type": {"code"},
+		"client_id":     {c.conf.ClientID},
+		"redirect_uri":  {c.conf.RedirectURL},
+		"scope":         {strings.Join(c.conf.Scopes, " ")},
+		"state":         {state},
+		"nonce":         {nonce},
+	}
+	sep := "?"
+	if strings.Contains(c.provider.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return c.provider.AuthorizationEndpoint + sep + v.Encode()
+}
+
+//Exchange exchanges an authorization code against the user it authenticates
+func (c *OIDCClient) Exchange(ctx context.Context, code, nonce string) (*sdk.User, error) {
+	claims, err := c.exchange(ctx, code, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return c.insertOrUpdateUser(c.dbFunc(), claims)
+}
+
+func (c *OIDCClient) exchange(ctx context.Context, code, nonce string) (map[string]interface{}, error) {
+	t, err := c.token(ctx, url.Values{
+		"grant_type":   {oidcGrantTypeAuthorizationCode},
+		"code":         {code},
+		"redirect_uri": {c.conf.RedirectURL},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.verifyIDToken(t.IDToken, nonce)
+}
+
+//DeviceAuthorization starts a device authorization on the provider
+func (c *OIDCClient) DeviceAuthorization(ctx context.Context) (*sdk.UserOIDCDeviceAuthorization, error) {
+	if c.provider.DeviceAuthorizationEndpoint == "" {
+		return nil, sdk.WrapError(sdk.ErrNotImplemented, "OIDC provider %s does not support device authorization", c.conf.Issuer)
+	}
+
+	res, err := c.postForm(ctx, c.provider.DeviceAuthorizationEndpoint, url.Values{
+		"client_id": {c.conf.ClientID},
+		"scope":     {strings.Join(c.conf.Scopes, " ")},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OIDC device authorization failed: HTTP %d %s", res.StatusCode, body)
+	}
+
+	var d sdk.UserOIDCDeviceAuthorization
+	if err := json.Unmarshal(body, &d); err != nil {
+		return nil, sdk.WrapError(err, "cannot read OIDC device authorization")
+	}
+	// Some providers still use the name of the draft of the specification
+	if d.VerificationURI == "" {
+		var draft struct {
+			VerificationURL string `json:"verification_url"`
+		}
+		_ = json.Unmarshal(body, &draft)
+		d.VerificationURI = draft.VerificationURL
+	}
+	return &d, nil
+}
+
+//DeviceToken returns the user authenticated by a device code, ErrOIDCAuthorizationPending is returned until the user approves it
+func (c *OIDCClient) DeviceToken(ctx context.Context, deviceCode string) (*sdk.User, error) {
+	claims, err := c.deviceToken(ctx, deviceCode)
+	if err != nil {
+		return nil, err
+	}
+	return c.insertOrUpdateUser(c.dbFunc(), claims)
+}
+
+func (c *OIDCClient) deviceToken(ctx context.Context, deviceCode string) (map[string]interface{}, error) {
+	t, err := c.token(ctx, url.Values{
+		"grant_type":  {oidcGrantTypeDeviceCode},
+		"device_code": {deviceCode},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.verifyIDToken(t.IDToken, "")
+}
+
+func (c *OIDCClient) getJSON(u string, out interface{}) error {
+	res, err := c.httpClient.Get(u)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d on %s", res.StatusCode, u)
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+func (c *OIDCClient) postForm(ctx context.Context, u string, form url.Values) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if c.conf.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.conf.ClientID), url.QueryEscape(c.conf.ClientSecret))
+	}
+	return c.httpClient.Do(req.WithContext(ctx))
+}
+
+func (c *OIDCClient) token(ctx context.Context, form url.Values) (*oidcToken, error) {
+	form.Set("client_id", c.conf.ClientID)
+	res, err := c.postForm(ctx, c.provider.TokenEndpoint, form)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var t oidcToken
+	if err := json.NewDecoder(res.Body).Decode(&t); err != nil {
+		return nil, sdk.WrapError(err, "cannot read OIDC token response (HTTP %d)", res.StatusCode)
+	}
+
+	switch t.Error {
+	case "":
+	case "authorization_pending", "slow_down":
+		return nil, sdk.ErrOIDCAuthorizationPending
+	case "access_denied", "expired_token", "invalid_grant":
+		return nil, sdk.WrapError(sdk.ErrUnauthorized, "OIDC token request failed: %s %s", t.Error, t.ErrorDescription)
+	default:
+		return nil, fmt.Errorf("OIDC token request failed: %s %s", t.Error, t.ErrorDescription)
+	}
+	if res.StatusCode != http.StatusOK || t.IDToken == "" {
+		return nil, fmt.Errorf("OIDC token request failed: HTTP %d without id_token", res.StatusCode)
+	}
+	return &t, nil
+}
+
+// refreshKeys loads the keys used by the provider to sign its tokens
+func (c *OIDCClient) refreshKeys() error {
+	var jwks struct {
+		Keys []oidcJSONWebKey `json:"keys"`
+	}
+	if err := c.getJSON(c.provider.JWKSURI, &jwks); err != nil {
+		return sdk.WrapError(err, "cannot load OIDC keys")
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		pub, err := k.publicKey()
+		if err != nil {
+			log.Warning("Auth> Ignoring OIDC key %s: %v", k.Kid, err)
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+
+	c.keysMutex.Lock()
+	c.keys = keys
+	c.keysMutex.Unlock()
+	return nil
+}
+
+func (c *OIDCClient) publicKey(kid string) (crypto.PublicKey, error) {
+	c.keysMutex.Lock()
+	k, ok := c.keys[kid]
+	c.keysMutex.Unlock()
+	if ok {
+		return k, nil
+	}
+
+	// The provider may have rotated its keys
+	if err := c.refreshKeys(); err != nil {
+		return nil, err
+	}
+	c.keysMutex.Lock()
+	k, ok = c.keys[kid]
+	c.keysMutex.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown OIDC key %s", kid)
+	}
+	return k, nil
+}
+
+func (k oidcJSONWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+}
+
+// verifyIDToken checks the signature and the claims of an ID token and returns its claims
+func (c *OIDCClient) verifyIDToken(raw, nonce string) (map[string]interface{}, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, sdk.WrapError(sdk.ErrUnauthorized, "malformed OIDC ID token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, sdk.WrapError(sdk.ErrUnauthorized, "malformed OIDC ID token header: %v", err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, sdk.WrapError(sdk.ErrUnauthorized, "malformed OIDC ID token signature: %v", err)
+	}
+	key, err := c.publicKey(header.Kid)
+	if err != nil {
+		return nil, sdk.WrapError(sdk.ErrUnauthorized, "cannot verify OIDC ID token: %v", err)
+	}
+	if err := verifyJWTSignature(header.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, sdk.WrapError(sdk.ErrUnauthorized, "invalid OIDC ID token signature: %v", err)
+	}
+
+	claims := map[string]interface{}{}
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, sdk.WrapError(sdk.ErrUnauthorized, "malformed OIDC ID token claims: %v", err)
+	}
+
+	if iss, _ := claims["iss"].(string); iss != c.conf.Issuer {
+		return nil, sdk.WrapError(sdk.ErrUnauthorized, "invalid OIDC ID token issuer %s", iss)
+	}
+	if !audienceContains(claims["aud"], c.conf.ClientID) {
+		return nil, sdk.WrapError(sdk.ErrUnauthorized, "OIDC ID token is not issued for %s", c.conf.ClientID)
+	}
+	exp, _ := claims["exp"].(float64)
+	if time.Unix(int64(exp), 0).Add(oidcClockSkew).Before(time.Now()) {
+		return nil, sdk.WrapError(sdk.ErrUnauthorized, "OIDC ID token is expired")
+	}
+	if n, _ := claims["nonce"].(string); nonce != "" && n != nonce {
+		return nil, sdk.WrapError(sdk.ErrUnauthorized, "invalid OIDC ID token nonce")
+	}
+	return claims, nil
+}
+
+func decodeJWTPart(s string, out interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+func verifyJWTSignature(alg string, key crypto.PublicKey, signed string, sig []byte) error {
+	var h crypto.Hash
+	switch strings.TrimLeft(alg, "RSPE") {
+	case "256":
+		h = crypto.SHA256
+	case "384":
+		h = crypto.SHA384
+	case "512":
+		h = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported algorithm %s", alg)
+	}
+	hasher := h.New()
+	hasher.Write([]byte(signed)) // nolint
+	digest := hasher.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		switch alg[:2] {
+		case "RS":
+			return rsa.VerifyPKCS1v15(k, h, digest, sig)
+		case "PS":
+			return rsa.VerifyPSS(k, h, digest, sig, nil)
+		}
+	case *ecdsa.PublicKey:
+		if alg[:2] != "ES" {
+			break
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return fmt.Errorf("invalid signature size")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("algorithm %s does not match key", alg)
+}
+
+func audienceContains(aud interface{}, clientID string) bool {
+	switch a := aud.(type) {
+	case string:
+		return a == clientID
+	case []interface{}:
+		for _, v := range a {
+			if s, _ := v.(string); s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// claimStrings returns the values of a claim holding a string or a list of strings
+func claimStrings(claims map[string]interface{}, name string) []string {
+	switch v := claims[name].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		res := make([]string, 0, len(v))
+		for _, s := range v {
+			if s, ok := s.(string); ok {
+				res = append(res, s)
+			}
+		}
+		return res
+	}
+	return nil
+}
+
+// insertOrUpdateUser returns the user linked to the OpenID Connect identity of the claims, by its issuer and subject.
+// An identity not linked yet is linked to a new user, or to the user created by a previous OIDC login with the same username.
+// It can't log in as a user with another origin unless an administrator has linked them.
+func (c *OIDCClient) insertOrUpdateUser(db *gorp.DbMap, claims map[string]interface{}) (*sdk.User, error) {
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, sdk.WrapError(sdk.ErrUnauthorized, "OIDC ID token has no claim sub")
+	}
+	username, _ := claims[c.conf.UsernameClaim].(string)
+	if username == "" {
+		return nil, sdk.WrapError(sdk.ErrInvalidUsername, "OIDC ID token has no claim %s", c.conf.UsernameClaim)
+	}
+
+	// The user, its link and its groups are saved together
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot start transaction")
+	}
+	defer tx.Rollback()
+
+	link, err := user.LoadOIDCLinkBySubject(tx, c.conf.Issuer, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	var u *sdk.User
+	var newUser bool
+	if link != nil {
+		u, err = user.LoadUserWithoutAuthByID(tx, link.UserID)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot load user %d linked to OIDC subject %s", link.UserID, subject)
+		}
+	} else {
+		u, err = user.LoadUserWithoutAuth(tx, username)
+		if sdk.Cause(err) == sql.ErrNoRows {
+			newUser = true
+			u = &sdk.User{
+				Admin:    false,
+				Username: username,
+				Origin:   "oidc",
+			}
+		} else if err != nil {
+			log.Warning("Auth> User %s not found : %s", username, err)
+			return nil, err
+		} else {
+			userLink, err := user.LoadOIDCLinkByUserID(tx, u.ID, c.conf.Issuer)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkOIDCUsername(u, userLink); err != nil {
+				log.Warning("Auth> OIDC subject %s refused for user %s: %v", subject, username, err)
+				return nil, err
+			}
+		}
+	}
+
+	// Only the users created by an OIDC login are synchronized with the claims
+	if u.Origin == "oidc" {
+		if name, _ := claims["name"].(string); name != "" {
+			u.Fullname = name
+		}
+		if email, _ := claims["email"].(string); email != "" {
+			u.Email = email
+		}
+	}
+
+	if newUser {
+		a := &sdk.Auth{
+			EmailVerified: true,
+		}
+		if err := user.InsertUser(tx, u, a); err != nil {
+			return nil, sdk.WrapError(err, "cannot insert user %s", username)
+		}
+		u.Auth = *a
+	} else if u.Origin == "oidc" {
+		if err := user.UpdateUser(tx, *u); err != nil {
+			return nil, sdk.WrapError(err, "cannot update user %s", username)
+		}
+	}
+
+	if link == nil {
+		link = &sdk.UserOIDCLink{
+			UserID:  u.ID,
+			Issuer:  c.conf.Issuer,
+			Subject: subject,
+		}
+		if err := user.InsertOIDCLink(tx, link); err != nil {
+			return nil, sdk.WrapError(err, "cannot link user %s to OIDC subject %s", username, subject)
+		}
+	}
+
+	if c.conf.GroupsClaim != "" && u.Origin == "oidc" {
+		if err := c.syncGroups(tx, u, claimStrings(claims, c.conf.GroupsClaim)); err != nil {
+			return nil, sdk.WrapError(err, "cannot synchronize groups of user %s", u.Username)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, sdk.WrapError(err, "cannot commit transaction")
+	}
+	return u, nil
+}
+
+// checkOIDCUsername returns an error if an OIDC identity not linked yet can't be linked to the existing user with its username:
+// only a user created by a previous OIDC login, and not linked to another identity of the issuer, is linked automatically
+func checkOIDCUsername(u *sdk.User, link *sdk.UserOIDCLink) error {
+	if u.Origin != "oidc" {
+		return sdk.WrapError(sdk.ErrOIDCUserNotLinked, "user %s with origin %s is not linked to an OIDC identity", u.Username, u.Origin)
+	}
+	if link != nil {
+		return sdk.WrapError(sdk.ErrOIDCUserNotLinked, "user %s is linked to another OIDC identity", u.Username)
+	}
+	return nil
+}
+
+// syncGroups adds the user in the existing groups listed in its groups claim, except the default and shared.infra groups. When a groups prefix is configured,
+// only groups starting with the prefix are considered and the user is also removed from the prefixed groups which
+// are not listed anymore.
+func (c *OIDCClient) syncGroups(db gorp.SqlExecutor, u *sdk.User, names []string) error {
+	wanted := map[string]bool{}
+	for _, n := range names {
+		if strings.HasPrefix(n, c.conf.GroupsPrefix) {
+			wanted[n] = true
+		}
+	}
+
+	current, err := group.LoadGroupByUser(db, u.ID)
+	if err != nil {
+		return err
+	}
+	member := map[string]bool{}
+	for _, g := range current {
+		member[g.Name] = true
+		if c.conf.GroupsPrefix == "" || wanted[g.Name] || !strings.HasPrefix(g.Name, c.conf.GroupsPrefix) ||
+			group.IsDefaultGroupName(g.Name) || g.Name == sdk.SharedInfraGroupName {
+			continue
+		}
+		log.Info("Auth> Removing user %s from group %s", u.Username, g.Name)
+		if err := group.DeleteUserFromGroup(db, g.ID, u.ID); err != nil {
+			log.Warning("Auth> Cannot remove user %s from group %s: %v", u.Username, g.Name, err)
+		}
+	}
+
+	for _, n := range names {
+		if !wanted[n] || member[n] {
+			continue
+		}
+		// The default groups and the shared infrastructure group are never granted by the identity provider
+		if group.IsDefaultGroupName(n) || n == sdk.SharedInfraGroupName {
+			log.Warning("Auth> Ignoring group %s of user %s", n, u.Username)
+			continue
+		}
+		member[n] = true
+		g, err := group.LoadGroup(db, n)
+		if err == sdk.ErrGroupNotFound {
+			log.Debug("Auth> Ignoring unknown group %s of user %s", n, u.Username)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		log.Info("Auth> Adding user %s in group %s", u.Username, n)
+		if err := group.InsertUserInGroup(db, g.ID, u.ID, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/sdk"
+)
+
+// fakeOIDCProvider is an OpenID Connect provider issuing RS256 tokens
+type fakeOIDCProvider struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]interface{}
+}
+
+func newFakeOIDCProvider(t *testing.T) *fakeOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	p := &fakeOIDCProvider{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(oidcProvider{ // nolint
+			Issuer:                      p.URL,
+			AuthorizationEndpoint:       p.URL + "/auth",
+			TokenEndpoint:               p.URL + "/token",
+			DeviceAuthorizationEndpoint: p.URL + "/device/code",
+			JWKSURI:                     p.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{ // nolint
+			"keys": []oidcJSONWebKey{{
+				Kty: "RSA",
+				Kid: "key1",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/device/code", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{ // nolint
+			"device_code":      "device",
+			"user_code":        "ABCD-EFGH",
+			"verification_url": p.URL + "/device",
+			"expires_in":       300,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if u, pass, _ := r.BasicAuth(); u != "cds" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(oidcToken{Error: "invalid_client"}) // nolint
+			return
+		}
+		switch {
+		case r.FormValue("grant_type") == oidcGrantTypeAuthorizationCode && r.FormValue("code") == "code":
+		case r.FormValue("grant_type") == oidcGrantTypeDeviceCode && r.FormValue("device_code") == "device":
+		case r.FormValue("grant_type") == oidcGrantTypeDeviceCode && r.FormValue("device_code") == "pending":
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(oidcToken{Error: "authorization_pending"}) // nolint
+			return
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(oidcToken{Error: "invalid_grant"}) // nolint
+			return
+		}
+		json.NewEncoder(w).Encode(oidcToken{AccessToken: "access", IDToken: p.sign(t, "RS256", "key1", p.claims)}) // nolint
+	})
+	p.Server = httptest.NewServer(mux)
+	return p
+}
+
+func (p *fakeOIDCProvider) sign(t *testing.T, alg, kid string, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	h := crypto.SHA256.New()
+	h.Write([]byte(signed)) // nolint
+	sig, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, h.Sum(nil))
+	if err != nil {
+		t.Fatalf("unable to sign token: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestOIDCClient(t *testing.T) {
+	p := newFakeOIDCProvider(t)
+	defer p.Close()
+
+	c := &OIDCClient{}
+	err := c.Open(OIDCConfig{
+		Issuer:       p.URL,
+		ClientID:     "cds",
+		ClientSecret: "secret",
+		RedirectURL:  "http://cds.local/callback",
+		Scopes:       []string{"openid", " groups", ""},
+		GroupsClaim:  "groups",
+	}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	u, err := url.Parse(c.AuthCodeURL("state", "nonce"))
+	assert.NoError(t, err)
+	assert.Equal(t, p.URL+"/auth", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "openid groups", u.Query().Get("scope"))
+	assert.Equal(t, "state", u.Query().Get("state"))
+	assert.Equal(t, "http://cds.local/callback", u.Query().Get("redirect_uri"))
+
+	p.claims = map[string]interface{}{
+		"iss":                p.URL,
+		"aud":                []string{"other", "cds"},
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"nonce":              "nonce",
+		"preferred_username": "john",
+		"groups":             []string{"devs", "ops"},
+	}
+	claims, err := c.exchange(context.Background(), "code", "nonce")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "john", claims["preferred_username"])
+		assert.Equal(t, []string{"devs", "ops"}, claimStrings(claims, "groups"))
+	}
+
+	_, err = c.exchange(context.Background(), "code", "another nonce")
+	assert.Error(t, err, "nonce must match")
+	_, err = c.exchange(context.Background(), "wrong", "nonce")
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrUnauthorized))
+
+	p.claims["aud"] = "other"
+	_, err = c.exchange(context.Background(), "code", "nonce")
+	assert.Error(t, err, "audience must contain the client id")
+	p.claims["aud"] = "cds"
+	p.claims["exp"] = time.Now().Add(-time.Hour).Unix()
+	_, err = c.exchange(context.Background(), "code", "nonce")
+	assert.Error(t, err, "token must not be expired")
+	p.claims["exp"] = time.Now().Add(time.Hour).Unix()
+
+	// Tokens signed by an unknown key or with a tampered payload are rejected
+	_, err = c.verifyIDToken(p.sign(t, "RS256", "key2", p.claims), "")
+	assert.Error(t, err)
+	parts := strings.Split(p.sign(t, "RS256", "key1", p.claims), ".")
+	tampered, _ := json.Marshal(map[string]interface{}{"iss": p.URL, "aud": "cds", "exp": p.claims["exp"], "preferred_username": "admin"})
+	parts[1] = base64.RawURLEncoding.EncodeToString(tampered)
+	_, err = c.verifyIDToken(strings.Join(parts, "."), "")
+	assert.Error(t, err)
+	parts[0] = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"key1"}`))
+	_, err = c.verifyIDToken(parts[0]+"."+parts[1]+".", "")
+	assert.Error(t, err)
+
+	d, err := c.DeviceAuthorization(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ABCD-EFGH", d.UserCode)
+		assert.Equal(t, p.URL+"/device", d.VerificationURI)
+	}
+	_, err = c.deviceToken(context.Background(), "pending")
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrOIDCAuthorizationPending))
+	claims, err = c.deviceToken(context.Background(), "device")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "john", claims["preferred_username"])
+	}
+}
+
+func TestVerifyJWTSignatureECDSA(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	jwk := oidcJSONWebKey{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(key.X.Bytes()),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.Bytes()),
+	}
+	pub, err := jwk.publicKey()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	h := crypto.SHA256.New()
+	h.Write([]byte("header.payload")) // nolint
+	r, s, err := ecdsa.Sign(rand.Reader, key, h.Sum(nil))
+	if err != nil {
+		t.Fatalf("unable to sign: %v", err)
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+
+	assert.NoError(t, verifyJWTSignature("ES256", pub, "header.payload", sig))
+	assert.Error(t, verifyJWTSignature("ES256", pub, "header.other", sig))
+	assert.Error(t, verifyJWTSignature("RS256", pub, "header.payload", sig))
+}
+
+func TestCheckOIDCUsername(t *testing.T) {
+	assert.NoError(t, checkOIDCUsername(&sdk.User{Username: "john", Origin: "oidc"}, nil))
+	assert.True(t, sdk.ErrorIs(checkOIDCUsername(&sdk.User{Username: "admin", Origin: "local", Admin: true}, nil), sdk.ErrOIDCUserNotLinked))
+	assert.True(t, sdk.ErrorIs(checkOIDCUsername(&sdk.User{Username: "john", Origin: "ldap"}, nil), sdk.ErrOIDCUserNotLinked))
+	assert.True(t, sdk.ErrorIs(checkOIDCUsername(&sdk.User{Username: "john", Origin: "oidc"}, &sdk.UserOIDCLink{Subject: "other"}), sdk.ErrOIDCUserNotLinked))
+}
+
+func TestOIDCInsertOrUpdateUser(t *testing.T) {
+	db, _, end := test.SetupPG(t)
+	defer end()
+
+	c := &OIDCClient{conf: OIDCConfig{Issuer: "https://idp.local", UsernameClaim: "preferred_username"}}
+
+	// A new identity creates a user linked to its subject
+	username := sdk.RandomString(10)
+	u, err := c.insertOrUpdateUser(db, map[string]interface{}{"sub": "sub-" + username, "preferred_username": username, "email": username + "@idp.local"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "oidc", u.Origin)
+	assert.Equal(t, username+"@idp.local", u.Email)
+	link, err := user.LoadOIDCLinkBySubject(db, "https://idp.local", "sub-"+username)
+	assert.NoError(t, err)
+	if assert.NotNil(t, link) {
+		assert.Equal(t, u.ID, link.UserID)
+	}
+
+	// The subject logs in as its user even if its username changed
+	u2, err := c.insertOrUpdateUser(db, map[string]interface{}{"sub": "sub-" + username, "preferred_username": "renamed-" + username})
+	assert.NoError(t, err)
+	assert.Equal(t, u.ID, u2.ID)
+
+	// Another subject can't take over the user with the same username
+	_, err = c.insertOrUpdateUser(db, map[string]interface{}{"sub": "another-" + username, "preferred_username": username})
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrOIDCUserNotLinked))
+
+	// An identity can't log in as the local admin with the same username
+	admin, _ := assets.InsertAdminUser(db)
+	_, err = c.insertOrUpdateUser(db, map[string]interface{}{"sub": "sub-" + admin.Username, "preferred_username": admin.Username})
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrOIDCUserNotLinked))
+	link, err = user.LoadOIDCLinkBySubject(db, "https://idp.local", "sub-"+admin.Username)
+	assert.NoError(t, err)
+	assert.Nil(t, link)
+
+	// Until an administrator links them
+	assert.NoError(t, user.InsertOIDCLink(db, &sdk.UserOIDCLink{UserID: admin.ID, Issuer: "https://idp.local", Subject: "sub-" + admin.Username}))
+	u3, err := c.insertOrUpdateUser(db, map[string]interface{}{"sub": "sub-" + admin.Username, "preferred_username": admin.Username})
+	assert.NoError(t, err)
+	assert.Equal(t, admin.ID, u3.ID)
+	assert.Equal(t, "local", u3.Origin)
+}
+
+func TestOIDCSyncGroups(t *testing.T) {
+	db, _, end := test.SetupPG(t)
+	defer end()
+
+	g := &sdk.Group{Name: "oidc-" + sdk.RandomString(10)}
+	test.NoError(t, group.InsertGroup(db, g))
+	if _, err := group.LoadGroup(db, sdk.SharedInfraGroupName); err == sdk.ErrGroupNotFound {
+		test.NoError(t, group.InsertGroup(db, &sdk.Group{Name: sdk.SharedInfraGroupName}))
+	}
+
+	// The shared infrastructure group is not granted by the groups claim
+	c := &OIDCClient{conf: OIDCConfig{Issuer: "https://idp.local", UsernameClaim: "preferred_username", GroupsClaim: "groups"}}
+	username := sdk.RandomString(10)
+	u, err := c.insertOrUpdateUser(db, map[string]interface{}{
+		"sub":                "sub-" + username,
+		"preferred_username": username,
+		"groups":             []interface{}{g.Name, sdk.SharedInfraGroupName},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	groups, err := group.LoadGroupByUser(db, u.ID)
+	test.NoError(t, err)
+	names := []string{}
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	assert.Contains(t, names, g.Name)
+	assert.NotContains(t, names, sdk.SharedInfraGroupName)
+}
+
+// TestOIDCClientDex runs against a local Dex instance, for example started with the dex example configuration
+// and a static client allowing the device code grant.
+func TestOIDCClientDex(t *testing.T) {
+	issuer := os.Getenv("CDS_TEST_OIDC_ISSUER")
+	if issuer == "" {
+		t.SkipNow()
+	}
+
+	c := &OIDCClient{}
+	err := c.Open(OIDCConfig{
+		Issuer:       issuer,
+		ClientID:     os.Getenv("CDS_TEST_OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("CDS_TEST_OIDC_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("CDS_TEST_OIDC_REDIRECT_URL"),
+		Scopes:       []string{"openid", "profile", "email", "groups"},
+		GroupsClaim:  "groups",
+	}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotEmpty(t, c.keys)
+	assert.Contains(t, c.AuthCodeURL("state", "nonce"), c.provider.AuthorizationEndpoint)
+
+	d, err := c.DeviceAuthorization(context.Background())
+	if assert.NoError(t, err) {
+		assert.NotEmpty(t, d.UserCode)
+		_, err = c.deviceToken(context.Background(), d.DeviceCode)
+		assert.True(t, sdk.ErrorIs(err, sdk.ErrOIDCAuthorizationPending))
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 )
@@ -14,3 +17,20 @@ func (api *API) ConfigUserHandler() service.Handler {
 		return service.WriteJSON(w, map[string]string{sdk.ConfigURLUIKey: api.Config.URL.UI}, http.StatusOK)
 	}
 }
+
+// getVCSGerritConfigurationHandler returns the gerrit servers configurations, it is used by the hooks service
+func (api *API) getVCSGerritConfigurationHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		// The configurations are only given to the hooks services of the shared infrastructure
+		srv := getService(ctx)
+		if srv == nil || srv.Type != services.TypeHooks || srv.GroupID == nil || group.SharedInfraGroup == nil || *srv.GroupID != group.SharedInfraGroup.ID {
+			return sdk.WrapError(sdk.ErrForbidden, "getVCSGerritConfigurationHandler> only hooks services are allowed")
+		}
+
+		res, err := repositoriesmanager.LoadGerritConfigurations(ctx, api.mustDB())
+		if err != nil {
+			return sdk.WrapError(err, "Cannot get gerrit configurations")
+		}
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
@@ -0,0 +1,144 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/streadway/amqp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// AMQPClient sends events on an AMQP exchange (ie. RabbitMQ)
+type AMQPClient struct {
+	options AMQPConfig
+	mutex   sync.Mutex
+	conn    *amqp.Connection
+	channel *amqp.Channel
+}
+
+// AMQPConfig handles all config to connect to an AMQP server
+type AMQPConfig struct {
+	SinkConfig
+	URI          string
+	Exchange     string
+	ExchangeType string
+	// RoutingKey of the published messages, default to the event type
+	RoutingKey string
+}
+
+// initialize checks the configuration. The connection is opened lazily so
+// the API can start while the AMQP server is down
+func (c *AMQPClient) initialize(options interface{}) (Broker, error) {
+	conf, ok := options.(AMQPConfig)
+	if !ok {
+		return nil, fmt.Errorf("Invalid AMQP Initialization")
+	}
+
+	if conf.URI == "" || (conf.Exchange == "" && conf.RoutingKey == "") {
+		return nil, fmt.Errorf("initAMQP> Invalid AMQP Configuration %s", conf.Name)
+	}
+	if conf.ExchangeType == "" {
+		conf.ExchangeType = amqp.ExchangeTopic
+	}
+	c.options = conf
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.connect(); err != nil {
+		log.Warning("initAMQP> Unable to connect to %s: %v", conf.Name, err)
+	}
+	return c, nil
+}
+
+// connect opens the connection and declares the exchange, it must be called with the mutex locked
+func (c *AMQPClient) connect() error {
+	conn, err := amqp.Dial(c.options.URI)
+	if err != nil {
+		return err
+	}
+	channel, err := conn.Channel()
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	if c.options.Exchange != "" {
+		if err := channel.ExchangeDeclare(
+			c.options.Exchange,     // name
+			c.options.ExchangeType, // type
+			true,                   // durable
+			false,                  // auto-deleted
+			false,                  // internal
+			false,                  // noWait
+			nil,                    // arguments
+		); err != nil {
+			_ = conn.Close()
+			return err
+		}
+	}
+	c.conn = conn
+	c.channel = channel
+	log.Debug("initAMQP> %s connected on exchange %s", c.options.Name, c.options.Exchange)
+	return nil
+}
+
+// disconnect must be called with the mutex locked
+func (c *AMQPClient) disconnect() {
+	if c.conn != nil {
+		if err := c.conn.Close(); err != nil && err != amqp.ErrClosed {
+			log.Warning("closeAMQP> Error while closing connection: %v", err)
+		}
+	}
+	c.conn = nil
+	c.channel = nil
+}
+
+// close closes the connection
+func (c *AMQPClient) close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.disconnect()
+}
+
+// sendEvent publishes the event, reconnecting if needed
+func (c *AMQPClient) sendEvent(event *sdk.Event) error {
+	data, errm := json.Marshal(event)
+	if errm != nil {
+		return errm
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.channel == nil {
+		if err := c.connect(); err != nil {
+			return err
+		}
+	}
+
+	routingKey := c.options.RoutingKey
+	if routingKey == "" {
+		routingKey = event.EventType
+	}
+	if err := c.channel.Publish(c.options.Exchange, routingKey, false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    event.Timestamp,
+		Type:         event.EventType,
+		Body:         data,
+	}); err != nil {
+		c.disconnect()
+		return err
+	}
+	return nil
+}
+
+func (c *AMQPClient) status() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.conn == nil {
+		return fmt.Sprintf("AMQP %s KO (not connected)", c.options.Name)
+	}
+	return fmt.Sprintf("AMQP %s OK", c.options.Name)
+}
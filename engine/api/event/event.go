@@ -13,8 +13,7 @@ import (
 )
 
 var hostname, cdsname string
-var kafkaBroker Broker
-var brokers []Broker
+var sinks []*sink
 var subscribers []chan<- sdk.Event
 
 func init() {
@@ -34,12 +33,25 @@ func getBroker(t string, option interface{}) (Broker, error) {
 	case "kafka":
 		k := &KafkaClient{}
 		return k.initialize(option)
+	case "amqp":
+		a := &AMQPClient{}
+		return a.initialize(option)
+	case "webhook":
+		w := &WebhookClient{}
+		return w.initialize(option)
 	}
 	return nil, fmt.Errorf("Invalid Broker Type %s", t)
 }
 
+// Config handles the configuration of the external brokers. Several AMQP and webhook sinks can be configured
+type Config struct {
+	Kafka    KafkaConfig
+	AMQP     []AMQPConfig
+	Webhooks []WebhookConfig
+}
+
 // Initialize initializes event system
-func Initialize(k KafkaConfig, cache cache.Store) error {
+func Initialize(c Config, cache cache.Store) error {
 	store = cache
 	var err error
 	hostname, err = os.Hostname()
@@ -56,18 +68,47 @@ func Initialize(k KafkaConfig, cache cache.Store) error {
 		}
 	}
 
-	brokers = []Broker{}
-	if k.Enabled {
-		var errk error
-		kafkaBroker, errk = getBroker("kafka", k)
+	sinks = []*sink{}
+	if c.Kafka.Enabled {
+		kafkaBroker, errk := getBroker("kafka", c.Kafka)
 		if errk != nil {
 			return errk
 		}
-		brokers = append(brokers, kafkaBroker)
+		sinks = append(sinks, newSink(SinkConfig{Name: "kafka"}, kafkaBroker))
+	}
+	for i, a := range c.AMQP {
+		if a.Name == "" {
+			a.Name = fmt.Sprintf("amqp-%d", i)
+		}
+		if err := addSink(a.SinkConfig, "amqp", a); err != nil {
+			return err
+		}
+	}
+	for i, w := range c.Webhooks {
+		if w.Name == "" {
+			w.Name = fmt.Sprintf("webhook-%d", i)
+		}
+		if err := addSink(w.SinkConfig, "webhook", w); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+func addSink(c SinkConfig, t string, option interface{}) error {
+	for _, s := range sinks {
+		if s.name == c.Name {
+			return fmt.Errorf("Duplicate event sink name %s", c.Name)
+		}
+	}
+	b, err := getBroker(t, option)
+	if err != nil {
+		return err
+	}
+	sinks = append(sinks, newSink(c, b))
+	return nil
+}
+
 // Subscribe to CDS events
 func Subscribe(ch chan<- sdk.Event) {
 	subscribers = append(subscribers, ch)
@@ -75,6 +116,10 @@ func Subscribe(ch chan<- sdk.Event) {
 
 // DequeueEvent runs in a goroutine and dequeue event from cache
 func DequeueEvent(c context.Context) {
+	for _, s := range sinks {
+		go s.run(c)
+	}
+
 	for {
 		e := sdk.Event{}
 		store.DequeueWithContext(c, "events", &e)
@@ -88,10 +133,8 @@ func DequeueEvent(c context.Context) {
 		}
 
 		// Send into external brokers
-		for _, b := range brokers {
-			if err := b.sendEvent(&e); err != nil {
-				log.Warning("Error while sending message [%s: %s/%s/%s/%s/%s]: %s", e.EventType, e.ProjectKey, e.WorkflowName, e.ApplicationName, e.PipelineName, e.EnvironmentName, err)
-			}
+		for _, s := range sinks {
+			s.push(e)
 		}
 	}
 }
@@ -108,8 +151,8 @@ func GetCDSName() string {
 
 // Close closes event system
 func Close() {
-	for _, b := range brokers {
-		b.close()
+	for _, s := range sinks {
+		s.broker.close()
 	}
 }
 
@@ -117,8 +160,8 @@ func Close() {
 func Status() sdk.MonitoringStatusLine {
 	var o string
 	var isAlert bool
-	for _, b := range brokers {
-		s := b.status()
+	for _, sk := range sinks {
+		s := sk.status()
 		if !strings.Contains(s, "OK") {
 			isAlert = true
 		}
@@ -0,0 +1,216 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/platform"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// projectExportsTTL is the delay before reloading the event exports of a project
+var projectExportsTTL = time.Minute
+
+type projectExports struct {
+	exports   []sdk.ProjectEventExport
+	platforms map[int64]sdk.ProjectPlatform
+	loadedAt  time.Time
+}
+
+// projectExportSink is the sink of an event export, it is rebuilt when its configuration changes
+type projectExportSink struct {
+	*sink
+	projectKey string
+	signature  string
+	cancel     context.CancelFunc
+}
+
+type projectExporter struct {
+	DBFunc   func() *gorp.DbMap
+	projects map[string]projectExports
+	sinks    map[int64]*projectExportSink
+}
+
+// ExportProjectEvents sends the events of each project to the platforms selected by its event exports
+func ExportProjectEvents(c context.Context, DBFunc func() *gorp.DbMap) {
+	eventChan := make(chan sdk.Event, 100)
+	Subscribe(eventChan)
+
+	e := &projectExporter{
+		DBFunc:   DBFunc,
+		projects: map[string]projectExports{},
+		sinks:    map[int64]*projectExportSink{},
+	}
+	for {
+		select {
+		case <-c.Done():
+			if c.Err() != nil {
+				log.Error("ExportProjectEvents> Exiting: %v", c.Err())
+			}
+			e.close()
+			return
+		case evt := <-eventChan:
+			if evt.ProjectKey == "" {
+				continue
+			}
+			if err := e.export(c, evt); err != nil {
+				log.Warning("ExportProjectEvents> Unable to export event %s on project %s: %v", evt.EventType, evt.ProjectKey, err)
+			}
+		}
+	}
+}
+
+func (e *projectExporter) export(c context.Context, evt sdk.Event) error {
+	p, err := e.load(evt.ProjectKey)
+	if err != nil {
+		return err
+	}
+	for i := range p.exports {
+		if !p.exports[i].Enabled {
+			continue
+		}
+		s, err := e.sink(c, evt.ProjectKey, p.exports[i], p.platforms[p.exports[i].ProjectPlatformID])
+		if err != nil {
+			log.Warning("ExportProjectEvents> Unable to initialize event export %d on project %s: %v", p.exports[i].ID, evt.ProjectKey, err)
+			continue
+		}
+		s.push(evt)
+	}
+	return nil
+}
+
+// load returns the event exports of a project and their platforms, they are kept in memory for projectExportsTTL
+func (e *projectExporter) load(key string) (projectExports, error) {
+	p, has := e.projects[key]
+	if has && time.Since(p.loadedAt) < projectExportsTTL {
+		return p, nil
+	}
+
+	db := e.DBFunc()
+	exports, err := platform.LoadEventExportsByProjectKey(db, key)
+	if err != nil {
+		return p, err
+	}
+	p = projectExports{exports: exports, platforms: map[int64]sdk.ProjectPlatform{}, loadedAt: time.Now()}
+	for _, export := range exports {
+		if _, has := p.platforms[export.ProjectPlatformID]; has || !export.Enabled {
+			continue
+		}
+		pf, err := platform.LoadByID(db, export.ProjectPlatformID, true)
+		if err != nil {
+			return p, err
+		}
+		if pf != nil {
+			p.platforms[export.ProjectPlatformID] = *pf
+		}
+	}
+	e.projects[key] = p
+
+	// Stop the sinks of the deleted or disabled exports
+	for id, s := range e.sinks {
+		if s.projectKey != key {
+			continue
+		}
+		var found bool
+		for _, export := range exports {
+			if export.ID == id && export.Enabled {
+				found = true
+				break
+			}
+		}
+		if !found {
+			s.stop()
+			delete(e.sinks, id)
+		}
+	}
+	return p, nil
+}
+
+// sink returns the sink of an event export, building it on first use or when the export or its platform changed
+func (e *projectExporter) sink(c context.Context, key string, export sdk.ProjectEventExport, pf sdk.ProjectPlatform) (*projectExportSink, error) {
+	if pf.ID == 0 {
+		return nil, sdk.ErrNotFound
+	}
+	btes, err := json.Marshal(struct {
+		Config     sdk.PlatformConfig
+		EventTypes []string
+		Topic      string
+	}{pf.Config, export.EventTypes, export.Topic})
+	if err != nil {
+		return nil, err
+	}
+	signature := string(btes)
+
+	s, has := e.sinks[export.ID]
+	if has && s.signature == signature {
+		return s, nil
+	}
+	if has {
+		s.stop()
+	}
+
+	b, err := newPlatformBroker(pf, export.Topic)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(c)
+	s = &projectExportSink{
+		sink:       newSink(SinkConfig{Name: fmt.Sprintf("project-export-%d", export.ID), EventTypes: export.EventTypes}, b),
+		projectKey: key,
+		signature:  signature,
+		cancel:     cancel,
+	}
+	exportID := export.ID
+	s.onSend = func(err error) {
+		if errU := platform.UpdateEventExportDelivery(e.DBFunc(), exportID, err); errU != nil {
+			log.Warning("ExportProjectEvents> %v", errU)
+		}
+	}
+	e.sinks[export.ID] = s
+	go s.run(ctx)
+	return s, nil
+}
+
+func (s *projectExportSink) stop() {
+	s.cancel()
+	s.broker.close()
+}
+
+func (e *projectExporter) close() {
+	for _, s := range e.sinks {
+		s.stop()
+	}
+}
+
+// newPlatformBroker returns a broker sending events to a project platform
+func newPlatformBroker(pf sdk.ProjectPlatform, topic string) (Broker, error) {
+	name := fmt.Sprintf("%s (%s)", pf.Name, pf.Model.Name)
+	switch pf.Model.Name {
+	case sdk.KafkaPlatformModel:
+		return getBroker("kafka", KafkaConfig{
+			Enabled:         true,
+			BrokerAddresses: pf.Config["broker url"].Value,
+			User:            pf.Config["username"].Value,
+			Password:        pf.Config["password"].Value,
+			Topic:           topic,
+		})
+	case sdk.RabbitMQPlatformModel:
+		return getBroker("amqp", AMQPConfig{
+			SinkConfig: SinkConfig{Name: name},
+			URI:        fmt.Sprintf("amqp://%s:%s@%s", pf.Config["username"].Value, pf.Config["password"].Value, pf.Config["uri"].Value),
+			Exchange:   topic,
+		})
+	case sdk.WebhookPlatformModel:
+		return getBroker("webhook", WebhookConfig{
+			SinkConfig: SinkConfig{Name: name},
+			URL:        pf.Config["url"].Value,
+			Secret:     pf.Config["secret"].Value,
+		})
+	}
+	return nil, fmt.Errorf("platform %s does not support events", name)
+}
@@ -79,3 +79,17 @@ func PublishWorkflowPermissionDelete(projKey string, w sdk.Workflow, gp sdk.Grou
 	}
 	publishWorkflowEvent(e, projKey, w.Name, u)
 }
+
+// PublishWorkflowNodeRunApproval publishes an event when a node run waiting for approval is approved or rejected
+func PublishWorkflowNodeRunApproval(projKey string, w sdk.Workflow, nr sdk.WorkflowNodeRun, a sdk.WorkflowNodeRunApproval, u *sdk.User) {
+	e := sdk.EventWorkflowNodeRunApproval{
+		WorkflowID: w.ID,
+		Number:     nr.Number,
+		NodeRunID:  nr.ID,
+		NodeName:   nr.WorkflowNodeName,
+		Approved:   a.Approved,
+		Comment:    a.Comment,
+		Status:     nr.Status,
+	}
+	publishWorkflowEvent(e, projKey, w.Name, u)
+}
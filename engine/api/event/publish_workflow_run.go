@@ -45,10 +45,10 @@ func PublishWorkflowRun(wr sdk.WorkflowRun, projectKey string) {
 	publishRunWorkflow(e, projectKey, wr.Workflow.Name, "", "", "", wr.Number, wr.LastSubNumber, wr.Status)
 }
 
-// PublishWorkflowNodeRun publish event on a workflow node run
-func PublishWorkflowNodeRun(db gorp.SqlExecutor, nr sdk.WorkflowNodeRun, w sdk.Workflow, previousWR *sdk.WorkflowNodeRun) {
+// PublishWorkflowNodeRun publish event on a workflow node run, with the status of its workflow run once it's over
+func PublishWorkflowNodeRun(db gorp.SqlExecutor, nr sdk.WorkflowNodeRun, w sdk.Workflow, previousWR *sdk.WorkflowNodeRun, wrStatus string, loadWebhookURLs notification.WebhookURLsLoader) {
 	// get and send all user notifications
-	for _, event := range notification.GetUserWorkflowEvents(db, w, previousWR, nr) {
+	for _, event := range notification.GetUserWorkflowEvents(db, w, previousWR, nr, loadWebhookURLs) {
 		Publish(event, nil)
 	}
 
@@ -69,6 +69,9 @@ func PublishWorkflowNodeRun(db gorp.SqlExecutor, nr sdk.WorkflowNodeRun, w sdk.W
 		StagesSummary:  make([]sdk.StageSummary, len(nr.Stages)),
 		HookUUID:       nr.UUID,
 	}
+	if sdk.StatusIsTerminated(wrStatus) {
+		e.WorkflowRunStatus = wrStatus
+	}
 
 	if nr.Callback != nil {
 		e.HookLog = nr.Callback.Log
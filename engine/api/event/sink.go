@@ -0,0 +1,224 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	defaultMaxRetries        = 3
+	defaultDeadLetterMaxSize = 10000
+	sinkBufferSize           = 100
+)
+
+var deadLetterReplayInterval = 30 * time.Second
+
+// SinkConfig is the configuration shared by all external brokers
+type SinkConfig struct {
+	Name              string
+	EventTypes        []string
+	ProjectKeys       []string
+	MaxRetries        int
+	DeadLetterMaxSize int
+}
+
+// Filter selects the events sent to a sink. An empty list matches everything
+type Filter struct {
+	EventTypes  []string
+	ProjectKeys []string
+}
+
+// Match returns true if the event has to be sent to the sink.
+// Event types can be given with or without the "sdk." prefix: sdk.EventRunWorkflow or EventRunWorkflow
+func (f Filter) Match(e *sdk.Event) bool {
+	if len(f.EventTypes) > 0 {
+		var found bool
+		for _, t := range f.EventTypes {
+			if strings.TrimPrefix(t, "sdk.") == strings.TrimPrefix(e.EventType, "sdk.") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.ProjectKeys) > 0 {
+		return sdk.IsInArray(e.ProjectKey, f.ProjectKeys)
+	}
+	return true
+}
+
+// sink sends the events matching its filter to a broker. Events that can not be sent
+// after some retries are pushed in a dead-letter queue in the cache, and replayed
+// once the broker is reachable again
+type sink struct {
+	name              string
+	broker            Broker
+	filter            Filter
+	maxRetries        int
+	deadLetterMaxSize int
+	events            chan sdk.Event
+	// onSend is called with the result of each delivery
+	onSend func(err error)
+
+	mutex   sync.RWMutex
+	lastErr error
+}
+
+func newSink(c SinkConfig, b Broker) *sink {
+	s := &sink{
+		name:              c.Name,
+		broker:            b,
+		filter:            Filter{EventTypes: c.EventTypes, ProjectKeys: c.ProjectKeys},
+		maxRetries:        c.MaxRetries,
+		deadLetterMaxSize: c.DeadLetterMaxSize,
+		events:            make(chan sdk.Event, sinkBufferSize),
+	}
+	if s.maxRetries <= 0 {
+		s.maxRetries = defaultMaxRetries
+	}
+	if s.deadLetterMaxSize <= 0 {
+		s.deadLetterMaxSize = defaultDeadLetterMaxSize
+	}
+	return s
+}
+
+func (s *sink) deadLetterKey() string {
+	return cache.Key("events", "deadletter", s.name)
+}
+
+// push queues an event for the sink without blocking the dequeuing of the events
+func (s *sink) push(e sdk.Event) {
+	if !s.filter.Match(&e) {
+		return
+	}
+	select {
+	case s.events <- e:
+	default:
+		s.deadLetter(e)
+	}
+}
+
+// run sends queued events until the context is done
+func (s *sink) run(c context.Context) {
+	ticker := time.NewTicker(deadLetterReplayInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.Done():
+			return
+		case e := <-s.events:
+			// Do not wait for retries while the broker is known as down, the replay will handle it
+			if s.err() != nil {
+				s.deadLetter(e)
+				continue
+			}
+			err := s.send(c, &e)
+			s.delivered(err)
+			if err != nil {
+				log.Warning("event> unable to send event [%s: %s/%s] to %s: %v", e.EventType, e.ProjectKey, e.WorkflowName, s.name, err)
+				s.setErr(err)
+				s.deadLetter(e)
+			}
+		case <-ticker.C:
+			s.replay(c)
+		}
+	}
+}
+
+// send sends an event to the broker, retrying with an exponential backoff
+func (s *sink) send(c context.Context, e *sdk.Event) error {
+	var err error
+	delay := time.Second
+	for i := 0; i <= s.maxRetries; i++ {
+		if err = s.broker.sendEvent(e); err == nil {
+			return nil
+		}
+		if i == s.maxRetries {
+			break
+		}
+		select {
+		case <-c.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	return err
+}
+
+func (s *sink) delivered(err error) {
+	if s.onSend != nil {
+		s.onSend(err)
+	}
+}
+
+func (s *sink) deadLetter(e sdk.Event) {
+	if store == nil {
+		return
+	}
+	if store.QueueLen(s.deadLetterKey()) >= s.deadLetterMaxSize {
+		log.Error("event> dead-letter queue of %s is full, dropping event [%s: %s/%s]", s.name, e.EventType, e.ProjectKey, e.WorkflowName)
+		return
+	}
+	store.Enqueue(s.deadLetterKey(), e)
+}
+
+// replay sends the events of the dead-letter queue. It stops on the first failure,
+// the failed event is queued again
+func (s *sink) replay(c context.Context) {
+	if store == nil {
+		return
+	}
+	for store.QueueLen(s.deadLetterKey()) > 0 {
+		ctx, cancel := context.WithTimeout(c, 2*time.Second)
+		e := sdk.Event{}
+		store.DequeueWithContext(ctx, s.deadLetterKey(), &e)
+		cancel()
+		if c.Err() != nil || e.EventType == "" {
+			return
+		}
+		err := s.broker.sendEvent(&e)
+		s.delivered(err)
+		if err != nil {
+			s.setErr(err)
+			store.Enqueue(s.deadLetterKey(), e)
+			return
+		}
+		s.setErr(nil)
+	}
+	s.setErr(nil)
+}
+
+func (s *sink) err() error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.lastErr
+}
+
+func (s *sink) setErr(err error) {
+	s.mutex.Lock()
+	s.lastErr = err
+	s.mutex.Unlock()
+}
+
+func (s *sink) status() string {
+	st := s.broker.status()
+	if err := s.err(); err != nil {
+		st = fmt.Sprintf("%s KO (%v)", s.name, err)
+	}
+	if store != nil {
+		if n := store.QueueLen(s.deadLetterKey()); n > 0 {
+			st += fmt.Sprintf(" [%d in dead-letter]", n)
+		}
+	}
+	return st
+}
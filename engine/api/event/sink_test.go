@@ -0,0 +1,80 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestFilterMatch(t *testing.T) {
+	e := &sdk.Event{EventType: "sdk.EventRunWorkflow", ProjectKey: "PROJ"}
+
+	assert.True(t, Filter{}.Match(e))
+	assert.True(t, Filter{EventTypes: []string{"EventRunWorkflow"}}.Match(e))
+	assert.True(t, Filter{EventTypes: []string{"sdk.EventRunWorkflow"}, ProjectKeys: []string{"OTHER", "PROJ"}}.Match(e))
+	assert.False(t, Filter{EventTypes: []string{"EventRunWorkflowNode"}}.Match(e))
+	assert.False(t, Filter{EventTypes: []string{"EventRunWorkflow"}, ProjectKeys: []string{"OTHER"}}.Match(e))
+}
+
+func TestWebhookSendEvent(t *testing.T) {
+	var statusCode = http.StatusOK
+	var received []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, "sdk.EventRunWorkflow", r.Header.Get(WebhookHeaderEventType))
+		assert.Equal(t, webhookSignature("my-secret", body), r.Header.Get(WebhookHeaderSignature))
+		received = append(received, string(body))
+		w.WriteHeader(statusCode)
+	}))
+	defer srv.Close()
+
+	b, err := getBroker("webhook", WebhookConfig{SinkConfig: SinkConfig{Name: "test"}, URL: srv.URL, Secret: "my-secret"})
+	assert.NoError(t, err)
+
+	e := &sdk.Event{EventType: "sdk.EventRunWorkflow", ProjectKey: "PROJ"}
+	assert.NoError(t, b.sendEvent(e))
+	assert.Len(t, received, 1)
+
+	statusCode = http.StatusInternalServerError
+	assert.Error(t, b.sendEvent(e))
+}
+
+func TestWebhookSignature(t *testing.T) {
+	// echo -n '{"a":1}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=aa9e2e3575f5d7098b6caccd790888c36d5fdb63342a73bada2d6a51747a8494", webhookSignature("secret", []byte(`{"a":1}`)))
+}
+
+type failingBroker struct {
+	failures int
+	calls    int
+}
+
+func (b *failingBroker) initialize(options interface{}) (Broker, error) { return b, nil }
+func (b *failingBroker) status() string                                 { return "Failing OK" }
+func (b *failingBroker) close()                                         {}
+func (b *failingBroker) sendEvent(event *sdk.Event) error {
+	b.calls++
+	if b.calls <= b.failures {
+		return fmt.Errorf("failure %d", b.calls)
+	}
+	return nil
+}
+
+func TestSinkSendRetries(t *testing.T) {
+	b := &failingBroker{failures: 1}
+	s := newSink(SinkConfig{Name: "test", MaxRetries: 1}, b)
+	assert.NoError(t, s.send(context.Background(), &sdk.Event{}))
+	assert.Equal(t, 2, b.calls)
+
+	b = &failingBroker{failures: 2}
+	s = newSink(SinkConfig{Name: "test", MaxRetries: 1}, b)
+	assert.Error(t, s.send(context.Background(), &sdk.Event{}))
+	assert.Equal(t, 2, b.calls)
+}
@@ -0,0 +1,100 @@
+package event
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	// WebhookHeaderEventType is the header containing the type of the event sent to a webhook
+	WebhookHeaderEventType = "X-CDS-Event-Type"
+	// WebhookHeaderSignature is the header containing the signature of the body sent to a webhook:
+	// sha256=<hex encoded HMAC-SHA256 of the body with the webhook secret>
+	WebhookHeaderSignature = "X-CDS-Signature"
+)
+
+// WebhookClient posts events on an HTTP endpoint
+type WebhookClient struct {
+	options WebhookConfig
+	client  *http.Client
+}
+
+// WebhookConfig handles all config to post events on an HTTP endpoint
+type WebhookConfig struct {
+	SinkConfig
+	URL     string
+	Secret  string
+	Timeout time.Duration
+}
+
+func (c *WebhookClient) initialize(options interface{}) (Broker, error) {
+	conf, ok := options.(WebhookConfig)
+	if !ok {
+		return nil, fmt.Errorf("Invalid Webhook Initialization")
+	}
+
+	if conf.URL == "" {
+		return nil, fmt.Errorf("initWebhook> Invalid Webhook Configuration %s", conf.Name)
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = 10 * time.Second
+	}
+	c.options = conf
+	c.client = &http.Client{Timeout: conf.Timeout}
+	return c, nil
+}
+
+// webhookSignature returns the value of the signature header for a body
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body) // nolint
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// sendEvent posts the event, any status other than 2xx is an error
+func (c *WebhookClient) sendEvent(event *sdk.Event) error {
+	data, errm := json.Marshal(event)
+	if errm != nil {
+		return errm
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.options.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "CDS/"+sdk.VERSION)
+	req.Header.Set(WebhookHeaderEventType, event.EventType)
+	if c.options.Secret != "" {
+		req.Header.Set(WebhookHeaderSignature, webhookSignature(c.options.Secret, data))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned HTTP %d", c.options.Name, resp.StatusCode)
+	}
+	return nil
+}
+
+// status: the endpoint is only checked when sending events, the sink reports the failures
+func (c *WebhookClient) status() string {
+	return fmt.Sprintf("Webhook %s OK", c.options.Name)
+}
+
+func (c *WebhookClient) close() {}
@@ -62,6 +62,7 @@ func (h *grpcHandlers) SendLog(stream grpc.WorkflowQueue_SendLogServer) error {
 		if err := workflow.AddLog(db, nil, in); err != nil {
 			return sdk.WrapError(err, "Unable to insert log ")
 		}
+		workflow.PublishStepLogs(h.store, in)
 	}
 }
 
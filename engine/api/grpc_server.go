@@ -10,6 +10,7 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	"github.com/ovh/cds/engine/api/auth"
+	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/database"
 	cdsgrpc "github.com/ovh/cds/engine/api/grpc"
 	"github.com/ovh/cds/sdk"
@@ -17,7 +18,7 @@ import (
 )
 
 // grpcInit initialize all GRPC services
-func grpcInit(dbConnectionFactory *database.DBConnectionFactory, addr string, port int, tls bool, certFile, keyFile string) error {
+func grpcInit(dbConnectionFactory *database.DBConnectionFactory, store cache.Store, addr string, port int, tls bool, certFile, keyFile string) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", addr, port))
 	if err != nil {
 		return err
@@ -27,6 +28,7 @@ func grpcInit(dbConnectionFactory *database.DBConnectionFactory, addr string, po
 
 	grpcHandlers := &grpcHandlers{
 		dbConnectionFactory: dbConnectionFactory,
+		store:               store,
 	}
 
 	opts := []grpc.ServerOption{
@@ -253,7 +253,7 @@ func CreateHook(tx gorp.SqlExecutor, store cache.Store, proj *sdk.Project, rm, r
 	if server == nil {
 		return nil, fmt.Errorf("Unable to find repository manager")
 	}
-	client, err := repositoriesmanager.AuthorizedClient(context.Background(), tx, store, server)
+	client, err := repositoriesmanager.AuthorizedClient(context.Background(), tx, store, proj.Key, server)
 	if err != nil {
 		return nil, sdk.WrapError(err, "Cannot get client, got  %s %s", proj.Key, rm)
 	}
@@ -0,0 +1,49 @@
+package logstore
+
+import (
+	"database/sql"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// databaseStore keeps the content of step logs in the value column of workflow_node_run_job_logs
+type databaseStore struct{}
+
+func (databaseStore) Name() string {
+	return DatabaseName
+}
+
+func (databaseStore) Status() sdk.MonitoringStatusLine {
+	return sdk.MonitoringStatusLine{Component: "Log-Store", Value: "Database", Status: sdk.MonitoringStatusOK}
+}
+
+func (databaseStore) Store(db gorp.SqlExecutor, l *sdk.Log, data io.Reader) error {
+	b, err := ioutil.ReadAll(data)
+	if err != nil {
+		return sdk.WrapError(err, "logstore.Database.Store> Unable to read log %d", l.Id)
+	}
+	if _, err := db.Exec("UPDATE workflow_node_run_job_logs SET value = $2 WHERE id = $1", l.Id, string(b)); err != nil {
+		return sdk.WrapError(err, "logstore.Database.Store> Unable to store log %d", l.Id)
+	}
+	return nil
+}
+
+func (databaseStore) Fetch(db gorp.SqlExecutor, l *sdk.Log) (io.ReadCloser, error) {
+	var value sql.NullString
+	if err := db.QueryRow("SELECT value FROM workflow_node_run_job_logs WHERE id = $1", l.Id).Scan(&value); err != nil {
+		return nil, sdk.WrapError(err, "logstore.Database.Fetch> Unable to load log %d", l.Id)
+	}
+	return ioutil.NopCloser(strings.NewReader(value.String)), nil
+}
+
+func (databaseStore) Delete(db gorp.SqlExecutor, l *sdk.Log) error {
+	if _, err := db.Exec("UPDATE workflow_node_run_job_logs SET value = '' WHERE id = $1", l.Id); err != nil {
+		return sdk.WrapError(err, "logstore.Database.Delete> Unable to delete log %d", l.Id)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package logstore
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// FilesystemStore stores the content of step logs in files
+type FilesystemStore struct {
+	basedir string
+}
+
+// NewFilesystemStore creates a new log store on the given directory
+func NewFilesystemStore(basedir string) (*FilesystemStore, error) {
+	log.Info("Logstore> Initialize Filesystem driver on directory: %s", basedir)
+	if basedir == "" {
+		return nil, fmt.Errorf("log storage is filesystem, but base directory is not provided")
+	}
+	return &FilesystemStore{basedir: basedir}, nil
+}
+
+// Name returns the name of the store
+func (fss *FilesystemStore) Name() string {
+	return FilesystemName
+}
+
+// Status returns filesystem storage status
+func (fss *FilesystemStore) Status() sdk.MonitoringStatusLine {
+	if _, err := os.Stat(fss.basedir); err != nil {
+		return sdk.MonitoringStatusLine{Component: "Log-Store", Value: "Filesystem Storage KO (" + err.Error() + ")", Status: sdk.MonitoringStatusAlert}
+	}
+	return sdk.MonitoringStatusLine{Component: "Log-Store", Value: "Filesystem Storage", Status: sdk.MonitoringStatusOK}
+}
+
+// Store writes the step log in a file
+func (fss *FilesystemStore) Store(db gorp.SqlExecutor, l *sdk.Log, data io.Reader) error {
+	o := logObject{l}
+	dir := filepath.Join(fss.basedir, o.GetPath())
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return sdk.WrapError(err, "logstore.Filesystem.Store> Unable to create directory %s", dir)
+	}
+	f, err := os.OpenFile(filepath.Join(dir, o.GetName()), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return sdk.WrapError(err, "logstore.Filesystem.Store> Unable to open log %d", l.Id)
+	}
+	if _, err := io.Copy(f, data); err != nil {
+		f.Close()
+		return sdk.WrapError(err, "logstore.Filesystem.Store> Unable to write log %d", l.Id)
+	}
+	return f.Close()
+}
+
+// Fetch opens the file of the step log
+func (fss *FilesystemStore) Fetch(db gorp.SqlExecutor, l *sdk.Log) (io.ReadCloser, error) {
+	o := logObject{l}
+	return os.Open(filepath.Join(fss.basedir, o.GetPath(), o.GetName()))
+}
+
+// Delete removes the file of the step log
+func (fss *FilesystemStore) Delete(db gorp.SqlExecutor, l *sdk.Log) error {
+	o := logObject{l}
+	if err := os.Remove(filepath.Join(fss.basedir, o.GetPath(), o.GetName())); err != nil && !os.IsNotExist(err) {
+		return sdk.WrapError(err, "logstore.Filesystem.Delete> Unable to delete log %d", l.Id)
+	}
+	return nil
+}
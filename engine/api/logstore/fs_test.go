@@ -0,0 +1,38 @@
+package logstore
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestFilesystemStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cds-logstore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := NewFilesystemStore(dir)
+	assert.NoError(t, err)
+
+	l := &sdk.Log{Id: 3, PipelineBuildID: 1, PipelineBuildJobID: 2}
+	assert.NoError(t, store.Store(nil, l, strings.NewReader("my logs")))
+
+	r, err := store.Fetch(nil, l)
+	if !assert.NoError(t, err) {
+		return
+	}
+	b, err := ioutil.ReadAll(r)
+	r.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, "my logs", string(b))
+
+	assert.NoError(t, store.Delete(nil, l))
+	_, err = store.Fetch(nil, l)
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, store.Delete(nil, l))
+}
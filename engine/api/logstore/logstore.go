@@ -0,0 +1,105 @@
+package logstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/sdk"
+)
+
+// Names of the log stores, saved with each step log to know where its content is
+const (
+	DatabaseName    = "database"
+	FilesystemName  = "filesystem"
+	ObjectstoreName = "objectstore"
+)
+
+var current Store
+var stores = map[string]Store{}
+
+// Store stores the content of step logs. Metadata of step logs (step order, dates...) always stay in database.
+// The database handler is given to each call for stores that need to work in the current transaction.
+// - Database (default)
+// - Filesystem
+// - Objectstore
+type Store interface {
+	Name() string
+	Status() sdk.MonitoringStatusLine
+	Store(db gorp.SqlExecutor, l *sdk.Log, data io.Reader) error
+	Fetch(db gorp.SqlExecutor, l *sdk.Log) (io.ReadCloser, error)
+	Delete(db gorp.SqlExecutor, l *sdk.Log) error
+}
+
+// Kind defines all supported log stores
+type Kind int
+
+// These are the defined log stores
+const (
+	Database Kind = iota
+	Filesystem
+	Objectstore
+)
+
+// Config represents the configuration of the log store
+type Config struct {
+	Kind    Kind
+	Basedir string
+}
+
+// Initialize setup wanted log store. The database store is always available to read logs that have not been migrated.
+func Initialize(c context.Context, cfg Config) error {
+	s, err := New(c, cfg)
+	if err != nil {
+		return err
+	}
+	stores = map[string]Store{DatabaseName: databaseStore{}}
+	stores[s.Name()] = s
+	current = s
+	return nil
+}
+
+// New initializes a new log store
+func New(c context.Context, cfg Config) (Store, error) {
+	switch cfg.Kind {
+	case Database:
+		return databaseStore{}, nil
+	case Filesystem:
+		return NewFilesystemStore(cfg.Basedir)
+	case Objectstore:
+		if objectstore.Storage() == nil {
+			return nil, fmt.Errorf("objectstore is not initialized")
+		}
+		return NewObjectstoreStore(objectstore.Storage()), nil
+	default:
+		return nil, fmt.Errorf("invalid log store kind %d", cfg.Kind)
+	}
+}
+
+// Current returns the log store where logs of finished runs are moved to
+func Current() Store {
+	if current == nil {
+		return databaseStore{}
+	}
+	return current
+}
+
+// Get returns the log store with the given name
+func Get(name string) (Store, error) {
+	if name == "" || name == DatabaseName {
+		return databaseStore{}, nil
+	}
+	s, ok := stores[name]
+	if !ok {
+		return nil, fmt.Errorf("log store %s is not available", name)
+	}
+	return s, nil
+}
+
+// Status is for status handler
+func Status() sdk.MonitoringStatusLine {
+	return Current().Status()
+}
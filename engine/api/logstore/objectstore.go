@@ -0,0 +1,73 @@
+package logstore
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/sdk"
+)
+
+// logObject implements objectstore.Object for a step log. Logs are stored by node run and job run,
+// and named after their id to keep logs of previous attempts of a job.
+type logObject struct {
+	*sdk.Log
+}
+
+func (o logObject) GetName() string {
+	return fmt.Sprintf("%d.log", o.Id)
+}
+
+func (o logObject) GetPath() string {
+	return fmt.Sprintf("logs/%d/%d", o.PipelineBuildID, o.PipelineBuildJobID)
+}
+
+// ObjectstoreStore stores the content of step logs with the objectstore driver used for artifacts
+type ObjectstoreStore struct {
+	driver objectstore.Driver
+}
+
+// NewObjectstoreStore creates a new log store on top of an objectstore driver
+func NewObjectstoreStore(driver objectstore.Driver) *ObjectstoreStore {
+	return &ObjectstoreStore{driver: driver}
+}
+
+// Name returns the name of the store
+func (s *ObjectstoreStore) Name() string {
+	return ObjectstoreName
+}
+
+// Status returns the objectstore status
+func (s *ObjectstoreStore) Status() sdk.MonitoringStatusLine {
+	st := s.driver.Status()
+	st.Component = "Log-Store"
+	return st
+}
+
+// Store uploads the step log
+func (s *ObjectstoreStore) Store(db gorp.SqlExecutor, l *sdk.Log, data io.Reader) error {
+	if _, err := s.driver.Store(logObject{l}, ioutil.NopCloser(data)); err != nil {
+		return sdk.WrapError(err, "logstore.Objectstore.Store> Unable to store log %d", l.Id)
+	}
+	return nil
+}
+
+// Fetch downloads the step log
+func (s *ObjectstoreStore) Fetch(db gorp.SqlExecutor, l *sdk.Log) (io.ReadCloser, error) {
+	r, err := s.driver.Fetch(logObject{l})
+	if err != nil {
+		return nil, sdk.WrapError(err, "logstore.Objectstore.Fetch> Unable to fetch log %d", l.Id)
+	}
+	return r, nil
+}
+
+// Delete removes the step log
+func (s *ObjectstoreStore) Delete(db gorp.SqlExecutor, l *sdk.Log) error {
+	if err := s.driver.Delete(logObject{l}); err != nil {
+		return sdk.WrapError(err, "logstore.Objectstore.Delete> Unable to delete log %d", l.Id)
+	}
+	return nil
+}
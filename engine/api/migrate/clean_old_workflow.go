@@ -137,7 +137,7 @@ func cleanApplicationHook(db *gorp.DbMap, store cache.Store, wg *sync.WaitGroup,
 	if vcsServer == nil {
 		return
 	}
-	client, err := repositoriesmanager.AuthorizedClient(context.Background(), db, store, vcsServer)
+	client, err := repositoriesmanager.AuthorizedClient(context.Background(), db, store, p.Key, vcsServer)
 	if err != nil {
 		log.Error("cleanApplicationHook> Cannot connect to repository manager: %s", err)
 		return
@@ -0,0 +1,252 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	objectStoreMigrationBatchSize = 100
+	objectStoreMigrationLockTTL   = 5 * 60
+	objectStoreMigrationMaxErrors = 20
+)
+
+var (
+	objectStoreMigrationKey     = cache.Key("objectstore", "migration")
+	objectStoreMigrationLockKey = cache.Key("objectstore", "migration", "lock")
+)
+
+// ObjectStoreMigrationStatus returns the progress of the last objectstore migration
+func ObjectStoreMigrationStatus(store cache.Store) (*sdk.ObjectStoreMigration, error) {
+	var m sdk.ObjectStoreMigration
+	if !store.Get(objectStoreMigrationKey, &m) {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "no objectstore migration found")
+	}
+	return &m, nil
+}
+
+// StartObjectStoreMigration starts the copy of all artifacts and static files from an objectstore driver to another one.
+// Drivers are named objectstore.PrimaryStorage or objectstore.MirrorStorage. When the destination is the primary storage,
+// object paths and public urls are updated in database.
+func StartObjectStoreMigration(ctx context.Context, store cache.Store, DBFunc func() *gorp.DbMap, from, to string, panicCallback func(s string) (io.WriteCloser, error)) (*sdk.ObjectStoreMigration, error) {
+	if from == to {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "source and destination objectstores must be different")
+	}
+	fromDriver, err := objectstore.StorageByName(from)
+	if err != nil {
+		return nil, err
+	}
+	toDriver, err := objectstore.StorageByName(to)
+	if err != nil {
+		return nil, err
+	}
+
+	if !store.Lock(objectStoreMigrationLockKey, objectStoreMigrationLockTTL*time.Second, 0, 1) {
+		return nil, sdk.NewErrorFrom(sdk.ErrConflict, "an objectstore migration is already running")
+	}
+
+	db := DBFunc()
+	nbArtifacts, err := workflow.CountArtifacts(db)
+	if err != nil {
+		store.Unlock(objectStoreMigrationLockKey)
+		return nil, sdk.WrapError(err, "cannot count artifacts")
+	}
+	nbStaticFiles, err := workflow.CountStaticFiles(db)
+	if err != nil {
+		store.Unlock(objectStoreMigrationLockKey)
+		return nil, sdk.WrapError(err, "cannot count static files")
+	}
+
+	m := &sdk.ObjectStoreMigration{
+		From:    from,
+		To:      to,
+		Status:  sdk.StatusBuilding,
+		Total:   nbArtifacts + nbStaticFiles,
+		Started: time.Now(),
+		Updated: time.Now(),
+	}
+	store.Set(objectStoreMigrationKey, m)
+
+	sdk.GoRoutine(ctx, "ObjectStoreMigration", func(ctx context.Context) {
+		defer store.Unlock(objectStoreMigrationLockKey)
+
+		// The lock is refreshed while the migration is running, whatever the time needed to copy an object
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			tick := time.NewTicker(objectStoreMigrationLockTTL * time.Second / 3)
+			defer tick.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-tick.C:
+					store.SetWithTTL(objectStoreMigrationLockKey, true, objectStoreMigrationLockTTL)
+				}
+			}
+		}()
+
+		runObjectStoreMigration(ctx, store, DBFunc, fromDriver, toDriver, to == objectstore.PrimaryStorage, *m)
+	}, panicCallback)
+
+	return m, nil
+}
+
+func runObjectStoreMigration(ctx context.Context, store cache.Store, DBFunc func() *gorp.DbMap, from, to objectstore.Driver, updateReferences bool, m sdk.ObjectStoreMigration) {
+	log.Info("ObjectStoreMigration> Begin copy from %s to %s", m.From, m.To)
+
+	progress := func(err error) {
+		if err != nil {
+			m.Failed++
+			m.LastErrors = append(m.LastErrors, err.Error())
+			if len(m.LastErrors) > objectStoreMigrationMaxErrors {
+				m.LastErrors = m.LastErrors[1:]
+			}
+		} else {
+			m.Copied++
+		}
+		m.Updated = time.Now()
+		store.Set(objectStoreMigrationKey, m)
+	}
+
+	skip := func(reason string) {
+		log.Warning("ObjectStoreMigration> %s", reason)
+		m.Skipped++
+		m.Updated = time.Now()
+		store.Set(objectStoreMigrationKey, m)
+	}
+
+	end := func(status sdk.Status) {
+		now := time.Now()
+		m.Status = status
+		m.Updated = now
+		m.Done = &now
+		store.Set(objectStoreMigrationKey, m)
+		log.Info("ObjectStoreMigration> %s: %d copied, %d failed, %d skipped on %d", status, m.Copied, m.Failed, m.Skipped, m.Total)
+	}
+
+	var lastID int64
+	for {
+		arts, err := workflow.LoadArtifactsFromID(DBFunc(), lastID, objectStoreMigrationBatchSize)
+		if err != nil {
+			log.Error("ObjectStoreMigration> %v", err)
+			end(sdk.StatusFail)
+			return
+		}
+		if len(arts) == 0 {
+			break
+		}
+		for i := range arts {
+			if ctx.Err() != nil {
+				end(sdk.StatusStopped)
+				return
+			}
+			a := &arts[i]
+			lastID = a.ID
+			objectPath, err := objectstore.Copy(from, to, a, a.SHA512sum)
+			if err == nil && updateReferences {
+				err = workflow.UpdateArtifactObjectPath(DBFunc(), a.ID, objectPath)
+			}
+			if err != nil {
+				err = fmt.Errorf("artifact %d %s: %v", a.ID, a.Name, err)
+				log.Warning("ObjectStoreMigration> %v", err)
+			}
+			progress(err)
+		}
+	}
+
+	lastID = 0
+	for {
+		sfs, err := workflow.LoadStaticFilesFromID(DBFunc(), lastID, objectStoreMigrationBatchSize)
+		if err != nil {
+			log.Error("ObjectStoreMigration> %v", err)
+			end(sdk.StatusFail)
+			return
+		}
+		if len(sfs) == 0 {
+			break
+		}
+		for i := range sfs {
+			if ctx.Err() != nil {
+				end(sdk.StatusStopped)
+				return
+			}
+			sf := &sfs[i]
+			lastID = sf.ID
+			if sf.NodeJobRunID == 0 {
+				found, err := resolveStaticFilesNodeJobRun(DBFunc(), from, sf)
+				if err != nil {
+					progress(fmt.Errorf("static files %d %s: %v", sf.ID, sf.Name, err))
+					continue
+				}
+				if !found {
+					skip(fmt.Sprintf("static files %d %s skipped: no job of node run %d has uploaded them", sf.ID, sf.Name, sf.NodeRunID))
+					continue
+				}
+			}
+			publicURL, err := objectstore.CopyStaticFiles(from, to, sf, sf.EntryPoint)
+			if err == nil && updateReferences {
+				err = workflow.UpdateStaticFilesPublicURL(DBFunc(), sf.ID, publicURL)
+			}
+			if err != nil {
+				err = fmt.Errorf("static files %d %s: %v", sf.ID, sf.Name, err)
+				log.Warning("ObjectStoreMigration> %v", err)
+			}
+			progress(err)
+		}
+	}
+
+	if m.Failed > 0 {
+		end(sdk.StatusFail)
+		return
+	}
+	end(sdk.StatusSuccess)
+}
+
+// resolveStaticFilesNodeJobRun looks for the job which uploaded static files stored before the job id was saved.
+// Their location in the objectstore depends on the job id, so the jobs of the node run are tried until the static files are found.
+func resolveStaticFilesNodeJobRun(db gorp.SqlExecutor, from objectstore.Driver, sf *sdk.StaticFiles) (bool, error) {
+	src, ok := from.(objectstore.DriverWithStaticFilesArchive)
+	if !ok {
+		return false, sdk.NewErrorFrom(sdk.ErrNotImplemented, "source objectstore is unable to export static files")
+	}
+	nr, err := workflow.LoadNodeRunByID(db, sf.NodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+	if err != nil {
+		if sdk.Cause(err) == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, s := range nr.Stages {
+		for _, rj := range s.RunJobs {
+			candidate := *sf
+			candidate.NodeJobRunID = rj.ID
+			r, err := src.FetchStaticFiles(&candidate)
+			if err != nil {
+				if sdk.ErrorIs(err, sdk.ErrNotFound) {
+					continue
+				}
+				return false, err
+			}
+			r.Close() // nolint
+			if err := workflow.UpdateStaticFilesNodeJobRunID(db, sf.ID, rj.ID); err != nil {
+				return false, err
+			}
+			sf.NodeJobRunID = rj.ID
+			return true, nil
+		}
+	}
+	return false, nil
+}
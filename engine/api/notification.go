@@ -27,6 +27,27 @@ func (api *API) getUserNotificationTypeHandler() service.Handler {
 				SendToGroups: &sdk.False,
 				Template:     &sdk.UserNotificationTemplateJabber,
 			},
+			sdk.SlackUserNotification: sdk.UserNotificationSettings{
+				OnSuccess: sdk.UserNotificationChange,
+				OnFailure: sdk.UserNotificationAlways,
+				OnStart:   &sdk.False,
+				Template:  &sdk.UserNotificationTemplateSlack,
+			},
+			sdk.MattermostUserNotification: sdk.UserNotificationSettings{
+				OnSuccess: sdk.UserNotificationChange,
+				OnFailure: sdk.UserNotificationAlways,
+				OnStart:   &sdk.False,
+				Template:  &sdk.UserNotificationTemplateSlack,
+			},
+			sdk.TeamsUserNotification: sdk.UserNotificationSettings{
+				OnSuccess: sdk.UserNotificationChange,
+				OnFailure: sdk.UserNotificationAlways,
+				OnStart:   &sdk.False,
+				Template:  &sdk.UserNotificationTemplateTeams,
+			},
+			sdk.VCSUserNotification: sdk.UserNotificationSettings{
+				PRComment: &sdk.False,
+			},
 		}, http.StatusOK)
 	}
 }
@@ -0,0 +1,124 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+var chatHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// WebhookURLsLoader loads the clear webhook urls of the notifications of a workflow run, indexed by notification id
+type WebhookURLsLoader func(db gorp.SqlExecutor, w *sdk.Workflow) (map[int64]string, error)
+
+// chatColor returns the color of the message for a status
+func chatColor(status string) string {
+	switch status {
+	case sdk.StatusSuccess.String():
+		return "#21BA45"
+	case sdk.StatusFail.String():
+		return "#DB2828"
+	}
+	return "#2185D0"
+}
+
+// chatPayload returns the body posted on the incoming webhook of a chat notification
+func chatPayload(notifType string, e sdk.EventNotif, status, buildURL string) (interface{}, error) {
+	switch notifType {
+	case sdk.SlackUserNotification, sdk.MattermostUserNotification:
+		return map[string]interface{}{
+			"text": e.Subject,
+			"attachments": []map[string]interface{}{{
+				"fallback": e.Subject,
+				"color":    chatColor(status),
+				"text":     e.Body,
+			}},
+		}, nil
+	case sdk.TeamsUserNotification:
+		return map[string]interface{}{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"themeColor": strings.TrimPrefix(chatColor(status), "#"),
+			"summary":    e.Subject,
+			"title":      e.Subject,
+			"text":       e.Body,
+			"potentialAction": []map[string]interface{}{{
+				"@type":   "OpenUri",
+				"name":    "Open in CDS",
+				"targets": []map[string]string{{"os": "default", "uri": buildURL}},
+			}},
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported chat notification %s", notifType)
+}
+
+// SendChatNotif posts a notification on the incoming webhook of a Slack, Mattermost or Microsoft Teams channel
+func SendChatNotif(notifType, webhookURL string, e sdk.EventNotif, status, buildURL string) {
+	if err := sendChatNotif(notifType, webhookURL, e, status, buildURL); err != nil {
+		log.Warning("notification.SendChatNotif> unable to send %s notification %s: %v", notifType, e.Subject, err)
+	}
+}
+
+func sendChatNotif(notifType, webhookURL string, e sdk.EventNotif, status, buildURL string) error {
+	payload, err := chatPayload(notifType, e, status, buildURL)
+	if err != nil {
+		return err
+	}
+	btes, err := json.Marshal(payload)
+	if err != nil {
+		return sdk.WrapError(err, "unable to marshal payload")
+	}
+
+	resp, err := chatHTTPClient.Post(webhookURL, "application/json", bytes.NewReader(btes))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// failedJobs returns the names of the failed jobs of a node run
+func failedJobs(nr sdk.WorkflowNodeRun) string {
+	var names []string
+	for _, s := range nr.Stages {
+		for _, j := range s.RunJobs {
+			if j.Status == sdk.StatusFail.String() {
+				names = append(names, j.Job.Action.Name)
+			}
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// commitsSummary returns one line per commit of a node run
+func commitsSummary(nr sdk.WorkflowNodeRun) string {
+	var buf bytes.Buffer
+	for _, c := range nr.Commits {
+		hash := c.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		author := c.Author.DisplayName
+		if author == "" {
+			author = c.Author.Name
+		}
+		msg := strings.SplitN(c.Message, "\n", 2)[0]
+		fmt.Fprintf(&buf, "- %s %s (%s)\n", hash, msg, author)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestSendChatNotif(t *testing.T) {
+	var payload map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+	}))
+	defer srv.Close()
+
+	e := sdk.EventNotif{Subject: "PROJ/wf#1 Fail", Body: "Failed jobs: build"}
+
+	assert.NoError(t, sendChatNotif(sdk.SlackUserNotification, srv.URL, e, sdk.StatusFail.String(), "http://cds/run/1"))
+	assert.Equal(t, "PROJ/wf#1 Fail", payload["text"])
+
+	assert.NoError(t, sendChatNotif(sdk.TeamsUserNotification, srv.URL, e, sdk.StatusFail.String(), "http://cds/run/1"))
+	assert.Equal(t, "MessageCard", payload["@type"])
+	assert.Equal(t, "DB2828", payload["themeColor"])
+
+	assert.Error(t, sendChatNotif(sdk.EmailUserNotification, srv.URL, e, sdk.StatusFail.String(), "http://cds/run/1"))
+}
+
+func TestCommitsSummary(t *testing.T) {
+	nr := sdk.WorkflowNodeRun{
+		Commits: []sdk.VCSCommit{
+			{Hash: "0123456789abcdef", Message: "fix build\n\nlong description", Author: sdk.VCSAuthor{Name: "john"}},
+			{Hash: "fedcba", Message: "add test", Author: sdk.VCSAuthor{Name: "jane", DisplayName: "Jane"}},
+		},
+	}
+	assert.Equal(t, "- 0123456 fix build (john)\n- fedcba add test (Jane)", commitsSummary(nr))
+}
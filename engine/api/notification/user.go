@@ -21,9 +21,10 @@ func Init(uiurl string) {
 	uiURL = uiurl
 }
 
-// GetUserWorkflowEvents return events to send for the given workflow run
-func GetUserWorkflowEvents(db gorp.SqlExecutor, w sdk.Workflow, previousWR *sdk.WorkflowNodeRun, nr sdk.WorkflowNodeRun) []sdk.EventNotif {
+// GetUserWorkflowEvents return events to send for the given workflow run, the webhook urls of the chat notifications are loaded when they are needed
+func GetUserWorkflowEvents(db gorp.SqlExecutor, w sdk.Workflow, previousWR *sdk.WorkflowNodeRun, nr sdk.WorkflowNodeRun, loadWebhookURLs WebhookURLsLoader) []sdk.EventNotif {
 	events := []sdk.EventNotif{}
+	var webhookURLs map[int64]string
 
 	//Compute notification
 	params := map[string]string{}
@@ -43,6 +44,8 @@ func GetUserWorkflowEvents(db gorp.SqlExecutor, w sdk.Workflow, previousWR *sdk.
 		params["cds.author"] = p
 	}
 	params["cds.status"] = nr.Status
+	params["cds.failed_jobs"] = failedJobs(nr)
+	params["cds.commits"] = commitsSummary(nr)
 
 	for _, notif := range w.Notifications {
 		if ShouldSendUserWorkflowNotification(notif, nr, previousWR) {
@@ -105,6 +108,30 @@ func GetUserWorkflowEvents(db gorp.SqlExecutor, w sdk.Workflow, previousWR *sdk.
 					log.Error("notification.GetUserWorkflowEvents> unable to handle event %+v: %v", jn, err)
 				}
 				go SendMailNotif(notif)
+
+			case sdk.SlackUserNotification, sdk.MattermostUserNotification, sdk.TeamsUserNotification:
+				if notif.Settings.WebhookURL == "" {
+					log.Warning("notification.GetUserWorkflowEvents> no webhook url on %s notification of workflow %s", notif.Type, w.Name)
+					continue
+				}
+				e, err := getWorkflowEvent(&notif.Settings, params)
+				if err != nil {
+					log.Error("notification.GetUserWorkflowEvents> unable to handle event %+v: %v", notif.Settings, err)
+					continue
+				}
+				if webhookURLs == nil {
+					webhookURLs, err = loadWebhookURLs(db, &w)
+					if err != nil {
+						log.Warning("notification.GetUserWorkflowEvents> unable to load webhook urls of workflow %s: %v", w.Name, err)
+						continue
+					}
+				}
+				webhookURL, ok := webhookURLs[notif.ID]
+				if !ok {
+					log.Warning("notification.GetUserWorkflowEvents> unable to find webhook url on %s notification of workflow %s", notif.Type, w.Name)
+					continue
+				}
+				go SendChatNotif(notif.Type, webhookURL, e, nr.Status, params["cds.buildURL"])
 			}
 		}
 	}
@@ -0,0 +1,135 @@
+package objectstore
+
+import (
+	"archive/tar"
+	"crypto/sha512"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// Copy an object from a driver to another one and returns its object path on the destination driver.
+// The object is checked against sha512sum if it is not empty, then fetched again from the destination to check its integrity.
+func Copy(from, to Driver, o Object, sha512sum string) (string, error) {
+	r, err := from.Fetch(o)
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to fetch %s/%s", o.GetPath(), o.GetName())
+	}
+
+	h := sha512.New()
+	objectPath, err := to.Store(o, ioutil.NopCloser(io.TeeReader(r, h)))
+	r.Close() // nolint
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to store %s/%s", o.GetPath(), o.GetName())
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+	if sha512sum != "" && sum != sha512sum {
+		deleteCorruptObject(to, o)
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid checksum of %s/%s on source objectstore", o.GetPath(), o.GetName())
+	}
+
+	r, err = to.Fetch(o)
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to fetch %s/%s on destination objectstore", o.GetPath(), o.GetName())
+	}
+	defer r.Close()
+	h.Reset()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", sdk.WrapError(err, "unable to read %s/%s on destination objectstore", o.GetPath(), o.GetName())
+	}
+	if hex.EncodeToString(h.Sum(nil)) != sum {
+		deleteCorruptObject(to, o)
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid checksum of %s/%s on destination objectstore", o.GetPath(), o.GetName())
+	}
+
+	return objectPath, nil
+}
+
+// deleteCorruptObject deletes an object whose copy is invalid, so that it is never served from the destination objectstore
+func deleteCorruptObject(d Driver, o Object) {
+	if err := d.Delete(o); err != nil {
+		log.Error("objectstore> unable to delete corrupt object %s/%s: %v", o.GetPath(), o.GetName(), err)
+	}
+}
+
+// CopyStaticFiles serves on a driver the static files served by another one and returns the new public URL.
+// If the destination driver can export its static files, they are checked against the source ones.
+func CopyStaticFiles(from, to Driver, o Object, entrypoint string) (string, error) {
+	src, ok := from.(DriverWithStaticFilesArchive)
+	if !ok {
+		return "", sdk.NewErrorFrom(sdk.ErrNotImplemented, "source objectstore is unable to export static files")
+	}
+
+	r, err := src.FetchStaticFiles(o)
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to fetch static files %s/%s", o.GetPath(), o.GetName())
+	}
+	pr, pw := io.Pipe()
+	sums := make(chan map[string]string, 1)
+	go func() {
+		s, err := tarSHA512sums(pr)
+		pr.CloseWithError(err) // nolint
+		sums <- s
+	}()
+	publicURL, err := to.ServeStaticFiles(o, entrypoint, ioutil.NopCloser(io.TeeReader(r, pw)))
+	r.Close()  // nolint
+	pw.Close() // nolint
+	srcSums := <-sums
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to serve static files %s/%s", o.GetPath(), o.GetName())
+	}
+
+	dst, ok := to.(DriverWithStaticFilesArchive)
+	if !ok {
+		return publicURL, nil
+	}
+	r, err = dst.FetchStaticFiles(o)
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to fetch static files %s/%s on destination objectstore", o.GetPath(), o.GetName())
+	}
+	defer r.Close()
+	dstSums, err := tarSHA512sums(r)
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to read static files %s/%s on destination objectstore", o.GetPath(), o.GetName())
+	}
+	if len(srcSums) != len(dstSums) {
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "static files %s/%s: %d files on source objectstore, %d on destination objectstore", o.GetPath(), o.GetName(), len(srcSums), len(dstSums))
+	}
+	for name, sum := range srcSums {
+		if dstSums[name] != sum {
+			return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid checksum of static file %s on destination objectstore", name)
+		}
+	}
+
+	return publicURL, nil
+}
+
+// tarSHA512sums returns the sha512 of each regular file of a tar archive
+func tarSHA512sums(r io.Reader) (map[string]string, error) {
+	sums := map[string]string{}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			// Consume the padding so that the writer is never blocked
+			_, err := io.Copy(ioutil.Discard, r)
+			return sums, err
+		}
+		if err != nil {
+			return sums, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		h := sha512.New()
+		if _, err := io.Copy(h, tr); err != nil {
+			return sums, err
+		}
+		sums[path.Clean(strings.TrimPrefix(hdr.Name, "./"))] = hex.EncodeToString(h.Sum(nil))
+	}
+}
@@ -0,0 +1,73 @@
+package objectstore
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestCopy(t *testing.T) {
+	from, end := newTestFilesystemStore(t)
+	defer end()
+	to, fake, endS3 := newFakeS3Store(t)
+	defer endS3()
+
+	o := s3TestObject{name: "file.txt", path: "project/1"}
+	_, err := from.Store(o, ioutil.NopCloser(strings.NewReader("content")))
+	assert.NoError(t, err)
+	sum := sha512.Sum512([]byte("content"))
+
+	_, err = Copy(from, to, o, "wrong")
+	assert.Error(t, err)
+	_, has := fake.objects["/cds/artifacts/project/1/file.txt"]
+	assert.False(t, has, "corrupt object should have been deleted")
+
+	objectPath, err := Copy(from, to, o, hex.EncodeToString(sum[:]))
+	assert.NoError(t, err)
+	assert.Equal(t, "cds/artifacts/project/1/file.txt", objectPath)
+	assert.Equal(t, "content", string(fake.objects["/cds/artifacts/project/1/file.txt"]))
+
+	_, err = Copy(from, to, s3TestObject{name: "unknown", path: "project/1"}, "")
+	assert.Error(t, err)
+}
+
+func TestCopyStaticFiles(t *testing.T) {
+	from, _, end := newFakeS3Store(t)
+	defer end()
+	to, fake, endTo := newFakeS3Store(t)
+	defer endTo()
+
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	for name, content := range map[string]string{"index.html": "<html></html>", "css/style.css": "body {}"} {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+
+	o := s3TestObject{name: "website", path: "static/1"}
+	_, err := from.ServeStaticFiles(o, "", ioutil.NopCloser(buf))
+	assert.NoError(t, err)
+
+	publicURL, err := CopyStaticFiles(from, to, o, "index.html")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(publicURL, "/cds/artifacts/static/1/website/index.html"))
+	assert.Equal(t, "body {}", string(fake.objects["/cds/artifacts/static/1/website/css/style.css"]))
+
+	_, err = CopyStaticFiles(from, to, s3TestObject{name: "unknown", path: "static/1"}, "")
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+
+	fss, endFS := newTestFilesystemStore(t)
+	defer endFS()
+	_, err = CopyStaticFiles(fss, to, o, "")
+	assert.Error(t, err)
+}
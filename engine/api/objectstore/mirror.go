@@ -0,0 +1,130 @@
+package objectstore
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// MirrorStore implements ObjectStore interface by writing objects on a primary and a secondary driver.
+// Objects are read from the primary driver, then from the secondary one if the primary fails.
+// Errors on the secondary driver are only logged.
+type MirrorStore struct {
+	primary   Driver
+	secondary Driver
+}
+
+// NewMirrorStore creates a new ObjectStore mirroring the primary driver on the secondary one
+func NewMirrorStore(primary, secondary Driver) *MirrorStore {
+	return &MirrorStore{primary: primary, secondary: secondary}
+}
+
+// Status returns the status of both drivers
+func (m *MirrorStore) Status() sdk.MonitoringStatusLine {
+	p := m.primary.Status()
+	s := m.secondary.Status()
+	status := p.Status
+	if status == sdk.MonitoringStatusOK {
+		status = s.Status
+	}
+	return sdk.MonitoringStatusLine{
+		Component: "Object-Store",
+		Value:     p.Value + " - Mirror " + s.Value,
+		Status:    status,
+	}
+}
+
+// Store stores an object on both drivers and returns the object path of the primary driver
+func (m *MirrorStore) Store(o Object, data io.ReadCloser) (string, error) {
+	var objectPath string
+	err := m.mirror(data, func(r io.ReadCloser) error {
+		var err error
+		objectPath, err = m.primary.Store(o, r)
+		return err
+	}, func(r io.ReadCloser) error {
+		_, err := m.secondary.Store(o, r)
+		return err
+	})
+	return objectPath, err
+}
+
+// ServeStaticFiles serves static files on both drivers and returns the public URL of the primary driver
+func (m *MirrorStore) ServeStaticFiles(o Object, entrypoint string, data io.ReadCloser) (string, error) {
+	var publicURL string
+	err := m.mirror(data, func(r io.ReadCloser) error {
+		var err error
+		publicURL, err = m.primary.ServeStaticFiles(o, entrypoint, r)
+		return err
+	}, func(r io.ReadCloser) error {
+		_, err := m.secondary.ServeStaticFiles(o, entrypoint, r)
+		return err
+	})
+	return publicURL, err
+}
+
+// Fetch an object from the primary driver, or from the secondary driver if the primary fails
+func (m *MirrorStore) Fetch(o Object) (io.ReadCloser, error) {
+	r, err := m.primary.Fetch(o)
+	if err == nil {
+		return r, nil
+	}
+	log.Warning("MirrorStore> unable to fetch %s/%s on primary storage, fetching mirror: %v", o.GetPath(), o.GetName(), err)
+	return m.secondary.Fetch(o)
+}
+
+// FetchStaticFiles returns the static files served by the primary driver, or by the secondary driver
+func (m *MirrorStore) FetchStaticFiles(o Object) (io.ReadCloser, error) {
+	if d, ok := m.primary.(DriverWithStaticFilesArchive); ok {
+		r, err := d.FetchStaticFiles(o)
+		if err == nil {
+			return r, nil
+		}
+		log.Warning("MirrorStore> unable to fetch static files %s/%s on primary storage: %v", o.GetPath(), o.GetName(), err)
+	}
+	if d, ok := m.secondary.(DriverWithStaticFilesArchive); ok {
+		return d.FetchStaticFiles(o)
+	}
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+// Delete an object from both drivers
+func (m *MirrorStore) Delete(o Object) error {
+	if err := m.secondary.Delete(o); err != nil {
+		log.Warning("MirrorStore> unable to delete %s/%s on mirror storage: %v", o.GetPath(), o.GetName(), err)
+	}
+	return m.primary.Delete(o)
+}
+
+// mirror buffers data on disk to write it with the primary then the secondary function
+func (m *MirrorStore) mirror(data io.ReadCloser, primary, secondary func(io.ReadCloser) error) error {
+	defer data.Close()
+
+	f, err := ioutil.TempFile("", "cds-mirror-")
+	if err != nil {
+		return sdk.WrapError(err, "unable to create temporary file")
+	}
+	defer os.Remove(f.Name()) // nolint
+	defer f.Close()
+
+	if _, err := io.Copy(f, data); err != nil {
+		return sdk.WrapError(err, "unable to buffer object")
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := primary(ioutil.NopCloser(f)); err != nil {
+		return err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := secondary(ioutil.NopCloser(f)); err != nil {
+		log.Warning("MirrorStore> unable to write on mirror storage: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package objectstore
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func newTestFilesystemStore(t *testing.T) (*FilesystemStore, func()) {
+	dir, err := ioutil.TempDir("", "cds-objectstore-")
+	if err != nil {
+		t.Fatalf("unable to create temporary directory: %v", err)
+	}
+	fss, err := NewFilesystemStore(dir)
+	if err != nil {
+		t.Fatalf("unable to create filesystem store: %v", err)
+	}
+	return fss, func() { os.RemoveAll(dir) } // nolint
+}
+
+func TestMirrorStore(t *testing.T) {
+	primary, end := newTestFilesystemStore(t)
+	defer end()
+	secondary, fake, endS3 := newFakeS3Store(t)
+	defer endS3()
+
+	m := NewMirrorStore(primary, secondary)
+	assert.Equal(t, sdk.MonitoringStatusOK, m.Status().Status)
+
+	o := s3TestObject{name: "file.txt", path: "project/1"}
+	objectPath, err := m.Store(o, ioutil.NopCloser(strings.NewReader("content")))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(objectPath, primary.basedir))
+	assert.Equal(t, "content", string(fake.objects["/cds/artifacts/project/1/file.txt"]))
+
+	// The object is fetched from the mirror when it is missing on the primary storage
+	assert.NoError(t, primary.Delete(o))
+	r, err := m.Fetch(o)
+	if assert.NoError(t, err) {
+		b, _ := ioutil.ReadAll(r)
+		r.Close()
+		assert.Equal(t, "content", string(b))
+	}
+
+	assert.NoError(t, m.Delete(o))
+	_, err = m.Fetch(o)
+	assert.Error(t, err)
+	assert.Empty(t, fake.objects)
+
+	// Errors on the mirror are not returned
+	fake.buckets["cds"] = false
+	_, err = m.Store(o, ioutil.NopCloser(strings.NewReader("content")))
+	assert.NoError(t, err)
+}
@@ -31,6 +31,33 @@ func Storage() Driver {
 	return storage
 }
 
+// Names of the drivers of a mirrored objectstore
+const (
+	PrimaryStorage = "primary"
+	MirrorStorage  = "mirror"
+)
+
+// StorageByName returns the primary or the mirror driver of the objectstore
+func StorageByName(name string) (Driver, error) {
+	if storage == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	m, isMirror := storage.(*MirrorStore)
+	switch name {
+	case PrimaryStorage:
+		if isMirror {
+			return m.primary, nil
+		}
+		return storage, nil
+	case MirrorStorage:
+		if isMirror {
+			return m.secondary, nil
+		}
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "objectstore mirror is not configured")
+	}
+	return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid objectstore %s, expected %s or %s", name, PrimaryStorage, MirrorStorage)
+}
+
 //Store an object with default objectstore driver
 func Store(o Object, data io.ReadCloser) (string, error) {
 	if storage != nil {
@@ -81,6 +108,9 @@ func FetchTempURL(o Object) (string, error) {
 // Driver allows artifact to be stored and retrieve the same way to any backend
 // - Openstack / Swift
 // - Filesystem
+// - S3
+// - SSH
+// - Mirror of two drivers
 type Driver interface {
 	Status() sdk.MonitoringStatusLine
 	Store(o Object, data io.ReadCloser) (string, error)
@@ -101,6 +131,12 @@ type DriverWithRedirect interface {
 	GetPublicURL(o Object) (url string, err error)
 }
 
+// DriverWithStaticFilesArchive has to be implemented if your storage backend can export the static files it serves
+type DriverWithStaticFilesArchive interface {
+	// FetchStaticFiles returns a tar archive of the static files served for an object
+	FetchStaticFiles(o Object) (io.ReadCloser, error)
+}
+
 // Initialize setup wanted ObjectStore driver
 func Initialize(c context.Context, cfg Config) error {
 	var err error
@@ -119,18 +155,24 @@ const (
 	Openstack Kind = iota
 	Filesystem
 	Swift
+	S3
+	SSH
 )
 
 // Config represents all the configuration for all objecstore drivers
 type Config struct {
 	Kind    Kind
 	Options ConfigOptions
+	// Mirror is the configuration of a secondary driver on which all objects are also written
+	Mirror *Config
 }
 
 // ConfigOptions is used by Config
 type ConfigOptions struct {
 	Openstack  ConfigOptionsOpenstack
 	Filesystem ConfigOptionsFilesystem
+	S3         ConfigOptionsS3
+	SSH        ConfigOptionsSSH
 }
 
 // ConfigOptionsOpenstack is used by ConfigOptions
@@ -150,31 +192,103 @@ type ConfigOptionsFilesystem struct {
 	Basedir string
 }
 
+// ConfigOptionsS3 is used by ConfigOptions
+type ConfigOptionsS3 struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Prefix          string
+	PublicURL       string
+	DisableTempURL  bool
+}
+
+// ConfigOptionsSSH is used by ConfigOptions
+type ConfigOptionsSSH struct {
+	Host          string
+	Port          int
+	User          string
+	Password      string
+	PrivateKey    string
+	HostKey       string
+	BaseDirectory string
+}
+
 // New initialise a new ArtifactStorage
 func New(c context.Context, cfg Config) (Driver, error) {
+	driver, store, err := newDriver(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Mirror != nil {
+		mirror, mirrorStore, err := newDriver(*cfg.Mirror)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to initialize objectstore mirror")
+		}
+		// Objects uploaded with a temporary URL would only be written on the primary driver
+		store.Name = store.Name + " (mirror " + mirrorStore.Name + ")"
+		store.TemporaryURLSupported = false
+		driver = NewMirrorStore(driver, mirror)
+	}
+
+	instance = store
+	return driver, nil
+}
+
+func newDriver(cfg Config) (Driver, sdk.ArtifactsStore, error) {
 	switch cfg.Kind {
 	case Openstack, Swift:
-		instance = sdk.ArtifactsStore{
+		store := sdk.ArtifactsStore{
 			Name:                  "Swift",
 			Private:               false,
 			TemporaryURLSupported: !cfg.Options.Openstack.DisableTempURL,
 		}
-		return NewSwiftStore(cfg.Options.Openstack.Address,
+		driver, err := NewSwiftStore(cfg.Options.Openstack.Address,
 			cfg.Options.Openstack.Username,
 			cfg.Options.Openstack.Password,
 			cfg.Options.Openstack.Region,
 			cfg.Options.Openstack.Tenant,
 			cfg.Options.Openstack.Domain,
 			cfg.Options.Openstack.ContainerPrefix)
+		return driver, store, err
 	case Filesystem:
-		instance = sdk.ArtifactsStore{
+		store := sdk.ArtifactsStore{
 			Name:                  "Local FS",
 			Private:               false,
 			TemporaryURLSupported: false,
 		}
-		return NewFilesystemStore(cfg.Options.Filesystem.Basedir)
+		driver, err := NewFilesystemStore(cfg.Options.Filesystem.Basedir)
+		if err != nil {
+			return nil, store, err
+		}
+		return driver, store, nil
+	case S3:
+		store := sdk.ArtifactsStore{
+			Name:                  "S3",
+			Private:               false,
+			TemporaryURLSupported: !cfg.Options.S3.DisableTempURL,
+		}
+		driver, err := NewS3Store(cfg.Options.S3)
+		if err != nil {
+			return nil, store, err
+		}
+		return driver, store, nil
+	case SSH:
+		store := sdk.ArtifactsStore{
+			Name:                  "SSH",
+			Private:               false,
+			TemporaryURLSupported: false,
+		}
+		driver, err := NewSSHStore(cfg.Options.SSH)
+		if err != nil {
+			return nil, store, err
+		}
+		return driver, store, nil
 	default:
-		return nil, fmt.Errorf("Invalid flag --artifact-mode")
+		return nil, sdk.ArtifactsStore{}, fmt.Errorf("Invalid flag --artifact-mode")
 	}
 }
 
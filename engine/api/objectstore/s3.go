@@ -0,0 +1,504 @@
+package objectstore
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3TimeFormat      = "20060102T150405Z"
+	s3TempURLExpire   = time.Hour
+)
+
+// S3Store implements ObjectStore interface with a S3 compatible storage (AWS S3, MinIO, Ceph RGW...).
+// Requests are signed with AWS signature v4 and buckets are addressed with path style URLs.
+type S3Store struct {
+	endpoint        *url.URL
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+	prefix          string
+	publicURL       string
+	client          *http.Client
+	now             func() time.Time
+}
+
+// NewS3Store creates a new ObjectStore with S3 driver and creates the bucket if it does not exist
+func NewS3Store(cfg ConfigOptionsS3) (*S3Store, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("artifact storage is s3, but bucket is not provided")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", cfg.Region)
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil {
+		return nil, sdk.WrapError(err, "invalid s3 endpoint %s", cfg.Endpoint)
+	}
+
+	s := &S3Store{
+		endpoint:        endpoint,
+		region:          cfg.Region,
+		bucket:          cfg.Bucket,
+		accessKeyID:     cfg.AccessKeyID,
+		secretAccessKey: cfg.SecretAccessKey,
+		sessionToken:    cfg.SessionToken,
+		prefix:          strings.Trim(cfg.Prefix, "/"),
+		publicURL:       strings.TrimSuffix(cfg.PublicURL, "/"),
+		client:          &http.Client{Timeout: 10 * time.Minute},
+		now:             time.Now,
+	}
+	if s.publicURL == "" {
+		s.publicURL = endpoint.String() + "/" + s.bucket
+	}
+
+	log.Info("Objectstore> Initialize S3 driver on bucket %s at %s", s.bucket, s.endpoint)
+	if err := s.createBucket(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Status returns the status of the bucket
+func (s *S3Store) Status() sdk.MonitoringStatusLine {
+	resp, err := s.do("HEAD", "", nil, -1, nil)
+	if err != nil {
+		return sdk.MonitoringStatusLine{Component: "Object-Store", Value: "S3 KO (" + err.Error() + ")", Status: sdk.MonitoringStatusAlert}
+	}
+	resp.Body.Close()
+	return sdk.MonitoringStatusLine{Component: "Object-Store", Value: fmt.Sprintf("S3 OK (bucket %s)", s.bucket), Status: sdk.MonitoringStatusOK}
+}
+
+// Store stores an object in the bucket
+func (s *S3Store) Store(o Object, data io.ReadCloser) (string, error) {
+	defer data.Close()
+	key := s.objectKey(o)
+	log.Debug("S3Store> Storing %s/%s", s.bucket, key)
+
+	// S3 needs the length of the object, so the object is buffered on disk before the upload
+	f, err := ioutil.TempFile("", "cds-s3-")
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to create temporary file")
+	}
+	defer os.Remove(f.Name()) // nolint
+	defer f.Close()
+
+	size, err := io.Copy(f, data)
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to buffer object %s", key)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", sdk.WrapError(err, "unable to read buffered object %s", key)
+	}
+
+	resp, err := s.do("PUT", key, f, size, map[string]string{"Content-Type": "application/octet-stream"})
+	if err != nil {
+		return "", sdk.WrapError(err, "unable to store object %s", key)
+	}
+	resp.Body.Close()
+
+	return s.bucket + "/" + key, nil
+}
+
+// ServeStaticFiles extracts the tar file in the bucket with a public read ACL and returns the public URL of the entrypoint
+func (s *S3Store) ServeStaticFiles(o Object, entrypoint string, data io.ReadCloser) (string, error) {
+	defer data.Close()
+	if entrypoint == "" {
+		entrypoint = "index.html"
+	}
+	dir := s.objectKey(o)
+	log.Debug("S3Store> Serving static files in %s/%s", s.bucket, dir)
+
+	tr := tar.NewReader(data)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", sdk.WrapError(err, "unable to read static files archive")
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		if strings.HasPrefix(name, "../") {
+			return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid file %s in static files archive", hdr.Name)
+		}
+		contentType := mime.TypeByExtension(path.Ext(name))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		key := dir + "/" + name
+		resp, err := s.do("PUT", key, tr, hdr.Size, map[string]string{
+			"Content-Type": contentType,
+			"X-Amz-Acl":    "public-read",
+		})
+		if err != nil {
+			return "", sdk.WrapError(err, "unable to store static file %s", key)
+		}
+		resp.Body.Close()
+	}
+
+	publicURL, err := s.GetPublicURL(o)
+	if err != nil {
+		return "", err
+	}
+	return publicURL + "/" + entrypoint, nil
+}
+
+// Fetch an object from the bucket
+func (s *S3Store) Fetch(o Object) (io.ReadCloser, error) {
+	key := s.objectKey(o)
+	log.Debug("S3Store> Fetching %s/%s", s.bucket, key)
+
+	resp, err := s.do("GET", key, nil, -1, nil)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to fetch object %s", key)
+	}
+	return resp.Body, nil
+}
+
+// Delete an object from the bucket
+func (s *S3Store) Delete(o Object) error {
+	key := s.objectKey(o)
+	resp, err := s.do("DELETE", key, nil, -1, nil)
+	if err != nil {
+		return sdk.WrapError(err, "unable to delete object %s", key)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// StoreURL returns a presigned url to upload an object and its signature as secret key
+func (s *S3Store) StoreURL(o Object) (string, string, error) {
+	return s.presign("PUT", s.objectKey(o))
+}
+
+// FetchURL returns a presigned url to download an object and its signature as secret key
+func (s *S3Store) FetchURL(o Object) (string, string, error) {
+	return s.presign("GET", s.objectKey(o))
+}
+
+// ServeStaticFilesURL is not supported: S3 does not extract archives uploaded with a presigned url
+func (s *S3Store) ServeStaticFilesURL(o Object, entrypoint string) (string, string, error) {
+	return "", "", sdk.NewErrorFrom(sdk.ErrNotImplemented, "s3 storage does not support static files upload with temporary url")
+}
+
+// GetPublicURL returns a public url to fetch an object (check your object ACLs before)
+func (s *S3Store) GetPublicURL(o Object) (string, error) {
+	return s.publicURL + "/" + s3EscapePath(s.objectKey(o)), nil
+}
+
+// FetchStaticFiles returns a tar archive of the static files served for an object
+func (s *S3Store) FetchStaticFiles(o Object) (io.ReadCloser, error) {
+	dir := s.objectKey(o)
+	keys, err := s.list(dir + "/")
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to list static files in %s", dir)
+	}
+	if len(keys) == 0 {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "no static files found in %s", dir)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		for _, k := range keys {
+			if err := s.tarObject(tw, k.Key, strings.TrimPrefix(k.Key, dir+"/"), k.Size); err != nil {
+				pw.CloseWithError(err) // nolint
+				return
+			}
+		}
+		pw.CloseWithError(tw.Close()) // nolint
+	}()
+	return pr, nil
+}
+
+func (s *S3Store) tarObject(tw *tar.Writer, key, name string, size int64) error {
+	resp, err := s.do("GET", key, nil, -1, nil)
+	if err != nil {
+		return sdk.WrapError(err, "unable to fetch static file %s", key)
+	}
+	defer resp.Body.Close()
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: size, Typeflag: tar.TypeReg}); err != nil {
+		return sdk.WithStack(err)
+	}
+	if _, err := io.Copy(tw, resp.Body); err != nil {
+		return sdk.WrapError(err, "unable to read static file %s", key)
+	}
+	return nil
+}
+
+type s3Object struct {
+	Key  string `xml:"Key"`
+	Size int64  `xml:"Size"`
+}
+
+type s3ListBucketResult struct {
+	Contents              []s3Object `xml:"Contents"`
+	IsTruncated           bool       `xml:"IsTruncated"`
+	NextContinuationToken string     `xml:"NextContinuationToken"`
+}
+
+// list returns all the objects of the bucket starting with prefix
+func (s *S3Store) list(prefix string) ([]s3Object, error) {
+	var objects []s3Object
+	var token string
+	for {
+		u := s.objectURL("")
+		q := url.Values{}
+		q.Set("list-type", "2")
+		q.Set("prefix", prefix)
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+		u.RawQuery = s3CanonicalQuery(q)
+
+		resp, err := s.doURL("GET", u, nil, -1, nil)
+		if err != nil {
+			return nil, err
+		}
+		var res s3ListBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&res)
+		resp.Body.Close()
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to decode bucket listing")
+		}
+
+		objects = append(objects, res.Contents...)
+		if !res.IsTruncated || res.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = res.NextContinuationToken
+	}
+}
+
+// objectKey returns the key of an object in the bucket
+func (s *S3Store) objectKey(o Object) string {
+	return strings.TrimPrefix(path.Join(s.prefix, o.GetPath(), o.GetName()), "/")
+}
+
+// objectURL returns the path style url of an object, or of the bucket if key is empty
+func (s *S3Store) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	u.Path = path.Join("/", u.Path, s.bucket)
+	if key != "" {
+		u.Path += "/" + key
+	}
+	u.RawPath = s3EscapePath(u.Path)
+	return &u
+}
+
+func (s *S3Store) createBucket() error {
+	resp, err := s.do("HEAD", "", nil, -1, nil)
+	if err == nil {
+		resp.Body.Close()
+		return nil
+	}
+	if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return sdk.WrapError(err, "unable to check bucket %s", s.bucket)
+	}
+
+	log.Info("Objectstore> Creating S3 bucket %s", s.bucket)
+	var body []byte
+	if s.region != "us-east-1" {
+		body = []byte(fmt.Sprintf(`<CreateBucketConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><LocationConstraint>%s</LocationConstraint></CreateBucketConfiguration>`, s.region))
+	}
+	resp, err = s.do("PUT", "", bytes.NewReader(body), int64(len(body)), nil)
+	if err != nil {
+		return sdk.WrapError(err, "unable to create bucket %s", s.bucket)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// do sends a signed request, the response body has to be closed by the caller
+func (s *S3Store) do(method, key string, body io.Reader, size int64, headers map[string]string) (*http.Response, error) {
+	return s.doURL(method, s.objectURL(key), body, size, headers)
+}
+
+// doURL sends a signed request to the given url of the bucket
+func (s *S3Store) doURL(method string, u *url.URL, body io.Reader, size int64, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 {
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	s.sign(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		b, _ := ioutil.ReadAll(resp.Body)
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "s3 %s %s: %s", method, req.URL.Path, b)
+		}
+		return nil, fmt.Errorf("s3 %s %s returned HTTP %d: %s", method, req.URL.Path, resp.StatusCode, b)
+	}
+	return resp, nil
+}
+
+// sign adds the AWS signature v4 authorization header to a request
+func (s *S3Store) sign(req *http.Request) {
+	t := s.now().UTC()
+	req.Header.Set("X-Amz-Date", t.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+
+	signedHeaders := []string{"host"}
+	canonicalHeaders := map[string]string{"host": req.URL.Host}
+	for k := range req.Header {
+		lk := strings.ToLower(k)
+		if lk == "content-type" || strings.HasPrefix(lk, "x-amz-") {
+			signedHeaders = append(signedHeaders, lk)
+			canonicalHeaders[lk] = strings.TrimSpace(req.Header.Get(k))
+		}
+	}
+	sort.Strings(signedHeaders)
+
+	var buf bytes.Buffer
+	for _, h := range signedHeaders {
+		buf.WriteString(h + ":" + canonicalHeaders[h] + "\n")
+	}
+
+	scope := s.scope(t)
+	signature := s.signature(t, scope, req.Method, req.URL, buf.String(), strings.Join(signedHeaders, ";"), s3UnsignedPayload)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKeyID, scope, strings.Join(signedHeaders, ";"), signature))
+}
+
+// presign returns a presigned url valid for one hour and its signature
+func (s *S3Store) presign(method, key string) (string, string, error) {
+	t := s.now().UTC()
+	u := s.objectURL(key)
+	scope := s.scope(t)
+
+	q := url.Values{}
+	q.Set("X-Amz-Algorithm", s3Algorithm)
+	q.Set("X-Amz-Credential", s.accessKeyID+"/"+scope)
+	q.Set("X-Amz-Date", t.Format(s3TimeFormat))
+	q.Set("X-Amz-Expires", fmt.Sprintf("%d", int(s3TempURLExpire.Seconds())))
+	q.Set("X-Amz-SignedHeaders", "host")
+	if s.sessionToken != "" {
+		q.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+	u.RawQuery = s3CanonicalQuery(q)
+
+	signature := s.signature(t, scope, method, u, "host:"+u.Host+"\n", "host", s3UnsignedPayload)
+	u.RawQuery += "&X-Amz-Signature=" + signature
+	return u.String(), signature, nil
+}
+
+func (s *S3Store) scope(t time.Time) string {
+	return t.Format("20060102") + "/" + s.region + "/s3/aws4_request"
+}
+
+// signature computes the AWS signature v4 of a canonical request
+func (s *S3Store) signature(t time.Time, scope, method string, u *url.URL, canonicalHeaders, signedHeaders, payloadHash string) string {
+	canonicalRequest := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		s3CanonicalQuery(u.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	h := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		t.Format(s3TimeFormat),
+		scope,
+		hex.EncodeToString(h[:]),
+	}, "\n")
+
+	key := s3HMAC([]byte("AWS4"+s.secretAccessKey), t.Format("20060102"))
+	key = s3HMAC(key, s.region)
+	key = s3HMAC(key, "s3")
+	key = s3HMAC(key, "aws4_request")
+	return hex.EncodeToString(s3HMAC(key, stringToSign))
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data)) // nolint
+	return mac.Sum(nil)
+}
+
+// s3Escape encodes a string as expected by AWS signature v4: only unreserved characters are kept
+func s3Escape(s string, encodeSlash bool) string {
+	var buf bytes.Buffer
+	for _, b := range []byte(s) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~', b == '/' && !encodeSlash:
+			buf.WriteByte(b)
+		default:
+			fmt.Fprintf(&buf, "%%%02X", b)
+		}
+	}
+	return buf.String()
+}
+
+func s3EscapePath(p string) string {
+	return s3Escape(p, false)
+}
+
+func s3CanonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		values := q[k]
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, s3Escape(k, true)+"="+s3Escape(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
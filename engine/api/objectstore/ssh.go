@@ -1,118 +1,199 @@
 package objectstore
 
 import (
-	"bytes"
-	"encoding/base64"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
 
 	"golang.org/x/crypto/ssh"
 )
 
 // SSHStore implements ObjectStore interface with ssh
 type SSHStore struct {
-	p      string
 	h      string
 	config ssh.ClientConfig
 	d      string
+	mutex  sync.Mutex
 	client *ssh.Client
 }
 
-//Status return filesystem storage status
-func (s *SSHStore) Status() sdk.MonitoringStatusLine {
-	if s.client == nil {
-		return sdk.MonitoringStatusLine{Component: "Object-Store", Value: "SSH Storage (no client)", Status: sdk.MonitoringStatusAlert}
+// NewSSHStore creates a new ObjectStore storing objects in a directory of a remote host through ssh
+func NewSSHStore(cfg ConfigOptionsSSH) (*SSHStore, error) {
+	log.Info("Objectstore> Initialize SSH driver on %s@%s:%s", cfg.User, cfg.Host, cfg.BaseDirectory)
+	if cfg.Host == "" || cfg.User == "" || cfg.BaseDirectory == "" {
+		return nil, fmt.Errorf("artifact storage is ssh, but host, user or base directory is not provided")
 	}
-	session, err := s.client.NewSession()
-	if err != nil {
-		return sdk.MonitoringStatusLine{Component: "Object-Store", Value: fmt.Sprintf("SSH Storage (%v)", err), Status: sdk.MonitoringStatusAlert}
+	if cfg.Port == 0 {
+		cfg.Port = 22
 	}
-	session.Close()
-	return sdk.MonitoringStatusLine{Component: "Object-Store", Value: "SSH Storage", Status: sdk.MonitoringStatusOK}
-}
 
-// Copies the encoded contents of an io.Reader to a remote location
-func (s *SSHStore) copy(session *ssh.Session, r io.Reader, path string) error {
-	b, err := ioutil.ReadAll(r)
+	if cfg.HostKey == "" {
+		return nil, fmt.Errorf("artifact storage is ssh, but the host key is not provided, you can get it with ssh-keyscan %s", cfg.Host)
+	}
+	hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(cfg.HostKey))
 	if err != nil {
-		return err
+		return nil, sdk.WrapError(err, "invalid ssh host key")
 	}
 
-	cmd := bytes.NewBufferString("echo \"")
-	cmd.WriteString(base64.StdEncoding.EncodeToString(b))
-	cmd.WriteString("\" >")
-	cmd.WriteString(path)
-
-	return session.Run(cmd.String())
-}
-
-func (s *SSHStore) read(session *ssh.Session, path string) (io.ReadCloser, error) {
-	output, err := session.CombinedOutput("cat " + path)
-	if err != nil {
-		return nil, err
+	var auths []ssh.AuthMethod
+	if cfg.PrivateKey != "" {
+		b, err := ioutil.ReadFile(cfg.PrivateKey)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to read ssh private key %s", cfg.PrivateKey)
+		}
+		signer, err := ssh.ParsePrivateKey(b)
+		if err != nil {
+			return nil, sdk.WrapError(err, "invalid ssh private key %s", cfg.PrivateKey)
+		}
+		auths = append(auths, ssh.PublicKeys(signer))
+	}
+	if cfg.Password != "" {
+		auths = append(auths, ssh.Password(cfg.Password))
+	}
+	if len(auths) == 0 {
+		return nil, fmt.Errorf("artifact storage is ssh, but neither a password nor a private key is provided")
 	}
 
-	output, err = base64.StdEncoding.DecodeString(string(output))
-	if err != nil {
+	s := &SSHStore{
+		h: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		config: ssh.ClientConfig{
+			User:            cfg.User,
+			Auth:            auths,
+			HostKeyCallback: ssh.FixedHostKey(hostKey),
+		},
+		d: cfg.BaseDirectory,
+	}
+	if _, err := s.session(); err != nil {
 		return nil, err
 	}
-
-	return ioutil.NopCloser(bytes.NewReader(output)), nil
+	return s, nil
 }
 
-// Store store a object on disk
-func (s *SSHStore) Store(o Object, data io.ReadCloser) (string, error) {
-	//Create the directory
-	session, err := s.client.NewSession()
+//Status return filesystem storage status
+func (s *SSHStore) Status() sdk.MonitoringStatusLine {
+	session, err := s.session()
 	if err != nil {
-		return "", err
+		return sdk.MonitoringStatusLine{Component: "Object-Store", Value: fmt.Sprintf("SSH Storage (%v)", err), Status: sdk.MonitoringStatusAlert}
 	}
+	defer session.Close()
+	if err := session.Run("test -d " + sshQuote(s.d)); err != nil {
+		return sdk.MonitoringStatusLine{Component: "Object-Store", Value: fmt.Sprintf("SSH Storage (%s: %v)", s.d, err), Status: sdk.MonitoringStatusAlert}
+	}
+	return sdk.MonitoringStatusLine{Component: "Object-Store", Value: "SSH Storage", Status: sdk.MonitoringStatusOK}
+}
 
-	cmd := fmt.Sprintf("mkdir -p %s/%s", s.d, o.GetPath())
-	if err := session.Run(cmd); err != nil {
-		session.Close()
-		return "", err
+// session opens a new ssh session, connecting again to the host if needed
+func (s *SSHStore) session() (*ssh.Session, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.client != nil {
+		session, err := s.client.NewSession()
+		if err == nil {
+			return session, nil
+		}
+		log.Warning("SSHStore> unable to open session on %s, reconnecting: %v", s.h, err)
+		s.client.Close() // nolint
+		s.client = nil
 	}
 
-	session.Close()
-	//Create the file
-	session, err = s.client.NewSession()
+	client, err := ssh.Dial("tcp", s.h, &s.config)
 	if err != nil {
-		return "", err
+		return nil, sdk.WrapError(err, "unable to connect to %s", s.h)
 	}
+	s.client = client
+	return s.client.NewSession()
+}
+
+func (s *SSHStore) path(o Object) string {
+	return path.Join(s.d, o.GetPath(), o.GetName())
+}
 
-	path := o.GetPath() + "/" + o.GetName()
-	if err := s.copy(session, data, path); err != nil {
+// ServeStaticFiles NOT YET IMPLEMENTED
+func (s *SSHStore) ServeStaticFiles(o Object, entrypoint string, data io.ReadCloser) (string, error) {
+	return "", sdk.ErrNotImplemented
+}
+
+// Store store a object on the remote host
+func (s *SSHStore) Store(o Object, data io.ReadCloser) (string, error) {
+	defer data.Close()
+	session, err := s.session()
+	if err != nil {
 		return "", err
 	}
-
 	defer session.Close()
 
-	return path, nil
+	p := s.path(o)
+	session.Stdin = data
+	if out, err := session.CombinedOutput(fmt.Sprintf("mkdir -p %s && cat > %s", sshQuote(path.Dir(p)), sshQuote(p))); err != nil {
+		return "", sdk.WrapError(err, "unable to store %s: %s", p, out)
+	}
+	return p, nil
 }
 
-// Fetch lookup on disk for data
+// Fetch lookup on the remote host for data
 func (s *SSHStore) Fetch(o Object) (io.ReadCloser, error) {
-	session, err := s.client.NewSession()
+	p := s.path(o)
+	session, err := s.session()
 	if err != nil {
 		return nil, err
 	}
+	if err := session.Run("test -f " + sshQuote(p)); err != nil {
+		session.Close()
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "object %s not found", p)
+	}
+	session.Close()
 
-	defer session.Close()
-	return s.read(session, o.GetPath()+"+"+o.GetName())
+	session, err = s.session()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+		return nil, sdk.WithStack(err)
+	}
+	if err := session.Start("cat " + sshQuote(p)); err != nil {
+		session.Close()
+		return nil, sdk.WrapError(err, "unable to fetch %s", p)
+	}
+	return &sshReader{Reader: stdout, session: session}, nil
 }
 
-// Delete data on disk
+// Delete data on the remote host
 func (s *SSHStore) Delete(o Object) error {
-	session, err := s.client.NewSession()
+	session, err := s.session()
 	if err != nil {
 		return err
 	}
-
 	defer session.Close()
 
-	return session.Run("rm -f " + o.GetPath() + "+" + o.GetName())
+	return session.Run("rm -f " + sshQuote(s.path(o)))
+}
+
+// sshReader closes the ssh session once the remote file is read
+type sshReader struct {
+	io.Reader
+	session *ssh.Session
+}
+
+func (r *sshReader) Close() error {
+	defer r.session.Close()
+	if _, err := io.Copy(ioutil.Discard, r.Reader); err != nil {
+		return err
+	}
+	return r.session.Wait()
+}
+
+// sshQuote quotes a string for a posix shell
+func sshQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
 }
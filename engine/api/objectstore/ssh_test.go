@@ -0,0 +1,132 @@
+package objectstore
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// newTestSSHServer starts a ssh server running exec requests with the local shell
+func newTestSSHServer(t *testing.T) (string, int, string, func()) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate host key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatalf("unable to create signer: %v", err)
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == "cds" && string(pass) == "secret" {
+				return nil, nil
+			}
+			return nil, sdk.ErrUnauthorized
+		},
+	}
+	config.AddHostKey(signer)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSSHConn(conn, config)
+		}
+	}()
+
+	addr := l.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, string(ssh.MarshalAuthorizedKey(signer.PublicKey())), func() { l.Close() } // nolint
+}
+
+func serveTestSSHConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer channel.Close()
+			for req := range requests {
+				if req.Type != "exec" || len(req.Payload) < 4 {
+					req.Reply(false, nil) // nolint
+					continue
+				}
+				req.Reply(true, nil) // nolint
+				cmd := exec.Command("sh", "-c", string(req.Payload[4:]))
+				cmd.Stdin = channel
+				cmd.Stdout = channel
+				cmd.Stderr = channel.Stderr()
+				var status uint32
+				if err := cmd.Run(); err != nil {
+					status = 1
+				}
+				b := make([]byte, 4)
+				binary.BigEndian.PutUint32(b, status)
+				channel.SendRequest("exit-status", false, b) // nolint
+				return
+			}
+		}()
+	}
+}
+
+func TestSSHStore(t *testing.T) {
+	host, port, hostKey, end := newTestSSHServer(t)
+	defer end()
+	dir, err := ioutil.TempDir("", "cds-ssh-")
+	if err != nil {
+		t.Fatalf("unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir) // nolint
+
+	_, err = NewSSHStore(ConfigOptionsSSH{Host: host, Port: port, User: "cds", Password: "secret", BaseDirectory: dir})
+	assert.Error(t, err, "host key is mandatory")
+	_, err = NewSSHStore(ConfigOptionsSSH{Host: host, Port: port, User: "cds", Password: "wrong", HostKey: hostKey, BaseDirectory: dir})
+	assert.Error(t, err)
+
+	s, err := NewSSHStore(ConfigOptionsSSH{Host: host, Port: port, User: "cds", Password: "secret", HostKey: hostKey, BaseDirectory: dir})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, sdk.MonitoringStatusOK, s.Status().Status)
+
+	o := s3TestObject{name: "it's a file.txt", path: "project/" + strconv.Itoa(port)}
+	objectPath, err := s.Store(o, ioutil.NopCloser(strings.NewReader("content")))
+	assert.NoError(t, err)
+	b, _ := ioutil.ReadFile(objectPath)
+	assert.Equal(t, "content", string(b))
+
+	r, err := s.Fetch(o)
+	if assert.NoError(t, err) {
+		b, _ := ioutil.ReadAll(r)
+		assert.NoError(t, r.Close())
+		assert.Equal(t, "content", string(b))
+	}
+
+	assert.NoError(t, s.Delete(o))
+	_, err = s.Fetch(o)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+}
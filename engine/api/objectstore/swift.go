@@ -1,6 +1,7 @@
 package objectstore
 
 import (
+	"archive/tar"
 	"fmt"
 	"io"
 	"time"
@@ -51,9 +52,7 @@ func (s *SwiftStore) Status() sdk.MonitoringStatusLine {
 
 // ServeStaticFiles send files to serve static files with the entrypoint of html page and return public URL taking a tar file
 func (s *SwiftStore) ServeStaticFiles(o Object, entrypoint string, data io.ReadCloser) (string, error) {
-	container := s.containerprefix + o.GetPath()
-	object := o.GetName()
-	escape(container, object)
+	container, object := escape(s.containerprefix+o.GetPath(), o.GetName())
 	log.Debug("SwiftStore> Storing /%s/%s\n", container, object)
 
 	if entrypoint == "" {
@@ -143,6 +142,36 @@ func (s *SwiftStore) Fetch(o Object) (io.ReadCloser, error) {
 	return pipeReader, nil
 }
 
+// FetchStaticFiles returns a tar archive of the static files served in the container of an object
+func (s *SwiftStore) FetchStaticFiles(o Object) (io.ReadCloser, error) {
+	container, _ := escape(s.containerprefix+o.GetPath(), o.GetName())
+
+	objects, err := s.ObjectsAll(container, nil)
+	if err != nil {
+		if err == swift.ContainerNotFound {
+			return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "container %s not found", container)
+		}
+		return nil, sdk.WrapError(err, "Unable to list objects of container %s", container)
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pipeWriter)
+		for _, object := range objects {
+			if err := tw.WriteHeader(&tar.Header{Name: object.Name, Mode: 0644, Size: object.Bytes, Typeflag: tar.TypeReg}); err != nil {
+				pipeWriter.CloseWithError(err) // nolint
+				return
+			}
+			if _, err := s.ObjectGet(container, object.Name, tw, false, nil); err != nil {
+				pipeWriter.CloseWithError(sdk.WrapError(err, "Unable to get object %s/%s", container, object.Name)) // nolint
+				return
+			}
+		}
+		pipeWriter.CloseWithError(tw.Close()) // nolint
+	}()
+	return pipeReader, nil
+}
+
 // Delete an object from swift
 func (s *SwiftStore) Delete(o Object) error {
 	container := s.containerprefix + o.GetPath()
@@ -184,9 +213,7 @@ func (s *SwiftStore) GetPublicURL(o Object) (url string, err error) {
 
 // ServeStaticFilesURL returns a temporary url and a secret key to serve static files in a container
 func (s *SwiftStore) ServeStaticFilesURL(o Object, entrypoint string) (string, string, error) {
-	container := s.containerprefix + o.GetPath()
-	object := o.GetName()
-	escape(container, object)
+	container, object := escape(s.containerprefix+o.GetPath(), o.GetName())
 	if entrypoint == "" {
 		entrypoint = "index.html"
 	}
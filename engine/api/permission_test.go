@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/ovh/cds/engine/api/auth"
+	"github.com/ovh/cds/engine/api/permission"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -486,3 +487,65 @@ func Test_checkEnvironmentPermissionsByUser(t *testing.T) {
 		}
 	}
 }
+
+func Test_restrictUserPermissionsToAccessToken(t *testing.T) {
+	newUser := func() *sdk.User {
+		return &sdk.User{
+			Admin: true,
+			Permissions: sdk.UserPermissions{
+				ProjectsPerm:  map[string]int{"PROJ1": 7, "PROJ2": 7},
+				WorkflowsPerm: sdk.UserPermissionsMap{"PROJ1/w1": 7, "PROJ2/w2": 5},
+			},
+		}
+	}
+
+	u := newUser()
+	restrictUserPermissionsToAccessToken(u, &sdk.AccessToken{Scopes: []string{sdk.AccessTokenScopeAdmin}})
+	if !reflect.DeepEqual(u, newUser()) {
+		t.Errorf("admin scope must not restrict permissions: %+v", u.Permissions)
+	}
+
+	u = newUser()
+	restrictUserPermissionsToAccessToken(u, &sdk.AccessToken{Scopes: []string{sdk.AccessTokenScopeRead}, Projects: []string{"PROJ1"}})
+	if u.Admin {
+		t.Errorf("only the admin scope keeps admin rights")
+	}
+	if want := map[string]int{"PROJ1": 4}; !reflect.DeepEqual(u.Permissions.ProjectsPerm, want) {
+		t.Errorf("got %v, want %v", u.Permissions.ProjectsPerm, want)
+	}
+	if want := (sdk.UserPermissionsMap{"PROJ1/w1": 4}); !reflect.DeepEqual(u.Permissions.WorkflowsPerm, want) {
+		t.Errorf("got %v, want %v", u.Permissions.WorkflowsPerm, want)
+	}
+
+	u = newUser()
+	restrictUserPermissionsToAccessToken(u, &sdk.AccessToken{Scopes: []string{sdk.AccessTokenScopeRun}})
+	if want := (sdk.UserPermissionsMap{"PROJ1/w1": 5, "PROJ2/w2": 5}); !reflect.DeepEqual(u.Permissions.WorkflowsPerm, want) {
+		t.Errorf("got %v, want %v", u.Permissions.WorkflowsPerm, want)
+	}
+}
+
+func Test_accessTokenAllowsRoute(t *testing.T) {
+	admin := &sdk.AccessToken{Scopes: []string{sdk.AccessTokenScopeAdmin}, Projects: []string{"PROJ1"}}
+	proj := &sdk.AccessToken{Scopes: []string{sdk.AccessTokenScopeProject}, Projects: []string{"PROJ1"}}
+
+	tests := []struct {
+		name     string
+		token    *sdk.AccessToken
+		routeVar map[string]string
+		perm     int
+		want     bool
+	}{
+		{"admin on user update", admin, map[string]string{"username": "u"}, permission.PermissionReadWriteExecute, true},
+		{"project on its project", proj, map[string]string{"permProjectKey": "PROJ1"}, permission.PermissionReadWriteExecute, true},
+		{"project on its project by key", proj, map[string]string{"key": "PROJ1", "permWorkflowName": "w1"}, permission.PermissionReadWriteExecute, true},
+		{"project on another project", proj, map[string]string{"key": "PROJ2"}, permission.PermissionRead, false},
+		{"project on user read", proj, map[string]string{"username": "u"}, permission.PermissionRead, true},
+		{"project on user update", proj, map[string]string{"username": "u"}, permission.PermissionReadWriteExecute, false},
+		{"project on group membership", proj, map[string]string{"permGroupName": "g"}, permission.PermissionReadWriteExecute, false},
+	}
+	for _, tt := range tests {
+		if got := accessTokenAllowsRoute(tt.token, tt.routeVar, tt.perm); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
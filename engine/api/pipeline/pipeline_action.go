@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -102,9 +103,18 @@ func InsertJob(db gorp.SqlExecutor, job *sdk.Job, stageID int64, pip *sdk.Pipeli
 	}
 	job.PipelineStageID = stage.ID
 
+	retryPolicy, errR := nullableJSON(job.RetryPolicy == nil, job.RetryPolicy)
+	if errR != nil {
+		return errR
+	}
+	matrix, errM := nullableJSON(job.Matrix == nil, job.Matrix)
+	if errM != nil {
+		return errM
+	}
+
 	// Create pipeline action
-	query := `INSERT INTO pipeline_action (pipeline_stage_id, action_id, enabled) VALUES ($1, $2, $3) RETURNING id`
-	return db.QueryRow(query, job.PipelineStageID, job.Action.ID, job.Enabled).Scan(&job.PipelineActionID)
+	query := `INSERT INTO pipeline_action (pipeline_stage_id, action_id, enabled, retry_policy, matrix) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	return db.QueryRow(query, job.PipelineStageID, job.Action.ID, job.Enabled, retryPolicy, matrix).Scan(&job.PipelineActionID)
 }
 
 // UpdateJob  updates the job by actionData.PipelineActionID and actionData.ID
@@ -132,14 +142,35 @@ func DeleteJob(db gorp.SqlExecutor, job sdk.Job, userID int64) error {
 
 // UpdatePipelineAction Update an action in a pipeline
 func UpdatePipelineAction(db gorp.SqlExecutor, job sdk.Job) error {
-	query := `UPDATE pipeline_action set action_id=$1, pipeline_stage_id=$2, enabled=$3 WHERE id=$4`
-	if _, err := db.Exec(query, job.Action.ID, job.PipelineStageID, job.Enabled, job.PipelineActionID); err != nil {
+	retryPolicy, errR := nullableJSON(job.RetryPolicy == nil, job.RetryPolicy)
+	if errR != nil {
+		return errR
+	}
+	matrix, errM := nullableJSON(job.Matrix == nil, job.Matrix)
+	if errM != nil {
+		return errM
+	}
+
+	query := `UPDATE pipeline_action set action_id=$1, pipeline_stage_id=$2, enabled=$3, retry_policy=$4, matrix=$5 WHERE id=$6`
+	if _, err := db.Exec(query, job.Action.ID, job.PipelineStageID, job.Enabled, retryPolicy, matrix, job.PipelineActionID); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// nullableJSON returns the value of a nullable JSONB column
+func nullableJSON(isNull bool, v interface{}) (interface{}, error) {
+	if isNull {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, sdk.WrapError(err, "nullableJSON> Cannot marshal %T", v)
+	}
+	return string(b), nil
+}
+
 // DeletePipelineAction Delete an action in a pipeline
 func DeletePipelineAction(db gorp.SqlExecutor, pipelineActionID int64) error {
 
@@ -159,6 +190,20 @@ func CheckJob(db gorp.SqlExecutor, job *sdk.Job) error {
 	log.Debug("CheckJob> Begin")
 	defer log.Debug("CheckJob> End (%d ns)", time.Since(t).Nanoseconds())
 	errs := []sdk.Message{}
+	//Check retry policy
+	if job.RetryPolicy != nil {
+		if err := job.RetryPolicy.IsValid(); err != nil {
+			return err
+		}
+	}
+
+	//Check matrix
+	if job.Matrix != nil {
+		if err := job.Matrix.IsValid(); err != nil {
+			return err
+		}
+	}
+
 	//Check steps
 	for i := range job.Action.Actions {
 		step := &job.Action.Actions[i]
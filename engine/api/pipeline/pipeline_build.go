@@ -690,7 +690,7 @@ func UpdatePipelineBuildCommits(db *gorp.DbMap, store cache.Store, p *sdk.Projec
 
 	res := []sdk.VCSCommit{}
 	//Get the RepositoriesManager Client
-	client, errclient := repositoriesmanager.AuthorizedClient(context.Background(), db, store, vcsServer)
+	client, errclient := repositoriesmanager.AuthorizedClient(context.Background(), db, store, p.Key, vcsServer)
 	if errclient != nil {
 		return nil, sdk.WrapError(errclient, "UpdatePipelineBuildCommits> Cannot get client")
 	}
@@ -772,7 +772,7 @@ func InsertPipelineBuild(tx gorp.SqlExecutor, store cache.Store, proj *sdk.Proje
 		}
 
 		//We don't need to pass apiURL and uiURL because they are not useful for commit
-		client, _ = repositoriesmanager.AuthorizedClient(context.Background(), tx, store, vcsServer)
+		client, _ = repositoriesmanager.AuthorizedClient(context.Background(), tx, store, proj.Key, vcsServer)
 	}
 
 	// Load last finished build
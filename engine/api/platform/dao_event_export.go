@@ -0,0 +1,131 @@
+package platform
+
+import (
+	"database/sql"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// PostGet is a db hook
+func (e *dbProjectEventExport) PostGet(db gorp.SqlExecutor) error {
+	query := `
+		SELECT project_event_export.event_types, project_platform.name
+		FROM project_event_export
+		JOIN project_platform ON project_platform.id = project_event_export.project_platform_id
+		WHERE project_event_export.id = $1
+	`
+	var types sql.NullString
+	if err := db.QueryRow(query, e.ID).Scan(&types, &e.PlatformName); err != nil {
+		return sdk.WrapError(err, "Cannot get event types")
+	}
+	return gorpmapping.JSONNullString(types, &e.EventTypes)
+}
+
+// PostInsert is a db hook
+func (e *dbProjectEventExport) PostInsert(db gorp.SqlExecutor) error {
+	types, err := gorpmapping.JSONToNullString(e.EventTypes)
+	if err != nil {
+		return sdk.WrapError(err, "Cannot marshal event types")
+	}
+	if _, err := db.Exec("UPDATE project_event_export SET event_types = $1 WHERE id = $2", types, e.ID); err != nil {
+		return sdk.WrapError(err, "Cannot update event types")
+	}
+	return nil
+}
+
+// LoadEventExportsByProjectID loads the event exports of a project
+func LoadEventExportsByProjectID(db gorp.SqlExecutor, projectID int64) ([]sdk.ProjectEventExport, error) {
+	return loadEventExports(db, "SELECT * FROM project_event_export WHERE project_id = $1 ORDER BY id", projectID)
+}
+
+// LoadEventExportsByProjectKey loads the event exports of a project
+func LoadEventExportsByProjectKey(db gorp.SqlExecutor, key string) ([]sdk.ProjectEventExport, error) {
+	query := `
+		SELECT project_event_export.*
+		FROM project_event_export
+		JOIN project ON project.id = project_event_export.project_id
+		WHERE project.projectkey = $1
+		ORDER BY project_event_export.id
+	`
+	return loadEventExports(db, query, key)
+}
+
+func loadEventExports(db gorp.SqlExecutor, query string, args ...interface{}) ([]sdk.ProjectEventExport, error) {
+	var res []dbProjectEventExport
+	if _, err := db.Select(&res, query, args...); err != nil {
+		return nil, sdk.WrapError(err, "Cannot load event exports")
+	}
+	exports := make([]sdk.ProjectEventExport, len(res))
+	for i := range res {
+		exports[i] = sdk.ProjectEventExport(res[i])
+	}
+	return exports, nil
+}
+
+// LoadEventExportByID loads an event export of a project
+func LoadEventExportByID(db gorp.SqlExecutor, projectID, id int64) (*sdk.ProjectEventExport, error) {
+	var e dbProjectEventExport
+	if err := db.SelectOne(&e, "SELECT * FROM project_event_export WHERE project_id = $1 AND id = $2", projectID, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.ErrNotFound
+		}
+		return nil, sdk.WrapError(err, "Cannot load event export %d", id)
+	}
+	res := sdk.ProjectEventExport(e)
+	return &res, nil
+}
+
+// InsertEventExport inserts an event export
+func InsertEventExport(db gorp.SqlExecutor, e *sdk.ProjectEventExport) error {
+	dbe := dbProjectEventExport(*e)
+	if err := db.Insert(&dbe); err != nil {
+		return sdk.WrapError(err, "Cannot insert event export")
+	}
+	e.ID = dbe.ID
+	return nil
+}
+
+// UpdateEventExport updates the configuration of an event export, the delivery status is kept
+func UpdateEventExport(db gorp.SqlExecutor, e sdk.ProjectEventExport) error {
+	types, err := gorpmapping.JSONToNullString(e.EventTypes)
+	if err != nil {
+		return sdk.WrapError(err, "Cannot marshal event types")
+	}
+	query := `
+		UPDATE project_event_export
+		SET project_platform_id = $1, topic = $2, enabled = $3, event_types = $4
+		WHERE id = $5
+	`
+	if _, err := db.Exec(query, e.ProjectPlatformID, e.Topic, e.Enabled, types, e.ID); err != nil {
+		return sdk.WrapError(err, "Cannot update event export %d", e.ID)
+	}
+	return nil
+}
+
+// DeleteEventExport deletes an event export
+func DeleteEventExport(db gorp.SqlExecutor, id int64) error {
+	if _, err := db.Exec("DELETE FROM project_event_export WHERE id = $1", id); err != nil {
+		return sdk.WrapError(err, "Cannot delete event export %d", id)
+	}
+	return nil
+}
+
+// UpdateEventExportDelivery records the result of a delivery
+func UpdateEventExportDelivery(db gorp.SqlExecutor, id int64, errDelivery error) error {
+	status, msg, delivered, failed := sdk.StatusSuccess.String(), "", 1, 0
+	if errDelivery != nil {
+		status, msg, delivered, failed = sdk.StatusFail.String(), errDelivery.Error(), 0, 1
+	}
+	query := `
+		UPDATE project_event_export
+		SET last_delivery = now(), last_status = $1, last_error = $2, delivered = delivered + $3, failed = failed + $4
+		WHERE id = $5
+	`
+	if _, err := db.Exec(query, status, msg, delivered, failed, id); err != nil {
+		return sdk.WrapError(err, "Cannot update delivery of event export %d", id)
+	}
+	return nil
+}
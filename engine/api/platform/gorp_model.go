@@ -9,9 +9,10 @@ import (
 // PlatformModel is a gorp wrapper around sdk.PlatformModel
 type platformModel sdk.PlatformModel
 type dbProjectPlatform sdk.ProjectPlatform
+type dbProjectEventExport sdk.ProjectEventExport
 
 func init() {
 	gorpmapping.Register(gorpmapping.New(platformModel{}, "platform_model", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbProjectPlatform{}, "project_platform", true, "id"))
-
+	gorpmapping.Register(gorpmapping.New(dbProjectEventExport{}, "project_event_export", true, "id"))
 }
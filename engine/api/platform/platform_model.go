@@ -12,6 +12,7 @@ var (
 	BuiltinModels = []sdk.PlatformModel{
 		sdk.KafkaPlatform,
 		sdk.RabbitMQPlatform,
+		sdk.WebhookPlatform,
 	}
 )
 
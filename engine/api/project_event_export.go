@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/platform"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getProjectEventExportsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars["permProjectKey"]
+
+		p, err := project.Load(api.mustDB(), api.Cache, projectKey, getUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "Cannot load project")
+		}
+
+		exports, err := platform.LoadEventExportsByProjectID(api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, exports, http.StatusOK)
+	}
+}
+
+func (api *API) getProjectEventExportHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars["permProjectKey"]
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), api.Cache, projectKey, getUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "Cannot load project")
+		}
+
+		export, err := platform.LoadEventExportByID(api.mustDB(), p.ID, id)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, export, http.StatusOK)
+	}
+}
+
+// checkProjectEventExport links the export to the platform of the project given by its name
+func checkProjectEventExport(p *sdk.Project, export *sdk.ProjectEventExport) error {
+	for _, pf := range p.Platforms {
+		if pf.Name == export.PlatformName {
+			export.ProjectID = p.ID
+			export.ProjectPlatformID = pf.ID
+			return export.IsValid(pf)
+		}
+	}
+	return sdk.NewErrorFrom(sdk.ErrWrongRequest, "platform %s not found", export.PlatformName)
+}
+
+func (api *API) postProjectEventExportHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars["permProjectKey"]
+
+		var export sdk.ProjectEventExport
+		if err := service.UnmarshalBody(r, &export); err != nil {
+			return sdk.WrapError(err, "Cannot read body")
+		}
+
+		p, err := project.Load(api.mustDB(), api.Cache, projectKey, getUser(ctx), project.LoadOptions.WithPlatforms)
+		if err != nil {
+			return sdk.WrapError(err, "Cannot load project")
+		}
+
+		if err := checkProjectEventExport(p, &export); err != nil {
+			return err
+		}
+
+		if err := platform.InsertEventExport(api.mustDB(), &export); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, export, http.StatusCreated)
+	}
+}
+
+func (api *API) putProjectEventExportHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars["permProjectKey"]
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		var export sdk.ProjectEventExport
+		if err := service.UnmarshalBody(r, &export); err != nil {
+			return sdk.WrapError(err, "Cannot read body")
+		}
+
+		p, err := project.Load(api.mustDB(), api.Cache, projectKey, getUser(ctx), project.LoadOptions.WithPlatforms)
+		if err != nil {
+			return sdk.WrapError(err, "Cannot load project")
+		}
+
+		old, err := platform.LoadEventExportByID(api.mustDB(), p.ID, id)
+		if err != nil {
+			return err
+		}
+
+		export.ID = old.ID
+		if err := checkProjectEventExport(p, &export); err != nil {
+			return err
+		}
+
+		if err := platform.UpdateEventExport(api.mustDB(), export); err != nil {
+			return err
+		}
+
+		updated, err := platform.LoadEventExportByID(api.mustDB(), p.ID, id)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, updated, http.StatusOK)
+	}
+}
+
+func (api *API) deleteProjectEventExportHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars["permProjectKey"]
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), api.Cache, projectKey, getUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "Cannot load project")
+		}
+
+		export, err := platform.LoadEventExportByID(api.mustDB(), p.ID, id)
+		if err != nil {
+			return err
+		}
+		return platform.DeleteEventExport(api.mustDB(), export.ID)
+	}
+}
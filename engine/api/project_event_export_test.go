@@ -0,0 +1,90 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/bootstrap"
+	"github.com/ovh/cds/engine/api/platform"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestAddAndDeleteProjectEventExport(t *testing.T) {
+	api, db, router, end := newTestAPI(t, bootstrap.InitiliazeDB)
+	defer end()
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10), nil)
+	u, pass := assets.InsertAdminUser(api.mustDB())
+
+	assert.NoError(t, platform.CreateBuiltinModels(db))
+	model, err := platform.LoadModelByName(db, sdk.WebhookPlatformModel, false)
+	test.NoError(t, err)
+
+	pp := sdk.ProjectPlatform{
+		Name:            "webhookTest",
+		ProjectID:       proj.ID,
+		Config:          sdk.WebhookPlatform.DefaultConfig.Clone(),
+		PlatformModelID: model.ID,
+	}
+	test.NoError(t, platform.InsertPlatform(db, &pp))
+
+	vars := map[string]string{"permProjectKey": proj.Key}
+
+	// ADD an export on an unknown platform
+	jsonBody, _ := json.Marshal(sdk.ProjectEventExport{PlatformName: "unknown", Enabled: true})
+	uri := router.GetRoute("POST", api.postProjectEventExportHandler, vars)
+	req, err := http.NewRequest("POST", uri, bytes.NewBuffer(jsonBody))
+	test.NoError(t, err)
+	assets.AuthentifyRequest(t, req, u, pass)
+	w := httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	assert.Equal(t, 400, w.Code)
+
+	// ADD event export
+	jsonBody, _ = json.Marshal(sdk.ProjectEventExport{PlatformName: pp.Name, EventTypes: []string{"EventRunWorkflow"}, Enabled: true})
+	req, err = http.NewRequest("POST", uri, bytes.NewBuffer(jsonBody))
+	test.NoError(t, err)
+	assets.AuthentifyRequest(t, req, u, pass)
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	assert.Equal(t, 201, w.Code)
+
+	// LIST event exports
+	uri = router.GetRoute("GET", api.getProjectEventExportsHandler, vars)
+	req, err = http.NewRequest("GET", uri, nil)
+	test.NoError(t, err)
+	assets.AuthentifyRequest(t, req, u, pass)
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	assert.Equal(t, 200, w.Code)
+
+	var exports []sdk.ProjectEventExport
+	test.NoError(t, json.Unmarshal(w.Body.Bytes(), &exports))
+	assert.Len(t, exports, 1)
+	assert.Equal(t, pp.Name, exports[0].PlatformName)
+	assert.Equal(t, []string{"EventRunWorkflow"}, exports[0].EventTypes)
+
+	// Record a delivery
+	test.NoError(t, platform.UpdateEventExportDelivery(db, exports[0].ID, fmt.Errorf("connection refused")))
+	export, err := platform.LoadEventExportByID(db, proj.ID, exports[0].ID)
+	test.NoError(t, err)
+	assert.Equal(t, sdk.StatusFail.String(), export.LastStatus)
+	assert.Equal(t, int64(1), export.Failed)
+
+	// DELETE event export
+	vars["id"] = fmt.Sprintf("%d", exports[0].ID)
+	uri = router.GetRoute("DELETE", api.deleteProjectEventExportHandler, vars)
+	req, err = http.NewRequest("DELETE", uri, nil)
+	test.NoError(t, err)
+	assets.AuthentifyRequest(t, req, u, pass)
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	assert.Equal(t, 200, w.Code)
+}
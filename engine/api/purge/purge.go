@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
 
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/project"
@@ -32,6 +33,11 @@ func Initialize(c context.Context, store cache.Store, DBFunc func() *gorp.DbMap)
 				log.Warning("purge> Error on deleteWorkflowRunsHistory : %v", err)
 			}
 
+			log.Debug("purge> Applying workflow retention policies...")
+			if err := retention(c, DBFunc(), store); err != nil {
+				log.Warning("purge> Error on retention : %v", err)
+			}
+
 			log.Debug("purge> Deleting all workflow marked to delete....")
 			if err := workflows(c, DBFunc(), store); err != nil {
 				log.Warning("purge> Error on workflows : %v", err)
@@ -117,9 +123,20 @@ func workflows(ctx context.Context, db *gorp.DbMap, store cache.Store) error {
 
 // deleteWorkflowRunsHistory is useful to delete all the workflow run marked with to delete flag in db
 func deleteWorkflowRunsHistory(db gorp.SqlExecutor) error {
-	query := `DELETE FROM workflow_run WHERE workflow_run.id IN (SELECT id FROM workflow_run WHERE to_delete = true LIMIT 30)`
+	var ids []int64
+	if _, err := db.Select(&ids, "SELECT id FROM workflow_run WHERE to_delete = true LIMIT 30"); err != nil {
+		return sdk.WrapError(err, "Unable to load workflow runs to delete")
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// Logs moved out of the database must be deleted before the runs, their metadata are deleted by cascade
+	if err := workflow.DeleteRunsStoredLogs(db, ids); err != nil {
+		return sdk.WrapError(err, "Unable to delete logs of workflow runs")
+	}
 
-	if _, err := db.Exec(query); err != nil {
+	if _, err := db.Exec("DELETE FROM workflow_run WHERE id = ANY($1)", pq.Int64Array(ids)); err != nil {
 		log.Warning("deleteWorkflowRunsHistory> Unable to delete workflow history %s", err)
 		return err
 	}
@@ -0,0 +1,356 @@
+package purge
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	retentionRunsPageSize    = 500
+	retentionMaxRunsToDelete = 100
+)
+
+// retentionRun is a workflow run as seen by the retention policy
+type retentionRun struct {
+	ID           int64
+	Number       int64
+	Status       string
+	Start        time.Time
+	VCSServer    string
+	Repository   string
+	Branch       string
+	Tags         []sdk.WorkflowRunTag
+	Environments []string
+}
+
+func (r retentionRun) branchKey() string {
+	return r.VCSServer + "/" + r.Repository + "@" + r.Branch
+}
+
+// retention applies the retention policies of all the workflows
+func retention(ctx context.Context, db *gorp.DbMap, store cache.Store) error {
+	query := "SELECT id, project_id FROM workflow WHERE retention_policy IS NOT NULL AND to_delete = false ORDER BY id ASC"
+	res := []struct {
+		ID        int64 `db:"id"`
+		ProjectID int64 `db:"project_id"`
+	}{}
+	if _, err := db.Select(&res, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return sdk.WrapError(err, "Unable to load workflows with retention policy")
+	}
+
+	var projects = map[int64]*sdk.Project{}
+	for _, r := range res {
+		proj, has := projects[r.ProjectID]
+		if !has {
+			p, err := project.LoadByID(db, store, r.ProjectID, nil)
+			if err != nil {
+				log.Error("purge.retention> unable to load project %d: %v", r.ProjectID, err)
+				continue
+			}
+			projects[r.ProjectID] = p
+			proj = p
+		}
+
+		w, err := workflow.LoadByID(db, store, proj, r.ID, nil, workflow.LoadOptions{})
+		if err != nil {
+			log.Error("purge.retention> unable to load workflow %d: %v", r.ID, err)
+			continue
+		}
+		if w.RetentionPolicy == nil {
+			continue
+		}
+
+		if err := applyRetentionPolicy(ctx, db, store, proj, w); err != nil {
+			log.Error("purge.retention> unable to apply retention policy on workflow %d: %v", w.ID, err)
+		}
+	}
+	return nil
+}
+
+// applyRetentionPolicy marks the runs to delete and deletes the outdated artifacts of a workflow.
+// Runs are loaded by pages from the last one, until enough runs to delete are found.
+func applyRetentionPolicy(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, w *sdk.Workflow) error {
+	state := newRetentionState()
+	var existingBranches map[string]map[string]bool
+	if w.RetentionPolicy.DeleteDeletedBranches {
+		existingBranches = map[string]map[string]bool{}
+	}
+
+	ids := []int64{}
+	beforeNumber := int64(-1)
+	//Don't mark as to_delete more than 100 workflow_runs
+	for len(ids) < retentionMaxRunsToDelete {
+		runs, err := loadRetentionRuns(db, w, beforeNumber, retentionRunsPageSize)
+		if err != nil {
+			return err
+		}
+		if len(runs) == 0 {
+			break
+		}
+		beforeNumber = runs[len(runs)-1].Number
+
+		var deletedBranches map[string]bool
+		if existingBranches != nil {
+			deletedBranches = loadDeletedBranches(ctx, db, store, proj, runs, existingBranches)
+		}
+		ids = append(ids, runsToDelete(w.RetentionPolicy, state, runs, deletedBranches, time.Now())...)
+	}
+	if len(ids) > retentionMaxRunsToDelete {
+		ids = ids[:retentionMaxRunsToDelete]
+	}
+
+	if len(ids) > 0 {
+		log.Info("purge.retention> mark %d runs to delete on workflow %s/%s", len(ids), proj.Key, w.Name)
+		if _, err := db.Exec("UPDATE workflow_run SET to_delete = true WHERE id = ANY($1)", pq.Int64Array(ids)); err != nil {
+			return sdk.WrapError(err, "Unable to mark workflow runs to delete")
+		}
+	}
+
+	if w.RetentionPolicy.ArtifactMaxAge > 0 {
+		return deleteArtifacts(db, w.ID, time.Now().Add(-days(w.RetentionPolicy.ArtifactMaxAge)))
+	}
+	return nil
+}
+
+func days(n int64) time.Duration {
+	return time.Duration(n) * 24 * time.Hour
+}
+
+// retentionState holds what is known of the runs already browsed, runs are browsed by number desc
+type retentionState struct {
+	perBranch        map[string]int64
+	lastSuccessFound bool
+}
+
+func newRetentionState() *retentionState {
+	return &retentionState{perBranch: map[string]int64{}}
+}
+
+// runsToDelete returns the ids of the runs to delete according to the retention policy, runs are ordered by number desc and follow the runs already browsed.
+// The runs not ended, the runs matching a keep rule and the last successful run are always kept.
+// The history length of the workflow is not used, KeepLastPerBranch limits the number of runs.
+func runsToDelete(p *sdk.WorkflowRetentionPolicy, state *retentionState, runs []retentionRun, deletedBranches map[string]bool, now time.Time) []int64 {
+	if p == nil {
+		return nil
+	}
+
+	ids := []int64{}
+	for _, r := range runs {
+		key := r.branchKey()
+		state.perBranch[key]++
+
+		if r.Status == sdk.StatusSuccess.String() && !state.lastSuccessFound {
+			state.lastSuccessFound = true
+			continue
+		}
+		if !sdk.StatusIsTerminated(r.Status) {
+			continue
+		}
+		if p.MatchTags(r.Tags) || matchEnvironments(p.KeepEnvironments, r.Environments) {
+			continue
+		}
+
+		switch {
+		case p.MaxAge > 0 && now.Sub(r.Start) > days(p.MaxAge),
+			p.DeleteDeletedBranches && r.Branch != "" && deletedBranches[key],
+			p.KeepLastPerBranch > 0 && state.perBranch[key] > p.KeepLastPerBranch:
+			ids = append(ids, r.ID)
+		}
+	}
+	return ids
+}
+
+func matchEnvironments(keep, deployed []string) bool {
+	for _, k := range keep {
+		for _, e := range deployed {
+			if k == e {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// loadRetentionRuns loads a page of the runs of the workflow not marked to delete, with their tags, branch and deployed environments.
+// Runs are ordered by number desc, starting before the given number if it's positive.
+func loadRetentionRuns(db gorp.SqlExecutor, w *sdk.Workflow, beforeNumber int64, limit int) ([]retentionRun, error) {
+	dbRuns := []struct {
+		ID     int64     `db:"id"`
+		Number int64     `db:"num"`
+		Status string    `db:"status"`
+		Start  time.Time `db:"start"`
+	}{}
+	if _, err := db.Select(&dbRuns, `
+		SELECT id, num, status, start FROM workflow_run
+		WHERE workflow_id = $1 AND to_delete = false AND ($2 < 0 OR num < $2)
+		ORDER BY num DESC LIMIT $3`, w.ID, beforeNumber, limit); err != nil {
+		return nil, sdk.WrapError(err, "Unable to load workflow runs")
+	}
+
+	runs := make([]retentionRun, len(dbRuns))
+	ids := make([]int64, len(dbRuns))
+	byID := make(map[int64]*retentionRun, len(dbRuns))
+	for i, r := range dbRuns {
+		runs[i] = retentionRun{ID: r.ID, Number: r.Number, Status: r.Status, Start: r.Start}
+		ids[i] = r.ID
+		byID[r.ID] = &runs[i]
+	}
+	if len(runs) == 0 {
+		return runs, nil
+	}
+
+	tags := []sdk.WorkflowRunTag{}
+	if _, err := db.Select(&tags, `
+		SELECT workflow_run_id, tag, value
+		FROM workflow_run_tag
+		WHERE workflow_run_id = ANY($1)`, pq.Int64Array(ids)); err != nil {
+		return nil, sdk.WrapError(err, "Unable to load workflow run tags")
+	}
+	for _, t := range tags {
+		if r, ok := byID[t.WorkflowRunID]; ok {
+			r.Tags = append(r.Tags, t)
+		}
+	}
+
+	nodeRuns := []struct {
+		WorkflowRunID  int64          `db:"workflow_run_id"`
+		WorkflowNodeID int64          `db:"workflow_node_id"`
+		Status         string         `db:"status"`
+		VCSServer      sql.NullString `db:"vcs_server"`
+		VCSRepository  sql.NullString `db:"vcs_repository"`
+		VCSBranch      sql.NullString `db:"vcs_branch"`
+	}{}
+	if _, err := db.Select(&nodeRuns, `
+		SELECT workflow_run_id, workflow_node_id, status, vcs_server, vcs_repository, vcs_branch
+		FROM workflow_node_run
+		WHERE workflow_run_id = ANY($1)
+		ORDER BY id ASC`, pq.Int64Array(ids)); err != nil {
+		return nil, sdk.WrapError(err, "Unable to load workflow node runs")
+	}
+
+	nodeEnvironments := map[int64]string{}
+	if w.WorkflowData != nil {
+		for _, n := range w.WorkflowData.Array() {
+			if n.Context == nil || n.Context.EnvironmentID == 0 {
+				continue
+			}
+			if env, ok := w.Environments[n.Context.EnvironmentID]; ok {
+				nodeEnvironments[n.ID] = env.Name
+			}
+		}
+	}
+
+	for _, nr := range nodeRuns {
+		r, ok := byID[nr.WorkflowRunID]
+		if !ok {
+			continue
+		}
+		// the branch of the run is the one of its first node run on a repository
+		if r.Branch == "" && nr.VCSBranch.String != "" {
+			r.VCSServer = nr.VCSServer.String
+			r.Repository = nr.VCSRepository.String
+			r.Branch = nr.VCSBranch.String
+		}
+		if env, ok := nodeEnvironments[nr.WorkflowNodeID]; ok && nr.Status == sdk.StatusSuccess.String() {
+			r.Environments = append(r.Environments, env)
+		}
+	}
+	return runs, nil
+}
+
+// loadDeletedBranches returns the branches of the runs which does not exist anymore on their repository.
+// The existing branches of each repository are cached in the given map, a nil entry means that they can't be listed.
+func loadDeletedBranches(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, runs []retentionRun, existingBranches map[string]map[string]bool) map[string]bool {
+	deleted := map[string]bool{}
+	for _, r := range runs {
+		if r.Branch == "" || r.VCSServer == "" {
+			continue
+		}
+		repo := r.VCSServer + "/" + r.Repository
+		existing, checked := existingBranches[repo]
+		if !checked {
+			existing = loadExistingBranches(ctx, db, store, proj, r.VCSServer, r.Repository)
+			existingBranches[repo] = existing
+		}
+		if existing != nil && !existing[r.Branch] {
+			deleted[r.branchKey()] = true
+		}
+	}
+	return deleted
+}
+
+// loadExistingBranches returns the branches of a repository, or nil if they can't be listed
+func loadExistingBranches(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, vcsServerName, repository string) map[string]bool {
+	vcsServer := repositoriesmanager.GetProjectVCSServer(proj, vcsServerName)
+	if vcsServer == nil {
+		return nil
+	}
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, vcsServer)
+	if err != nil {
+		log.Warning("purge.loadDeletedBranches> cannot get client on %s: %v", vcsServerName, err)
+		return nil
+	}
+	branches, err := client.Branches(ctx, repository)
+	if err != nil || len(branches) == 0 {
+		log.Warning("purge.loadDeletedBranches> cannot list branches of %s/%s: %v", vcsServerName, repository, err)
+		return nil
+	}
+
+	existing := make(map[string]bool, len(branches))
+	for _, b := range branches {
+		existing[b.DisplayID] = true
+	}
+	return existing
+}
+
+// deleteArtifacts deletes the artifacts of the runs of the workflow started before the given date
+func deleteArtifacts(db gorp.SqlExecutor, workflowID int64, before time.Time) error {
+	var arts []workflow.NodeRunArtifact
+	if _, err := db.Select(&arts, `
+		SELECT workflow_node_run_artifacts.id,
+			workflow_node_run_artifacts.name,
+			workflow_node_run_artifacts.tag,
+			workflow_node_run_artifacts.ref,
+			workflow_node_run_artifacts.workflow_node_run_id,
+			workflow_node_run_artifacts.download_hash,
+			workflow_node_run_artifacts.size,
+			workflow_node_run_artifacts.perm,
+			workflow_node_run_artifacts.md5sum,
+			workflow_node_run_artifacts.object_path,
+			workflow_node_run_artifacts.created,
+			workflow_node_run_artifacts.workflow_run_id,
+			coalesce(workflow_node_run_artifacts.sha512sum, '') AS sha512sum
+		FROM workflow_node_run_artifacts
+		JOIN workflow_run ON workflow_run.id = workflow_node_run_artifacts.workflow_run_id
+		WHERE workflow_run.workflow_id = $1 AND workflow_run.start < $2
+		LIMIT 100`, workflowID, before); err != nil {
+		return sdk.WrapError(err, "Unable to load artifacts")
+	}
+
+	for i := range arts {
+		art := sdk.WorkflowNodeRunArtifact(arts[i])
+		if err := objectstore.Delete(&art); err != nil {
+			log.Warning("purge.deleteArtifacts> unable to delete artifact %s (%d) from storage: %v", art.Name, art.ID, err)
+			continue
+		}
+		if _, err := db.Exec("DELETE FROM workflow_node_run_artifacts WHERE id = $1", art.ID); err != nil {
+			return sdk.WrapError(err, "Unable to delete artifact %d", art.ID)
+		}
+	}
+	return nil
+}
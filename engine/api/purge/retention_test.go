@@ -0,0 +1,80 @@
+package purge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_runsToDelete(t *testing.T) {
+	now := time.Now()
+	run := func(id int64, status sdk.Status, age time.Duration, branch string) retentionRun {
+		return retentionRun{ID: id, Number: id, Status: status.String(), Start: now.Add(-age), VCSServer: "github", Repository: "ovh/cds", Branch: branch}
+	}
+
+	// runs are ordered by number desc
+	runs := []retentionRun{
+		run(10, sdk.StatusBuilding, time.Hour, "feat/a"),
+		run(9, sdk.StatusFail, time.Hour, "feat/a"),
+		run(8, sdk.StatusFail, time.Hour, "feat/a"),
+		run(7, sdk.StatusSuccess, time.Hour, "feat/b"),
+		run(6, sdk.StatusFail, 2*time.Hour, "feat/b"),
+		run(5, sdk.StatusSuccess, 3*time.Hour, "master"),
+		run(4, sdk.StatusSuccess, 10*24*time.Hour, "master"),
+		run(3, sdk.StatusSuccess, 20*24*time.Hour, "master"),
+		run(2, sdk.StatusSuccess, 40*24*time.Hour, "master"),
+		run(1, sdk.StatusFail, 50*24*time.Hour, "master"),
+	}
+	runs[7].Tags = []sdk.WorkflowRunTag{{Tag: "release", Value: "v1.0.0"}}
+	runs[8].Environments = []string{"production"}
+
+	w := sdk.Workflow{
+		HistoryLength: 20,
+		RetentionPolicy: &sdk.WorkflowRetentionPolicy{
+			MaxAge:                30,
+			KeepLastPerBranch:     2,
+			KeepTags:              []string{"release"},
+			KeepEnvironments:      []string{"production"},
+			DeleteDeletedBranches: true,
+		},
+	}
+	deletedBranches := map[string]bool{runs[3].branchKey(): true}
+
+	ids := runsToDelete(w.RetentionPolicy, newRetentionState(), runs, deletedBranches, now)
+	// 10: building, 9: last 2 of feat/a, 7: last success, 5 and 4: last 2 of master,
+	// 3: tagged release, 2: deployed on production
+	// 8: third of feat/a, 6: feat/b is deleted, 1: too old
+	assert.Equal(t, []int64{8, 6, 1}, ids)
+
+	// runs browsed by pages give the same result
+	state := newRetentionState()
+	ids = runsToDelete(w.RetentionPolicy, state, runs[:4], deletedBranches, now)
+	ids = append(ids, runsToDelete(w.RetentionPolicy, state, runs[4:], deletedBranches, now)...)
+	assert.Equal(t, []int64{8, 6, 1}, ids)
+
+	// the history length is not used with a retention policy, only the last run of each branch and the last success are kept
+	w.RetentionPolicy = &sdk.WorkflowRetentionPolicy{KeepLastPerBranch: 1}
+	w.HistoryLength = 1
+	ids = runsToDelete(w.RetentionPolicy, newRetentionState(), runs, nil, now)
+	assert.Equal(t, []int64{9, 8, 6, 4, 3, 2, 1}, ids)
+
+	// a run waiting for an approval is not ended, it is kept whatever its age and position
+	gated := []retentionRun{
+		run(3, sdk.StatusSuccess, time.Hour, "master"),
+		run(2, sdk.StatusWaitingApproval, 40*24*time.Hour, "master"),
+		run(1, sdk.StatusFail, 50*24*time.Hour, "master"),
+	}
+	ids = runsToDelete(&sdk.WorkflowRetentionPolicy{MaxAge: 30, KeepLastPerBranch: 1}, newRetentionState(), gated, nil, now)
+	assert.Equal(t, []int64{1}, ids)
+}
+
+func Test_WorkflowRetentionPolicyMatchTags(t *testing.T) {
+	p := sdk.WorkflowRetentionPolicy{KeepTags: []string{"release", "git.branch=master"}}
+	assert.True(t, p.MatchTags([]sdk.WorkflowRunTag{{Tag: "release", Value: "v1"}}))
+	assert.True(t, p.MatchTags([]sdk.WorkflowRunTag{{Tag: "git.branch", Value: "master"}}))
+	assert.False(t, p.MatchTags([]sdk.WorkflowRunTag{{Tag: "git.branch", Value: "feat/a"}}))
+	assert.False(t, p.MatchTags(nil))
+}
@@ -298,7 +298,7 @@ func (api *API) getReposFromRepositoriesManagerHandler() service.Handler {
 		log.Debug("getReposFromRepositoriesManagerHandler> Loading repo for %s; ok", vcsServer.Name)
 
 		var errAuthClient error
-		client, errAuthClient := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, vcsServer)
+		client, errAuthClient := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, proj.Key, vcsServer)
 		if errAuthClient != nil {
 			return sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "getReposFromRepositoriesManagerHandler> Cannot get client got %s %s: %v", projectKey, rmName, errAuthClient)
 		}
@@ -344,7 +344,7 @@ func (api *API) getRepoFromRepositoriesManagerHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "getReposFromRepositoriesManagerHandler> Cannot get client got %s %s", projectKey, rmName)
 		}
 
-		client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, vcsServer)
+		client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, proj.Key, vcsServer)
 		if err != nil {
 			return sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "getRepoFromRepositoriesManagerHandler> Cannot get client got %s %s : %s", projectKey, rmName, err)
 		}
@@ -381,7 +381,7 @@ func (api *API) attachRepositoriesManagerHandler() service.Handler {
 		}
 
 		//Get an authorized Client
-		client, err := repositoriesmanager.AuthorizedClient(ctx, db, api.Cache, rm)
+		client, err := repositoriesmanager.AuthorizedClient(ctx, db, api.Cache, projectKey, rm)
 		if err != nil {
 			return sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "attachRepositoriesManager> Cannot get client got %s %s : %s", projectKey, rmName, err)
 		}
@@ -479,7 +479,7 @@ func (api *API) detachRepositoriesManagerHandler() service.Handler {
 		}
 
 		//Get an authorized Client
-		client, err := repositoriesmanager.AuthorizedClient(ctx, db, api.Cache, rm)
+		client, err := repositoriesmanager.AuthorizedClient(ctx, db, api.Cache, projectKey, rm)
 		if err != nil {
 			return sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "attachRepositoriesManager> Cannot get client got %s %s : %s", projectKey, rmName, err)
 		}
@@ -541,7 +541,7 @@ func (api *API) detachRepositoriesManagerHandler() service.Handler {
 				}
 
 				for _, nodeHook := range nodeHooks {
-					if nodeHook.WorkflowHookModel.Name != sdk.RepositoryWebHookModelName && nodeHook.WorkflowHookModel.Name != sdk.GitPollerModelName {
+					if nodeHook.WorkflowHookModel.Name != sdk.RepositoryWebHookModelName && nodeHook.WorkflowHookModel.Name != sdk.GitPollerModelName && nodeHook.WorkflowHookModel.Name != sdk.GerritHookModelName {
 						continue
 					}
 					hookToDelete[nodeHook.UUID] = nodeHook
@@ -700,7 +700,7 @@ func (api *API) deleteHookOnRepositoriesManagerHandler() service.Handler {
 			return sdk.ErrNoReposManager
 		}
 
-		client, errauth := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, rm)
+		client, errauth := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, proj.Key, rm)
 		if errauth != nil {
 			return sdk.WrapError(errauth, "deleteHookOnRepositoriesManagerHandler> Cannot get client %s %s", projectKey, app.VCSServer)
 		}
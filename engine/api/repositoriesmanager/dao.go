@@ -44,6 +44,41 @@ func InsertForProject(db gorp.SqlExecutor, proj *sdk.Project, vcsServer *sdk.Pro
 	return nil
 }
 
+//UpdateForProject updates the data of a repository manager linked with a project
+func UpdateForProject(db gorp.SqlExecutor, projectKey string, vcsServer *sdk.ProjectVCSServer) error {
+	servers, err := LoadAllForProject(db, projectKey)
+	if err != nil {
+		return err
+	}
+
+	var found bool
+	for i := range servers {
+		if servers[i].Name == vcsServer.Name {
+			servers[i] = *vcsServer
+			found = true
+			break
+		}
+	}
+	if !found {
+		return sdk.ErrNoReposManager
+	}
+
+	b1, err := yaml.Marshal(servers)
+	if err != nil {
+		return err
+	}
+
+	encryptedVCSServerStr, err := secret.Encrypt(b1)
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.Exec("update project set vcs_servers = $2 where projectkey = $1", projectKey, encryptedVCSServerStr); err != nil {
+		return err
+	}
+	return nil
+}
+
 //DeleteForProject unlink a project with a repository manager
 func DeleteForProject(db gorp.SqlExecutor, proj *sdk.Project, vcsServer *sdk.ProjectVCSServer) error {
 	servers, err := LoadAllForProject(db, proj.Key)
@@ -40,6 +40,20 @@ func LoadAll(ctx context.Context, db *gorp.DbMap, store cache.Store) ([]string,
 	return servers, nil
 }
 
+// LoadGerritConfigurations returns the configurations used by the hooks service to listen the gerrit servers
+func LoadGerritConfigurations(ctx context.Context, db *gorp.DbMap) (map[string]sdk.VCSGerritConfiguration, error) {
+	srvs, err := services.FindByType(db, services.TypeVCS)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Unable to load services")
+	}
+
+	res := map[string]sdk.VCSGerritConfiguration{}
+	if _, err := services.DoJSONRequest(ctx, srvs, "GET", "/vcsgerrit", nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 type vcsConsumer struct {
 	name   string
 	proj   *sdk.Project
@@ -48,11 +62,13 @@ type vcsConsumer struct {
 }
 
 type vcsClient struct {
-	name   string
-	token  string
-	secret string
-	srvs   []sdk.Service
-	cache  *gocache.Cache
+	name       string
+	projectKey string
+	token      string
+	secret     string
+	srvs       []sdk.Service
+	cache      *gocache.Cache
+	db         gorp.SqlExecutor
 }
 
 func (c *vcsClient) Cache() *gocache.Cache {
@@ -129,24 +145,26 @@ func (c *vcsConsumer) GetAuthorizedClient(ctx context.Context, token string, sec
 	}
 
 	return &vcsClient{
-		name:   c.name,
-		token:  token,
-		secret: secret,
-		srvs:   srvs,
-		cache:  gocache.New(5*time.Second, 60*time.Second),
+		name:       c.name,
+		projectKey: c.proj.Key,
+		token:      token,
+		secret:     secret,
+		srvs:       srvs,
+		cache:      gocache.New(5*time.Second, 60*time.Second),
+		db:         c.dbFunc(),
 	}, nil
 }
 
 var local = localAuthorizedClientCache{
-	cache: make(map[uint64]sdk.VCSAuthorizedClient),
+	cache: make(map[uint64]*vcsClient),
 }
 
 type localAuthorizedClientCache struct {
 	mutex sync.RWMutex
-	cache map[uint64]sdk.VCSAuthorizedClient
+	cache map[uint64]*vcsClient
 }
 
-func (c *localAuthorizedClientCache) Set(repo *sdk.ProjectVCSServer, vcs sdk.VCSAuthorizedClient) {
+func (c *localAuthorizedClientCache) Set(repo *sdk.ProjectVCSServer, vcs *vcsClient) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
@@ -157,7 +175,7 @@ func (c *localAuthorizedClientCache) Set(repo *sdk.ProjectVCSServer, vcs sdk.VCS
 	c.cache[hash] = vcs
 }
 
-func (c *localAuthorizedClientCache) Get(repo *sdk.ProjectVCSServer) (sdk.VCSAuthorizedClient, bool) {
+func (c *localAuthorizedClientCache) Get(repo *sdk.ProjectVCSServer) (*vcsClient, bool) {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
@@ -165,37 +183,45 @@ func (c *localAuthorizedClientCache) Get(repo *sdk.ProjectVCSServer) (sdk.VCSAut
 	return vcs, ok
 }
 
-//AuthorizedClient returns an implementation of AuthorizedClient wrapping calls to vcs uService
-func AuthorizedClient(ctx context.Context, db gorp.SqlExecutor, store cache.Store, repo *sdk.ProjectVCSServer) (sdk.VCSAuthorizedClient, error) {
+//AuthorizedClient returns an implementation of AuthorizedClient wrapping calls to vcs uService,
+//the tokens refreshed by the vcs uService are stored in the project with the given key
+func AuthorizedClient(ctx context.Context, db gorp.SqlExecutor, store cache.Store, projectKey string, repo *sdk.ProjectVCSServer) (sdk.VCSAuthorizedClient, error) {
 	if repo == nil {
 		return nil, sdk.ErrNoReposManagerClientAuth
 	}
 
 	vcs, has := local.Get(repo)
-	if has {
-		return vcs, nil
-	}
+	if !has {
+		srvs, err := services.FindByType(db, services.TypeVCS)
+		if err != nil {
+			return nil, err
+		}
 
-	srvs, err := services.FindByType(db, services.TypeVCS)
-	if err != nil {
-		return nil, err
+		vcs = &vcsClient{
+			name:   repo.Name,
+			token:  repo.Data["token"],
+			secret: repo.Data["secret"],
+			srvs:   srvs,
+			cache:  gocache.New(5*time.Second, 60*time.Second),
+		}
+		local.Set(repo, vcs)
 	}
 
-	vcs = &vcsClient{
-		name:   repo.Name,
-		token:  repo.Data["token"],
-		secret: repo.Data["secret"],
-		srvs:   srvs,
-	}
-	local.Set(repo, vcs)
-	return vcs, nil
+	// Clients are shared by repositories manager data, the project and the database are those of the caller
+	c := *vcs
+	c.projectKey = projectKey
+	c.db = db
+	return &c, nil
 }
 
 func (c *vcsClient) doJSONRequest(ctx context.Context, method, path string, in interface{}, out interface{}) (int, error) {
-	code, err := services.DoJSONRequest(ctx, c.srvs, method, path, in, out, func(req *http.Request) {
+	headers, code, err := services.DoJSONRequestWithHeaders(ctx, c.srvs, method, path, in, out, func(req *http.Request) {
 		req.Header.Set("X-CDS-ACCESS-TOKEN", base64.StdEncoding.EncodeToString([]byte(c.token)))
 		req.Header.Set("X-CDS-ACCESS-TOKEN-SECRET", base64.StdEncoding.EncodeToString([]byte(c.secret)))
 	})
+	if err == nil {
+		c.updateTokens(headers)
+	}
 
 	if code >= 400 {
 		switch code {
@@ -215,6 +241,45 @@ func (c *vcsClient) doJSONRequest(ctx context.Context, method, path string, in i
 	return code, err
 }
 
+// updateTokens stores the tokens sent back by the vcs uService when it has refreshed them
+func (c *vcsClient) updateTokens(headers http.Header) {
+	if headers.Get("X-CDS-ACCESS-TOKEN") == "" {
+		return
+	}
+	token, err := base64.StdEncoding.DecodeString(headers.Get("X-CDS-ACCESS-TOKEN"))
+	if err != nil {
+		log.Error("vcsClient.updateTokens> unable to decode access token: %v", err)
+		return
+	}
+	secret, err := base64.StdEncoding.DecodeString(headers.Get("X-CDS-ACCESS-TOKEN-SECRET"))
+	if err != nil {
+		log.Error("vcsClient.updateTokens> unable to decode access token secret: %v", err)
+		return
+	}
+	if string(token) == c.token && string(secret) == c.secret {
+		return
+	}
+	c.token = string(token)
+	c.secret = string(secret)
+
+	if c.db == nil || c.projectKey == "" {
+		return
+	}
+	vcsServer, err := LoadForProject(c.db, c.projectKey, c.name)
+	if err != nil {
+		log.Error("vcsClient.updateTokens> unable to load %s for project %s: %v", c.name, c.projectKey, err)
+		return
+	}
+	if vcsServer.Data == nil {
+		vcsServer.Data = map[string]string{}
+	}
+	vcsServer.Data["token"] = c.token
+	vcsServer.Data["secret"] = c.secret
+	if err := UpdateForProject(c.db, c.projectKey, vcsServer); err != nil {
+		log.Error("vcsClient.updateTokens> unable to update %s for project %s: %v", c.name, c.projectKey, err)
+	}
+}
+
 func (c *vcsClient) postMultipart(ctx context.Context, path string, fileContent []byte, out interface{}) (int, error) {
 	return services.PostMultipart(ctx, c.srvs, "POST", path, fileContent, out, func(req *http.Request) {
 		req.Header.Set("X-CDS-ACCESS-TOKEN", base64.StdEncoding.EncodeToString([]byte(c.token)))
@@ -513,9 +578,10 @@ func (c *vcsClient) GrantReadPermission(ctx context.Context, repo string) error
 
 // WebhooksInfos is a set of info about webhooks
 type WebhooksInfos struct {
-	WebhooksSupported bool   `json:"webhooks_supported"`
-	WebhooksDisabled  bool   `json:"webhooks_disabled"`
-	Icon              string `json:"webhooks_icon"`
+	WebhooksSupported  bool   `json:"webhooks_supported"`
+	WebhooksDisabled   bool   `json:"webhooks_disabled"`
+	Icon               string `json:"webhooks_icon"`
+	GerritHookDisabled bool   `json:"gerrithook_disabled"`
 }
 
 // GetWebhooksInfos returns webhooks_supported, webhooks_disabled, webhooks_creation_supported, webhooks_creation_disabled for a vcs server
@@ -66,7 +66,11 @@ func (api *API) authMiddleware(ctx context.Context, w http.ResponseWriter, req *
 			}
 		default:
 			var err error
-			ctx, err = api.Router.AuthDriver.CheckAuth(ctx, w, req)
+			if auth.GetAccessToken(headers) != "" {
+				ctx, err = auth.CheckAccessTokenAuth(ctx, api.mustDB(), headers)
+			} else {
+				ctx, err = api.Router.AuthDriver.CheckAuth(ctx, w, req)
+			}
 			if err != nil {
 				return ctx, sdk.WrapError(sdk.ErrUnauthorized, "Router> Authorization denied on %s %s for %s agent %s : %s", req.Method, req.URL, req.RemoteAddr, getAgent(req), err)
 			}
@@ -121,6 +125,9 @@ func (api *API) authMiddleware(ctx context.Context, w http.ResponseWriter, req *
 		if err := loadUserPermissions(api.mustDB(), api.Cache, getUser(ctx)); err != nil {
 			return ctx, sdk.WrapError(sdk.ErrUnauthorized, "Router> Unable to load user %d permission: %v", getUser(ctx).ID, err)
 		}
+		if t := getAccessToken(ctx); t != nil {
+			restrictUserPermissionsToAccessToken(getUser(ctx), t)
+		}
 	}
 
 	if rc.Options["auth"] != "true" {
@@ -131,6 +138,13 @@ func (api *API) authMiddleware(ctx context.Context, w http.ResponseWriter, req *
 		return ctx, sdk.WrapError(sdk.ErrUnauthorized, "Router> Unable to find connected user")
 	}
 
+	if t := getAccessToken(ctx); t != nil {
+		perm := getPermissionByMethod(req.Method, rc.Options["isExecution"] == "true")
+		if perm > accessTokenMaxPermission(t) || !accessTokenAllowsRoute(t, mux.Vars(req), perm) {
+			return ctx, sdk.WrapError(sdk.ErrForbidden, "Router> Access token %d not authorized on %s %s", t.ID, req.Method, req.URL)
+		}
+	}
+
 	if rc.Options["allowServices"] == "true" && getService(ctx) != nil {
 		return ctx, nil
 	}
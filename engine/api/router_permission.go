@@ -3,12 +3,15 @@ package api
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/engine/api/application"
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/environment"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/permission"
 	"github.com/ovh/cds/engine/api/pipeline"
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/workflow"
@@ -158,3 +161,68 @@ func loadPermissionsByGroupID(db gorp.SqlExecutor, store cache.Store, groupID in
 
 	return g, u.Permissions, nil
 }
+
+// accessTokenMaxPermission returns the highest permission granted by the scopes of an access token
+func accessTokenMaxPermission(t *sdk.AccessToken) int {
+	switch {
+	case t.HasScope(sdk.AccessTokenScopeAdmin), t.HasScope(sdk.AccessTokenScopeProject):
+		return permission.PermissionReadWriteExecute
+	case t.HasScope(sdk.AccessTokenScopeRun):
+		return permission.PermissionReadExecute
+	case t.HasScope(sdk.AccessTokenScopeRead):
+		return permission.PermissionRead
+	}
+	return 0
+}
+
+// accessTokenAllowsRoute returns true if an access token can be used on a route. Without the admin scope, a token
+// is limited to the routes of its projects, and to read only routes for the ones that are not about a project.
+func accessTokenAllowsRoute(t *sdk.AccessToken, routeVar map[string]string, perm int) bool {
+	if t.HasScope(sdk.AccessTokenScopeAdmin) {
+		return true
+	}
+	projectKey, has := routeVar["permProjectKey"]
+	if !has {
+		projectKey, has = routeVar["key"]
+	}
+	if !has {
+		return perm == permission.PermissionRead
+	}
+	return len(t.Projects) == 0 || sdk.IsInArray(projectKey, t.Projects)
+}
+
+// restrictUserPermissionsToAccessToken limits the permissions of a user to the scopes and the projects of its access token.
+// Only the admin scope keeps the admin rights of the user, whatever the projects of the token.
+func restrictUserPermissionsToAccessToken(u *sdk.User, t *sdk.AccessToken) {
+	if t.HasScope(sdk.AccessTokenScopeAdmin) {
+		return
+	}
+	u.Admin = false
+
+	// Members of shared.infra are granted all permissions
+	groups := make([]sdk.Group, 0, len(u.Groups))
+	for _, g := range u.Groups {
+		if group.SharedInfraGroup != nil && g.ID == group.SharedInfraGroup.ID {
+			continue
+		}
+		groups = append(groups, g)
+	}
+	u.Groups = groups
+
+	max := accessTokenMaxPermission(t)
+	restrict := func(perms map[string]int) {
+		for k, p := range perms {
+			projectKey := strings.SplitN(k, "/", 2)[0]
+			if len(t.Projects) > 0 && !sdk.IsInArray(projectKey, t.Projects) {
+				delete(perms, k)
+			} else if p > max {
+				perms[k] = max
+			}
+		}
+	}
+	restrict(u.Permissions.ProjectsPerm)
+	restrict(u.Permissions.ApplicationsPerm)
+	restrict(u.Permissions.PipelinesPerm)
+	restrict(u.Permissions.EnvironmentsPerm)
+	restrict(u.Permissions.WorkflowsPerm)
+}
@@ -9,6 +9,7 @@ import (
 	"database/sql"
 	"fmt"
 	"io"
+	"path"
 	"strings"
 
 	"github.com/ovh/cds/sdk"
@@ -27,6 +28,10 @@ const (
 var (
 	key    []byte
 	prefix = "3DICC3It"
+	// vaultClient resolves vault variables, it is nil if vault is not configured
+	vaultClient *Secret
+	// vaultPathPrefix is the path under which the secrets of each project are stored, as <prefix>/<project key>
+	vaultPathPrefix string
 )
 
 type Secret struct {
@@ -74,6 +79,82 @@ func (secret *Secret) GetFromVault(s string) (string, error) {
 	return fmt.Sprintf("%v", value), nil
 }
 
+// InitVault initializes the vault client used to resolve vault variables.
+// A project can only read the secrets stored under pathPrefix/<project key>.
+func InitVault(token, addr, pathPrefix string) error {
+	pathPrefix = strings.Trim(pathPrefix, "/")
+	if pathPrefix == "" {
+		return fmt.Errorf("a path prefix is required to restrict the secrets read by projects")
+	}
+	s, err := New(token, addr)
+	if err != nil {
+		return err
+	}
+	vaultClient = s
+	vaultPathPrefix = pathPrefix
+	return nil
+}
+
+// checkVaultPath returns an error if the path is not under the path of the project in vault
+func checkVaultPath(projectKey, p string) error {
+	if projectKey == "" {
+		return fmt.Errorf("unknown project")
+	}
+	projectPath := path.Join(vaultPathPrefix, projectKey)
+	if path.Clean(p) != p || !strings.HasPrefix(p, projectPath+"/") {
+		return fmt.Errorf("path %q is not allowed, secrets of project %s must be stored under %s/", p, projectKey, projectPath)
+	}
+	return nil
+}
+
+// GetVariableFromVault reads the value of a key in a vault secret.
+// Secrets of the key/value secrets engine version 2 are nested in a data field, they are read transparently.
+func (secret *Secret) GetVariableFromVault(path, k string) (string, error) {
+	s, err := secret.Client.Logical().Read(path)
+	if err != nil {
+		return "", err
+	}
+	if s == nil {
+		return "", fmt.Errorf("no secret found at %q", path)
+	}
+
+	data := s.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok && s.Data["metadata"] != nil {
+		data = nested
+	}
+	value, exists := data[k]
+	if !exists || value == nil {
+		return "", fmt.Errorf("no field %q found in secret %q", k, path)
+	}
+	return fmt.Sprintf("%v", value), nil
+}
+
+// ResolveVaultVariable replaces the reference of a vault variable by the value read in vault.
+// The secret must be stored under the path of the project, the resolved variable is a password so that it is hidden like other secrets.
+func ResolveVaultVariable(projectKey string, v *sdk.Variable) error {
+	if v.Type != sdk.VaultVariable {
+		return nil
+	}
+	path, k, err := sdk.ParseVaultReference(v.Value)
+	if err != nil {
+		return err
+	}
+	if vaultClient == nil {
+		return fmt.Errorf("cannot resolve variable %s: vault is not configured", v.Name)
+	}
+	if err := checkVaultPath(projectKey, path); err != nil {
+		return fmt.Errorf("cannot resolve variable %s: %v", v.Name, err)
+	}
+
+	value, err := vaultClient.GetVariableFromVault(path, k)
+	if err != nil {
+		return fmt.Errorf("cannot resolve variable %s: %v", v.Name, err)
+	}
+	v.Value = value
+	v.Type = sdk.SecretVariable
+	return nil
+}
+
 // Encrypt data using aes+hmac algorithm
 // Init() must be called before any encryption
 func Encrypt(data []byte) ([]byte, error) {
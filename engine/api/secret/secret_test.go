@@ -3,6 +3,10 @@ package secret
 import (
 	"bytes"
 	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/ovh/cds/sdk"
@@ -99,3 +103,88 @@ func TestDecryptS(t *testing.T) {
 	}
 
 }
+
+func TestResolveVaultVariable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/secret/data/cds/PROJ/myapp":
+			fmt.Fprint(w, `{"data": {"data": {"password": "kv2-value"}, "metadata": {"version": 1}}}`)
+		case "/v1/secret/data/cds/PROJ/kv/myapp", "/v1/secret/data/cds/OTHER/myapp":
+			fmt.Fprint(w, `{"data": {"data": "kv1-value", "password": "kv1-password"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	vaultClient = nil
+	v := sdk.Variable{Name: "password", Type: sdk.VaultVariable, Value: "secret/data/cds/PROJ/myapp#password"}
+	if err := ResolveVaultVariable("PROJ", &v); err == nil {
+		t.Fatalf("ResolveVaultVariable should have failed without vault client")
+	}
+
+	if err := InitVault("token", srv.URL, ""); err == nil {
+		t.Fatalf("InitVault should have failed without path prefix")
+	}
+	if err := InitVault("token", srv.URL, "/secret/data/cds/"); err != nil {
+		t.Fatalf("InitVault failed: %s", err)
+	}
+	defer func() { vaultClient = nil }()
+
+	tests := map[string]string{
+		"secret/data/cds/PROJ/myapp#password":     "kv2-value",
+		"/secret/data/cds/PROJ/kv/myapp#password": "kv1-password",
+		"secret/data/cds/PROJ/kv/myapp":           "kv1-value",
+	}
+	for ref, expected := range tests {
+		v := sdk.Variable{Name: "password", Type: sdk.VaultVariable, Value: ref}
+		if err := ResolveVaultVariable("PROJ", &v); err != nil {
+			t.Fatalf("ResolveVaultVariable failed for %s: %s", ref, err)
+		}
+		if v.Value != expected || v.Type != sdk.SecretVariable {
+			t.Fatalf("Fail: Expected secret '%s' for %s, got %s '%s'", expected, ref, v.Type, v.Value)
+		}
+	}
+
+	for _, ref := range []string{
+		"secret/data/cds/PROJ/myapp#unknown", "secret/data/cds/PROJ/unknown#password", "#password", "secret/data/cds/PROJ/myapp#",
+		// secrets of other projects or outside of the path prefix can't be read
+		"secret/data/cds/OTHER/myapp#password", "secret/data/cds/PROJ/../OTHER/myapp#password", "secret/data/cds/PROJ#password", "secret/data/cds/PROJ2/myapp#password", "secret/data/myapp#password",
+	} {
+		v := sdk.Variable{Name: "password", Type: sdk.VaultVariable, Value: ref}
+		if err := ResolveVaultVariable("PROJ", &v); err == nil {
+			t.Fatalf("ResolveVaultVariable should have failed for %s", ref)
+		}
+		if v.Value != ref {
+			t.Fatalf("Fail: reference %s should not have been modified, got '%s'", ref, v.Value)
+		}
+	}
+}
+
+// TestResolveVaultVariableDevServer runs against a vault dev server, for example started with: vault server -dev -dev-root-token-id=root
+func TestResolveVaultVariableDevServer(t *testing.T) {
+	addr := os.Getenv("CDS_TEST_VAULT_ADDR")
+	if addr == "" {
+		t.SkipNow()
+	}
+
+	if err := InitVault(os.Getenv("CDS_TEST_VAULT_TOKEN"), addr, "secret/data/cds"); err != nil {
+		t.Fatalf("InitVault failed: %s", err)
+	}
+	defer func() { vaultClient = nil }()
+
+	value := sdk.RandomString(20)
+	if _, err := vaultClient.Client.Logical().Write("secret/data/cds/PROJ/test", map[string]interface{}{
+		"data": map[string]interface{}{"password": value},
+	}); err != nil {
+		t.Fatalf("Unable to write secret: %s", err)
+	}
+
+	v := sdk.Variable{Name: "password", Type: sdk.VaultVariable, Value: "secret/data/cds/PROJ/test#password"}
+	if err := ResolveVaultVariable("PROJ", &v); err != nil {
+		t.Fatalf("ResolveVaultVariable failed: %s", err)
+	}
+	if v.Value != value {
+		t.Fatalf("Fail: Expected '%s', got '%s'", value, v.Value)
+	}
+}
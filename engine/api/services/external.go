@@ -72,7 +72,7 @@ func ping(db gorp.SqlExecutor, s sdk.ExternalService) error {
 	} else {
 		pingURL = fmt.Sprintf("%s:%s", s.HealthURL, s.HealthPort)
 	}
-	_, code, err := doRequest(context.Background(), pingURL, "", "GET", s.HealthPath, nil)
+	_, _, code, err := doRequest(context.Background(), pingURL, "", "GET", s.HealthPath, nil)
 	if err != nil || code >= 400 {
 		mon.Lines[0].Status = sdk.MonitoringStatusWarn
 		mon.Lines[0].Value = "Health: KO"
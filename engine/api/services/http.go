@@ -22,6 +22,12 @@ var HTTPClient sdk.HTTPClient
 
 // DoJSONRequest performs an http request on a service
 func DoJSONRequest(ctx context.Context, srvs []sdk.Service, method, path string, in interface{}, out interface{}, mods ...sdk.RequestModifier) (int, error) {
+	_, code, err := DoJSONRequestWithHeaders(ctx, srvs, method, path, in, out, mods...)
+	return code, err
+}
+
+// DoJSONRequestWithHeaders performs an http request on a service and returns the headers of the response
+func DoJSONRequestWithHeaders(ctx context.Context, srvs []sdk.Service, method, path string, in interface{}, out interface{}, mods ...sdk.RequestModifier) (http.Header, int, error) {
 	var lastErr error
 	var lastCode int
 	var attempt int
@@ -29,9 +35,9 @@ func DoJSONRequest(ctx context.Context, srvs []sdk.Service, method, path string,
 		attempt++
 		for i := range srvs {
 			srv := &srvs[i]
-			code, err := doJSONRequest(ctx, srv, method, path, in, out, mods...)
+			headers, code, err := doJSONRequest(ctx, srv, method, path, in, out, mods...)
 			if err == nil {
-				return code, nil
+				return headers, code, nil
 			}
 			lastErr = err
 			lastCode = code
@@ -40,34 +46,34 @@ func DoJSONRequest(ctx context.Context, srvs []sdk.Service, method, path string,
 			break
 		}
 	}
-	return lastCode, lastErr
+	return nil, lastCode, lastErr
 }
 
 // DoJSONRequest performs an http request on service
-func doJSONRequest(ctx context.Context, srv *sdk.Service, method, path string, in interface{}, out interface{}, mods ...sdk.RequestModifier) (int, error) {
+func doJSONRequest(ctx context.Context, srv *sdk.Service, method, path string, in interface{}, out interface{}, mods ...sdk.RequestModifier) (http.Header, int, error) {
 	var b = []byte{}
 	var err error
 
 	if in != nil {
 		b, err = json.Marshal(in)
 		if err != nil {
-			return 0, sdk.WrapError(err, "Unable to marshal input")
+			return nil, 0, sdk.WrapError(err, "Unable to marshal input")
 		}
 	}
 
 	mods = append(mods, sdk.SetHeader("Content-Type", "application/json"))
-	res, code, err := doRequest(ctx, srv.HTTPURL, srv.Hash, method, path, b, mods...)
+	res, headers, code, err := doRequest(ctx, srv.HTTPURL, srv.Hash, method, path, b, mods...)
 	if err != nil {
-		return code, sdk.WrapError(err, "Unable to perform request on service %s (%s)", srv.Name, srv.Type)
+		return headers, code, sdk.WrapError(err, "Unable to perform request on service %s (%s)", srv.Name, srv.Type)
 	}
 
 	if out != nil {
 		if err := json.Unmarshal(res, out); err != nil {
-			return code, sdk.WrapError(err, "Unable to marshal output")
+			return headers, code, sdk.WrapError(err, "Unable to marshal output")
 		}
 	}
 
-	return code, nil
+	return headers, code, nil
 }
 
 // PostMultipart post a file content through multipart upload
@@ -92,7 +98,7 @@ func PostMultipart(ctx context.Context, srvs []sdk.Service, path string, filenam
 		attempt++
 		for i := range srvs {
 			srv := &srvs[i]
-			res, code, err := doRequest(ctx, srv.HTTPURL, srv.Hash, "POST", path, body.Bytes(), mods...)
+			res, _, code, err := doRequest(ctx, srv.HTTPURL, srv.Hash, "POST", path, body.Bytes(), mods...)
 			lastCode = code
 			lastErr = err
 
@@ -122,7 +128,7 @@ func DoRequest(ctx context.Context, srvs []sdk.Service, method, path string, arg
 		attempt++
 		for i := range srvs {
 			srv := &srvs[i]
-			btes, code, err := doRequest(ctx, srv.HTTPURL, srv.Hash, method, path, args, mods...)
+			btes, _, code, err := doRequest(ctx, srv.HTTPURL, srv.Hash, method, path, args, mods...)
 			if err == nil {
 				return btes, code, nil
 			}
@@ -137,7 +143,7 @@ func DoRequest(ctx context.Context, srvs []sdk.Service, method, path string, arg
 }
 
 // doRequest performs an http request on service
-func doRequest(ctx context.Context, httpURL string, hash string, method, path string, args []byte, mods ...sdk.RequestModifier) ([]byte, int, error) {
+func doRequest(ctx context.Context, httpURL string, hash string, method, path string, args []byte, mods ...sdk.RequestModifier) ([]byte, http.Header, int, error) {
 	if HTTPClient == nil {
 		HTTPClient = &http.Client{
 			Timeout: 60 * time.Second,
@@ -146,7 +152,7 @@ func doRequest(ctx context.Context, httpURL string, hash string, method, path st
 
 	callURL, err := url.ParseRequestURI(httpURL + path)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, 0, err
 	}
 
 	var requestError error
@@ -157,7 +163,7 @@ func doRequest(ctx context.Context, httpURL string, hash string, method, path st
 		req, requestError = http.NewRequest(method, callURL.String(), nil)
 	}
 	if requestError != nil {
-		return nil, 0, requestError
+		return nil, nil, 0, requestError
 	}
 
 	req = req.WithContext(ctx)
@@ -187,27 +193,27 @@ func doRequest(ctx context.Context, httpURL string, hash string, method, path st
 	//Do the request
 	resp, errDo := HTTPClient.Do(req)
 	if errDo != nil {
-		return nil, 0, sdk.WrapError(errDo, "services.DoRequest> Request failed")
+		return nil, nil, 0, sdk.WrapError(errDo, "services.DoRequest> Request failed")
 	}
 	defer resp.Body.Close()
 
 	// Read the body
 	body, errBody := ioutil.ReadAll(resp.Body)
 	if errBody != nil {
-		return nil, resp.StatusCode, sdk.WrapError(errBody, "services.DoRequest> Unable to read body")
+		return nil, resp.Header, resp.StatusCode, sdk.WrapError(errBody, "services.DoRequest> Unable to read body")
 	}
 
 	log.Debug("services.DoRequest> response code:%d body:%s", resp.StatusCode, string(body))
 
 	// if everything is fine, return body
 	if resp.StatusCode < 400 {
-		return body, resp.StatusCode, nil
+		return body, resp.Header, resp.StatusCode, nil
 	}
 
 	// Try to catch the CDS Error
 	if cdserr := sdk.DecodeError(body); cdserr != nil {
-		return nil, resp.StatusCode, cdserr
+		return nil, resp.Header, resp.StatusCode, cdserr
 	}
 
-	return nil, resp.StatusCode, fmt.Errorf("Request Failed")
+	return nil, resp.Header, resp.StatusCode, fmt.Errorf("Request Failed")
 }
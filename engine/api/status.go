@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/logstore"
 	"github.com/ovh/cds/engine/api/mail"
 	"github.com/ovh/cds/engine/api/objectstore"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
@@ -40,6 +41,7 @@ func (api *API) Status() sdk.MonitoringStatus {
 	m.Lines = append(m.Lines, getStatusLine(api.Cache.Status()))
 	m.Lines = append(m.Lines, getStatusLine(sessionstore.Status))
 	m.Lines = append(m.Lines, getStatusLine(objectstore.Status()))
+	m.Lines = append(m.Lines, getStatusLine(logstore.Status()))
 	m.Lines = append(m.Lines, getStatusLine(mail.Status()))
 	m.Lines = append(m.Lines, getStatusLine(api.DBConnectionFactory.Status()))
 	m.Lines = append(m.Lines, getStatusLine(worker.Status(api.mustDB())))
@@ -255,8 +255,8 @@ func (api *API) postUserFavoriteHandler() service.Handler {
 // AddUser creates a new user and generate verification email
 func (api *API) addUserHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
-		//returns forbidden if LDAP mode is activated
-		if _, ldap := api.Router.AuthDriver.(*auth.LDAPClient); ldap {
+		//returns forbidden if LDAP or OIDC mode is activated
+		if api.isExternalAuth() {
 			return sdk.ErrForbidden
 		}
 
@@ -331,8 +331,8 @@ func (api *API) addUserHandler() service.Handler {
 
 func (api *API) resetUserHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
-		//returns forbidden if LDAP mode is activated
-		if _, ldap := api.Router.AuthDriver.(*auth.LDAPClient); ldap {
+		//returns forbidden if LDAP or OIDC mode is activated
+		if api.isExternalAuth() {
 			return sdk.ErrForbidden
 		}
 
@@ -369,12 +369,24 @@ func (api *API) resetUserHandler() service.Handler {
 	}
 }
 
-//AuthModeHandler returns the auth mode : local ok ldap
+// isExternalAuth returns true if users are managed by LDAP or OIDC instead of CDS
+func (api *API) isExternalAuth() bool {
+	switch api.Router.AuthDriver.(type) {
+	case *auth.LDAPClient, *auth.OIDCClient:
+		return true
+	}
+	return false
+}
+
+//AuthModeHandler returns the auth mode : local, ldap or oidc
 func (api *API) authModeHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		mode := "local"
-		if _, ldap := api.Router.AuthDriver.(*auth.LDAPClient); ldap {
+		switch api.Router.AuthDriver.(type) {
+		case *auth.LDAPClient:
 			mode = "ldap"
+		case *auth.OIDCClient:
+			mode = "oidc"
 		}
 		res := map[string]string{
 			"auth_mode": mode,
@@ -386,8 +398,8 @@ func (api *API) authModeHandler() service.Handler {
 // ConfirmUser verify token send via email and mark user as verified
 func (api *API) confirmUserHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
-		//returns forbidden if LDAP mode is activated
-		if _, ldap := api.Router.AuthDriver.(*auth.LDAPClient); ldap {
+		//returns forbidden if LDAP or OIDC mode is activated
+		if api.isExternalAuth() {
 			return sdk.ErrForbidden
 		}
 
@@ -485,40 +497,45 @@ func (api *API) loginUserHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrWrongRequest, "Auth> Login error %s: %s", loginUserRequest.Username, errl)
 		}
 
-		// Prepare response
-		response := sdk.UserAPIResponse{
-			User: *u,
-		}
+		return api.writeLoginUserResponse(w, u, logFromCLI)
+	}
+}
 
-		if err := group.CheckUserInDefaultGroup(api.mustDB(), u.ID); err != nil {
-			log.Warning("Auth> Error while check user in default group:%s\n", err)
-		}
+// writeLoginUserResponse creates a session for an authenticated user and writes it in the response
+func (api *API) writeLoginUserResponse(w http.ResponseWriter, u *sdk.User, logFromCLI bool) error {
+	// Prepare response
+	response := sdk.UserAPIResponse{
+		User: *u,
+	}
 
-		var sessionKey sessionstore.SessionKey
-		var errs error
-		if !logFromCLI {
-			//Standard login, new session
-			sessionKey, errs = auth.NewSession(api.Router.AuthDriver, u)
-			if errs != nil {
-				log.Error("Auth> Error while creating new session: %s\n", errs)
-			}
-		} else {
-			//CLI login, generate user key as persistent session
-			sessionKey, errs = auth.NewPersistentSession(api.mustDB(), api.Router.AuthDriver, u)
-			if errs != nil {
-				log.Error("Auth> Error while creating new session: %s\n", errs)
-			}
-		}
+	if err := group.CheckUserInDefaultGroup(api.mustDB(), u.ID); err != nil {
+		log.Warning("Auth> Error while check user in default group:%s\n", err)
+	}
 
-		if sessionKey != "" {
-			w.Header().Set(sdk.SessionTokenHeader, string(sessionKey))
-			response.Token = string(sessionKey)
+	var sessionKey sessionstore.SessionKey
+	var errs error
+	if !logFromCLI {
+		//Standard login, new session
+		sessionKey, errs = auth.NewSession(api.Router.AuthDriver, u)
+		if errs != nil {
+			log.Error("Auth> Error while creating new session: %s\n", errs)
+		}
+	} else {
+		//CLI login, generate user key as persistent session
+		sessionKey, errs = auth.NewPersistentSession(api.mustDB(), api.Router.AuthDriver, u)
+		if errs != nil {
+			log.Error("Auth> Error while creating new session: %s\n", errs)
 		}
+	}
 
-		response.User.Auth = sdk.Auth{}
-		response.User.Permissions = sdk.UserPermissions{}
-		return service.WriteJSON(w, response, http.StatusOK)
+	if sessionKey != "" {
+		w.Header().Set(sdk.SessionTokenHeader, string(sessionKey))
+		response.Token = string(sessionKey)
 	}
+
+	response.User.Auth = sdk.Auth{}
+	response.User.Permissions = sdk.UserPermissions{}
+	return service.WriteJSON(w, response, http.StatusOK)
 }
 
 func (api *API) importUsersHandler() service.Handler {
@@ -0,0 +1,119 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"database/sql"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// PostGet is a db hook
+func (t *dbAccessToken) PostGet(db gorp.SqlExecutor) error {
+	var scopes, projects sql.NullString
+	if err := db.QueryRow("SELECT scopes, projects FROM access_token WHERE id = $1", t.ID).Scan(&scopes, &projects); err != nil {
+		return sdk.WrapError(err, "Cannot get access token scopes")
+	}
+	if err := gorpmapping.JSONNullString(scopes, &t.Scopes); err != nil {
+		return sdk.WrapError(err, "Cannot unmarshal access token scopes")
+	}
+	return gorpmapping.JSONNullString(projects, &t.Projects)
+}
+
+// PostInsert is a db hook
+func (t *dbAccessToken) PostInsert(db gorp.SqlExecutor) error {
+	scopes, err := gorpmapping.JSONToNullString(t.Scopes)
+	if err != nil {
+		return sdk.WrapError(err, "Cannot marshal access token scopes")
+	}
+	projects, err := gorpmapping.JSONToNullString(t.Projects)
+	if err != nil {
+		return sdk.WrapError(err, "Cannot marshal access token projects")
+	}
+	if _, err := db.Exec("UPDATE access_token SET scopes = $1, projects = $2 WHERE id = $3", scopes, projects, t.ID); err != nil {
+		return sdk.WrapError(err, "Cannot update access token scopes")
+	}
+	return nil
+}
+
+// hashAccessToken returns the value stored in database for an access token
+func hashAccessToken(value string) string {
+	h := sha512.Sum512([]byte(value))
+	return hex.EncodeToString(h[:])
+}
+
+// InsertAccessToken generates the value of a new access token of a user and inserts it, the value is only kept in t.Token
+func InsertAccessToken(db gorp.SqlExecutor, u *sdk.User, t *sdk.AccessToken) error {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return sdk.WrapError(err, "Cannot generate access token")
+	}
+	value := sdk.AccessTokenPrefix + hex.EncodeToString(b)
+
+	t.UserID = u.ID
+	t.Hash = hashAccessToken(value)
+	t.Created = time.Now()
+	t.LastUsed = nil
+	dbt := dbAccessToken(*t)
+	if err := db.Insert(&dbt); err != nil {
+		return sdk.WrapError(err, "Cannot insert access token for user %s", u.Username)
+	}
+	t.ID = dbt.ID
+	t.Token = value
+	return nil
+}
+
+// LoadAccessTokensByUserID loads the access tokens of a user
+func LoadAccessTokensByUserID(db gorp.SqlExecutor, userID int64) ([]sdk.AccessToken, error) {
+	var res []dbAccessToken
+	if _, err := db.Select(&res, "SELECT * FROM access_token WHERE user_id = $1 ORDER BY id", userID); err != nil {
+		return nil, sdk.WrapError(err, "Cannot load access tokens of user %d", userID)
+	}
+	tokens := make([]sdk.AccessToken, len(res))
+	for i := range res {
+		tokens[i] = sdk.AccessToken(res[i])
+	}
+	return tokens, nil
+}
+
+// LoadAccessTokenByValue loads an access token from its value
+func LoadAccessTokenByValue(db gorp.SqlExecutor, value string) (*sdk.AccessToken, error) {
+	if !strings.HasPrefix(value, sdk.AccessTokenPrefix) {
+		return nil, sdk.ErrInvalidToken
+	}
+	var t dbAccessToken
+	if err := db.SelectOne(&t, "SELECT * FROM access_token WHERE hash = $1", hashAccessToken(value)); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.ErrInvalidToken
+		}
+		return nil, sdk.WrapError(err, "Cannot load access token")
+	}
+	res := sdk.AccessToken(t)
+	return &res, nil
+}
+
+// UpdateAccessTokenLastUsed records the last use of an access token
+func UpdateAccessTokenLastUsed(db gorp.SqlExecutor, id int64, lastUsed time.Time) error {
+	if _, err := db.Exec("UPDATE access_token SET last_used = $1 WHERE id = $2", lastUsed, id); err != nil {
+		return sdk.WrapError(err, "Cannot update last use of access token %d", id)
+	}
+	return nil
+}
+
+// DeleteAccessToken deletes an access token of a user
+func DeleteAccessToken(db gorp.SqlExecutor, userID, id int64) error {
+	res, err := db.Exec("DELETE FROM access_token WHERE user_id = $1 AND id = $2", userID, id)
+	if err != nil {
+		return sdk.WrapError(err, "Cannot delete access token %d", id)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sdk.ErrNotFound
+	}
+	return nil
+}
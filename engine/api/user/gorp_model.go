@@ -8,6 +8,12 @@ import (
 
 type persistentSessionToken sdk.UserToken
 
+type dbAccessToken sdk.AccessToken
+
+type dbOIDCLink sdk.UserOIDCLink
+
 func init() {
 	gorpmapping.Register(gorpmapping.New(persistentSessionToken{}, "user_persistent_session", false, "token"))
+	gorpmapping.Register(gorpmapping.New(dbAccessToken{}, "access_token", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbOIDCLink{}, "user_oidc_link", true, "id"))
 }
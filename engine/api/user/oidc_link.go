@@ -0,0 +1,53 @@
+package user
+
+import (
+	"database/sql"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadOIDCLinkBySubject returns the link of an OpenID Connect identity, nil if the identity is not linked to a user
+func LoadOIDCLinkBySubject(db gorp.SqlExecutor, issuer, subject string) (*sdk.UserOIDCLink, error) {
+	var l dbOIDCLink
+	if err := db.SelectOne(&l, "SELECT * FROM user_oidc_link WHERE issuer = $1 AND subject = $2", issuer, subject); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, sdk.WrapError(err, "Cannot load OIDC link of subject %s", subject)
+	}
+	res := sdk.UserOIDCLink(l)
+	return &res, nil
+}
+
+// LoadOIDCLinkByUserID returns the link of a user to an identity of an OpenID Connect issuer, nil if the user is not linked
+func LoadOIDCLinkByUserID(db gorp.SqlExecutor, userID int64, issuer string) (*sdk.UserOIDCLink, error) {
+	var l dbOIDCLink
+	if err := db.SelectOne(&l, "SELECT * FROM user_oidc_link WHERE user_id = $1 AND issuer = $2", userID, issuer); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, sdk.WrapError(err, "Cannot load OIDC link of user %d", userID)
+	}
+	res := sdk.UserOIDCLink(l)
+	return &res, nil
+}
+
+// InsertOIDCLink links a user to an OpenID Connect identity
+func InsertOIDCLink(db gorp.SqlExecutor, l *sdk.UserOIDCLink) error {
+	dbl := dbOIDCLink(*l)
+	if err := db.Insert(&dbl); err != nil {
+		return sdk.WrapError(err, "Cannot insert OIDC link of user %d", l.UserID)
+	}
+	*l = sdk.UserOIDCLink(dbl)
+	return nil
+}
+
+// DeleteOIDCLink removes the link of a user to the identities of an OpenID Connect issuer
+func DeleteOIDCLink(db gorp.SqlExecutor, userID int64, issuer string) error {
+	if _, err := db.Exec("DELETE FROM user_oidc_link WHERE user_id = $1 AND issuer = $2", userID, issuer); err != nil {
+		return sdk.WrapError(err, "Cannot delete OIDC link of user %d", userID)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getUserAccessTokensHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		tokens, err := user.LoadAccessTokensByUserID(api.mustDB(), getUser(ctx).ID)
+		if err != nil {
+			return sdk.WrapError(err, "getUserAccessTokensHandler")
+		}
+		return service.WriteJSON(w, tokens, http.StatusOK)
+	}
+}
+
+func (api *API) postUserAccessTokenHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		// An access token must not be able to create tokens with more rights than itself
+		if getAccessToken(ctx) != nil {
+			return sdk.WrapError(sdk.ErrForbidden, "postUserAccessTokenHandler> access tokens cannot be created with an access token")
+		}
+
+		var t sdk.AccessToken
+		if err := service.UnmarshalBody(r, &t); err != nil {
+			return err
+		}
+		if err := t.IsValid(); err != nil {
+			return err
+		}
+
+		u := getUser(ctx)
+		if t.HasScope(sdk.AccessTokenScopeAdmin) && !u.Admin {
+			return sdk.WrapError(sdk.ErrForbidden, "postUserAccessTokenHandler> admin scope is reserved to CDS administrators")
+		}
+		for _, key := range t.Projects {
+			if !u.Admin && u.Permissions.ProjectsPerm[key] == 0 {
+				return sdk.NewErrorFrom(sdk.ErrNoProject, "project %s does not exist or is not readable by %s", key, u.Username)
+			}
+		}
+
+		if err := user.InsertAccessToken(api.mustDB(), u, &t); err != nil {
+			return sdk.WrapError(err, "postUserAccessTokenHandler")
+		}
+		return service.WriteJSON(w, t, http.StatusCreated)
+	}
+}
+
+func (api *API) deleteUserAccessTokenHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid access token id")
+		}
+
+		if err := user.DeleteAccessToken(api.mustDB(), getUser(ctx).ID, id); err != nil {
+			return sdk.WrapError(err, "deleteUserAccessTokenHandler> cannot delete access token %d", id)
+		}
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/bootstrap"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestAddUseAndDeleteUserAccessToken(t *testing.T) {
+	api, db, router, end := newTestAPI(t, bootstrap.InitiliazeDB)
+	defer end()
+	g := &sdk.Group{Name: sdk.RandomString(10)}
+	u, pass := assets.InsertLambdaUser(db, g)
+	proj1 := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10), u)
+	proj2 := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10), u)
+
+	// ADD a token with the admin scope
+	uri := router.GetRoute("POST", api.postUserAccessTokenHandler, nil)
+	tok := sdk.AccessToken{Name: "ci", Scopes: []string{sdk.AccessTokenScopeAdmin}, ExpireAt: time.Now().Add(time.Hour)}
+	req := assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, tok)
+	w := httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	assert.Equal(t, 403, w.Code)
+
+	// ADD a read only token on the first project
+	tok = sdk.AccessToken{Name: "ci", Scopes: []string{sdk.AccessTokenScopeRead}, Projects: []string{proj1.Key}, ExpireAt: time.Now().Add(time.Hour)}
+	req = assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, tok)
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	assert.Equal(t, 201, w.Code)
+	test.NoError(t, json.Unmarshal(w.Body.Bytes(), &tok))
+	assert.Contains(t, tok.Token, sdk.AccessTokenPrefix)
+
+	// USE the token
+	newTokenRequest := func(method, uri string) *httptest.ResponseRecorder {
+		req := assets.NewAuthentifiedRequest(t, u, pass, method, uri, nil)
+		req.Header.Del(sdk.SessionTokenHeader)
+		req.Header.Set("Authorization", "Bearer "+tok.Token)
+		w := httptest.NewRecorder()
+		router.Mux.ServeHTTP(w, req)
+		return w
+	}
+	assert.Equal(t, 200, newTokenRequest("GET", router.GetRoute("GET", api.getProjectHandler, map[string]string{"permProjectKey": proj1.Key})).Code)
+	assert.Equal(t, 403, newTokenRequest("GET", router.GetRoute("GET", api.getProjectHandler, map[string]string{"permProjectKey": proj2.Key})).Code)
+	assert.Equal(t, 403, newTokenRequest("DELETE", router.GetRoute("DELETE", api.deleteProjectHandler, map[string]string{"permProjectKey": proj1.Key})).Code)
+
+	// LIST tokens
+	req = assets.NewAuthentifiedRequest(t, u, pass, "GET", router.GetRoute("GET", api.getUserAccessTokensHandler, nil), nil)
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	assert.Equal(t, 200, w.Code)
+	var tokens []sdk.AccessToken
+	test.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+	if assert.Len(t, tokens, 1) {
+		assert.Equal(t, []string{proj1.Key}, tokens[0].Projects)
+		assert.NotNil(t, tokens[0].LastUsed)
+		assert.Empty(t, tokens[0].Token)
+	}
+
+	// DELETE the token
+	uri = router.GetRoute("DELETE", api.deleteUserAccessTokenHandler, map[string]string{"id": fmt.Sprintf("%d", tok.ID)})
+	req = assets.NewAuthentifiedRequest(t, u, pass, "DELETE", uri, nil)
+	w = httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, 401, newTokenRequest("GET", router.GetRoute("GET", api.getProjectHandler, map[string]string{"permProjectKey": proj1.Key})).Code)
+}
+
+func TestUserAccessTokenOutsideOfProjects(t *testing.T) {
+	api, db, router, end := newTestAPI(t, bootstrap.InitiliazeDB)
+	defer end()
+	g := &sdk.Group{Name: sdk.RandomString(10)}
+	u, pass := assets.InsertLambdaUser(db, g)
+	test.NoError(t, group.SetUserGroupAdmin(db, g.ID, u.ID))
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10), u)
+
+	// ADD a token with the project scope on the project
+	uri := router.GetRoute("POST", api.postUserAccessTokenHandler, nil)
+	tok := sdk.AccessToken{Name: "ci", Scopes: []string{sdk.AccessTokenScopeProject}, Projects: []string{proj.Key}, ExpireAt: time.Now().Add(time.Hour)}
+	req := assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, tok)
+	w := httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	assert.Equal(t, 201, w.Code)
+	test.NoError(t, json.Unmarshal(w.Body.Bytes(), &tok))
+
+	newTokenRequest := func(method, uri string, i interface{}) *httptest.ResponseRecorder {
+		req := assets.NewAuthentifiedRequest(t, u, pass, method, uri, i)
+		req.Header.Del(sdk.SessionTokenHeader)
+		req.Header.Set("Authorization", "Bearer "+tok.Token)
+		w := httptest.NewRecorder()
+		router.Mux.ServeHTTP(w, req)
+		return w
+	}
+
+	// Read only routes that are not about a project are allowed
+	assert.Equal(t, 200, newTokenRequest("GET", router.GetRoute("GET", api.getUserHandler, map[string]string{"username": u.Username}), nil).Code)
+
+	// Write routes that are not about a project are not
+	assert.Equal(t, 403, newTokenRequest("PUT", router.GetRoute("PUT", api.updateUserHandler, map[string]string{"username": u.Username}), u).Code)
+	other, _ := assets.InsertLambdaUser(db)
+	uri = router.GetRoute("POST", api.addUserInGroupHandler, map[string]string{"permGroupName": g.Name})
+	assert.Equal(t, 403, newTokenRequest("POST", uri, []string{other.Username}).Code)
+	assert.Equal(t, 403, newTokenRequest("POST", router.GetRoute("POST", api.addProjectHandler, nil), sdk.Project{Key: sdk.RandomString(10)}).Code)
+}
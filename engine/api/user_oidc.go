@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/auth"
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/user"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// oidcStateTTL is the time given to the user to login on the OpenID Connect provider, in seconds
+const oidcStateTTL = 10 * 60
+
+func (api *API) oidcDriver() (*auth.OIDCClient, error) {
+	d, ok := api.Router.AuthDriver.(*auth.OIDCClient)
+	if !ok {
+		return nil, sdk.WrapError(sdk.ErrNotImplemented, "OIDC authentication is not enabled")
+	}
+	return d, nil
+}
+
+// getLoginOIDCHandler returns the URL of the OpenID Connect provider where the user has to login
+func (api *API) getLoginOIDCHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		d, err := api.oidcDriver()
+		if err != nil {
+			return err
+		}
+
+		state := sdk.UUID()
+		nonce := sdk.UUID()
+		api.Cache.SetWithTTL(cache.Key("auth", "oidc", "state", state), nonce, oidcStateTTL)
+
+		return service.WriteJSON(w, sdk.UserOIDCRequest{
+			URL:   d.AuthCodeURL(state, nonce),
+			State: state,
+		}, http.StatusOK)
+	}
+}
+
+// postLoginOIDCCallbackHandler creates a session from the authorization code returned by the OpenID Connect provider
+func (api *API) postLoginOIDCCallbackHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		d, err := api.oidcDriver()
+		if err != nil {
+			return err
+		}
+
+		var req sdk.UserOIDCLoginRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		var nonce string
+		key := cache.Key("auth", "oidc", "state", req.State)
+		if req.State == "" || !api.Cache.Get(key, &nonce) {
+			return sdk.WrapError(sdk.ErrUnauthorized, "postLoginOIDCCallbackHandler> unknown or expired state")
+		}
+		api.Cache.Delete(key)
+
+		u, err := d.Exchange(ctx, req.Code, nonce)
+		if err != nil {
+			return sdk.WrapError(err, "postLoginOIDCCallbackHandler> cannot authenticate user")
+		}
+
+		return api.writeLoginUserResponse(w, u, r.Header.Get(sdk.RequestedWithHeader) == sdk.RequestedWithValue)
+	}
+}
+
+// postLoginOIDCDeviceHandler starts a device authorization on the OpenID Connect provider
+func (api *API) postLoginOIDCDeviceHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		d, err := api.oidcDriver()
+		if err != nil {
+			return err
+		}
+
+		device, err := d.DeviceAuthorization(ctx)
+		if err != nil {
+			return sdk.WrapError(err, "postLoginOIDCDeviceHandler> cannot start device authorization")
+		}
+		return service.WriteJSON(w, device, http.StatusOK)
+	}
+}
+
+// postLoginOIDCDeviceTokenHandler creates a session once the user approved the device authorization
+func (api *API) postLoginOIDCDeviceTokenHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		d, err := api.oidcDriver()
+		if err != nil {
+			return err
+		}
+
+		var req sdk.UserOIDCDeviceLoginRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
+		u, err := d.DeviceToken(ctx, req.DeviceCode)
+		if err != nil {
+			return err
+		}
+
+		return api.writeLoginUserResponse(w, u, r.Header.Get(sdk.RequestedWithHeader) == sdk.RequestedWithValue)
+	}
+}
+
+// postUserOIDCLinkHandler links a user to an OpenID Connect identity, so that it can log in as this user
+// even if the user was not created by an OIDC login
+func (api *API) postUserOIDCLinkHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		d, err := api.oidcDriver()
+		if err != nil {
+			return err
+		}
+
+		var link sdk.UserOIDCLink
+		if err := service.UnmarshalBody(r, &link); err != nil {
+			return err
+		}
+		if link.Subject == "" {
+			return sdk.WrapError(sdk.ErrWrongRequest, "postUserOIDCLinkHandler> subject is required")
+		}
+
+		username := mux.Vars(r)["username"]
+		u, err := user.LoadUserWithoutAuth(api.mustDB(), username)
+		if err != nil {
+			return sdk.WrapError(sdk.ErrUserNotFound, "postUserOIDCLinkHandler> cannot load user %s: %v", username, err)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		old, err := user.LoadOIDCLinkBySubject(tx, d.Issuer(), link.Subject)
+		if err != nil {
+			return err
+		}
+		if old != nil && old.UserID != u.ID {
+			return sdk.WrapError(sdk.ErrWrongRequest, "postUserOIDCLinkHandler> subject %s is already linked to user %d", link.Subject, old.UserID)
+		}
+		if err := user.DeleteOIDCLink(tx, u.ID, d.Issuer()); err != nil {
+			return err
+		}
+
+		link.UserID = u.ID
+		link.Issuer = d.Issuer()
+		if err := user.InsertOIDCLink(tx, &link); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return sdk.WrapError(err, "cannot commit transaction")
+		}
+		return service.WriteJSON(w, link, http.StatusOK)
+	}
+}
+
+// deleteUserOIDCLinkHandler removes the link of a user to its OpenID Connect identity
+func (api *API) deleteUserOIDCLinkHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		d, err := api.oidcDriver()
+		if err != nil {
+			return err
+		}
+
+		username := mux.Vars(r)["username"]
+		u, err := user.LoadUserWithoutAuth(api.mustDB(), username)
+		if err != nil {
+			return sdk.WrapError(sdk.ErrUserNotFound, "deleteUserOIDCLinkHandler> cannot load user %s: %v", username, err)
+		}
+		if err := user.DeleteOIDCLink(api.mustDB(), u.ID, d.Issuer()); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
@@ -176,7 +176,7 @@ func (api *API) postWorkflowRollbackHandler() service.Handler {
 			_ = tx.Rollback()
 		}()
 
-		newWf, _, errP := workflow.ParseAndImport(ctx, tx, api.Cache, proj, &exportWf, u, workflow.ImportOptions{Force: true, WorkflowName: workflowName})
+		newWf, _, errP := workflow.ParseAndImport(ctx, tx, api.Cache, proj, &exportWf, u, workflow.ImportOptions{Force: true, WorkflowName: workflowName, DecryptFunc: project.DecryptWithBuiltinKey})
 		if errP != nil {
 			return sdk.WrapError(errP, "postWorkflowRollbackHandler> cannot parse and import previous workflow")
 		}
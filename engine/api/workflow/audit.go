@@ -24,6 +24,7 @@ var (
 		fmt.Sprintf("%T", sdk.EventWorkflowPermissionAdd{}):    addWorkflowPermissionAudit{},
 		fmt.Sprintf("%T", sdk.EventWorkflowPermissionUpdate{}): updateWorkflowPermissionAudit{},
 		fmt.Sprintf("%T", sdk.EventWorkflowPermissionDelete{}): deleteWorkflowPermissionAudit{},
+		fmt.Sprintf("%T", sdk.EventWorkflowNodeRunApproval{}):  nodeRunApprovalAudit{},
 	}
 )
 
@@ -215,3 +216,28 @@ func (a deleteWorkflowPermissionAudit) Compute(db gorp.SqlExecutor, e sdk.Event)
 	}
 	return InsertAudit(db, &audit)
 }
+
+type nodeRunApprovalAudit struct{}
+
+func (a nodeRunApprovalAudit) Compute(db gorp.SqlExecutor, e sdk.Event) error {
+	var wEvent sdk.EventWorkflowNodeRunApproval
+	if err := mapstructure.Decode(e.Payload, &wEvent); err != nil {
+		return sdk.WrapError(err, "Unable to decode payload")
+	}
+
+	b, err := json.MarshalIndent(wEvent, "", "  ")
+	if err != nil {
+		return sdk.WrapError(err, "Unable to marshal approval")
+	}
+
+	audit := sdk.AuditWorklflow{
+		EventType:   strings.Replace(e.EventType, "sdk.Event", "", -1),
+		Created:     e.Timestamp,
+		TriggeredBy: e.Username,
+		DataAfter:   string(b),
+		WorkflowID:  wEvent.WorkflowID,
+		ProjectKey:  e.ProjectKey,
+		DataType:    "json",
+	}
+	return InsertAudit(db, &audit)
+}
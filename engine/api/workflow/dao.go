@@ -110,12 +110,14 @@ func (w *Workflow) PostInsert(db gorp.SqlExecutor) error {
 // PostGet is a db hook
 func (w *Workflow) PostGet(db gorp.SqlExecutor) error {
 	var res = struct {
-		Metadata     sql.NullString `db:"metadata"`
-		PurgeTags    sql.NullString `db:"purge_tags"`
-		WorkflowData sql.NullString `db:"workflow_data"`
+		Metadata             sql.NullString `db:"metadata"`
+		PurgeTags            sql.NullString `db:"purge_tags"`
+		RetentionPolicy      sql.NullString `db:"retention_policy"`
+		AutoCancelSuperseded sql.NullBool   `db:"auto_cancel_superseded"`
+		WorkflowData         sql.NullString `db:"workflow_data"`
 	}{}
 
-	if err := db.SelectOne(&res, "SELECT metadata, purge_tags, workflow_data FROM workflow WHERE id = $1", w.ID); err != nil {
+	if err := db.SelectOne(&res, "SELECT metadata, purge_tags, retention_policy, auto_cancel_superseded, workflow_data FROM workflow WHERE id = $1", w.ID); err != nil {
 		return sdk.WrapError(err, "PostGet> Unable to load marshalled workflow")
 	}
 
@@ -131,6 +133,15 @@ func (w *Workflow) PostGet(db gorp.SqlExecutor) error {
 	}
 	w.PurgeTags = purgeTags
 
+	if res.RetentionPolicy.Valid {
+		policy := &sdk.WorkflowRetentionPolicy{}
+		if err := gorpmapping.JSONNullString(res.RetentionPolicy, policy); err != nil {
+			return sdk.WrapError(err, "Unable to unmarshall retention policy")
+		}
+		w.RetentionPolicy = policy
+	}
+	w.AutoCancelSuperseded = res.AutoCancelSuperseded.Bool
+
 	data := &sdk.WorkflowData{}
 	if err := gorpmapping.JSONNullString(res.WorkflowData, data); err != nil {
 		return sdk.WrapError(err, "Unable to unmarshall workflow data")
@@ -167,11 +178,20 @@ func (w *Workflow) PostUpdate(db gorp.SqlExecutor) error {
 		return errPt
 	}
 
+	var rp sql.NullString
+	if w.RetentionPolicy != nil {
+		var errRp error
+		rp, errRp = gorpmapping.JSONToNullString(w.RetentionPolicy)
+		if errRp != nil {
+			return sdk.WrapError(errRp, "Workflow.PostUpdate> Unable to marshall retention policy")
+		}
+	}
+
 	data, errD := gorpmapping.JSONToNullString(w.WorkflowData)
 	if errD != nil {
 		return sdk.WrapError(errD, "Workflow.PostUpdate> Unable to marshall workflow data")
 	}
-	if _, err := db.Exec("update workflow set purge_tags = $1, workflow_data = $3 where id = $2", pt, w.ID, data); err != nil {
+	if _, err := db.Exec("update workflow set purge_tags = $1, workflow_data = $3, retention_policy = $4, auto_cancel_superseded = $5 where id = $2", pt, w.ID, data, rp, w.AutoCancelSuperseded); err != nil {
 		return err
 	}
 
@@ -782,6 +802,10 @@ func Update(ctx context.Context, db gorp.SqlExecutor, store cache.Store, w *sdk.
 		}
 	}
 
+	if err := restoreWebhookURLs(db, w, oldWorkflow); err != nil {
+		return err
+	}
+
 	if err := deleteNotifications(db, oldWorkflow.ID); err != nil {
 		return sdk.WrapError(err, "unable to delete all notifications on workflow(%d)", w.ID)
 	}
@@ -905,6 +929,15 @@ func Delete(ctx context.Context, db gorp.SqlExecutor, store cache.Store, p *sdk.
 		return sdk.WrapError(err, "Delete> Unable to delete workflow data")
 	}
 
+	// Delete logs moved out of the database, runs are deleted by cascade with the workflow
+	var runIDs []int64
+	if _, err := db.Select(&runIDs, "select id from workflow_run where workflow_id = $1", w.ID); err != nil {
+		return sdk.WrapError(err, "Unable to load workflow runs")
+	}
+	if err := DeleteRunsStoredLogs(db, runIDs); err != nil {
+		return sdk.WrapError(err, "Unable to delete logs of workflow runs")
+	}
+
 	//Delete workflow
 	dbw := Workflow(*w)
 	if _, err := db.Delete(&dbw); err != nil {
@@ -936,6 +969,12 @@ func IsValid(ctx context.Context, store cache.Store, db gorp.SqlExecutor, w *sdk
 		return sdk.NewError(sdk.ErrWorkflowInvalid, fmt.Errorf("Invalid workflow name. It should match %s", sdk.NamePattern))
 	}
 
+	if w.RetentionPolicy != nil {
+		if err := w.RetentionPolicy.IsValid(); err != nil {
+			return err
+		}
+	}
+
 	//Check duplicate refs
 	refs := w.References()
 	for i, ref1 := range refs {
@@ -1283,8 +1322,20 @@ func checkPipeline(ctx context.Context, db gorp.SqlExecutor, proj *sdk.Project,
 	return nil
 }
 
+// PushHooks are called in the transaction of a push: BeforeImport with the names of the pushed applications,
+// pipelines and environments before anything is imported, AfterImport with the imported workflow.
+type PushHooks struct {
+	BeforeImport func(tx gorp.SqlExecutor, apps, pips, envs []string) error
+	AfterImport  func(tx gorp.SqlExecutor, w *sdk.Workflow) error
+}
+
 // Push push a workflow from cds files
 func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Project, tr *tar.Reader, opts *PushOption, u *sdk.User, decryptFunc keys.DecryptFunc) ([]sdk.Message, *sdk.Workflow, error) {
+	return PushWithHooks(ctx, db, store, proj, tr, opts, PushHooks{}, u, decryptFunc)
+}
+
+// PushWithHooks push a workflow from cds files and calls the given hooks in the push transaction
+func PushWithHooks(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Project, tr *tar.Reader, opts *PushOption, hooks PushHooks, u *sdk.User, decryptFunc keys.DecryptFunc) ([]sdk.Message, *sdk.Workflow, error) {
 	ctx, end := observability.Span(ctx, "workflow.Push")
 	defer end()
 
@@ -1368,6 +1419,24 @@ func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Proj
 	}
 	defer tx.Rollback()
 
+	if hooks.BeforeImport != nil {
+		appNames := make([]string, 0, len(apps))
+		for _, app := range apps {
+			appNames = append(appNames, app.Name)
+		}
+		pipNames := make([]string, 0, len(pips))
+		for _, pip := range pips {
+			pipNames = append(pipNames, pip.Name)
+		}
+		envNames := make([]string, 0, len(envs))
+		for _, env := range envs {
+			envNames = append(envNames, env.Name)
+		}
+		if err := hooks.BeforeImport(tx, appNames, pipNames, envNames); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	allMsg := []sdk.Message{}
 	for filename, app := range apps {
 		log.Debug("Push> Parsing %s", filename)
@@ -1465,7 +1534,7 @@ func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Proj
 		}
 	}
 
-	wf, msgList, err := ParseAndImport(ctx, tx, store, proj, &wrkflw, u, ImportOptions{DryRun: dryRun, Force: true})
+	wf, msgList, err := ParseAndImport(ctx, tx, store, proj, &wrkflw, u, ImportOptions{DryRun: dryRun, Force: true, DecryptFunc: decryptFunc})
 	if err != nil {
 		log.Error("Push> Unable to import workflow: %v", err)
 		return nil, nil, sdk.WrapError(err, "unable to import workflow %s", wrkflw.Name)
@@ -1517,6 +1586,12 @@ func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Proj
 
 	allMsg = append(allMsg, msgList...)
 
+	if hooks.AfterImport != nil {
+		if err := hooks.AfterImport(tx, wf); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	isDefaultBranch := false
 	if opts != nil {
 		isDefaultBranch = opts.IsDefaultBranch
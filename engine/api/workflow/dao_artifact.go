@@ -90,6 +90,47 @@ func loadArtifactByNodeRunID(db gorp.SqlExecutor, nodeRunID int64) ([]sdk.Workfl
 	return artifacts, nil
 }
 
+// CountArtifacts returns the number of artifacts
+func CountArtifacts(db gorp.SqlExecutor) (int64, error) {
+	return db.SelectInt("SELECT COUNT(id) FROM workflow_node_run_artifacts")
+}
+
+// LoadArtifactsFromID loads a batch of artifacts ordered by id, starting after the given id
+func LoadArtifactsFromID(db gorp.SqlExecutor, fromID int64, limit int) ([]sdk.WorkflowNodeRunArtifact, error) {
+	var artifactsGorp []NodeRunArtifact
+	if _, err := db.Select(&artifactsGorp, `SELECT
+			id,
+			name,
+			tag,
+			ref,
+			workflow_node_run_id,
+			download_hash,
+			size,
+			perm,
+			md5sum,
+			object_path,
+			created,
+			workflow_run_id,
+			coalesce(sha512sum, '') AS sha512sum
+		FROM workflow_node_run_artifacts WHERE id > $1 ORDER BY id LIMIT $2`, fromID, limit); err != nil {
+		return nil, sdk.WrapError(err, "cannot load artifacts")
+	}
+
+	artifacts := make([]sdk.WorkflowNodeRunArtifact, len(artifactsGorp))
+	for i := range artifactsGorp {
+		artifacts[i] = sdk.WorkflowNodeRunArtifact(artifactsGorp[i])
+	}
+	return artifacts, nil
+}
+
+// UpdateArtifactObjectPath updates the object path of an artifact
+func UpdateArtifactObjectPath(db gorp.SqlExecutor, id int64, objectPath string) error {
+	if _, err := db.Exec("UPDATE workflow_node_run_artifacts SET object_path = $2 WHERE id = $1", id, objectPath); err != nil {
+		return sdk.WrapError(err, "cannot update object path of artifact %d", id)
+	}
+	return nil
+}
+
 // InsertArtifact insert in table workflow_artifacts
 func InsertArtifact(db gorp.SqlExecutor, a *sdk.WorkflowNodeRunArtifact) error {
 	wArtifactDB := NodeRunArtifact(*a)
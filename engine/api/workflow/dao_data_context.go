@@ -20,6 +20,8 @@ type sqlNodeContextData struct {
 	DefaultPipelineParameters sql.NullString `db:"default_pipeline_parameters"`
 	Conditions                sql.NullString `db:"conditions"`
 	Mutex                     bool           `db:"mutex"`
+	Approval                  sql.NullString `db:"approval"`
+	Concurrency               sql.NullString `db:"concurrency"`
 }
 
 func insertNodeContextData(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) error {
@@ -49,6 +51,10 @@ func insertNodeContextData(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) er
 		return sdk.WrapError(errDP, "insertNodeContextData> Cannot stringify default payload")
 	}
 
+	if err := n.Context.Conditions.IsValid(); err != nil {
+		return err
+	}
+
 	var errC error
 	tempContext.Conditions, errC = gorpmapping.JSONToNullString(n.Context.Conditions)
 	if errC != nil {
@@ -57,6 +63,28 @@ func insertNodeContextData(db gorp.SqlExecutor, w *sdk.Workflow, n *sdk.Node) er
 
 	tempContext.Mutex = n.Context.Mutex
 
+	if n.Context.Approval != nil {
+		if err := n.Context.Approval.IsValid(); err != nil {
+			return err
+		}
+		var errA error
+		tempContext.Approval, errA = gorpmapping.JSONToNullString(n.Context.Approval)
+		if errA != nil {
+			return sdk.WrapError(errA, "insertNodeContextData> Cannot stringify approval")
+		}
+	}
+
+	if n.Context.Concurrency != nil {
+		if err := n.Context.Concurrency.IsValid(); err != nil {
+			return err
+		}
+		var errCo error
+		tempContext.Concurrency, errCo = gorpmapping.JSONToNullString(n.Context.Concurrency)
+		if errCo != nil {
+			return sdk.WrapError(errCo, "insertNodeContextData> Cannot stringify concurrency")
+		}
+	}
+
 	if n.Context.PipelineID != 0 {
 		//Checks pipeline parameters
 		if len(n.Context.DefaultPipelineParameters) > 0 {
@@ -161,9 +161,9 @@ func insertNode(db gorp.SqlExecutor, store cache.Store, w *sdk.Workflow, n *sdk.
 			Configurable: false,
 		}
 
-		if h.WorkflowHookModel.Name == sdk.RepositoryWebHookModelName || h.WorkflowHookModel.Name == sdk.GitPollerModelName {
+		if h.WorkflowHookModel.Name == sdk.RepositoryWebHookModelName || h.WorkflowHookModel.Name == sdk.GitPollerModelName || h.WorkflowHookModel.Name == sdk.GerritHookModelName {
 			if n.Context.Application == nil || n.Context.Application.RepositoryFullname == "" || n.Context.Application.VCSServer == "" {
-				return sdk.WrapError(sdk.ErrForbidden, "InsertOrUpdateNode> Cannot create a git poller or repository webhook on an application without a repository")
+				return sdk.WrapError(sdk.ErrForbidden, "InsertOrUpdateNode> Cannot create a git poller, a gerrit hook or repository webhook on an application without a repository")
 			}
 			h.Config["vcsServer"] = sdk.WorkflowNodeHookConfigValue{
 				Value:        n.Context.Application.VCSServer,
@@ -240,6 +240,8 @@ type sqlContext struct {
 	DefaultPipelineParameters sql.NullString `db:"default_pipeline_parameters"`
 	Conditions                sql.NullString `db:"conditions"`
 	Mutex                     sql.NullBool   `db:"mutex"`
+	Approval                  sql.NullString `db:"approval"`
+	Concurrency               sql.NullString `db:"concurrency"`
 }
 
 // UpdateNodeContext updates the node context in database
@@ -281,10 +283,8 @@ func UpdateNodeContext(db gorp.SqlExecutor, c *sdk.WorkflowNodeContext) error {
 		sqlContext.DefaultPipelineParameters = sql.NullString{String: string(b), Valid: true}
 	}
 
-	for _, cond := range c.Conditions.PlainConditions {
-		if _, ok := sdk.WorkflowConditionsOperators[cond.Operator]; !ok {
-			return sdk.ErrWorkflowConditionBadOperator
-		}
+	if err := c.Conditions.IsValid(); err != nil {
+		return err
 	}
 
 	var errC error
@@ -293,6 +293,30 @@ func UpdateNodeContext(db gorp.SqlExecutor, c *sdk.WorkflowNodeContext) error {
 		return sdk.WrapError(errC, "updateNodeContext> Unable to marshall workflow node context(%d) conditions", c.ID)
 	}
 
+	// Set Approval in context
+	if c.Approval != nil {
+		if err := c.Approval.IsValid(); err != nil {
+			return err
+		}
+		b, errM := json.Marshal(c.Approval)
+		if errM != nil {
+			return sdk.WrapError(errM, "updateNodeContext> Unable to marshall workflow node context(%d) approval", c.ID)
+		}
+		sqlContext.Approval = sql.NullString{String: string(b), Valid: true}
+	}
+
+	// Set Concurrency in context
+	if c.Concurrency != nil {
+		if err := c.Concurrency.IsValid(); err != nil {
+			return err
+		}
+		b, errM := json.Marshal(c.Concurrency)
+		if errM != nil {
+			return sdk.WrapError(errM, "updateNodeContext> Unable to marshall workflow node context(%d) concurrency", c.ID)
+		}
+		sqlContext.Concurrency = sql.NullString{String: string(b), Valid: true}
+	}
+
 	if _, err := db.Update(&sqlContext); err != nil {
 		return sdk.WrapError(err, "Unable to update workflow node context(%d)", c.ID)
 	}
@@ -459,7 +483,7 @@ func LoadNodeContext(db gorp.SqlExecutor, store cache.Store, proj *sdk.Project,
 func postLoadNodeContext(db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, u *sdk.User, ctx *sdk.WorkflowNodeContext, opts LoadOptions) error {
 	var sqlContext = sqlContext{}
 	if err := db.SelectOne(&sqlContext,
-		"select application_id, environment_id, default_payload, default_pipeline_parameters, conditions, mutex, project_platform_id from workflow_node_context where id = $1", ctx.ID); err != nil {
+		"select application_id, environment_id, default_payload, default_pipeline_parameters, conditions, mutex, approval, concurrency, project_platform_id from workflow_node_context where id = $1", ctx.ID); err != nil {
 		return err
 	}
 	if sqlContext.AppID.Valid {
@@ -474,6 +498,18 @@ func postLoadNodeContext(db gorp.SqlExecutor, store cache.Store, proj *sdk.Proje
 	if sqlContext.Mutex.Valid {
 		ctx.Mutex = sqlContext.Mutex.Bool
 	}
+	if sqlContext.Approval.Valid {
+		ctx.Approval = new(sdk.WorkflowNodeApproval)
+		if err := gorpmapping.JSONNullString(sqlContext.Approval, ctx.Approval); err != nil {
+			return sdk.WrapError(err, "Unable to unmarshall context %d approval", ctx.ID)
+		}
+	}
+	if sqlContext.Concurrency.Valid {
+		ctx.Concurrency = new(sdk.WorkflowNodeConcurrency)
+		if err := gorpmapping.JSONNullString(sqlContext.Concurrency, ctx.Concurrency); err != nil {
+			return sdk.WrapError(err, "Unable to unmarshall context %d concurrency", ctx.ID)
+		}
+	}
 
 	//Unmarshal payload
 	if err := gorpmapping.JSONNullString(sqlContext.DefaultPayload, &ctx.DefaultPayload); err != nil {
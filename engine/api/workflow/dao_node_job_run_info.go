@@ -55,3 +55,12 @@ func insertNodeRunJobInfo(db gorp.SqlExecutor, info *sdk.WorkflowNodeJobRunInfo)
 	log.Debug("insertNodeRunJobInfo> on node run: %d (%d)", info.ID, info.WorkflowNodeJobRunID)
 	return nil
 }
+
+// deleteNodeRunJobInfo deletes all spawninfos of a Workflow Node Job Run
+func deleteNodeRunJobInfo(db gorp.SqlExecutor, jobID int64) error {
+	query := "DELETE FROM workflow_node_run_job_info WHERE workflow_node_run_job_id = $1"
+	if _, err := db.Exec(query, jobID); err != nil {
+		return sdk.WrapError(err, "deleteNodeRunJobInfo> Unable to delete spawninfos of job %d", jobID)
+	}
+	return nil
+}
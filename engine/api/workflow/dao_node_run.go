@@ -51,7 +51,9 @@ workflow_node_run.uuid,
 workflow_node_run.outgoinghook,
 workflow_node_run.hook_execution_timestamp,
 workflow_node_run.execution_id,
-workflow_node_run.callback
+workflow_node_run.callback,
+workflow_node_run.approvals,
+workflow_node_run.concurrency_group
 `
 
 const nodeRunTestsField string = ", workflow_node_run.tests"
@@ -381,6 +383,16 @@ func fromDBNodeRun(rr NodeRun, opts LoadRunOptions) (*sdk.WorkflowNodeRun, error
 		}
 	}
 
+	if rr.Approvals.Valid {
+		if err := gorpmapping.JSONNullString(rr.Approvals, &r.Approvals); err != nil {
+			return nil, sdk.WrapError(err, "fromDBNodeRun>Error loading node run %d: Approvals", r.ID)
+		}
+	}
+
+	if rr.ConcurrencyGroup.Valid {
+		r.ConcurrencyGroup = rr.ConcurrencyGroup.String
+	}
+
 	return r, nil
 }
 
@@ -506,6 +518,18 @@ func makeDBNodeRun(n sdk.WorkflowNodeRun) (*NodeRun, error) {
 	}
 	nodeRunDB.OutgoingHook = oh
 
+	if len(n.Approvals) > 0 {
+		ap, err := gorpmapping.JSONToNullString(n.Approvals)
+		if err != nil {
+			return nil, sdk.WrapError(err, "makeDBNodeRun> unable to get json from approvals")
+		}
+		nodeRunDB.Approvals = ap
+	}
+
+	if n.ConcurrencyGroup != "" {
+		nodeRunDB.ConcurrencyGroup = sql.NullString{Valid: true, String: n.ConcurrencyGroup}
+	}
+
 	return nodeRunDB, nil
 }
 
@@ -560,7 +584,7 @@ func GetNodeRunBuildCommits(ctx context.Context, db gorp.SqlExecutor, store cach
 
 	res := []sdk.VCSCommit{}
 	//Get the RepositoriesManager Client
-	client, errclient := repositoriesmanager.AuthorizedClient(ctx, db, store, vcsServer)
+	client, errclient := repositoriesmanager.AuthorizedClient(ctx, db, store, p.Key, vcsServer)
 	if errclient != nil {
 		return nil, cur, sdk.WrapError(errclient, "GetNodeRunBuildCommits> Cannot get client")
 	}
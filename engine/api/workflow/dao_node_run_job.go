@@ -264,6 +264,36 @@ func LoadDeadNodeJobRun(db gorp.SqlExecutor, store cache.Store) ([]sdk.WorkflowN
 	return deadJobs, nil
 }
 
+//LoadTimedOutNodeJobRun load NodeJobRuns which are Building for longer than their job timeout
+func LoadTimedOutNodeJobRun(db gorp.SqlExecutor, store cache.Store) ([]sdk.WorkflowNodeJobRun, error) {
+	var jobsDB []JobRun
+	query := `SELECT workflow_node_run_job.* FROM workflow_node_run_job
+	WHERE status = $1
+	AND COALESCE((job->'action'->>'timeout')::BIGINT, 0) > 0
+	AND start + ((job->'action'->>'timeout') || ' seconds')::INTERVAL < $2`
+	if _, err := db.Select(&jobsDB, query, sdk.StatusBuilding.String(), time.Now()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	jobs := make([]sdk.WorkflowNodeJobRun, len(jobsDB))
+	for i, j := range jobsDB {
+		if store != nil {
+			getHatcheryInfo(store, &j)
+		}
+
+		jr, err := j.WorkflowNodeRunJob()
+		if err != nil {
+			return nil, err
+		}
+		jobs[i] = jr
+	}
+
+	return jobs, nil
+}
+
 //LoadAndLockNodeJobRunWait load for update a NodeJobRun given its ID
 func LoadAndLockNodeJobRunWait(db gorp.SqlExecutor, store cache.Store, id int64) (*sdk.WorkflowNodeJobRun, error) {
 	j := JobRun{}
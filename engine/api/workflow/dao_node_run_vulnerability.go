@@ -23,7 +23,7 @@ func HandleVulnerabilityReport(ctx context.Context, db gorp.SqlExecutor, cache c
 	if nr.VCSServer != "" {
 		// Get vcs info to known if we are on the default branch or not
 		projectVCSServer := repositoriesmanager.GetProjectVCSServer(proj, nr.VCSServer)
-		client, erra := repositoriesmanager.AuthorizedClient(ctx, db, cache, projectVCSServer)
+		client, erra := repositoriesmanager.AuthorizedClient(ctx, db, cache, proj.Key, projectVCSServer)
 		if erra != nil {
 			return sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "HandleVulnerabilityReport> Cannot get repo client %s : %v", nr.VCSServer, erra)
 		}
@@ -2,11 +2,15 @@ package workflow
 
 import (
 	"database/sql"
+	"encoding/base64"
+	"sort"
+	"strings"
 
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/secret"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -71,6 +75,13 @@ func insertNotification(db gorp.SqlExecutor, store cache.Store, w *sdk.Workflow,
 	n.SourceNodeIDs = nil
 	dbNotif := Notification(*n)
 
+	if sdk.IsChatUserNotification(n.Type) && n.Settings.WebhookURL == "" {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "a webhook url is required by %s notifications", n.Type)
+	}
+	if n.Settings.WebhookURL == sdk.PasswordPlaceholder {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to find the webhook url of %s notification", n.Type)
+	}
+
 	//Check references to sources
 	if len(n.SourceNodeRefs) == 0 {
 		return sdk.WrapError(sdk.ErrWorkflowNodeRef, "insertNotification> No notification references")
@@ -90,6 +101,9 @@ func insertNotification(db gorp.SqlExecutor, store cache.Store, w *sdk.Workflow,
 		return sdk.WrapError(err, "Unable to insert workflow notification")
 	}
 	n.ID = dbNotif.ID
+	if n.Settings.WebhookURL != "" {
+		n.Settings.WebhookURL = sdk.PasswordPlaceholder
+	}
 
 	//Insert associations with sources
 	query := "insert into workflow_notification_source(workflow_node_id, workflow_notification_id) values ($1, $2)"
@@ -102,9 +116,17 @@ func insertNotification(db gorp.SqlExecutor, store cache.Store, w *sdk.Workflow,
 	return nil
 }
 
-// PostInsert is a db hook
+// PostInsert is a db hook, the webhook url is encrypted
 func (no *Notification) PostInsert(db gorp.SqlExecutor) error {
-	b, err := gorpmapping.JSONToNullString(no.Settings)
+	settings := no.Settings
+	if settings.WebhookURL != "" {
+		encrypted, err := secret.Encrypt([]byte(settings.WebhookURL))
+		if err != nil {
+			return sdk.WrapError(err, "Unable to encrypt webhook url")
+		}
+		settings.WebhookURL = base64.StdEncoding.EncodeToString(encrypted)
+	}
+	b, err := gorpmapping.JSONToNullString(settings)
 	if err != nil {
 		return err
 	}
@@ -124,5 +146,166 @@ func (no *Notification) PostGet(db gorp.SqlExecutor) error {
 	if err := gorpmapping.JSONNullString(res, &no.Settings); err != nil {
 		return sdk.WrapError(err, "cannot parse user notification")
 	}
+	// The webhook url is only decrypted to send the notification
+	if no.Settings.WebhookURL != "" {
+		no.Settings.WebhookURL = sdk.PasswordPlaceholder
+	}
+	return nil
+}
+
+// decryptWebhookURL decrypts a webhook url as stored in the notification settings
+func decryptWebhookURL(encrypted string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", sdk.WrapError(err, "cannot decode webhook url")
+	}
+	clear, err := secret.Decrypt(b)
+	if err != nil {
+		return "", sdk.WrapError(err, "cannot decrypt webhook url")
+	}
+	return string(clear), nil
+}
+
+// loadWebhookURLs returns the clear webhook urls of the notifications of a workflow, indexed by notification id
+func loadWebhookURLs(db gorp.SqlExecutor, workflowID int64) (map[int64]string, error) {
+	rows, err := db.Query("SELECT id, settings FROM workflow_notification WHERE workflow_id = $1", workflowID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Unable to load notifications settings on workflow %d", workflowID)
+	}
+	defer rows.Close()
+
+	urls := map[int64]string{}
+	for rows.Next() {
+		var id int64
+		var res sql.NullString
+		if err := rows.Scan(&id, &res); err != nil {
+			return nil, sdk.WrapError(err, "Unable to scan notification settings")
+		}
+		var settings sdk.UserNotificationSettings
+		if err := gorpmapping.JSONNullString(res, &settings); err != nil {
+			return nil, sdk.WrapError(err, "cannot parse user notification")
+		}
+		if settings.WebhookURL == "" {
+			continue
+		}
+		clear, err := decryptWebhookURL(settings.WebhookURL)
+		if err != nil {
+			return nil, err
+		}
+		urls[id] = clear
+	}
+	return urls, nil
+}
+
+// restoreWebhookURLs replaces the masked webhook urls of the notifications by the ones stored for the old workflow.
+// Notifications are matched on their id, or on their type and sources when they have been re-imported.
+func restoreWebhookURLs(db gorp.SqlExecutor, w *sdk.Workflow, oldWorkflow *sdk.Workflow) error {
+	var urls map[int64]string
+	for i := range w.Notifications {
+		n := &w.Notifications[i]
+		if n.Settings.WebhookURL != sdk.PasswordPlaceholder {
+			continue
+		}
+
+		if urls == nil {
+			var err error
+			urls, err = loadWebhookURLs(db, oldWorkflow.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		url, ok := urls[n.ID]
+		if !ok {
+			for _, old := range oldWorkflow.Notifications {
+				if old.Type == n.Type && sameSources(old.SourceNodeRefs, n.SourceNodeRefs) {
+					url, ok = urls[old.ID]
+					break
+				}
+			}
+		}
+		if !ok {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to find the webhook url of %s notification", n.Type)
+		}
+		n.Settings.WebhookURL = url
+	}
 	return nil
 }
+
+// LoadWebhookURLs returns the clear webhook urls of the notifications of a workflow as it was run, indexed by notification id.
+// The notifications are matched with the stored ones on their id, or on their type and sources when the workflow has been updated since.
+func LoadWebhookURLs(db gorp.SqlExecutor, w *sdk.Workflow) (map[int64]string, error) {
+	urls, err := loadWebhookURLs(db, w.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []sdk.WorkflowNotification
+	res := map[int64]string{}
+	for _, n := range w.Notifications {
+		if url, ok := urls[n.ID]; ok {
+			res[n.ID] = url
+			continue
+		}
+		if stored == nil {
+			stored, err = loadNotificationsSources(db, w.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, s := range stored {
+			if s.Type == n.Type && sameSources(s.SourceNodeRefs, n.SourceNodeRefs) {
+				if url, ok := urls[s.ID]; ok {
+					res[n.ID] = url
+				}
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+// loadNotificationsSources loads the type and the source node names of the notifications of a workflow
+func loadNotificationsSources(db gorp.SqlExecutor, workflowID int64) ([]sdk.WorkflowNotification, error) {
+	rows, err := db.Query(`
+		SELECT workflow_notification.id, workflow_notification.type, workflow_node.name
+		FROM workflow_notification
+		LEFT JOIN workflow_notification_source ON workflow_notification_source.workflow_notification_id = workflow_notification.id
+		LEFT JOIN workflow_node ON workflow_node.id = workflow_notification_source.workflow_node_id
+		WHERE workflow_notification.workflow_id = $1
+		ORDER BY workflow_notification.id`, workflowID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Unable to load notifications sources on workflow %d", workflowID)
+	}
+	defer rows.Close()
+
+	notifs := []sdk.WorkflowNotification{}
+	for rows.Next() {
+		var id int64
+		var t string
+		var name sql.NullString
+		if err := rows.Scan(&id, &t, &name); err != nil {
+			return nil, sdk.WrapError(err, "Unable to scan notification sources")
+		}
+		if len(notifs) == 0 || notifs[len(notifs)-1].ID != id {
+			notifs = append(notifs, sdk.WorkflowNotification{ID: id, Type: t})
+		}
+		if name.Valid {
+			n := &notifs[len(notifs)-1]
+			n.SourceNodeRefs = append(n.SourceNodeRefs, name.String)
+		}
+	}
+	return notifs, nil
+}
+
+// sameSources returns true if the notifications have the same source nodes, whatever their order
+func sameSources(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sa := append([]string{}, a...)
+	sb := append([]string{}, b...)
+	sort.Strings(sa)
+	sort.Strings(sb)
+	return strings.Join(sa, ",") == strings.Join(sb, ",")
+}
@@ -289,6 +289,27 @@ func LoadAndLockRunByJobID(db gorp.SqlExecutor, id int64, loadOpts LoadRunOption
 	return loadRun(db, loadOpts, query, id)
 }
 
+// LoadSupersededRunIDs returns the IDs of the older runs of the workflow of a run,
+// on the same git branch and not terminated yet
+func LoadSupersededRunIDs(db gorp.SqlExecutor, wr *sdk.WorkflowRun, branch string) ([]int64, error) {
+	query := `select distinct workflow_run.id
+	from workflow_run
+	join workflow_run_tag on workflow_run_tag.workflow_run_id = workflow_run.id
+	where workflow_run.workflow_id = $1
+	and workflow_run.num < $2
+	and workflow_run.status = ANY(string_to_array($3, ','))
+	and workflow_run_tag.tag = $4
+	and workflow_run_tag.value = $5
+	order by workflow_run.id`
+	status := []string{sdk.StatusWaiting.String(), sdk.StatusBuilding.String(), sdk.StatusWaitingApproval.String()}
+
+	var ids []int64
+	if _, err := db.Select(&ids, query, wr.WorkflowID, wr.Number, strings.Join(status, ","), tagGitBranch, branch); err != nil {
+		return nil, sdk.WrapError(err, "unable to load superseded runs of workflow %d on branch %s", wr.WorkflowID, branch)
+	}
+	return ids, nil
+}
+
 //LoadRuns loads all runs
 //It retuns runs, offset, limit count and an error
 func LoadRuns(db gorp.SqlExecutor, projectkey, workflowname string, offset, limit int, tagFilter map[string]string) ([]sdk.WorkflowRun, int, int, int, error) {
@@ -562,6 +583,11 @@ func PurgeWorkflowRun(db gorp.SqlExecutor, wf sdk.Workflow) error {
 		return nil
 	}
 
+	if wf.RetentionPolicy != nil {
+		log.Debug("PurgeWorkflowRun> workflow %d has a retention policy, runs are purged by the purge routine", wf.ID)
+		return nil
+	}
+
 	filteredPurgeTags := []string{}
 	for _, t := range wf.PurgeTags {
 		if t != "" {
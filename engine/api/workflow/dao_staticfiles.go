@@ -17,7 +17,8 @@ func loadStaticFilesByNodeRunID(db gorp.SqlExecutor, nodeRunID int64) ([]sdk.Sta
 			entrypoint,
 			created,
 			public_url,
-			workflow_node_run_id
+			workflow_node_run_id,
+			workflow_node_run_job_id
 		FROM workflow_node_run_static_files WHERE workflow_node_run_id = $1`, nodeRunID); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -42,3 +43,46 @@ func InsertStaticFiles(db gorp.SqlExecutor, sf *sdk.StaticFiles) error {
 	sf.ID = dbstaticFiles.ID
 	return nil
 }
+
+// CountStaticFiles returns the number of static files
+func CountStaticFiles(db gorp.SqlExecutor) (int64, error) {
+	return db.SelectInt("SELECT COUNT(id) FROM workflow_node_run_static_files")
+}
+
+// LoadStaticFilesFromID loads a batch of static files ordered by id, starting after the given id
+func LoadStaticFilesFromID(db gorp.SqlExecutor, fromID int64, limit int) ([]sdk.StaticFiles, error) {
+	var dbstaticFiles []dbStaticFiles
+	if _, err := db.Select(&dbstaticFiles, `SELECT
+			id,
+			name,
+			entrypoint,
+			created,
+			public_url,
+			workflow_node_run_id,
+			workflow_node_run_job_id
+		FROM workflow_node_run_static_files WHERE id > $1 ORDER BY id LIMIT $2`, fromID, limit); err != nil {
+		return nil, sdk.WrapError(err, "cannot load static files")
+	}
+
+	staticFiles := make([]sdk.StaticFiles, len(dbstaticFiles))
+	for i := range dbstaticFiles {
+		staticFiles[i] = sdk.StaticFiles(dbstaticFiles[i])
+	}
+	return staticFiles, nil
+}
+
+// UpdateStaticFilesPublicURL updates the public url of static files
+func UpdateStaticFilesPublicURL(db gorp.SqlExecutor, id int64, publicURL string) error {
+	if _, err := db.Exec("UPDATE workflow_node_run_static_files SET public_url = $2 WHERE id = $1", id, publicURL); err != nil {
+		return sdk.WrapError(err, "cannot update public url of static files %d", id)
+	}
+	return nil
+}
+
+// UpdateStaticFilesNodeJobRunID updates the id of the job which uploaded static files
+func UpdateStaticFilesNodeJobRunID(db gorp.SqlExecutor, id, nodeJobRunID int64) error {
+	if _, err := db.Exec("UPDATE workflow_node_run_static_files SET workflow_node_run_job_id = $2 WHERE id = $1", id, nodeJobRunID); err != nil {
+		return sdk.WrapError(err, "cannot update job of static files %d", id)
+	}
+	return nil
+}
@@ -1,12 +1,15 @@
 package workflow_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"sort"
 	"testing"
 
 	"github.com/fsamin/go-dump"
+	"github.com/go-gorp/gorp"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ovh/cds/engine/api/application"
@@ -1265,6 +1268,80 @@ func TestUpdateWorkflowWithJoins(t *testing.T) {
 	test.NoError(t, workflow.Delete(context.TODO(), db, cache, proj, w2))
 }
 
+func TestUpdateWorkflowWithChatNotification(t *testing.T) {
+	db, cache, end := test.SetupPG(t)
+	defer end()
+	u, _ := assets.InsertAdminUser(db)
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, cache, key, key, u)
+
+	pip := sdk.Pipeline{
+		ProjectID:  proj.ID,
+		ProjectKey: proj.Key,
+		Name:       "pip1",
+		Type:       sdk.BuildPipeline,
+	}
+	test.NoError(t, pipeline.InsertPipeline(db, cache, proj, &pip, u))
+
+	w := sdk.Workflow{
+		Name:       "test_1",
+		ProjectID:  proj.ID,
+		ProjectKey: proj.Key,
+		WorkflowData: &sdk.WorkflowData{
+			Node: sdk.Node{
+				Name: "node1",
+				Ref:  "node1",
+				Type: sdk.NodeTypePipeline,
+				Context: &sdk.NodeContext{
+					PipelineID: pip.ID,
+				},
+			},
+		},
+		Notifications: []sdk.WorkflowNotification{
+			{
+				Type:           sdk.SlackUserNotification,
+				SourceNodeRefs: []string{"node1"},
+				Settings: sdk.UserNotificationSettings{
+					OnFailure:  sdk.UserNotificationAlways,
+					OnSuccess:  sdk.UserNotificationChange,
+					WebhookURL: "https://hooks.slack.com/services/secret",
+				},
+			},
+		},
+	}
+
+	proj, _ = project.LoadByID(db, cache, proj.ID, u, project.LoadOptions.WithApplications, project.LoadOptions.WithPipelines, project.LoadOptions.WithEnvironments, project.LoadOptions.WithGroups)
+
+	w.RetroMigrate()
+	test.NoError(t, workflow.Insert(db, cache, &w, proj, u))
+	assert.Equal(t, sdk.PasswordPlaceholder, w.Notifications[0].Settings.WebhookURL)
+
+	w1, err := workflow.Load(context.TODO(), db, cache, proj, "test_1", u, workflow.LoadOptions{})
+	test.NoError(t, err)
+	assert.Len(t, w1.Notifications, 1)
+	assert.Equal(t, sdk.PasswordPlaceholder, w1.Notifications[0].Settings.WebhookURL)
+
+	// The masked url is kept on update
+	w1old := *w1
+	w1.Notifications[0].Settings.OnStart = &sdk.True
+	test.NoError(t, workflow.Update(context.TODO(), db, cache, w1, &w1old, proj, u))
+
+	w2, err := workflow.Load(context.TODO(), db, cache, proj, "test_1", u, workflow.LoadOptions{})
+	test.NoError(t, err)
+	assert.Len(t, w2.Notifications, 1)
+	assert.Equal(t, sdk.PasswordPlaceholder, w2.Notifications[0].Settings.WebhookURL)
+
+	// The url is only readable by the export, encrypted with the project key
+	encrypt := func(db gorp.SqlExecutor, projectID int64, name, content string) (string, error) {
+		return "encrypted:" + base64.StdEncoding.EncodeToString([]byte(content)), nil
+	}
+	buf := new(bytes.Buffer)
+	_, err = workflow.Export(context.TODO(), db, cache, proj, "test_1", exportentities.FormatYAML, encrypt, u, buf)
+	test.NoError(t, err)
+	assert.Contains(t, buf.String(), "encrypted:"+base64.StdEncoding.EncodeToString([]byte("https://hooks.slack.com/services/secret")))
+	assert.NotContains(t, buf.String(), "https://hooks.slack.com")
+}
+
 func TestInsertSimpleWorkflowWithHookAndExport(t *testing.T) {
 	db, cache, end := test.SetupPG(t)
 	defer end()
@@ -0,0 +1,120 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// ApproveNodeRun records the approval or the rejection of a user on a node run waiting for approval.
+// A rejection stops the node run, enough approvals execute it with its manual data and parameters.
+func ApproveNodeRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, wr *sdk.WorkflowRun, nodeRun *sdk.WorkflowNodeRun, u *sdk.User, req sdk.WorkflowNodeRunApprovalRequest) (*ProcessorReport, error) {
+	var end func()
+	ctx, end = observability.Span(ctx, "workflow.ApproveNodeRun",
+		observability.Tag(observability.TagWorkflowRun, nodeRun.Number),
+		observability.Tag(observability.TagWorkflowNodeRun, nodeRun.ID),
+	)
+	defer end()
+
+	report := new(ProcessorReport)
+
+	if nodeRun.Status != sdk.StatusWaitingApproval.String() {
+		return nil, sdk.WrapError(sdk.ErrWorkflowNodeRunNotWaitingApproval, "node run %d is at status %s", nodeRun.ID, nodeRun.Status)
+	}
+
+	runContext, approval, _ := getNodeRunContext(wr, nodeRun)
+	if approval == nil {
+		return nil, sdk.WrapError(sdk.ErrWorkflowNodeRunNotWaitingApproval, "node %d has no approval gate", nodeRun.WorkflowNodeID)
+	}
+	if !approval.IsApprover(*u) {
+		return nil, sdk.WrapError(sdk.ErrForbidden, "user %s is not a member of the approval groups %v", u.Username, approval.Groups)
+	}
+	if nodeRun.HasApproved(u.Username) {
+		return nil, sdk.WrapError(sdk.ErrWorkflowNodeRunAlreadyApproved, "user %s has already approved node run %d", u.Username, nodeRun.ID)
+	}
+
+	nodeRun.Approvals = append(nodeRun.Approvals, sdk.WorkflowNodeRunApproval{
+		Username: u.Username,
+		Fullname: u.Fullname,
+		Approved: req.Approved,
+		Comment:  req.Comment,
+		Date:     time.Now(),
+	})
+
+	msg := sdk.MsgWorkflowNodeApproved
+	if !req.Approved {
+		msg = sdk.MsgWorkflowNodeRejected
+	}
+	AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+		ID:   msg.ID,
+		Args: []interface{}{u.Username, req.Comment},
+	})
+
+	switch nodeRun.ApprovalStatus(*approval) {
+	case sdk.StatusStopped:
+		stopWorkflowNodeRunStages(nodeRun)
+		nodeRun.Status = sdk.StatusStopped.String()
+		nodeRun.Done = time.Now()
+	case sdk.StatusWaiting:
+		nodeRun.Status = sdk.StatusWaiting.String()
+	}
+
+	if err := UpdateNodeRun(db, nodeRun); err != nil {
+		return nil, sdk.WrapError(err, "unable to update node run %d", nodeRun.ID)
+	}
+	report.Add(*nodeRun)
+
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, sdk.WrapError(err, "unable to update workflow run %d", wr.ID)
+	}
+
+	if nodeRun.Status == sdk.StatusWaiting.String() {
+		locked, err := nodeRunMutexLocked(db, wr, nodeRun)
+		if err != nil {
+			return nil, err
+		}
+		if locked {
+			// The node run will be executed when the mutex is released
+			log.Debug("ApproveNodeRun> Noderun %s approved but not executed because of mutex", nodeRun.WorkflowNodeName)
+			AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+				ID:   sdk.MsgWorkflowNodeMutex.ID,
+				Args: []interface{}{nodeRun.WorkflowNodeName},
+			})
+			if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+				return nil, sdk.WrapError(err, "unable to update workflow run %d", wr.ID)
+			}
+		} else {
+			r1, err := execute(ctx, db, store, proj, nodeRun, runContext)
+			if err != nil {
+				return nil, sdk.WrapError(err, "unable to execute node run %d", nodeRun.ID)
+			}
+			_, _ = report.Merge(r1, nil)
+			if isQueuedInConcurrencyGroup(nodeRun) {
+				AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+					ID:   sdk.MsgWorkflowNodeConcurrencyQueued.ID,
+					Args: []interface{}{nodeRun.WorkflowNodeName, nodeRun.ConcurrencyGroup},
+				})
+				if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+					return nil, sdk.WrapError(err, "unable to update workflow run %d", wr.ID)
+				}
+			}
+		}
+	}
+
+	oldStatus := wr.Status
+	r1, err := computeAndUpdateWorkflowRunStatus(ctx, db, wr)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to compute workflow run status")
+	}
+	_, _ = report.Merge(r1, nil)
+	if wr.Status != oldStatus {
+		report.Add(*wr)
+	}
+	return report, nil
+}
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -475,7 +476,7 @@ func LoadNodeJobRunKeys(p *sdk.Project, wr *sdk.WorkflowRun, nodeRun *sdk.Workfl
 func LoadSecrets(db gorp.SqlExecutor, store cache.Store, nodeRun *sdk.WorkflowNodeRun, w *sdk.WorkflowRun, pv []sdk.Variable) ([]sdk.Variable, error) {
 	var secrets []sdk.Variable
 
-	pv = sdk.VariablesFilter(pv, sdk.SecretVariable, sdk.KeyVariable)
+	pv = sdk.VariablesFilter(pv, sdk.SecretVariable, sdk.KeyVariable, sdk.VaultVariable)
 	pv = sdk.VariablesPrefix(pv, "cds.proj.")
 	secrets = append(secrets, pv...)
 
@@ -526,7 +527,7 @@ func LoadSecrets(db gorp.SqlExecutor, store cache.Store, nodeRun *sdk.WorkflowNo
 			if errA != nil {
 				return nil, sdk.WrapError(errA, "LoadSecrets> Cannot load application variables")
 			}
-			av = sdk.VariablesFilter(appv, sdk.SecretVariable, sdk.KeyVariable)
+			av = sdk.VariablesFilter(appv, sdk.SecretVariable, sdk.KeyVariable, sdk.VaultVariable)
 			av = sdk.VariablesPrefix(av, "cds.app.")
 
 			if err := application.DecryptVCSStrategyPassword(app); err != nil {
@@ -547,7 +548,7 @@ func LoadSecrets(db gorp.SqlExecutor, store cache.Store, nodeRun *sdk.WorkflowNo
 			if errE != nil {
 				return nil, sdk.WrapError(errE, "LoadSecrets> Cannot load environment variables")
 			}
-			ev = sdk.VariablesFilter(envv, sdk.SecretVariable, sdk.KeyVariable)
+			ev = sdk.VariablesFilter(envv, sdk.SecretVariable, sdk.KeyVariable, sdk.VaultVariable)
 			ev = sdk.VariablesPrefix(ev, "cds.env.")
 		}
 		secrets = append(secrets, ev...)
@@ -596,9 +597,15 @@ func LoadSecrets(db gorp.SqlExecutor, store cache.Store, nodeRun *sdk.WorkflowNo
 		}
 	}
 
-	//Decrypt secrets
+	//Decrypt secrets and resolve vault variables, their values are never stored in database
 	for i := range secrets {
 		s := &secrets[i]
+		if s.Type == sdk.VaultVariable {
+			if err := secret.ResolveVaultVariable(w.Workflow.ProjectKey, s); err != nil {
+				return nil, sdk.WrapError(err, "LoadSecrets> Unable to resolve vault variables")
+			}
+			continue
+		}
 		if err := secret.DecryptVariable(s); err != nil {
 			return nil, sdk.WrapError(err, "Unable to decrypt variables")
 		}
@@ -764,3 +771,113 @@ func RestartWorkflowNodeJob(ctx context.Context, db gorp.SqlExecutor, wNodeJob s
 
 	return nil
 }
+
+// UpdateNodeJobRunResult puts a job run back in queue if its retry policy allows a new attempt after the given result,
+// or updates its status with the status of the result
+func UpdateNodeJobRunResult(ctx context.Context, dbFunc func() *gorp.DbMap, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, job *sdk.WorkflowNodeJobRun, res sdk.Result) (*ProcessorReport, error) {
+	if job.Job.RetryPolicy != nil && job.Job.RetryPolicy.ShouldRetry(job.Job.CurrentAttempt(), res.Status, res.ExitCode) {
+		log.Debug("UpdateNodeJobRunResult> Retrying %d after attempt %d", job.ID, job.Job.CurrentAttempt())
+		if err := RetryNodeJobRun(ctx, db, job, res); err != nil {
+			return nil, sdk.WrapError(err, "Cannot retry NodeJobRun %d", job.ID)
+		}
+		return new(ProcessorReport), nil
+	}
+	return UpdateNodeJobRunStatus(ctx, dbFunc, db, store, proj, job, sdk.Status(res.Status))
+}
+
+// RetryNodeJobRun archives the current attempt of a job run according to its retry policy
+// and puts it back in queue after the backoff delay
+func RetryNodeJobRun(ctx context.Context, db gorp.SqlExecutor, job *sdk.WorkflowNodeJobRun, res sdk.Result) error {
+	var end func()
+	ctx, end = observability.Span(ctx, "workflow.RetryNodeJobRun")
+	defer end()
+
+	policy := job.Job.RetryPolicy
+	attempt := job.Job.CurrentAttempt()
+	backoff := policy.BackoffDuration(attempt)
+
+	spawnInfos, errI := loadNodeRunJobInfo(db, job.ID)
+	if errI != nil {
+		return sdk.WrapError(errI, "RetryNodeJobRun> Cannot load spawn infos of job %d", job.ID)
+	}
+
+	reason := res.Reason
+	if reason == "" {
+		reason = res.Status
+	}
+	job.Job.PreviousAttempts = append(job.Job.PreviousAttempts, sdk.ExecutedJobAttempt{
+		Attempt:    attempt,
+		Status:     res.Status,
+		Reason:     res.Reason,
+		ExitCode:   res.ExitCode,
+		WorkerName: job.Job.WorkerName,
+		Start:      job.Start,
+		Done:       time.Now(),
+		StepStatus: job.Job.StepStatus,
+		SpawnInfos: spawnInfos,
+	})
+
+	if err := archiveLogs(db, job.ID, attempt); err != nil {
+		return err
+	}
+	if err := deleteNodeRunJobInfo(db, job.ID); err != nil {
+		return err
+	}
+
+	infos := []sdk.SpawnInfo{{
+		RemoteTime: time.Now(),
+		Message:    sdk.SpawnMsg{ID: sdk.MsgSpawnInfoJobRetry.ID, Args: []interface{}{attempt, policy.MaxAttempts, strings.TrimSpace(reason), backoff.String()}},
+	}}
+	if err := AddSpawnInfosNodeJobRun(db, job.ID, infos); err != nil {
+		return sdk.WrapError(err, "RetryNodeJobRun> Cannot save spawn info on job %d", job.ID)
+	}
+
+	// Reset the job run, the queue will only return it once the backoff is over
+	job.Job.StepStatus = nil
+	job.Job.Reason = ""
+	job.Job.WorkerName = ""
+	job.Job.WorkerID = ""
+	job.Status = sdk.StatusWaiting.String()
+	job.Retry = 0
+	job.Queued = time.Now().Add(backoff)
+	job.Start = time.Time{}
+	job.Done = time.Time{}
+	job.SpawnAttempts = nil
+	job.SpawnInfos = infos
+	if err := UpdateNodeJobRun(ctx, db, job); err != nil {
+		return sdk.WrapError(err, "RetryNodeJobRun> Cannot update job %d", job.ID)
+	}
+
+	nodeRun, errNR := LoadAndLockNodeRunByID(ctx, db, job.WorkflowNodeRunID, true)
+	if errNR != nil {
+		return sdk.WrapError(errNR, "RetryNodeJobRun> Cannot load node run")
+	}
+
+	//Synchronise struct but not in db
+	for i := range nodeRun.Stages {
+		s := &nodeRun.Stages[i]
+		for j := range s.RunJobs {
+			runJob := &s.RunJobs[j]
+			if runJob.ID != job.ID {
+				continue
+			}
+			runJob.Job.PreviousAttempts = job.Job.PreviousAttempts
+			runJob.Job.StepStatus = nil
+			runJob.Job.Reason = ""
+			runJob.Status = job.Status
+			runJob.Queued = job.Queued
+			runJob.SpawnInfos = infos
+		}
+	}
+
+	if errU := UpdateNodeRun(db, nodeRun); errU != nil {
+		return sdk.WrapError(errU, "RetryNodeJobRun> Cannot update node run")
+	}
+
+	query := "UPDATE worker SET action_build_id = NULL where action_build_id = $1"
+	if _, err := db.Exec(query, job.ID); err != nil {
+		return sdk.WrapError(err, "RetryNodeJobRun> Unable to unlink worker from job %d", job.ID)
+	}
+
+	return nil
+}
@@ -1,79 +1,152 @@
 package workflow
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-gorp/gorp"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/logstore"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
 )
 
 //LoadStepLogs load logs (workflow_node_run_job_logs) for a job (workflow_node_run_job) for a specific step_order
 func LoadStepLogs(db gorp.SqlExecutor, id int64, order int64) (*sdk.Log, error) {
+	return LoadAttemptStepLogs(db, id, order, 0)
+}
+
+//LoadAttemptStepLogs load logs (workflow_node_run_job_logs) of a previous attempt of a job (workflow_node_run_job) for a specific step_order.
+//Logs of the current attempt are loaded if attempt is 0.
+func LoadAttemptStepLogs(db gorp.SqlExecutor, id int64, order int64, attempt int) (*sdk.Log, error) {
+	logs, r, err := OpenAttemptStepLogs(db, id, order, attempt)
+	if err != nil || logs == nil {
+		return nil, err
+	}
+	defer r.Close()
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, sdk.WrapError(err, "LoadAttemptStepLogs> Unable to read log %d", logs.Id)
+	}
+	logs.Val = string(b)
+	return logs, nil
+}
+
+//OpenAttemptStepLogs load logs metadata of a job (workflow_node_run_job) for a specific step_order, and returns
+//a reader on their content from the log store they have been moved to. Logs of the current attempt are loaded if attempt is 0.
+func OpenAttemptStepLogs(db gorp.SqlExecutor, id int64, order int64, attempt int) (*sdk.Log, io.ReadCloser, error) {
 	query := `
-		SELECT id, workflow_node_run_job_id, workflow_node_run_id, start, last_modified, done, step_order, value
+		SELECT id, workflow_node_run_job_id, workflow_node_run_id, start, last_modified, done, step_order, value, storage
 		FROM workflow_node_run_job_logs
-		WHERE workflow_node_run_job_id = $1 AND step_order = $2`
+		WHERE workflow_node_run_job_id = $1 AND step_order = $2 AND archived_attempt IS NULL`
+	args := []interface{}{id, order}
+	if attempt > 0 {
+		query = `
+		SELECT id, workflow_node_run_job_id, workflow_node_run_id, start, last_modified, done, step_order, value, storage
+		FROM workflow_node_run_job_logs
+		WHERE workflow_node_run_job_id = $1 AND step_order = $2 AND archived_attempt = $3`
+		args = append(args, attempt)
+	}
+
 	logs := &sdk.Log{}
-	var s, m, d time.Time
-	if err := db.QueryRow(query, id, order).Scan(&logs.Id, &logs.PipelineBuildJobID, &logs.PipelineBuildID, &s, &m, &d, &logs.StepOrder, &logs.Val); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
+	var storage sql.NullString
+	if err := scanLog(db.QueryRow(query, args...), logs, &storage); err != nil {
+		if sdk.Cause(err) == sql.ErrNoRows {
+			return nil, nil, nil
 		}
-		return nil, err
+		return nil, nil, err
 	}
-	var err error
-	logs.Start, err = ptypes.TimestampProto(s)
+
+	if !storage.Valid || storage.String == logstore.DatabaseName {
+		return logs, ioutil.NopCloser(strings.NewReader(logs.Val)), nil
+	}
+	store, err := logstore.Get(storage.String)
 	if err != nil {
-		return nil, err
+		return nil, nil, sdk.WrapError(err, "OpenAttemptStepLogs> Unable to get store of log %d", logs.Id)
 	}
-	logs.LastModified, err = ptypes.TimestampProto(m)
+	r, err := store.Fetch(db, logs)
 	if err != nil {
-		return nil, err
+		return nil, nil, sdk.WrapError(err, "OpenAttemptStepLogs> Unable to fetch log %d", logs.Id)
+	}
+	return logs, r, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanLog(row rowScanner, l *sdk.Log, storage *sql.NullString) error {
+	var s, m, d time.Time
+	var value sql.NullString
+	if err := row.Scan(&l.Id, &l.PipelineBuildJobID, &l.PipelineBuildID, &s, &m, &d, &l.StepOrder, &value, storage); err != nil {
+		return err
 	}
-	logs.Done, err = ptypes.TimestampProto(d)
+	l.Val = value.String
+	var err error
+	l.Start, err = ptypes.TimestampProto(s)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return logs, nil
+	l.LastModified, err = ptypes.TimestampProto(m)
+	if err != nil {
+		return err
+	}
+	l.Done, err = ptypes.TimestampProto(d)
+	return err
 }
 
 //LoadLogs load logs (workflow_node_run_job_logs) for a job (workflow_node_run_job)
 func LoadLogs(db gorp.SqlExecutor, id int64) ([]sdk.Log, error) {
 	query := `
-		SELECT id, workflow_node_run_job_id, workflow_node_run_id, start, last_modified, done, step_order, value
+		SELECT id, workflow_node_run_job_id, workflow_node_run_id, start, last_modified, done, step_order, value, storage
 		FROM workflow_node_run_job_logs
-		WHERE workflow_node_run_job_id = $1
+		WHERE workflow_node_run_job_id = $1 AND archived_attempt IS NULL
 		ORDER BY id`
 	rows, err := db.Query(query, id)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+
 	var logs []sdk.Log
+	var storages []sql.NullString
 	for rows.Next() {
-		l := &sdk.Log{}
-		var s, m, d time.Time
-
-		if err := rows.Scan(&l.Id, &l.PipelineBuildJobID, &l.PipelineBuildID, &s, &m, &d, &l.StepOrder, &l.Val); err != nil {
+		l := sdk.Log{}
+		var storage sql.NullString
+		if err := scanLog(rows, &l, &storage); err != nil {
 			return nil, err
 		}
+		logs = append(logs, l)
+		storages = append(storages, storage)
+	}
 
-		var err error
-		l.Start, err = ptypes.TimestampProto(s)
+	for i := range logs {
+		if !storages[i].Valid || storages[i].String == logstore.DatabaseName {
+			continue
+		}
+		store, err := logstore.Get(storages[i].String)
 		if err != nil {
-			return nil, err
+			return nil, sdk.WrapError(err, "LoadLogs> Unable to get store of log %d", logs[i].Id)
 		}
-		l.LastModified, err = ptypes.TimestampProto(m)
+		r, err := store.Fetch(db, &logs[i])
 		if err != nil {
-			return nil, err
+			return nil, sdk.WrapError(err, "LoadLogs> Unable to fetch log %d", logs[i].Id)
 		}
-		l.Done, err = ptypes.TimestampProto(d)
+		b, err := ioutil.ReadAll(r)
+		r.Close()
 		if err != nil {
-			return nil, err
+			return nil, sdk.WrapError(err, "LoadLogs> Unable to read log %d", logs[i].Id)
 		}
-
-		logs = append(logs, *l)
+		logs[i].Val = string(b)
 	}
 	return logs, nil
 }
@@ -108,6 +181,7 @@ func insertLog(db gorp.SqlExecutor, logs *sdk.Log) error {
 	return db.QueryRow(query, logs.PipelineBuildJobID, logs.PipelineBuildID, s, m, d, logs.StepOrder, logs.Val).Scan(&logs.Id)
 }
 
+// updateLog updates a step log with its whole content, that is stored back in database if it had been moved to a log store
 func updateLog(db gorp.SqlExecutor, logs *sdk.Log) error {
 	if logs.Start == nil {
 		logs.Start, _ = ptypes.TimestampProto(time.Now())
@@ -127,7 +201,8 @@ func updateLog(db gorp.SqlExecutor, logs *sdk.Log) error {
 			last_modified = $4,
 			done = $5,
 			step_order = $6,
-			value = $7
+			value = $7,
+			storage = NULL
 		where id = $8`
 
 	s, errs := ptypes.Timestamp(logs.Start)
@@ -148,3 +223,207 @@ func updateLog(db gorp.SqlExecutor, logs *sdk.Log) error {
 	}
 	return nil
 }
+
+// archiveLogs flags all current logs of a job (workflow_node_run_job) as belonging to the given attempt
+func archiveLogs(db gorp.SqlExecutor, id int64, attempt int) error {
+	query := `
+		UPDATE workflow_node_run_job_logs SET archived_attempt = $2
+		WHERE workflow_node_run_job_id = $1 AND archived_attempt IS NULL`
+	if _, err := db.Exec(query, id, attempt); err != nil {
+		return sdk.WrapError(err, "archiveLogs> Unable to archive logs of job %d", id)
+	}
+	return nil
+}
+
+// MigrateLogs moves periodically the logs of finished node runs out of the database to the configured log store
+func MigrateLogs(c context.Context, DBFunc func() *gorp.DbMap) {
+	tick := time.NewTicker(time.Minute)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-c.Done():
+			if c.Err() != nil {
+				log.Error("Exiting MigrateLogs: %v", c.Err())
+				return
+			}
+		case <-tick.C:
+			store := logstore.Current()
+			if store.Name() == logstore.DatabaseName {
+				continue
+			}
+			for {
+				tx, err := DBFunc().Begin()
+				if err != nil {
+					log.Warning("MigrateLogs> Unable to start transaction: %v", err)
+					break
+				}
+				n, err := migrateLogs(tx, store, 100)
+				if err != nil {
+					_ = tx.Rollback()
+					log.Warning("MigrateLogs> Error: %v", err)
+					break
+				}
+				if err := tx.Commit(); err != nil {
+					_ = tx.Rollback()
+					log.Warning("MigrateLogs> Unable to commit transaction: %v", err)
+					break
+				}
+				if n == 0 {
+					break
+				}
+				log.Debug("MigrateLogs> %d logs moved to %s", n, store.Name())
+			}
+		}
+	}
+}
+
+// migrateLogs moves at most limit logs of finished node runs from the database to the given store.
+// It must be called in a transaction, the logs locked by another API instance are skipped.
+func migrateLogs(db gorp.SqlExecutor, store logstore.Store, limit int) (int, error) {
+	query := `
+		SELECT workflow_node_run_job_logs.id, workflow_node_run_job_logs.workflow_node_run_job_id, workflow_node_run_job_logs.workflow_node_run_id,
+			workflow_node_run_job_logs.start, workflow_node_run_job_logs.last_modified, workflow_node_run_job_logs.done,
+			workflow_node_run_job_logs.step_order, workflow_node_run_job_logs.value, workflow_node_run_job_logs.storage
+		FROM workflow_node_run_job_logs
+		JOIN workflow_node_run ON workflow_node_run.id = workflow_node_run_job_logs.workflow_node_run_id
+		WHERE workflow_node_run_job_logs.storage IS NULL
+		AND workflow_node_run.status = ANY(string_to_array($1, ','))
+		LIMIT $2
+		FOR UPDATE OF workflow_node_run_job_logs SKIP LOCKED`
+	status := strings.Join([]string{sdk.StatusSuccess.String(), sdk.StatusFail.String(), sdk.StatusStopped.String()}, ",")
+	rows, err := db.Query(query, status, limit)
+	if err != nil {
+		return 0, sdk.WrapError(err, "migrateLogs> Unable to load logs")
+	}
+	var logs []sdk.Log
+	for rows.Next() {
+		l := sdk.Log{}
+		var storage sql.NullString
+		if err := scanLog(rows, &l, &storage); err != nil {
+			rows.Close()
+			return 0, sdk.WrapError(err, "migrateLogs> Unable to scan log")
+		}
+		logs = append(logs, l)
+	}
+	rows.Close()
+
+	for i := range logs {
+		l := &logs[i]
+		if err := store.Store(db, l, strings.NewReader(l.Val)); err != nil {
+			return i, err
+		}
+		if _, err := db.Exec("UPDATE workflow_node_run_job_logs SET storage = $2, value = '' WHERE id = $1 AND storage IS NULL", l.Id, store.Name()); err != nil {
+			return i, sdk.WrapError(err, "migrateLogs> Unable to update log %d", l.Id)
+		}
+	}
+	return len(logs), nil
+}
+
+// DeleteRunsStoredLogs deletes from their log store the logs of workflow runs which are not kept in database.
+// It must be called before deleting the runs, as the logs metadata are deleted with them.
+func DeleteRunsStoredLogs(db gorp.SqlExecutor, runIDs []int64) error {
+	query := `
+		SELECT workflow_node_run_job_logs.id, workflow_node_run_job_logs.workflow_node_run_job_id, workflow_node_run_job_logs.workflow_node_run_id,
+			workflow_node_run_job_logs.start, workflow_node_run_job_logs.last_modified, workflow_node_run_job_logs.done,
+			workflow_node_run_job_logs.step_order, '', workflow_node_run_job_logs.storage
+		FROM workflow_node_run_job_logs
+		JOIN workflow_node_run ON workflow_node_run.id = workflow_node_run_job_logs.workflow_node_run_id
+		WHERE workflow_node_run.workflow_run_id = ANY($1)
+		AND workflow_node_run_job_logs.storage IS NOT NULL`
+	rows, err := db.Query(query, pq.Int64Array(runIDs))
+	if err != nil {
+		return sdk.WrapError(err, "DeleteRunsStoredLogs> Unable to load logs")
+	}
+	var logs []sdk.Log
+	var storages []string
+	for rows.Next() {
+		l := sdk.Log{}
+		var storage sql.NullString
+		if err := scanLog(rows, &l, &storage); err != nil {
+			rows.Close()
+			return sdk.WrapError(err, "DeleteRunsStoredLogs> Unable to scan log")
+		}
+		logs = append(logs, l)
+		storages = append(storages, storage.String)
+	}
+	rows.Close()
+
+	for i := range logs {
+		store, err := logstore.Get(storages[i])
+		if err != nil {
+			log.Warning("DeleteRunsStoredLogs> Unable to delete log %d: %v", logs[i].Id, err)
+			continue
+		}
+		if err := store.Delete(db, &logs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StepLogsEvent is published on the cache each time a job step receives new logs or changes of status
+type StepLogsEvent struct {
+	Log    *sdk.Log `json:"log,omitempty"`
+	Status string   `json:"status,omitempty"`
+}
+
+func stepLogsChannel(jobID, stepOrder int64) string {
+	return cache.Key("workflow", "job", strconv.FormatInt(jobID, 10), "step", strconv.FormatInt(stepOrder, 10), "logs")
+}
+
+func publishStepLogsEvent(store cache.Store, jobID, stepOrder int64, e StepLogsEvent) {
+	if store == nil {
+		return
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		log.Warning("publishStepLogsEvent> Unable to marshal event: %v", err)
+		return
+	}
+	store.Publish(stepLogsChannel(jobID, stepOrder), string(b))
+}
+
+// PublishStepLogs pushes new logs of a job step to the clients following it
+func PublishStepLogs(store cache.Store, logs *sdk.Log) {
+	publishStepLogsEvent(store, logs.PipelineBuildJobID, logs.StepOrder, StepLogsEvent{Log: logs})
+}
+
+// PublishStepStatus pushes the new status of a job step to the clients following it
+func PublishStepStatus(store cache.Store, jobID, stepOrder int64, status string) {
+	publishStepLogsEvent(store, jobID, stepOrder, StepLogsEvent{Status: status})
+}
+
+// SubscribeStepLogs returns a channel receiving the logs and status changes of a job step until the context is done
+func SubscribeStepLogs(ctx context.Context, store cache.Store, jobID, stepOrder int64) <-chan StepLogsEvent {
+	events := make(chan StepLogsEvent)
+	pubSub := store.Subscribe(stepLogsChannel(jobID, stepOrder))
+	if pubSub == nil {
+		close(events)
+		return events
+	}
+	sdk.GoRoutine(ctx, "SubscribeStepLogs", func(ctx context.Context) {
+		defer close(events)
+		defer pubSub.Unsubscribe() // nolint
+		for ctx.Err() == nil {
+			msg, err := store.GetMessageFromSubscription(ctx, pubSub)
+			if err != nil {
+				log.Warning("SubscribeStepLogs> Cannot get message: %v", err)
+				continue
+			}
+			if msg == "" {
+				continue
+			}
+			var e StepLogsEvent
+			if err := json.Unmarshal([]byte(msg), &e); err != nil {
+				log.Warning("SubscribeStepLogs> Cannot unmarshal message %s: %v", msg, err)
+				continue
+			}
+			select {
+			case events <- e:
+			case <-ctx.Done():
+			}
+		}
+	})
+	return events
+}
@@ -103,6 +103,25 @@ func execute(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *
 		return nil, nil
 	}
 
+	//If status is waiting for approval: nothing to do until it's approved
+	if nr.Status == sdk.StatusWaitingApproval.String() {
+		return nil, nil
+	}
+
+	//If the node run is in a concurrency group: wait for a free slot in the group
+	if isQueuedInConcurrencyGroup(nr) {
+		if _, _, concurrency := getNodeRunContext(wr, nr); concurrency != nil {
+			r1, locked, err := lockConcurrencyGroup(ctx, db, store, wr, nr, *concurrency)
+			if err != nil {
+				return nil, sdk.WrapError(err, "unable to lock concurrency group %s", nr.ConcurrencyGroup)
+			}
+			report, _ = report.Merge(r1, nil)
+			if locked {
+				return report, nil
+			}
+		}
+	}
+
 	var newStatus = nr.Status
 
 	//If no stages ==> success
@@ -243,6 +262,15 @@ func execute(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *
 			return nil, sdk.WrapError(err, "Unable to delete node %d job runs ", nr.ID)
 		}
 
+		//Do we release a slot of a concurrency group ?
+		if proj != nil && nr.ConcurrencyGroup != "" {
+			r1, err := releaseConcurrencyGroup(ctx, db, store, proj, nr.ConcurrencyGroup, nr.ID)
+			if err != nil {
+				return nil, sdk.WrapError(err, "unable to release concurrency group %s", nr.ConcurrencyGroup)
+			}
+			report, _ = report.Merge(r1, nil)
+		}
+
 		var hasMutex bool
 		var nodeName string
 		if updatedWorkflowRun.Version < 2 {
@@ -356,95 +384,109 @@ func addJobsToQueue(ctx context.Context, db gorp.SqlExecutor, stage *sdk.Stage,
 	next()
 
 	skippedOrDisabledJobs := 0
+	nbJobRuns := 0
 	//Browse the jobs
 	for j := range stage.Jobs {
 		job := &stage.Jobs[j]
-		errs := sdk.MultiError{}
-		//Process variables for the jobs
-		_, next = observability.Span(ctx, "workflow..getNodeJobRunParameters")
-		jobParams, errParam := getNodeJobRunParameters(db, *job, run, stage)
-		next()
 
-		if errParam != nil {
-			errs.Join(*errParam)
+		// A job with a matrix is fanned out in one job run by combination
+		combinations := []map[string]string{nil}
+		if job.Matrix != nil {
+			combinations = job.Matrix.Combinations()
 		}
 
-		_, next = observability.Span(ctx, "workflow.getNodeJobRunRequirements")
-		jobRequirements, containsService, modelType, errReq := getNodeJobRunRequirements(db, *job, run)
-		next()
+		for _, matrix := range combinations {
+			errs := sdk.MultiError{}
+			//Process variables for the jobs
+			_, next = observability.Span(ctx, "workflow..getNodeJobRunParameters")
+			jobParams, errParam := getNodeJobRunParameters(db, *job, run, stage, matrix)
+			next()
 
-		if errReq != nil {
-			errs.Join(*errReq)
-		}
+			if errParam != nil {
+				errs.Join(*errParam)
+			}
 
-		// add requirements in job parameters, to use them as {{.job.requirement...}} in job
-		_, next = observability.Span(ctx, "workflow.prepareRequirementsToNodeJobRunParameters")
-		jobParams = append(jobParams, prepareRequirementsToNodeJobRunParameters(jobRequirements)...)
-		next()
+			_, next = observability.Span(ctx, "workflow.getNodeJobRunRequirements")
+			jobRequirements, containsService, modelType, errReq := getNodeJobRunRequirements(db, *job, run, matrix)
+			next()
 
-		if errGroups != nil {
-			return report, sdk.WrapError(errGroups, "addJobsToQueue> error on getJobExecutablesGroups")
-		}
+			if errReq != nil {
+				errs.Join(*errReq)
+			}
 
-		//Create the job run
-		wjob := sdk.WorkflowNodeJobRun{
-			ProjectID:              wr.ProjectID,
-			WorkflowNodeRunID:      run.ID,
-			Start:                  time.Time{},
-			Queued:                 time.Now(),
-			Status:                 sdk.StatusWaiting.String(),
-			Parameters:             jobParams,
-			ExecGroups:             groups,
-			PlatformPluginBinaries: platformPluginBinaries,
-			Job: sdk.ExecutedJob{
-				Job: *job,
-			},
-			Header:          run.Header,
-			ContainsService: containsService,
-			ModelType:       modelType,
-		}
-		wjob.Job.Job.Action.Requirements = jobRequirements // Set the interpolated requirements on the job run only
+			// add requirements in job parameters, to use them as {{.job.requirement...}} in job
+			_, next = observability.Span(ctx, "workflow.prepareRequirementsToNodeJobRunParameters")
+			jobParams = append(jobParams, prepareRequirementsToNodeJobRunParameters(jobRequirements)...)
+			next()
 
-		if !stage.Enabled || !wjob.Job.Enabled {
-			wjob.Status = sdk.StatusDisabled.String()
-			skippedOrDisabledJobs++
-		} else if !conditionsOK {
-			wjob.Status = sdk.StatusSkipped.String()
-			skippedOrDisabledJobs++
-		}
+			if errGroups != nil {
+				return report, sdk.WrapError(errGroups, "addJobsToQueue> error on getJobExecutablesGroups")
+			}
 
-		if errParam != nil {
-			wjob.Status = sdk.StatusFail.String()
-			spawnInfos := sdk.SpawnMsg{
-				ID: sdk.MsgSpawnInfoJobError.ID,
+			//Create the job run
+			wjob := sdk.WorkflowNodeJobRun{
+				ProjectID:              wr.ProjectID,
+				WorkflowNodeRunID:      run.ID,
+				Start:                  time.Time{},
+				Queued:                 time.Now(),
+				Status:                 sdk.StatusWaiting.String(),
+				Parameters:             jobParams,
+				ExecGroups:             groups,
+				PlatformPluginBinaries: platformPluginBinaries,
+				Job: sdk.ExecutedJob{
+					Job: *job,
+				},
+				Header:          run.Header,
+				ContainsService: containsService,
+				ModelType:       modelType,
+			}
+			wjob.Job.Job.Action.Requirements = jobRequirements // Set the interpolated requirements on the job run only
+			if matrix != nil {
+				wjob.Job.Job.Action.Name = fmt.Sprintf("%s (%s)", job.Action.Name, job.Matrix.CombinationName(matrix))
 			}
 
-			for _, e := range *errParam {
-				spawnInfos.Args = append(spawnInfos.Args, e.Error())
+			if !stage.Enabled || !wjob.Job.Enabled {
+				wjob.Status = sdk.StatusDisabled.String()
+				skippedOrDisabledJobs++
+			} else if !conditionsOK {
+				wjob.Status = sdk.StatusSkipped.String()
+				skippedOrDisabledJobs++
 			}
 
-			wjob.SpawnInfos = []sdk.SpawnInfo{sdk.SpawnInfo{
-				APITime:    time.Now(),
-				Message:    spawnInfos,
-				RemoteTime: time.Now(),
-			}}
-		}
+			if errParam != nil {
+				wjob.Status = sdk.StatusFail.String()
+				spawnInfos := sdk.SpawnMsg{
+					ID: sdk.MsgSpawnInfoJobError.ID,
+				}
+
+				for _, e := range *errParam {
+					spawnInfos.Args = append(spawnInfos.Args, e.Error())
+				}
+
+				wjob.SpawnInfos = []sdk.SpawnInfo{sdk.SpawnInfo{
+					APITime:    time.Now(),
+					Message:    spawnInfos,
+					RemoteTime: time.Now(),
+				}}
+			}
 
-		//Insert in database
-		_, next = observability.Span(ctx, "workflow.insertWorkflowNodeJobRun")
-		if err := insertWorkflowNodeJobRun(db, &wjob); err != nil {
+			//Insert in database
+			_, next = observability.Span(ctx, "workflow.insertWorkflowNodeJobRun")
+			if err := insertWorkflowNodeJobRun(db, &wjob); err != nil {
+				next()
+				return report, sdk.WrapError(err, "Unable to insert in table workflow_node_run_job")
+			}
 			next()
-			return report, sdk.WrapError(err, "Unable to insert in table workflow_node_run_job")
-		}
-		next()
 
-		//Put the job run in database
-		stage.RunJobs = append(stage.RunJobs, wjob)
+			//Put the job run in database
+			stage.RunJobs = append(stage.RunJobs, wjob)
+			nbJobRuns++
 
-		report.Add(wjob)
+			report.Add(wjob)
+		}
 	}
 
-	if skippedOrDisabledJobs == len(stage.Jobs) {
+	if skippedOrDisabledJobs == nbJobRuns {
 		stage.Status = sdk.StatusSkipped
 	}
 
@@ -792,24 +834,8 @@ func stopWorkflowNodeJobRun(ctx context.Context, dbFunc func() *gorp.DbMap, stor
 			return report
 		}
 
-		njr, errNRJ := LoadAndLockNodeJobRunWait(tx, store, njrID)
-		if errNRJ != nil {
-			chanErr <- sdk.WrapError(errNRJ, "StopWorkflowNodeRun> Cannot load node job run id")
-			tx.Rollback()
-			wg.Done()
-			return report
-		}
-
-		if err := AddSpawnInfosNodeJobRun(tx, njr.ID, []sdk.SpawnInfo{stopInfos}); err != nil {
-			chanErr <- sdk.WrapError(err, "Cannot save spawn info job %d", njr.ID)
-			tx.Rollback()
-			wg.Done()
-			return report
-		}
-
-		njr.SpawnInfos = append(njr.SpawnInfos, stopInfos)
-		if _, err := report.Merge(UpdateNodeJobRunStatus(ctx, dbFunc, tx, store, proj, njr, sdk.StatusStopped)); err != nil {
-			chanErr <- sdk.WrapError(err, "Cannot update node job run")
+		if _, err := report.Merge(stopNodeJobRun(ctx, dbFunc, tx, store, proj, njrID, stopInfos)); err != nil {
+			chanErr <- err
 			tx.Rollback()
 			wg.Done()
 			return report
@@ -827,6 +853,25 @@ func stopWorkflowNodeJobRun(ctx context.Context, dbFunc func() *gorp.DbMap, stor
 	return report
 }
 
+// stopNodeJobRun stops a node job run, the worker is notified by the stopped status of its job
+func stopNodeJobRun(ctx context.Context, dbFunc func() *gorp.DbMap, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, njrID int64, stopInfos sdk.SpawnInfo) (*ProcessorReport, error) {
+	njr, errNRJ := LoadAndLockNodeJobRunWait(db, store, njrID)
+	if errNRJ != nil {
+		return nil, sdk.WrapError(errNRJ, "StopWorkflowNodeRun> Cannot load node job run id")
+	}
+
+	if err := AddSpawnInfosNodeJobRun(db, njr.ID, []sdk.SpawnInfo{stopInfos}); err != nil {
+		return nil, sdk.WrapError(err, "Cannot save spawn info job %d", njr.ID)
+	}
+
+	njr.SpawnInfos = append(njr.SpawnInfos, stopInfos)
+	report, err := UpdateNodeJobRunStatus(ctx, dbFunc, db, store, proj, njr, sdk.StatusStopped)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Cannot update node job run")
+	}
+	return report, nil
+}
+
 // SyncNodeRunRunJob sync step status and spawnInfos in a specific run job
 func SyncNodeRunRunJob(ctx context.Context, db gorp.SqlExecutor, nodeRun *sdk.WorkflowNodeRun, nodeJobRun sdk.WorkflowNodeJobRun) (bool, error) {
 	var end func()
@@ -870,7 +915,7 @@ func (i vcsInfos) String() string {
 	return fmt.Sprintf("%s:%s:%s:%s", i.Server, i.Repository, i.Branch, i.Hash)
 }
 
-func getVCSInfos(ctx context.Context, db gorp.SqlExecutor, store cache.Store, vcsServer *sdk.ProjectVCSServer, gitValues map[string]string, applicationName, applicationVCSServer, applicationRepositoryFullname string, isChildNode bool, previousGitRepo string) (i vcsInfos, err error) {
+func getVCSInfos(ctx context.Context, db gorp.SqlExecutor, store cache.Store, projectKey string, vcsServer *sdk.ProjectVCSServer, gitValues map[string]string, applicationName, applicationVCSServer, applicationRepositoryFullname string, isChildNode bool, previousGitRepo string) (i vcsInfos, err error) {
 	var vcsInfos vcsInfos
 	vcsInfos.Repository = gitValues[tagGitRepository]
 	vcsInfos.Branch = gitValues[tagGitBranch]
@@ -912,7 +957,7 @@ func getVCSInfos(ctx context.Context, db gorp.SqlExecutor, store cache.Store, vc
 	}()
 
 	//Get the RepositoriesManager Client
-	client, errclient := repositoriesmanager.AuthorizedClient(ctx, db, store, vcsServer)
+	client, errclient := repositoriesmanager.AuthorizedClient(ctx, db, store, projectKey, vcsServer)
 	if errclient != nil {
 		return vcsInfos, sdk.WrapError(errclient, "computeVCSInfos> Cannot get client")
 	}
@@ -1017,3 +1062,315 @@ func getVCSInfos(ctx context.Context, db gorp.SqlExecutor, store cache.Store, vc
 
 	return vcsInfos, nil
 }
+
+// getNodeRunContext returns the run context of the node of a node run, with its approval gate and its concurrency group
+func getNodeRunContext(wr *sdk.WorkflowRun, nodeRun *sdk.WorkflowNodeRun) (nodeRunContext, *sdk.WorkflowNodeApproval, *sdk.WorkflowNodeConcurrency) {
+	runContext := nodeRunContext{}
+	if wr.Version < 2 {
+		n := wr.Workflow.GetNode(nodeRun.WorkflowNodeID)
+		if n == nil || n.Context == nil {
+			return runContext, nil, nil
+		}
+		if pip, has := wr.Workflow.Pipelines[n.PipelineID]; has {
+			runContext.Pipeline = pip
+		}
+		if app, has := n.Application(); has {
+			runContext.Application = app
+		}
+		if env, has := n.Environment(); has {
+			runContext.Environment = env
+		}
+		if pp, has := n.ProjectPlatform(); has {
+			runContext.ProjectPlatform = pp
+		}
+		return runContext, n.Context.Approval, n.Context.Concurrency
+	}
+
+	n := wr.Workflow.WorkflowData.NodeByID(nodeRun.WorkflowNodeID)
+	if n == nil || n.Context == nil {
+		return runContext, nil, nil
+	}
+	if pip, has := wr.Workflow.Pipelines[n.Context.PipelineID]; has {
+		runContext.Pipeline = pip
+	}
+	if app, has := wr.Workflow.Applications[n.Context.ApplicationID]; has {
+		runContext.Application = app
+	}
+	if env, has := wr.Workflow.Environments[n.Context.EnvironmentID]; has {
+		runContext.Environment = env
+	}
+	if pp, has := wr.Workflow.ProjectPlatforms[n.Context.ProjectPlatformID]; has {
+		runContext.ProjectPlatform = pp
+	}
+	return runContext, n.Context.Approval, n.Context.Concurrency
+}
+
+// nodeRunMutexLocked returns true if the node has a mutex and another run of the node is building
+func nodeRunMutexLocked(db gorp.SqlExecutor, wr *sdk.WorkflowRun, nodeRun *sdk.WorkflowNodeRun) (bool, error) {
+	var hasMutex bool
+	if wr.Version < 2 {
+		n := wr.Workflow.GetNode(nodeRun.WorkflowNodeID)
+		hasMutex = n != nil && n.Context != nil && n.Context.Mutex
+	} else {
+		n := wr.Workflow.WorkflowData.NodeByID(nodeRun.WorkflowNodeID)
+		hasMutex = n != nil && n.Context != nil && n.Context.Mutex
+	}
+	if !hasMutex {
+		return false, nil
+	}
+
+	mutexQuery := `select count(1)
+	from workflow_node_run
+	join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+	where workflow_run.workflow_id = $1
+	and workflow_node_run.id <> $2
+	and workflow_node_run.workflow_node_name = $3
+	and workflow_node_run.status = $4`
+	nbMutex, err := db.SelectInt(mutexQuery, wr.WorkflowID, nodeRun.ID, nodeRun.WorkflowNodeName, string(sdk.StatusBuilding))
+	if err != nil {
+		return false, sdk.WrapError(err, "unable to check mutexes")
+	}
+	return nbMutex > 0, nil
+}
+
+// isQueuedInConcurrencyGroup returns true if the node run is waiting for a free slot in its concurrency group
+func isQueuedInConcurrencyGroup(nr *sdk.WorkflowNodeRun) bool {
+	return nr.ConcurrencyGroup != "" && nr.Status == sdk.StatusWaiting.String() && len(nr.Stages) > 0 && nr.Stages[0].Status.String() == ""
+}
+
+// lockConcurrencyGroup applies the policy of the concurrency group of a node run not started yet,
+// then returns true if the group has no free slot for it.
+// Runs of a group are executed in order: a node run waits for the older pending runs of its group.
+func lockConcurrencyGroup(ctx context.Context, db gorp.SqlExecutor, store cache.Store, wr *sdk.WorkflowRun, nr *sdk.WorkflowNodeRun, concurrency sdk.WorkflowNodeConcurrency) (*ProcessorReport, bool, error) {
+	var end func()
+	ctx, end = observability.Span(ctx, "workflow.lockConcurrencyGroup",
+		observability.Tag(observability.TagWorkflowNodeRun, nr.ID),
+		observability.Tag("concurrency_group", concurrency.Group),
+	)
+	defer end()
+
+	report := new(ProcessorReport)
+
+	if err := lockConcurrencyGroupSlots(db, wr.ProjectID, nr.ConcurrencyGroup); err != nil {
+		return nil, false, err
+	}
+
+	var cancelQuery string
+	var cancelArgs []interface{}
+	switch concurrency.Policy {
+	case sdk.ConcurrencyPolicyCancelPending:
+		cancelQuery = `select workflow_node_run.id
+		from workflow_node_run
+		join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+		where workflow_run.project_id = $1
+		and workflow_node_run.concurrency_group = $2
+		and workflow_node_run.id < $3
+		and workflow_node_run.workflow_run_id <> $4
+		and workflow_node_run.status = $5
+		order by workflow_node_run.id`
+		cancelArgs = []interface{}{wr.ProjectID, nr.ConcurrencyGroup, nr.ID, wr.ID, sdk.StatusWaiting.String()}
+	case sdk.ConcurrencyPolicyCancelInProgress:
+		cancelQuery = `select workflow_node_run.id
+		from workflow_node_run
+		join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+		where workflow_run.project_id = $1
+		and workflow_node_run.concurrency_group = $2
+		and workflow_node_run.id < $3
+		and workflow_node_run.workflow_run_id <> $4
+		and workflow_node_run.status in ($5, $6)
+		and workflow_node_run.vcs_repository = $7
+		and workflow_node_run.vcs_branch = $8
+		and workflow_node_run.vcs_hash <> $9
+		order by workflow_node_run.id`
+		cancelArgs = []interface{}{wr.ProjectID, nr.ConcurrencyGroup, nr.ID, wr.ID, sdk.StatusWaiting.String(), sdk.StatusBuilding.String(), nr.VCSRepository, nr.VCSBranch, nr.VCSHash}
+	}
+
+	if cancelQuery != "" && (concurrency.Policy != sdk.ConcurrencyPolicyCancelInProgress || nr.VCSBranch != "") {
+		var ids []int64
+		if _, err := db.Select(&ids, cancelQuery, cancelArgs...); err != nil {
+			return nil, false, sdk.WrapError(err, "unable to load node runs to cancel in concurrency group %s", nr.ConcurrencyGroup)
+		}
+		for _, id := range ids {
+			r1, err := cancelNodeRunInConcurrencyGroup(ctx, db, store, wr, id, concurrency)
+			if err != nil {
+				return nil, false, err
+			}
+			_, _ = report.Merge(r1, nil)
+		}
+	}
+
+	// Older pending or building runs and newer building runs of the group use its slots
+	countQuery := `select count(1)
+	from workflow_node_run
+	join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+	where workflow_run.project_id = $1
+	and workflow_node_run.concurrency_group = $2
+	and workflow_node_run.id <> $3
+	and ((workflow_node_run.id < $3 and workflow_node_run.status = $4) or workflow_node_run.status = $5)`
+	nb, err := db.SelectInt(countQuery, wr.ProjectID, nr.ConcurrencyGroup, nr.ID, sdk.StatusWaiting.String(), sdk.StatusBuilding.String())
+	if err != nil {
+		return nil, false, sdk.WrapError(err, "unable to count node runs in concurrency group %s", nr.ConcurrencyGroup)
+	}
+	if nb >= concurrency.MaxRunning() {
+		log.Debug("workflow.lockConcurrencyGroup> node run %d is queued in concurrency group %s (%d/%d)", nr.ID, nr.ConcurrencyGroup, nb, concurrency.MaxRunning())
+		return report, true, nil
+	}
+	return report, false, nil
+}
+
+// lockConcurrencyGroupSlots serializes the computation of the free slots of a concurrency group until the end of the transaction
+func lockConcurrencyGroupSlots(db gorp.SqlExecutor, projectID int64, group string) error {
+	if _, err := db.Exec("select pg_advisory_xact_lock(hashtext($1))", fmt.Sprintf("%d:%s", projectID, group)); err != nil {
+		return sdk.WrapError(err, "unable to lock concurrency group %s", group)
+	}
+	return nil
+}
+
+// cancelNodeRunInConcurrencyGroup stops a node run of another workflow run, superseded by a newer run of its concurrency group.
+// Its jobs are stopped like from StopWorkflowNodeRun, in the current transaction, so the workers are stopped and the mutex released.
+func cancelNodeRunInConcurrencyGroup(ctx context.Context, db gorp.SqlExecutor, store cache.Store, by *sdk.WorkflowRun, id int64, concurrency sdk.WorkflowNodeConcurrency) (*ProcessorReport, error) {
+	report := new(ProcessorReport)
+
+	nr, err := LoadNodeRunByID(db, id, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load node run %d", id)
+	}
+	// Only the pending runs are cancelled with the cancel-pending policy
+	if concurrency.Policy == sdk.ConcurrencyPolicyCancelPending && !isQueuedInConcurrencyGroup(nr) {
+		return report, nil
+	}
+
+	stopInfos := sdk.SpawnInfo{
+		APITime: time.Now(),
+		Message: sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeConcurrencyCancelled.ID,
+			Args: []interface{}{nr.WorkflowNodeName, by.Workflow.Name, by.Number, nr.ConcurrencyGroup},
+		},
+	}
+
+	ids, err := LoadNodeJobRunIDByNodeRunID(db, nr.ID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load node job run ids of node run %d", nr.ID)
+	}
+	// No project is given to not release the concurrency group which is being computed
+	for _, njrID := range ids {
+		r1, err := stopNodeJobRun(ctx, nil, db, store, nil, njrID, stopInfos)
+		if err != nil {
+			return nil, err
+		}
+		_, _ = report.Merge(r1, nil)
+	}
+
+	// The node run is stopped by its last job, pending node runs have no job
+	nr, err = LoadNodeRunByID(db, id, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load node run %d", id)
+	}
+	if !sdk.StatusIsTerminated(nr.Status) {
+		stopWorkflowNodeRunStages(nr)
+		nr.Status = sdk.StatusStopped.String()
+		nr.Done = time.Now()
+		if err := UpdateNodeRun(db, nr); err != nil {
+			return nil, sdk.WrapError(err, "unable to update node run %d", nr.ID)
+		}
+	}
+	report.Add(*nr)
+
+	wr, err := LoadRunByID(db, nr.WorkflowRunID, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load workflow run %d", nr.WorkflowRunID)
+	}
+	AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeConcurrencyCancelled.ID,
+		Args: []interface{}{nr.WorkflowNodeName, by.Workflow.Name, by.Number, nr.ConcurrencyGroup},
+	})
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, sdk.WrapError(err, "unable to update workflow run %d", wr.ID)
+	}
+
+	oldStatus := wr.Status
+	r1, err := computeAndUpdateWorkflowRunStatus(ctx, db, wr)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to compute workflow run %d status", wr.ID)
+	}
+	_, _ = report.Merge(r1, nil)
+	if wr.Status != oldStatus {
+		report.Add(*wr)
+	}
+	log.Info("workflow.cancelNodeRunInConcurrencyGroup> node run %d cancelled by workflow %s #%d in concurrency group %s", nr.ID, by.Workflow.Name, by.Number, nr.ConcurrencyGroup)
+	return report, nil
+}
+
+// releaseConcurrencyGroup executes the oldest pending runs of a concurrency group while it has free slots
+func releaseConcurrencyGroup(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, group string, releasedBy int64) (*ProcessorReport, error) {
+	var end func()
+	ctx, end = observability.Span(ctx, "workflow.releaseConcurrencyGroup",
+		observability.Tag("concurrency_group", group),
+	)
+	defer end()
+
+	report := new(ProcessorReport)
+
+	query := `select workflow_node_run.id
+	from workflow_node_run
+	join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+	where workflow_run.project_id = $1
+	and workflow_node_run.concurrency_group = $2
+	and workflow_node_run.id <> $3
+	and workflow_node_run.status = $4
+	order by workflow_node_run.id
+	limit 10`
+	var ids []int64
+	if _, err := db.Select(&ids, query, proj.ID, group, releasedBy, sdk.StatusWaiting.String()); err != nil {
+		return nil, sdk.WrapError(err, "unable to load pending node runs of concurrency group %s", group)
+	}
+
+	for _, id := range ids {
+		nr, err := LoadNodeRunByID(db, id, LoadRunOptions{})
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to load node run %d", id)
+		}
+		if !isQueuedInConcurrencyGroup(nr) {
+			continue
+		}
+		wr, err := LoadRunByID(db, nr.WorkflowRunID, LoadRunOptions{})
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to load workflow run %d", nr.WorkflowRunID)
+		}
+		runContext, _, concurrency := getNodeRunContext(wr, nr)
+		if concurrency == nil {
+			continue
+		}
+		locked, err := nodeRunMutexLocked(db, wr, nr)
+		if err != nil {
+			return nil, err
+		}
+		if locked {
+			continue
+		}
+		r1, locked, err := lockConcurrencyGroup(ctx, db, store, wr, nr, *concurrency)
+		if err != nil {
+			return nil, err
+		}
+		_, _ = report.Merge(r1, nil)
+		// The next runs of the group are queued behind this one
+		if locked {
+			break
+		}
+
+		AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeConcurrencyRelease.ID,
+			Args: []interface{}{nr.WorkflowNodeName, group},
+		})
+		if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+			return nil, sdk.WrapError(err, "unable to update workflow run %d after concurrency group release", wr.ID)
+		}
+
+		log.Debug("workflow.releaseConcurrencyGroup> process the node run %d released by concurrency group %s", nr.ID, group)
+		report, err = report.Merge(execute(ctx, db, store, proj, nr, runContext))
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to execute node run %d", nr.ID)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,4 @@
+package workflow
+
+// FailTimedOutJob exposes failTimedOutJob to the tests of the workflow_test package
+var FailTimedOutJob = failTimedOutJob
@@ -77,6 +77,8 @@ type NodeRun struct {
 	HookExecutionTimestamp sql.NullInt64  `db:"hook_execution_timestamp"`
 	ExecutionID            sql.NullString `db:"execution_id"`
 	Callback               sql.NullString `db:"callback"`
+	Approvals              sql.NullString `db:"approvals"`
+	ConcurrencyGroup       sql.NullString `db:"concurrency_group"`
 }
 
 // JobRun is a gorp wrapper around sdk.WorkflowNodeJobRun
@@ -2,6 +2,8 @@ package workflow
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/go-gorp/gorp"
 
@@ -28,17 +30,31 @@ func restartDeadJob(ctx context.Context, DBFunc func() *gorp.DbMap, store cache.
 		}
 
 		if deadJob.Retry >= maxRetry {
-			if _, err := UpdateNodeJobRunStatus(ctx, DBFunc, tx, store, nil, &deadJob, sdk.StatusStopped); err != nil {
-				log.Error("restartDeadJob> Cannot update node run job %d : %v", deadJob.ID, err)
+			infos := []sdk.SpawnInfo{{
+				RemoteTime: time.Now(),
+				Message:    sdk.SpawnMsg{ID: sdk.MsgSpawnInfoWorkerDisappeared.ID, Args: []interface{}{deadJob.Retry}},
+			}}
+			if err := AddSpawnInfosNodeJobRun(tx, deadJob.ID, infos); err != nil {
+				log.Error("restartDeadJob> Cannot save spawn info on node run job %d : %v", deadJob.ID, err)
 				_ = tx.Rollback()
 				continue
 			}
 
-			if err := DeleteNodeJobRuns(tx, deadJob.WorkflowNodeRunID); err != nil {
-				log.Error("restartDeadJob> Cannot delete node run job %d : %v", deadJob.ID, err)
+			res := sdk.Result{Status: sdk.StatusFail.String(), Reason: "Worker disappeared"}
+			if _, err := UpdateNodeJobRunResult(ctx, DBFunc, tx, store, nil, &deadJob, res); err != nil {
+				log.Error("restartDeadJob> Cannot update node run job %d : %v", deadJob.ID, err)
 				_ = tx.Rollback()
 				continue
 			}
+
+			// A job put back in queue by its retry policy is kept
+			if deadJob.Status != sdk.StatusWaiting.String() {
+				if err := DeleteNodeJobRuns(tx, deadJob.WorkflowNodeRunID); err != nil {
+					log.Error("restartDeadJob> Cannot delete node run job %d : %v", deadJob.ID, err)
+					_ = tx.Rollback()
+					continue
+				}
+			}
 		} else {
 			if err := RestartWorkflowNodeJob(ctx, tx, deadJob); err != nil {
 				log.Warning("restartDeadJob> Cannot restart node job run %d: %v", deadJob.ID, err)
@@ -54,3 +70,52 @@ func restartDeadJob(ctx context.Context, DBFunc func() *gorp.DbMap, store cache.
 
 	return nil
 }
+
+// failTimedOutJob fails all jobs which are building for longer than their timeout
+func failTimedOutJob(ctx context.Context, DBFunc func() *gorp.DbMap, store cache.Store) error {
+	db := DBFunc()
+	timedOutJobs, err := LoadTimedOutNodeJobRun(db, store)
+	if err != nil {
+		return sdk.WrapError(err, "Cannot load timed out node job run")
+	}
+
+	for _, timedOutJob := range timedOutJobs {
+		tx, errTx := db.Begin()
+		if errTx != nil {
+			log.Error("failTimedOutJob> Cannot create transaction : %v", errTx)
+			continue
+		}
+
+		njr, errL := LoadAndLockNodeJobRunNoWait(ctx, tx, store, timedOutJob.ID)
+		if errL != nil {
+			// The job is locked by the worker which is sending its result
+			log.Debug("failTimedOutJob> Cannot lock node run job %d : %v", timedOutJob.ID, errL)
+			_ = tx.Rollback()
+			continue
+		}
+
+		infos := []sdk.SpawnInfo{{
+			RemoteTime: time.Now(),
+			Message:    sdk.SpawnMsg{ID: sdk.MsgSpawnInfoJobTimeout.ID, Args: []interface{}{njr.Job.Action.TimeoutDuration().String()}},
+		}}
+		if err := AddSpawnInfosNodeJobRun(tx, njr.ID, infos); err != nil {
+			log.Error("failTimedOutJob> Cannot save spawn info on node run job %d : %v", njr.ID, err)
+			_ = tx.Rollback()
+			continue
+		}
+
+		njr.SpawnInfos = append(njr.SpawnInfos, infos...)
+		res := sdk.Result{Status: sdk.StatusFail.String(), Reason: fmt.Sprintf("Timeout after %s", njr.Job.Action.TimeoutDuration())}
+		if _, err := UpdateNodeJobRunResult(ctx, DBFunc, tx, store, nil, njr, res); err != nil {
+			log.Error("failTimedOutJob> Cannot update node run job %d : %v", njr.ID, err)
+			_ = tx.Rollback()
+			continue
+		}
+
+		if err := tx.Commit(); err != nil {
+			log.Error("failTimedOutJob> Cannot commit transaction : %v", err)
+		}
+	}
+
+	return nil
+}
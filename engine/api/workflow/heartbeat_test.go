@@ -0,0 +1,112 @@
+package workflow_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/bootstrap"
+	"github.com/ovh/cds/engine/api/pipeline"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestFailTimedOutJobWithRetry(t *testing.T) {
+	db, cache, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+	u, _ := assets.InsertAdminUser(db)
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, cache, key, key, u)
+	ctx := context.Background()
+
+	pip := sdk.Pipeline{
+		ProjectID:  proj.ID,
+		ProjectKey: proj.Key,
+		Name:       "pip1",
+		Type:       sdk.BuildPipeline,
+	}
+	test.NoError(t, pipeline.InsertPipeline(db, cache, proj, &pip, u))
+
+	s := sdk.NewStage("stage 1")
+	s.Enabled = true
+	s.PipelineID = pip.ID
+	pipeline.InsertStage(db, s)
+	j := &sdk.Job{
+		Enabled: true,
+		Action: sdk.Action{
+			Enabled: true,
+		},
+	}
+	pipeline.InsertJob(db, j, s.ID, &pip)
+
+	proj, _ = project.LoadByID(db, cache, proj.ID, u, project.LoadOptions.WithApplications, project.LoadOptions.WithPipelines, project.LoadOptions.WithEnvironments, project.LoadOptions.WithGroups)
+
+	w := sdk.Workflow{
+		Name:       "test_1",
+		ProjectID:  proj.ID,
+		ProjectKey: proj.Key,
+		WorkflowData: &sdk.WorkflowData{
+			Node: sdk.Node{
+				Name: "node1",
+				Ref:  "node1",
+				Type: sdk.NodeTypePipeline,
+				Context: &sdk.NodeContext{
+					PipelineID: pip.ID,
+				},
+			},
+		},
+	}
+
+	(&w).RetroMigrate()
+	test.NoError(t, workflow.Insert(db, cache, &w, proj, u))
+	w1, err := workflow.Load(ctx, db, cache, proj, "test_1", u, workflow.LoadOptions{
+		DeepPipeline: true,
+	})
+	test.NoError(t, err)
+
+	_, _, err = workflow.ManualRun(ctx, db, cache, proj, w1, &sdk.WorkflowNodeRunManual{User: *u}, nil)
+	test.NoError(t, err)
+
+	lastrun, err := workflow.LoadLastRun(db, proj.Key, "test_1", workflow.LoadRunOptions{})
+	test.NoError(t, err)
+	nodeRun := lastrun.WorkflowNodeRuns[w1.RootID][0]
+	if !assert.Len(t, nodeRun.Stages[0].RunJobs, 1) {
+		t.FailNow()
+	}
+
+	// The job is building for longer than its timeout, with a retry policy allowing one more attempt
+	job, err := workflow.LoadNodeJobRun(db, cache, nodeRun.Stages[0].RunJobs[0].ID)
+	test.NoError(t, err)
+	job.Status = sdk.StatusBuilding.String()
+	job.Start = time.Now().Add(-time.Hour)
+	job.Job.Action.Timeout = 60
+	job.Job.RetryPolicy = &sdk.JobRetryPolicy{MaxAttempts: 2}
+	test.NoError(t, workflow.UpdateNodeJobRun(ctx, db, job))
+
+	dbFunc := func() *gorp.DbMap { return db }
+	test.NoError(t, workflow.FailTimedOutJob(ctx, dbFunc, cache))
+
+	job, err = workflow.LoadNodeJobRun(db, cache, job.ID)
+	test.NoError(t, err)
+	assert.Equal(t, sdk.StatusWaiting.String(), job.Status)
+	if assert.Len(t, job.Job.PreviousAttempts, 1) {
+		assert.Equal(t, sdk.StatusFail.String(), job.Job.PreviousAttempts[0].Status)
+		assert.Equal(t, "Timeout after 1m0s", job.Job.PreviousAttempts[0].Reason)
+	}
+
+	// The last attempt fails the job
+	job.Status = sdk.StatusBuilding.String()
+	job.Start = time.Now().Add(-time.Hour)
+	test.NoError(t, workflow.UpdateNodeJobRun(ctx, db, job))
+	test.NoError(t, workflow.FailTimedOutJob(ctx, dbFunc, cache))
+
+	nodeRunAfter, err := workflow.LoadNodeRunByID(db, nodeRun.ID, workflow.LoadRunOptions{})
+	test.NoError(t, err)
+	assert.Equal(t, sdk.StatusFail.String(), nodeRunAfter.Status)
+}
@@ -170,7 +170,7 @@ func DeleteHookConfiguration(ctx context.Context, db gorp.SqlExecutor, store cac
 			// Call VCS to know if repository allows webhook and get the configuration fields
 			projectVCSServer := repositoriesmanager.GetProjectVCSServer(p, h.Config["vcsServer"].Value)
 			if projectVCSServer != nil {
-				client, errclient := repositoriesmanager.AuthorizedClient(ctx, db, store, projectVCSServer)
+				client, errclient := repositoriesmanager.AuthorizedClient(ctx, db, store, p.Key, projectVCSServer)
 				if errclient != nil {
 					return sdk.WrapError(errclient, "deleteHookConfiguration> Cannot get vcs client")
 				}
@@ -213,7 +213,7 @@ func createVCSConfiguration(ctx context.Context, db gorp.SqlExecutor, store cach
 		return nil
 	}
 
-	client, errclient := repositoriesmanager.AuthorizedClient(ctx, db, store, projectVCSServer)
+	client, errclient := repositoriesmanager.AuthorizedClient(ctx, db, store, p.Key, projectVCSServer)
 	if errclient != nil {
 		return sdk.WrapError(errclient, "createVCSConfiguration> Cannot get vcs client")
 	}
@@ -317,7 +317,7 @@ func DefaultPayload(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 		defaultBranch := "master"
 		projectVCSServer := repositoriesmanager.GetProjectVCSServer(p, wf.Root.Context.Application.VCSServer)
 		if projectVCSServer != nil {
-			client, errclient := repositoriesmanager.AuthorizedClient(ctx, db, store, projectVCSServer)
+			client, errclient := repositoriesmanager.AuthorizedClient(ctx, db, store, p.Key, projectVCSServer)
 			if errclient != nil {
 				return wf.Root.Context.DefaultPayload, sdk.WrapError(errclient, "DefaultPayload> Cannot get authorized client")
 			}
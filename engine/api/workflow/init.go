@@ -34,6 +34,9 @@ func Initialize(c context.Context, DBFunc func() *gorp.DbMap, store cache.Store,
 			if err := restartDeadJob(c, DBFunc, store); err != nil {
 				log.Warning("workflow.restartDeadJob> Error on restartDeadJob : %v", err)
 			}
+			if err := failTimedOutJob(c, DBFunc, store); err != nil {
+				log.Warning("workflow.failTimedOutJob> Error on failTimedOutJob : %v", err)
+			}
 		case <-tickStop.C:
 			if err := stopRunsBlocked(db); err != nil {
 				log.Warning("workflow.stopRunsBlocked> Error on stopRunsBlocked : %v", err)
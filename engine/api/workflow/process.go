@@ -765,7 +765,7 @@ func processWorkflowNodeRun(ctx context.Context, db gorp.SqlExecutor, store cach
 	var vcsInfos vcsInfos
 	var errVcs error
 	vcsServer := repositoriesmanager.GetProjectVCSServer(p, app.VCSServer)
-	vcsInfos, errVcs = getVCSInfos(ctx, db, store, vcsServer, gitValues, app.Name, app.VCSServer, app.RepositoryFullname, !isRoot, previousGitValues[tagGitRepository])
+	vcsInfos, errVcs = getVCSInfos(ctx, db, store, p.Key, vcsServer, gitValues, app.Name, app.VCSServer, app.RepositoryFullname, !isRoot, previousGitValues[tagGitRepository])
 	if errVcs != nil {
 		if strings.Contains(errVcs.Error(), "branch has been deleted") {
 			AddWorkflowRunInfo(w, true, sdk.SpawnMsg{
@@ -807,12 +807,12 @@ func processWorkflowNodeRun(ctx context.Context, db gorp.SqlExecutor, store cach
 			return report, false, sdk.WrapError(sdk.ErrWorkflowNodeNotFound, "processWorkflowNodeRun> Unable to find node %d", hook.WorkflowNodeID)
 		}
 
-		if !checkNodeRunCondition(w, dest.Context.Conditions, params) {
+		if !checkNodeRunCondition(w, dest.Context.Conditions, params, run.Payload) {
 			log.Debug("processWorkflowNodeRun> Avoid trigger workflow from hook %s", hook.UUID)
 			return report, false, nil
 		}
 	} else {
-		if !checkNodeRunCondition(w, n.Context.Conditions, run.BuildParameters) {
+		if !checkNodeRunCondition(w, n.Context.Conditions, run.BuildParameters, run.Payload) {
 			log.Debug("processWorkflowNodeRun> Condition failed %d/%d", w.ID, n.ID)
 			return report, false, nil
 		}
@@ -852,6 +852,20 @@ func processWorkflowNodeRun(ctx context.Context, db gorp.SqlExecutor, store cach
 		}
 	}
 
+	//The node run will be executed according to its concurrency group
+	if n.Context != nil && n.Context.Concurrency != nil {
+		run.ConcurrencyGroup = n.Context.Concurrency.Group
+	}
+
+	//Check the context.approval, the node run will wait for its approvals
+	if n.Context != nil && n.Context.Approval != nil && run.Status == sdk.StatusWaiting.String() {
+		run.Status = string(sdk.StatusWaitingApproval)
+		AddWorkflowRunInfo(w, false, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeWaitingApproval.ID,
+			Args: []interface{}{n.Name, n.Context.Approval.Required(), strings.Join(n.Context.Approval.Groups, ", ")},
+		})
+	}
+
 	if err := insertWorkflowNodeRun(db, run); err != nil {
 		return report, true, sdk.WrapError(err, "unable to insert run (node id : %d, node name : %s, subnumber : %d)", run.WorkflowNodeID, run.WorkflowNodeName, run.SubNumber)
 	}
@@ -887,6 +901,12 @@ func processWorkflowNodeRun(ctx context.Context, db gorp.SqlExecutor, store cach
 		return report, true, sdk.WrapError(err, "unable to update workflow run")
 	}
 
+	//The node run will be executed once approved
+	if run.Status == sdk.StatusWaitingApproval.String() {
+		log.Debug("processWorkflowNodeRun> Noderun %s processed but not executed because it's waiting for approval", n.Name)
+		return report, true, nil
+	}
+
 	//Check the context.mutex to know if we are allowed to run it
 	if n.Context.Mutex {
 		//Check if there are builing workflownoderun with the same workflow_node_name for the same workflow
@@ -925,6 +945,17 @@ func processWorkflowNodeRun(ctx context.Context, db gorp.SqlExecutor, store cach
 		return report, true, sdk.WrapError(err, "unable to execute workflow run")
 	}
 	_, _ = report.Merge(r1, nil)
+
+	//Concurrency group is full: the node run will be executed when a slot is released
+	if isQueuedInConcurrencyGroup(run) {
+		AddWorkflowRunInfo(w, false, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeConcurrencyQueued.ID,
+			Args: []interface{}{n.Name, run.ConcurrencyGroup},
+		})
+		if err := UpdateWorkflowRun(ctx, db, w); err != nil {
+			return report, true, sdk.WrapError(err, "unable to update workflow run")
+		}
+	}
 	return report, true, nil
 }
 
@@ -945,13 +976,12 @@ func setValuesGitInBuildParameters(run *sdk.WorkflowNodeRun, vcsInfos vcsInfos)
 	sdk.ParameterAddOrSetValue(&run.BuildParameters, tagGitHTTPURL, sdk.StringParameter, vcsInfos.HTTPUrl)
 }
 
-func checkNodeRunCondition(wr *sdk.WorkflowRun, conditions sdk.WorkflowNodeConditions, params []sdk.Parameter) bool {
+func checkNodeRunCondition(wr *sdk.WorkflowRun, conditions sdk.WorkflowNodeConditions, params []sdk.Parameter, payload interface{}) bool {
 
 	var conditionsOK bool
 	var errc error
-	if conditions.LuaScript == "" {
-		conditionsOK, errc = sdk.WorkflowCheckConditions(conditions.PlainConditions, params)
-	} else {
+	switch {
+	case conditions.LuaScript != "":
 		luacheck, err := luascript.NewCheck()
 		if err != nil {
 			log.Warning("processWorkflowNodeRun> WorkflowCheckConditions error: %s", err)
@@ -963,18 +993,49 @@ func checkNodeRunCondition(wr *sdk.WorkflowRun, conditions sdk.WorkflowNodeCondi
 		luacheck.SetVariables(sdk.ParametersToMap(params))
 		errc = luacheck.Perform(conditions.LuaScript)
 		conditionsOK = luacheck.Result
+	case conditions.Expression != "":
+		payloadParams, err := payloadConditionParameters(payload)
+		if err != nil {
+			errc = err
+			break
+		}
+		conditionsOK, errc = sdk.WorkflowCheckConditionsExpression(conditions.Expression, append(payloadParams, params...))
+	default:
+		conditionsOK, errc = sdk.WorkflowCheckConditions(conditions.PlainConditions, params)
 	}
 	if errc != nil {
 		log.Warning("processWorkflowNodeRun> WorkflowCheckConditions error: %s", errc)
 		AddWorkflowRunInfo(wr, true, sdk.SpawnMsg{
 			ID:   sdk.MsgWorkflowError.ID,
-			Args: []interface{}{fmt.Sprintf("Error on LUA Condition: %v", errc)},
+			Args: []interface{}{fmt.Sprintf("Error on Condition: %v", errc)},
 		})
 		return false
 	}
 	return conditionsOK
 }
 
+// payloadConditionParameters flattens the run payload as payload.* parameters for condition expressions
+func payloadConditionParameters(payload interface{}) ([]sdk.Parameter, error) {
+	if payload == nil {
+		return nil, nil
+	}
+	e := dump.NewDefaultEncoder(new(bytes.Buffer))
+	e.Formatters = []dump.KeyFormatterFunc{dump.WithDefaultLowerCaseFormatter()}
+	e.ExtraFields.DetailedMap = false
+	e.ExtraFields.DetailedStruct = false
+	e.ExtraFields.Len = false
+	e.ExtraFields.Type = false
+	m, err := e.ToStringMap(payload)
+	if err != nil {
+		return nil, sdk.WrapError(err, "payloadConditionParameters> do-dump error")
+	}
+	params := make([]sdk.Parameter, 0, len(m))
+	for k, v := range m {
+		sdk.AddParameter(&params, "payload."+k, sdk.StringParameter, v)
+	}
+	return params, nil
+}
+
 // AddWorkflowRunInfo add WorkflowRunInfo on a WorkflowRun
 func AddWorkflowRunInfo(run *sdk.WorkflowRun, isError bool, infos ...sdk.SpawnMsg) {
 	for _, i := range infos {
@@ -989,7 +1050,7 @@ func AddWorkflowRunInfo(run *sdk.WorkflowRun, isError bool, infos ...sdk.SpawnMs
 
 // computeRunStatus is useful to compute number of runs in success, building and fail
 type statusCounter struct {
-	success, building, failed, stoppped, skipped, disabled int
+	success, building, waitingApproval, failed, stoppped, skipped, disabled int
 }
 
 // getRunStatus return the status depending on number of runs in success, building, stopped and fail
@@ -997,6 +1058,8 @@ func getRunStatus(counter statusCounter) string {
 	switch {
 	case counter.building > 0:
 		return sdk.StatusBuilding.String()
+	case counter.waitingApproval > 0:
+		return sdk.StatusWaitingApproval.String()
 	case counter.failed > 0:
 		return sdk.StatusFail.String()
 	case counter.stoppped > 0:
@@ -1018,6 +1081,8 @@ func computeRunStatus(status string, counter *statusCounter) {
 		counter.success++
 	case sdk.StatusBuilding.String(), sdk.StatusWaiting.String():
 		counter.building++
+	case sdk.StatusWaitingApproval.String():
+		counter.waitingApproval++
 	case sdk.StatusFail.String():
 		counter.failed++
 	case sdk.StatusStopped.String():
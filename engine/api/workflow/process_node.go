@@ -344,7 +344,7 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 
 	var errVcs error
 	vcsServer := repositoriesmanager.GetProjectVCSServer(proj, app.VCSServer)
-	vcsInfos, errVcs = getVCSInfos(ctx, db, store, vcsServer, gitValues, app.Name, app.VCSServer, app.RepositoryFullname, !isRoot, previousGitValues[tagGitRepository])
+	vcsInfos, errVcs = getVCSInfos(ctx, db, store, proj.Key, vcsServer, gitValues, app.Name, app.VCSServer, app.RepositoryFullname, !isRoot, previousGitValues[tagGitRepository])
 	if errVcs != nil {
 		if strings.Contains(errVcs.Error(), "branch has been deleted") {
 			AddWorkflowRunInfo(wr, true, sdk.SpawnMsg{
@@ -385,12 +385,12 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 			return nil, false, sdk.WrapError(sdk.ErrWorkflowNodeNotFound, "Unable to find node %d", hook.NodeID)
 		}
 
-		if !checkNodeRunCondition(wr, dest.Context.Conditions, params) {
+		if !checkNodeRunCondition(wr, dest.Context.Conditions, params, run.Payload) {
 			log.Debug("Avoid trigger workflow from hook %s", hook.UUID)
 			return nil, false, nil
 		}
 	} else {
-		if !checkNodeRunCondition(wr, n.Context.Conditions, run.BuildParameters) {
+		if !checkNodeRunCondition(wr, n.Context.Conditions, run.BuildParameters, run.Payload) {
 			log.Debug("Condition failed %d/%d %+v", wr.ID, n.ID, run.BuildParameters)
 			return nil, false, nil
 		}
@@ -430,6 +430,20 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		}
 	}
 
+	//The node run will be executed according to its concurrency group
+	if n.Context.Concurrency != nil {
+		run.ConcurrencyGroup = n.Context.Concurrency.Group
+	}
+
+	//Check the context.approval, the node run will wait for its approvals
+	if n.Context.Approval != nil && run.Status == sdk.StatusWaiting.String() {
+		run.Status = string(sdk.StatusWaitingApproval)
+		AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeWaitingApproval.ID,
+			Args: []interface{}{n.Name, n.Context.Approval.Required(), strings.Join(n.Context.Approval.Groups, ", ")},
+		})
+	}
+
 	if err := insertWorkflowNodeRun(db, run); err != nil {
 		return nil, false, sdk.WrapError(err, "unable to insert run (node id : %d, node name : %s, subnumber : %d)", run.WorkflowNodeID, run.WorkflowNodeName, run.SubNumber)
 	}
@@ -465,6 +479,12 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		return nil, false, sdk.WrapError(err, "unable to update workflow run")
 	}
 
+	//The node run will be executed once approved
+	if run.Status == sdk.StatusWaitingApproval.String() {
+		log.Debug("Noderun %s processed but not executed because it's waiting for approval", n.Name)
+		return report, false, nil
+	}
+
 	//Check the context.mutex to know if we are allowed to run it
 	if n.Context.Mutex {
 		//Check if there are builing workflownoderun with the same workflow_node_name for the same workflow
@@ -503,5 +523,16 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		return nil, false, sdk.WrapError(err, "unable to execute workflow run")
 	}
 	_, _ = report.Merge(r1, nil)
+
+	//Concurrency group is full: the node run will be executed when a slot is released
+	if isQueuedInConcurrencyGroup(run) {
+		AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeConcurrencyQueued.ID,
+			Args: []interface{}{n.Name, run.ConcurrencyGroup},
+		})
+		if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+			return nil, false, sdk.WrapError(err, "unable to update workflow run")
+		}
+	}
 	return report, true, nil
 }
@@ -14,12 +14,17 @@ import (
 	"github.com/ovh/cds/sdk/interpolate"
 )
 
-func getNodeJobRunParameters(db gorp.SqlExecutor, j sdk.Job, run *sdk.WorkflowNodeRun, stage *sdk.Stage) ([]sdk.Parameter, *sdk.MultiError) {
-	params := run.BuildParameters
+func getNodeJobRunParameters(db gorp.SqlExecutor, j sdk.Job, run *sdk.WorkflowNodeRun, stage *sdk.Stage, matrix map[string]string) ([]sdk.Parameter, *sdk.MultiError) {
+	// Copy build parameters, they are shared by all jobs of the node run
+	params := make([]sdk.Parameter, len(run.BuildParameters))
+	copy(params, run.BuildParameters)
 	tmp := map[string]string{
 		"cds.stage": stage.Name,
 		"cds.job":   j.Action.Name,
 	}
+	for k, v := range matrix {
+		tmp["cds.matrix."+k] = v
+	}
 	errm := &sdk.MultiError{}
 
 	for k, v := range tmp {
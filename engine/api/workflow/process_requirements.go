@@ -14,7 +14,7 @@ import (
 
 // getNodeJobRunRequirements returns requirements list interpolated, and true or false if at least
 // one requirement is of type "Service"
-func getNodeJobRunRequirements(db gorp.SqlExecutor, j sdk.Job, run *sdk.WorkflowNodeRun) (sdk.RequirementList, bool, string, *sdk.MultiError) {
+func getNodeJobRunRequirements(db gorp.SqlExecutor, j sdk.Job, run *sdk.WorkflowNodeRun, matrix map[string]string) (sdk.RequirementList, bool, string, *sdk.MultiError) {
 	requirements := sdk.RequirementList{}
 	tmp := map[string]string{}
 	errm := &sdk.MultiError{}
@@ -24,6 +24,10 @@ func getNodeJobRunRequirements(db gorp.SqlExecutor, j sdk.Job, run *sdk.Workflow
 	for _, v := range run.BuildParameters {
 		tmp[v.Name] = v.Value
 	}
+	// matrix values can be used in requirements, ie. model: "{{.cds.matrix.os}}"
+	for k, v := range matrix {
+		tmp["cds.matrix."+k] = v
+	}
 
 	for _, v := range j.Action.Requirements {
 		name, errName := interpolate.Do(v.Name, tmp)
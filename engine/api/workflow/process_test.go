@@ -2,7 +2,9 @@ package workflow
 
 import (
 	"testing"
+	"time"
 
+	"github.com/ovh/cds/engine/api/test"
 	"github.com/ovh/cds/sdk"
 	"github.com/stretchr/testify/assert"
 )
@@ -29,6 +31,11 @@ func TestComputeRunStatus(t *testing.T) {
 	assert.Equal(t, 2, runStatus.building)
 	assert.Equal(t, 0, runStatus.failed)
 	assert.Equal(t, 0, runStatus.stoppped)
+
+	computeRunStatus(sdk.StatusWaitingApproval.String(), runStatus)
+
+	assert.Equal(t, 2, runStatus.building)
+	assert.Equal(t, 1, runStatus.waitingApproval)
 }
 
 func TestGetWorkflowRunStatus(t *testing.T) {
@@ -47,6 +54,8 @@ func TestGetWorkflowRunStatus(t *testing.T) {
 		{runStatus: statusCounter{success: 0, building: 0, failed: 0, stoppped: 0, skipped: 1}, status: sdk.StatusSkipped.String()},
 		{runStatus: statusCounter{success: 0, building: 0, failed: 0, stoppped: 0, skipped: 1, disabled: 1}, status: sdk.StatusSkipped.String()},
 		{runStatus: statusCounter{success: 0, building: 0, failed: 0, stoppped: 0, skipped: 0, disabled: 1}, status: sdk.StatusDisabled.String()},
+		{runStatus: statusCounter{success: 1, building: 1, waitingApproval: 1}, status: sdk.StatusBuilding.String()},
+		{runStatus: statusCounter{success: 1, waitingApproval: 1, failed: 1}, status: sdk.StatusWaitingApproval.String()},
 		{status: sdk.StatusNeverBuilt.String()},
 	}
 
@@ -55,3 +64,66 @@ func TestGetWorkflowRunStatus(t *testing.T) {
 		assert.Equal(t, tc.status, status)
 	}
 }
+
+func TestIsQueuedInConcurrencyGroup(t *testing.T) {
+	nr := &sdk.WorkflowNodeRun{
+		Status: sdk.StatusWaiting.String(),
+		Stages: []sdk.Stage{{Name: "build"}},
+	}
+	assert.False(t, isQueuedInConcurrencyGroup(nr))
+
+	nr.ConcurrencyGroup = "staging-env"
+	assert.True(t, isQueuedInConcurrencyGroup(nr))
+
+	// the first stage is started: the node run has a slot
+	nr.Stages[0].Status = sdk.StatusWaiting
+	assert.False(t, isQueuedInConcurrencyGroup(nr))
+
+	nr.Stages[0].Status = ""
+	nr.Status = sdk.StatusWaitingApproval.String()
+	assert.False(t, isQueuedInConcurrencyGroup(nr))
+}
+
+func TestLockConcurrencyGroupSlots(t *testing.T) {
+	db, _, end := test.SetupPG(t)
+	defer end()
+
+	tx1, err := db.Begin()
+	test.NoError(t, err)
+	defer tx1.Rollback() // nolint
+	test.NoError(t, lockConcurrencyGroupSlots(tx1, 1, "staging-env"))
+
+	// Another group is not locked
+	tx2, err := db.Begin()
+	test.NoError(t, err)
+	defer tx2.Rollback() // nolint
+	test.NoError(t, lockConcurrencyGroupSlots(tx2, 1, "production-env"))
+	test.NoError(t, lockConcurrencyGroupSlots(tx2, 2, "staging-env"))
+	test.NoError(t, tx2.Commit())
+
+	// The same group waits for the end of the first transaction
+	locked := make(chan error)
+	go func() {
+		tx3, err := db.Begin()
+		if err != nil {
+			locked <- err
+			return
+		}
+		defer tx3.Rollback() // nolint
+		locked <- lockConcurrencyGroupSlots(tx3, 1, "staging-env")
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("concurrency group must be locked by the first transaction")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	test.NoError(t, tx1.Commit())
+	select {
+	case err := <-locked:
+		test.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("concurrency group must be released by the commit of the first transaction")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
 	"reflect"
 
 	"github.com/go-gorp/gorp"
@@ -20,7 +21,7 @@ import (
 )
 
 // Export a workflow
-func Export(ctx context.Context, db gorp.SqlExecutor, cache cache.Store, proj *sdk.Project, name string, f exportentities.Format, u *sdk.User, w io.Writer, opts ...exportentities.WorkflowOptions) (int, error) {
+func Export(ctx context.Context, db gorp.SqlExecutor, cache cache.Store, proj *sdk.Project, name string, f exportentities.Format, encryptFunc sdk.EncryptFunc, u *sdk.User, w io.Writer, opts ...exportentities.WorkflowOptions) (int, error) {
 	ctx, end := observability.Span(ctx, "workflow.Export")
 	defer end()
 
@@ -34,10 +35,50 @@ func Export(ctx context.Context, db gorp.SqlExecutor, cache cache.Store, proj *s
 		opts = append(opts, exportentities.WorkflowSkipIfOnlyOneRepoWebhook)
 	}
 
+	if err := encryptNotificationSecrets(db, proj.ID, wf, encryptFunc); err != nil {
+		return 0, err
+	}
+
 	return exportWorkflow(*wf, f, w, opts...)
 }
 
+// encryptNotificationSecrets encrypts the stored webhook urls of the notifications with the project key
+func encryptNotificationSecrets(db gorp.SqlExecutor, projectID int64, wf *sdk.Workflow, encryptFunc sdk.EncryptFunc) error {
+	urls, err := loadWebhookURLs(db, wf.ID)
+	if err != nil {
+		return err
+	}
+
+	notifs := make([]sdk.WorkflowNotification, len(wf.Notifications))
+	for i, n := range wf.Notifications {
+		if url, ok := urls[n.ID]; ok {
+			content, err := encryptFunc(db, projectID, fmt.Sprintf("workflowID:%d:notification:%d", wf.ID, i), url)
+			if err != nil {
+				return sdk.WrapError(err, "Unable to encrypt webhook url of notification %s", n.Type)
+			}
+			n.Settings.WebhookURL = content
+		}
+		notifs[i] = n
+	}
+	wf.Notifications = notifs
+	return nil
+}
+
+// hideNotificationSecrets replaces the clear webhook urls, which have not been encrypted, by a placeholder
+func hideNotificationSecrets(wf *sdk.Workflow) {
+	notifs := make([]sdk.WorkflowNotification, len(wf.Notifications))
+	for i, n := range wf.Notifications {
+		if u, err := url.Parse(n.Settings.WebhookURL); err == nil && u.Scheme != "" && u.Host != "" {
+			n.Settings.WebhookURL = sdk.PasswordPlaceholder
+		}
+		notifs[i] = n
+	}
+	wf.Notifications = notifs
+}
+
 func exportWorkflow(wf sdk.Workflow, f exportentities.Format, w io.Writer, opts ...exportentities.WorkflowOptions) (int, error) {
+	hideNotificationSecrets(&wf)
+
 	e, err := exportentities.NewWorkflow(wf, opts...)
 	if err != nil {
 		return 0, sdk.WrapError(err, "exportWorkflow")
@@ -102,6 +143,10 @@ func Pull(ctx context.Context, db gorp.SqlExecutor, cache cache.Store, proj *sdk
 		}
 	}
 
+	if err := encryptNotificationSecrets(db, proj.ID, wf, encryptFunc); err != nil {
+		return err
+	}
+
 	tw := tar.NewWriter(w)
 
 	buffw := new(bytes.Buffer)
@@ -2,11 +2,13 @@ package workflow
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/keys"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/exportentities"
@@ -18,6 +20,8 @@ type ImportOptions struct {
 	DryRun       bool
 	Force        bool
 	WorkflowName string
+	// DecryptFunc decrypts the secrets of the workflow, ie. webhook urls of the notifications
+	DecryptFunc keys.DecryptFunc
 }
 
 // Parse parse an exportentities.workflow and return the parsed workflow
@@ -63,6 +67,10 @@ func ParseAndImport(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 		return nil, nil, errW
 	}
 
+	if err := decryptNotificationSecrets(db, proj, w, opts.DecryptFunc); err != nil {
+		return nil, nil, err
+	}
+
 	// Browse all node to find IDs
 	if err := IsValid(ctx, store, db, w, proj, u); err != nil {
 		return nil, nil, sdk.WrapError(err, "Workflow is not valid")
@@ -100,3 +108,24 @@ func ParseAndImport(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 
 	return w, msgList, globalError
 }
+
+// decryptNotificationSecrets decrypts the webhook urls of the notifications, clear urls are kept as is.
+// Placeholders, ie. from an audit, are kept and replaced by the stored url of the same notification on update
+func decryptNotificationSecrets(db gorp.SqlExecutor, proj *sdk.Project, w *sdk.Workflow, decryptFunc keys.DecryptFunc) error {
+	for i := range w.Notifications {
+		n := &w.Notifications[i]
+		if n.Settings.WebhookURL == "" || n.Settings.WebhookURL == sdk.PasswordPlaceholder || strings.Contains(n.Settings.WebhookURL, "://") {
+			continue
+		}
+
+		if decryptFunc == nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid webhook url on %s notification", n.Type)
+		}
+		clear, err := decryptFunc(db, proj.ID, n.Settings.WebhookURL)
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to decrypt webhook url on %s notification: %v", n.Type, err)
+		}
+		n.Settings.WebhookURL = clear
+	}
+	return nil
+}
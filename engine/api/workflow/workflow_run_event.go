@@ -51,7 +51,7 @@ func SendEvent(db gorp.SqlExecutor, key string, report *ProcessorReport) {
 			}
 		}
 
-		event.PublishWorkflowNodeRun(db, wnr, wr.Workflow, &previousNodeRun)
+		event.PublishWorkflowNodeRun(db, wnr, wr.Workflow, &previousNodeRun, wr.Status, LoadWebhookURLs)
 	}
 
 	for _, jobrun := range report.jobs {
@@ -94,6 +94,11 @@ func ResyncCommitStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 			return nil
 		}
 
+		notif, enabled := vcsNotification(wr.Workflow, node.Name)
+		if !enabled {
+			continue
+		}
+
 		vcsServer := repositoriesmanager.GetProjectVCSServer(proj, node.Context.Application.VCSServer)
 		if vcsServer == nil {
 			return nil
@@ -102,7 +107,7 @@ func ResyncCommitStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 		details := fmt.Sprintf("on project:%s workflow:%s node:%s num:%d sub:%d vcs:%s", proj.Name, wr.Workflow.Name, nodeRun.WorkflowNodeName, nodeRun.Number, nodeRun.SubNumber, vcsServer.Name)
 
 		//Get the RepositoriesManager Client
-		client, errClient := repositoriesmanager.AuthorizedClient(ctx, db, store, vcsServer)
+		client, errClient := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, vcsServer)
 		if errClient != nil {
 			return sdk.WrapError(errClient, "resyncCommitStatus> Cannot get client %s", details)
 		}
@@ -117,9 +122,13 @@ func ResyncCommitStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 		}
 
 		var statusFound *sdk.VCSCommitStatus
-		expected := sdk.VCSCommitStatusDescription(proj.Key, wr.Workflow.Name, sdk.EventRunWorkflowNode{
+		expectedEvent := sdk.EventRunWorkflowNode{
 			NodeName: node.Name,
-		})
+		}
+		if notif != nil {
+			expectedEvent.StatusContext = notif.Settings.StatusContext
+		}
+		expected := sdk.VCSCommitStatusDescription(proj.Key, wr.Workflow.Name, expectedEvent)
 
 		for i, status := range statuses {
 			if status.Decription == expected {
@@ -129,14 +138,14 @@ func ResyncCommitStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 		}
 
 		if statusFound == nil || statusFound.State == "" {
-			if err := sendVCSEventStatus(ctx, db, store, proj, wr, &nodeRun); err != nil {
+			if err := sendVCSEventStatus(ctx, db, store, proj, wr, &nodeRun, notif); err != nil {
 				log.Error("resyncCommitStatus> Error sending status %s err: %v", details, err)
 			}
 			continue
 		}
 
 		if statusFound.State == sdk.StatusBuilding.String() {
-			if err := sendVCSEventStatus(ctx, db, store, proj, wr, &nodeRun); err != nil {
+			if err := sendVCSEventStatus(ctx, db, store, proj, wr, &nodeRun, notif); err != nil {
 				log.Error("resyncCommitStatus> Error sending status %s err: %v", details, err)
 			}
 			continue
@@ -144,7 +153,7 @@ func ResyncCommitStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 
 		switch statusFound.State {
 		case sdk.StatusBuilding.String():
-			if err := sendVCSEventStatus(ctx, db, store, proj, wr, &nodeRun); err != nil {
+			if err := sendVCSEventStatus(ctx, db, store, proj, wr, &nodeRun, notif); err != nil {
 				log.Error("resyncCommitStatus> Error sending status %s %s err:%v", statusFound.State, details, err)
 			}
 			continue
@@ -154,7 +163,7 @@ func ResyncCommitStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 			case sdk.StatusSuccess.String():
 				continue
 			default:
-				if err := sendVCSEventStatus(ctx, db, store, proj, wr, &nodeRun); err != nil {
+				if err := sendVCSEventStatus(ctx, db, store, proj, wr, &nodeRun, notif); err != nil {
 					log.Error("resyncCommitStatus> Error sending status %s %s err:%v", statusFound.State, details, err)
 				}
 				continue
@@ -165,7 +174,7 @@ func ResyncCommitStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 			case sdk.StatusFail.String():
 				continue
 			default:
-				if err := sendVCSEventStatus(ctx, db, store, proj, wr, &nodeRun); err != nil {
+				if err := sendVCSEventStatus(ctx, db, store, proj, wr, &nodeRun, notif); err != nil {
 					log.Error("resyncCommitStatus> Error sending status %s %s err:%v", statusFound.State, details, err)
 				}
 				continue
@@ -176,7 +185,7 @@ func ResyncCommitStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 			case sdk.StatusDisabled.String(), sdk.StatusNeverBuilt.String(), sdk.StatusSkipped.String():
 				continue
 			default:
-				if err := sendVCSEventStatus(ctx, db, store, proj, wr, &nodeRun); err != nil {
+				if err := sendVCSEventStatus(ctx, db, store, proj, wr, &nodeRun, notif); err != nil {
 					log.Error("resyncCommitStatus> Error sending status %s %s err:%v", statusFound.State, details, err)
 				}
 				continue
@@ -186,8 +195,26 @@ func ResyncCommitStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 	return nil
 }
 
+// vcsNotification returns the vcs notification of a workflow node and if commit statuses
+// have to be sent for this node. Without vcs notification, statuses are sent for every node.
+func vcsNotification(w sdk.Workflow, nodeName string) (*sdk.WorkflowNotification, bool) {
+	var hasVCSNotification bool
+	for i := range w.Notifications {
+		if w.Notifications[i].Type != sdk.VCSUserNotification {
+			continue
+		}
+		hasVCSNotification = true
+		for _, ref := range w.Notifications[i].SourceNodeRefs {
+			if ref == nodeName {
+				return &w.Notifications[i], true
+			}
+		}
+	}
+	return nil, !hasVCSNotification
+}
+
 // sendVCSEventStatus send status
-func sendVCSEventStatus(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, wr *sdk.WorkflowRun, nodeRun *sdk.WorkflowNodeRun) error {
+func sendVCSEventStatus(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, wr *sdk.WorkflowRun, nodeRun *sdk.WorkflowNodeRun, notif *sdk.WorkflowNotification) error {
 	log.Debug("Send status for node run %d", nodeRun.ID)
 
 	node := wr.Workflow.GetNode(nodeRun.WorkflowNodeID)
@@ -200,7 +227,7 @@ func sendVCSEventStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 		return nil
 	}
 	//Get the RepositoriesManager Client
-	client, errClient := repositoriesmanager.AuthorizedClient(ctx, db, store, vcsServer)
+	client, errClient := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, vcsServer)
 	if errClient != nil {
 		return sdk.WrapError(errClient, "sendVCSEventStatus> Cannot get client")
 	}
@@ -224,6 +251,12 @@ func sendVCSEventStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 		StagesSummary:  make([]sdk.StageSummary, len(nodeRun.Stages)),
 		NodeName:       node.Name,
 	}
+	if notif != nil {
+		eventWNR.StatusContext = notif.Settings.StatusContext
+	}
+	if sdk.StatusIsTerminated(wr.Status) {
+		eventWNR.WorkflowRunStatus = wr.Status
+	}
 
 	for i := range nodeRun.Stages {
 		eventWNR.StagesSummary[i] = nodeRun.Stages[i].ToSummary()
@@ -255,6 +288,18 @@ func sendVCSEventStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 		return fmt.Errorf("sendEvent> err:%s", err)
 	}
 
+	//Without vcs notification, only failures are commented
+	var comment bool
+	if notif == nil {
+		comment = nodeRun.Status == sdk.StatusFail.String()
+	} else {
+		comment = notif.Settings.PRComment != nil && *notif.Settings.PRComment &&
+			(nodeRun.Status == sdk.StatusFail.String() || nodeRun.Status == sdk.StatusSuccess.String())
+	}
+	if !comment {
+		return nil
+	}
+
 	//Check if this branch and this commit is a pullrequest
 	prs, err := client.PullRequests(ctx, node.Context.Application.RepositoryFullname)
 	if err != nil {
@@ -265,9 +310,6 @@ func sendVCSEventStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 	//Send comment on pull request
 	for _, pr := range prs {
 		if pr.Head.Branch.DisplayID == nodeRun.VCSBranch && pr.Head.Branch.LatestCommit == nodeRun.VCSHash {
-			if nodeRun.Status != sdk.StatusFail.String() {
-				continue
-			}
 			report, err := nodeRun.Report()
 			if err != nil {
 				log.Error("sendVCSEventStatus> unable to compute node run report%v", err)
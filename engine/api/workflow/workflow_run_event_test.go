@@ -0,0 +1,41 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_vcsNotification(t *testing.T) {
+	w := sdk.Workflow{
+		Notifications: []sdk.WorkflowNotification{
+			{Type: sdk.EmailUserNotification, SourceNodeRefs: []string{"build", "deploy"}},
+		},
+	}
+
+	// Without vcs notification, every node sends its statuses
+	notif, enabled := vcsNotification(w, "deploy")
+	assert.Nil(t, notif)
+	assert.True(t, enabled)
+
+	w.Notifications = append(w.Notifications, sdk.WorkflowNotification{
+		Type:           sdk.VCSUserNotification,
+		SourceNodeRefs: []string{"build"},
+		Settings:       sdk.UserNotificationSettings{StatusContext: "build"},
+	})
+
+	notif, enabled = vcsNotification(w, "build")
+	assert.True(t, enabled)
+	if assert.NotNil(t, notif) {
+		assert.Equal(t, "CDS/build", sdk.VCSCommitStatusDescription("PROJ", "wf", sdk.EventRunWorkflowNode{
+			NodeName:      "build",
+			StatusContext: notif.Settings.StatusContext,
+		}))
+	}
+
+	notif, enabled = vcsNotification(w, "deploy")
+	assert.Nil(t, notif)
+	assert.False(t, enabled)
+}
@@ -68,7 +68,7 @@ func (api *API) getWorkflowHookModelsHandler() service.Handler {
 
 		// Post processing  on repositoryWebHook
 		hasRepoManager := false
-		repoWebHookEnable, repoPollerEnable := false, false
+		repoWebHookEnable, repoPollerEnable, gerritHookEnable := false, false, false
 		if node.IsLinkedToRepo(wf) {
 			hasRepoManager = true
 		}
@@ -77,7 +77,7 @@ func (api *API) getWorkflowHookModelsHandler() service.Handler {
 			// Call VCS to know if repository allows webhook and get the configuration fields
 			vcsServer := repositoriesmanager.GetProjectVCSServer(p, wf.GetApplication(node.Context.ApplicationID).VCSServer)
 			if vcsServer != nil {
-				client, errclient := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, vcsServer)
+				client, errclient := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, p.Key, vcsServer)
 				if errclient != nil {
 					return sdk.WrapError(errclient, "getWorkflowHookModelsHandler> Cannot get vcs client")
 				}
@@ -87,6 +87,7 @@ func (api *API) getWorkflowHookModelsHandler() service.Handler {
 					return sdk.WrapError(errWH, "getWorkflowHookModelsHandler> Cannot get vcs web hook info")
 				}
 				repoWebHookEnable = webHookInfo.WebhooksSupported && !webHookInfo.WebhooksDisabled
+				gerritHookEnable = !webHookInfo.GerritHookDisabled
 
 				pollInfo, errPoll := repositoriesmanager.GetPollingInfos(ctx, client, *p)
 				if errPoll != nil {
@@ -116,6 +117,10 @@ func (api *API) getWorkflowHookModelsHandler() service.Handler {
 				if repoPollerEnable {
 					models = append(models, m[i])
 				}
+			case sdk.GerritHookModelName:
+				if gerritHookEnable {
+					models = append(models, m[i])
+				}
 			case sdk.KafkaHookModelName:
 				if hasKafka {
 					models = append(models, m[i])
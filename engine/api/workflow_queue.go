@@ -481,7 +481,7 @@ func postJobResult(ctx context.Context, dbFunc func(context.Context) *gorp.DbMap
 	newDBFunc := func() *gorp.DbMap {
 		return dbFunc(context.Background())
 	}
-	report, err := workflow.UpdateNodeJobRunStatus(ctx, newDBFunc, tx, store, proj, job, sdk.Status(res.Status))
+	report, err := workflow.UpdateNodeJobRunResult(ctx, newDBFunc, tx, store, proj, job, *res)
 	if err != nil {
 		return nil, sdk.WrapError(err, "Cannot update NodeJobRun %d status", job.ID)
 	}
@@ -526,6 +526,7 @@ func (api *API) postWorkflowJobLogsHandler() service.AsynchronousHandler {
 		if err := workflow.AddLog(api.mustDB(), pbJob, &logs); err != nil {
 			return sdk.WithStack(err)
 		}
+		workflow.PublishStepLogs(api.Cache, &logs)
 
 		return nil
 	}
@@ -664,6 +665,7 @@ func (api *API) postWorkflowJobStepStatusHandler() service.Handler {
 		if err := tx.Commit(); err != nil {
 			return sdk.WrapError(err, "Cannot commit transaction")
 		}
+		workflow.PublishStepStatus(api.Cache, id, int64(step.StepOrder), step.Status)
 
 		if nodeRun.ID == 0 {
 			nodeRunP, errN := workflow.LoadNodeRunByID(api.mustDB(), nodeJobRun.WorkflowNodeRunID, workflow.LoadRunOptions{
@@ -682,7 +684,7 @@ func (api *API) postWorkflowJobStepStatusHandler() service.Handler {
 			return nil
 		}
 		nodeRun.Translate(r.Header.Get("Accept-Language"))
-		event.PublishWorkflowNodeRun(api.mustDB(), nodeRun, work, nil)
+		event.PublishWorkflowNodeRun(api.mustDB(), nodeRun, work, nil, "", workflow.LoadWebhookURLs)
 		return nil
 	}
 }
@@ -937,7 +939,7 @@ func (api *API) postWorkflowJobTestsResultsHandler() service.Handler {
 
 			// Get vcs info to known if we are on the default branch or not
 			projectVCSServer := repositoriesmanager.GetProjectVCSServer(p, nr.VCSServer)
-			client, erra := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, projectVCSServer)
+			client, erra := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, p.Key, projectVCSServer)
 			if erra != nil {
 				log.Error("postWorkflowJobTestsResultsHandler> Cannot get repo client %s : %v", nr.VCSServer, erra)
 				return nil
@@ -248,3 +248,212 @@ func (api *API) postWorkflowJobArtifactWithTempURLCallbackHandler() service.Hand
 		return nil
 	}
 }
+
+// artifactUploadTTL is the time in seconds a chunked upload can be resumed
+const artifactUploadTTL = 24 * 60 * 60
+
+func (api *API) postWorkflowJobArtifactUploadHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, errI := requestVarInt(r, "permID")
+		if errI != nil {
+			return sdk.WrapError(sdk.ErrInvalidID, "Invalid node job run ID")
+		}
+		ref := mux.Vars(r)["ref"]
+
+		var upload sdk.WorkflowNodeRunArtifactUpload
+		if err := service.UnmarshalBody(r, &upload); err != nil {
+			return err
+		}
+
+		// Resume an existing upload
+		if upload.ID != "" {
+			current, err := api.loadArtifactUpload(id, upload.ID)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, current, http.StatusOK)
+		}
+
+		nodeJobRun, errJ := workflow.LoadNodeJobRun(api.mustDB(), api.Cache, id)
+		if errJ != nil {
+			return sdk.WrapError(errJ, "Cannot load node job run")
+		}
+
+		nodeRun, errR := workflow.LoadNodeRunByID(api.mustDB(), nodeJobRun.WorkflowNodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if errR != nil {
+			return sdk.WrapError(errR, "Cannot load node run")
+		}
+
+		tag, errT := base64.RawURLEncoding.DecodeString(ref)
+		if errT != nil {
+			return sdk.WrapError(errT, "Cannot decode ref")
+		}
+
+		hash, errG := generateHash()
+		if errG != nil {
+			return sdk.WrapError(errG, "Could not generate hash")
+		}
+
+		upload.Artifact.WorkflowID = nodeRun.WorkflowRunID
+		upload.Artifact.WorkflowNodeRunID = nodeRun.ID
+		upload.Artifact.DownloadHash = hash
+		upload.Artifact.Tag = string(tag)
+		upload.Artifact.Ref = ref
+		upload.Artifact.Created = time.Now()
+		upload.ID = sdk.UUID()
+		upload.NodeJobRunID = id
+		upload.Offset = 0
+		upload.Chunks = nil
+
+		// The checksum is required to check the artifact reassembled from the chunks
+		if upload.Artifact.Name == "" || upload.Artifact.Size < 0 || upload.Artifact.SHA512sum == "" {
+			return sdk.WrapError(sdk.ErrWrongRequest, "Invalid artifact")
+		}
+
+		api.Cache.SetWithTTL(cache.Key("workflows:artifacts:upload", upload.ID), upload, artifactUploadTTL)
+		return service.WriteJSON(w, upload, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowJobArtifactUploadChunkHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, errI := requestVarInt(r, "permID")
+		if errI != nil {
+			return sdk.WrapError(sdk.ErrInvalidID, "Invalid node job run ID")
+		}
+		uploadID := mux.Vars(r)["uploadID"]
+
+		offset, errO := strconv.ParseInt(r.FormValue("offset"), 10, 64)
+		if errO != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid offset")
+		}
+
+		lockKey := cache.Key("workflows:artifacts:upload", uploadID, "lock")
+		if !api.Cache.Lock(lockKey, 5*time.Minute, 0, 1) {
+			return sdk.NewErrorFrom(sdk.ErrConflict, "a chunk of this artifact is already being uploaded")
+		}
+		defer api.Cache.Unlock(lockKey)
+
+		upload, err := api.loadArtifactUpload(id, uploadID)
+		if err != nil {
+			return err
+		}
+
+		if err := artifact.StoreUploadChunk(upload, offset, r.Body); err != nil {
+			return err
+		}
+
+		api.Cache.SetWithTTL(cache.Key("workflows:artifacts:upload", upload.ID), upload, artifactUploadTTL)
+		return service.WriteJSON(w, upload, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowJobArtifactUploadChunkURLHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, errI := requestVarInt(r, "permID")
+		if errI != nil {
+			return sdk.WrapError(sdk.ErrInvalidID, "Invalid node job run ID")
+		}
+		uploadID := mux.Vars(r)["uploadID"]
+
+		offset, errO := strconv.ParseInt(r.FormValue("offset"), 10, 64)
+		if errO != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid offset")
+		}
+
+		upload, err := api.loadArtifactUpload(id, uploadID)
+		if err != nil {
+			return err
+		}
+
+		upload.TempURL, err = artifact.UploadChunkURL(upload, offset)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, upload, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowJobArtifactUploadChunkURLCallbackHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, errI := requestVarInt(r, "permID")
+		if errI != nil {
+			return sdk.WrapError(sdk.ErrInvalidID, "Invalid node job run ID")
+		}
+		uploadID := mux.Vars(r)["uploadID"]
+
+		offset, errO := strconv.ParseInt(r.FormValue("offset"), 10, 64)
+		if errO != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid offset")
+		}
+		size, errS := strconv.ParseInt(r.FormValue("size"), 10, 64)
+		if errS != nil {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid size")
+		}
+
+		lockKey := cache.Key("workflows:artifacts:upload", uploadID, "lock")
+		if !api.Cache.Lock(lockKey, 5*time.Minute, 0, 1) {
+			return sdk.NewErrorFrom(sdk.ErrConflict, "a chunk of this artifact is already being uploaded")
+		}
+		defer api.Cache.Unlock(lockKey)
+
+		upload, err := api.loadArtifactUpload(id, uploadID)
+		if err != nil {
+			return err
+		}
+
+		if err := artifact.AddUploadChunk(upload, offset, size); err != nil {
+			return err
+		}
+
+		api.Cache.SetWithTTL(cache.Key("workflows:artifacts:upload", upload.ID), upload, artifactUploadTTL)
+		return service.WriteJSON(w, upload, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowJobArtifactUploadCompleteHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, errI := requestVarInt(r, "permID")
+		if errI != nil {
+			return sdk.WrapError(sdk.ErrInvalidID, "Invalid node job run ID")
+		}
+		uploadID := mux.Vars(r)["uploadID"]
+
+		lockKey := cache.Key("workflows:artifacts:upload", uploadID, "lock")
+		if !api.Cache.Lock(lockKey, 5*time.Minute, 0, 1) {
+			return sdk.NewErrorFrom(sdk.ErrConflict, "a chunk of this artifact is being uploaded")
+		}
+		defer api.Cache.Unlock(lockKey)
+
+		upload, err := api.loadArtifactUpload(id, uploadID)
+		if err != nil {
+			return err
+		}
+
+		if err := artifact.CompleteUpload(upload); err != nil {
+			// The chunks of a complete upload which does not match its checksum are useless, the artifact has to be uploaded again
+			if upload.Offset == upload.Artifact.Size && sdk.ErrorIs(err, sdk.ErrWrongRequest) {
+				artifact.DeleteUploadChunks(upload)
+				api.Cache.Delete(cache.Key("workflows:artifacts:upload", upload.ID))
+			}
+			return err
+		}
+		artifact.DeleteUploadChunks(upload)
+		api.Cache.Delete(cache.Key("workflows:artifacts:upload", upload.ID))
+
+		if err := workflow.InsertArtifact(api.mustDB(), &upload.Artifact); err != nil {
+			_ = objectstore.Delete(&upload.Artifact)
+			return sdk.WrapError(err, "Cannot insert artifact")
+		}
+		return service.WriteJSON(w, upload.Artifact, http.StatusOK)
+	}
+}
+
+// loadArtifactUpload returns the state of a chunked upload of a job from the cache
+func (api *API) loadArtifactUpload(nodeJobRunID int64, uploadID string) (*sdk.WorkflowNodeRunArtifactUpload, error) {
+	var upload sdk.WorkflowNodeRunArtifactUpload
+	if !api.Cache.Get(cache.Key("workflows:artifacts:upload", uploadID), &upload) || upload.NodeJobRunID != nodeJobRunID {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "artifact upload %s not found", uploadID)
+	}
+	return &upload, nil
+}
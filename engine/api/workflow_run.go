@@ -1,7 +1,9 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,9 +14,11 @@ import (
 	"time"
 
 	"github.com/go-gorp/gorp"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/feature"
 	"github.com/ovh/cds/engine/api/objectstore"
 	"github.com/ovh/cds/engine/api/observability"
@@ -494,6 +498,50 @@ func stopWorkflowRun(ctx context.Context, dbFunc func() *gorp.DbMap, store cache
 	return report, nil
 }
 
+// stopSupersededWorkflowRuns stops the older runs of a workflow on the branch of a new run
+// triggered by a repository webhook or a git repository poller
+func stopSupersededWorkflowRuns(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, p *sdk.Project, wf *sdk.Workflow, wr *sdk.WorkflowRun, e *sdk.WorkflowNodeRunHookEvent, u *sdk.User) (*workflow.ProcessorReport, error) {
+	h, has := wf.GetHooks()[e.WorkflowNodeHookUUID]
+	if !has || wf.Root == nil || h.WorkflowNodeID != wf.Root.ID {
+		return nil, nil
+	}
+	if h.WorkflowHookModel.Name != sdk.RepositoryWebHookModelName && h.WorkflowHookModel.Name != sdk.GitPollerModelName {
+		return nil, nil
+	}
+	if wr.Status == sdk.StatusNeverBuilt.String() {
+		return nil, nil
+	}
+	rootRun := wr.RootRun()
+	if rootRun == nil || rootRun.VCSBranch == "" {
+		return nil, nil
+	}
+
+	ids, err := workflow.LoadSupersededRunIDs(dbFunc(), wr, rootRun.VCSBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	report := new(workflow.ProcessorReport)
+	for _, id := range ids {
+		run, err := workflow.LoadRunByID(dbFunc(), id, workflow.LoadRunOptions{})
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to load workflow run %d", id)
+		}
+		workflow.AddWorkflowRunInfo(run, false, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowRunSuperseded.ID,
+			Args: []interface{}{wr.Number, rootRun.VCSBranch},
+		})
+
+		log.Info("stopSupersededWorkflowRuns> workflow %s #%d superseded by #%d on branch %s", wf.Name, run.Number, wr.Number, rootRun.VCSBranch)
+		r1, err := stopWorkflowRun(ctx, dbFunc, store, p, run, u, 0)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to stop workflow run %d", run.ID)
+		}
+		_, _ = report.Merge(r1, nil)
+	}
+	return report, nil
+}
+
 func updateParentWorkflowRun(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, run *sdk.WorkflowRun) error {
 	if !run.HasParentWorkflow() {
 		return nil
@@ -745,6 +793,69 @@ func (api *API) stopWorkflowNodeRun(ctx context.Context, dbFunc func() *gorp.DbM
 	return report, nil
 }
 
+func (api *API) approveWorkflowNodeRunHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		id, err := requestVarInt(r, "nodeRunID")
+		if err != nil {
+			return err
+		}
+
+		var req sdk.WorkflowNodeRunApprovalRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return sdk.WrapError(err, "Unable to unmarshal request")
+		}
+
+		p, errP := project.Load(api.mustDB(), api.Cache, key, getUser(ctx), project.LoadOptions.WithVariables)
+		if errP != nil {
+			return sdk.WrapError(errP, "Cannot load project")
+		}
+
+		// Check that the node run belongs to the workflow run
+		if _, err := workflow.LoadNodeRun(api.mustDB(), key, name, number, id, workflow.LoadRunOptions{DisableDetailledNodeRun: true}); err != nil {
+			return sdk.WrapError(err, "Unable to load workflow node run")
+		}
+
+		tx, errTx := api.mustDB().Begin()
+		if errTx != nil {
+			return sdk.WrapError(errTx, "Unable to create transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		// Lock the node run to record approvals one after the other
+		nodeRun, err := workflow.LoadAndLockNodeRunByID(ctx, tx, id, true)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to lock workflow node run %d", id)
+		}
+
+		wr, err := workflow.LoadRun(tx, key, name, number, workflow.LoadRunOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "Unable to load workflow run %s #%d", name, number)
+		}
+
+		u := getUser(ctx)
+		report, err := workflow.ApproveNodeRun(ctx, tx, api.Cache, p, wr, nodeRun, u, req)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to approve workflow node run %d", id)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WrapError(err, "Unable to commit transaction")
+		}
+
+		event.PublishWorkflowNodeRunApproval(p.Key, wr.Workflow, *nodeRun, nodeRun.Approvals[len(nodeRun.Approvals)-1], u)
+		go workflow.SendEvent(api.mustDB(), p.Key, report)
+
+		return service.WriteJSON(w, nodeRun, http.StatusOK)
+	}
+}
+
 func (api *API) getWorkflowNodeRunHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -1025,7 +1136,7 @@ func startWorkflowRun(ctx context.Context, db *gorp.DbMap, store cache.Store, p
 
 	//Run from hook
 	if opts.Hook != nil {
-		_, r1, err := workflow.RunFromHook(ctx, tx, store, p, wf, opts.Hook, asCodeInfos)
+		wr, r1, err := workflow.RunFromHook(ctx, tx, store, p, wf, opts.Hook, asCodeInfos)
 		if err != nil {
 			return nil, sdk.WrapError(err, "Unable to run workflow from hook")
 		}
@@ -1034,8 +1145,18 @@ func startWorkflowRun(ctx context.Context, db *gorp.DbMap, store cache.Store, p
 		if err := tx.Commit(); err != nil {
 			return nil, sdk.WrapError(err, "Unable to commit transaction")
 		}
+		_, _ = report.Merge(r1, nil)
+
+		//Stop the older runs on the same branch
+		if wf.AutoCancelSuperseded && wr != nil {
+			r2, err := stopSupersededWorkflowRuns(ctx, func() *gorp.DbMap { return db }, store, p, wf, wr, opts.Hook, u)
+			if err != nil {
+				log.Error("startWorkflowRun> Unable to stop superseded runs of workflow %s: %v", wf.Name, err)
+			}
+			_, _ = report.Merge(r2, nil)
+		}
 
-		return report.Merge(r1, nil)
+		return report, nil
 	}
 
 	//Default manual run
@@ -1389,7 +1510,18 @@ func (api *API) getWorkflowNodeRunJobStepHandler() service.Handler {
 			return sdk.WrapError(errNR, "getWorkflowNodeRunJobBuildLogsHandler> Cannot find nodeRun %d/%d for workflow %s in project %s", nodeRunID, number, workflowName, projectKey)
 		}
 
+		// Logs of a previous attempt of the job can be requested with ?attempt=N
+		var attempt int
+		if attemptS := r.FormValue("attempt"); attemptS != "" {
+			var errA error
+			attempt, errA = strconv.Atoi(attemptS)
+			if errA != nil || attempt < 1 {
+				return sdk.WrapError(sdk.ErrWrongRequest, "getWorkflowNodeRunJobStepHandler> attempt: invalid number")
+			}
+		}
+
 		var stepStatus string
+		var archived bool
 		// Find job/step in nodeRun
 	stageLoop:
 		for _, s := range nodeRun.Stages {
@@ -1398,6 +1530,10 @@ func (api *API) getWorkflowNodeRunJobStepHandler() service.Handler {
 					continue
 				}
 				ss := rj.Job.StepStatus
+				if attempt > 0 && attempt < rj.Job.CurrentAttempt() {
+					ss = rj.Job.PreviousAttempts[attempt-1].StepStatus
+					archived = true
+				}
 				for _, sss := range ss {
 					if int64(sss.StepOrder) == stepOrder {
 						stepStatus = sss.Status
@@ -1413,7 +1549,30 @@ func (api *API) getWorkflowNodeRunJobStepHandler() service.Handler {
 				stepOrder, runJobID, nodeRunID, number, workflowName, projectKey)
 		}
 
-		logs, errL := workflow.LoadStepLogs(api.mustDB(), runJobID, stepOrder)
+		var logsAttempt int
+		if archived {
+			logsAttempt = attempt
+		}
+
+		// Raw logs are streamed from the log store with ?format=raw
+		if r.FormValue("format") == "raw" {
+			_, f, errL := workflow.OpenAttemptStepLogs(api.mustDB(), runJobID, stepOrder, logsAttempt)
+			if errL != nil {
+				return sdk.WrapError(errL, "getWorkflowNodeRunJobStepHandler> Cannot open log for runJob %d on step %d", runJobID, stepOrder)
+			}
+			w.Header().Add("Content-Type", "text/plain; charset=utf-8")
+			w.Header().Add("X-CDS-Step-Status", stepStatus)
+			if f == nil {
+				return nil
+			}
+			if _, err := io.Copy(w, f); err != nil {
+				_ = f.Close()
+				return sdk.WrapError(err, "getWorkflowNodeRunJobStepHandler> Cannot stream log")
+			}
+			return f.Close()
+		}
+
+		logs, errL := workflow.LoadAttemptStepLogs(api.mustDB(), runJobID, stepOrder, logsAttempt)
 		if errL != nil {
 			return sdk.WrapError(errL, "getWorkflowNodeRunJobStepHandler> Cannot load log for runJob %d on step %d", runJobID, stepOrder)
 		}
@@ -1431,6 +1590,178 @@ func (api *API) getWorkflowNodeRunJobStepHandler() service.Handler {
 	}
 }
 
+// nodeRunJobStepStatus returns the current status of a step of a job run, or an empty string if not found
+func nodeRunJobStepStatus(nodeRun *sdk.WorkflowNodeRun, runJobID, stepOrder int64) string {
+	for _, s := range nodeRun.Stages {
+		for _, rj := range s.RunJobs {
+			if rj.ID != runJobID {
+				continue
+			}
+			for _, ss := range rj.Job.StepStatus {
+				if int64(ss.StepOrder) == stepOrder {
+					return ss.Status
+				}
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// writeStepLogsEvent sends a server-sent event to a client following logs of a step
+func writeStepLogsEvent(w http.ResponseWriter, event string, msg sdk.StepLogsMessage) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return sdk.WrapError(err, "Unable to marshal message")
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("event: " + event + "\n")
+	buffer.WriteString("data: ")
+	buffer.Write(b)
+	buffer.WriteString("\n\n")
+	if _, err := w.Write(buffer.Bytes()); err != nil {
+		return sdk.WrapError(err, "Unable to write to client")
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// getWorkflowNodeRunJobStepLogsStreamHandler streams logs of a job step as server-sent events: existing logs are sent first,
+// then new logs as soon as they are received from the worker, until the step is over
+func (api *API) getWorkflowNodeRunJobStepLogsStreamHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars["key"]
+		workflowName := vars["permWorkflowName"]
+		number, errN := requestVarInt(r, "number")
+		if errN != nil {
+			return sdk.WrapError(errN, "getWorkflowNodeRunJobStepLogsStreamHandler> Number: invalid number")
+		}
+		nodeRunID, errNI := requestVarInt(r, "nodeRunID")
+		if errNI != nil {
+			return sdk.WrapError(errNI, "getWorkflowNodeRunJobStepLogsStreamHandler> id: invalid number")
+		}
+		runJobID, errJ := requestVarInt(r, "runJobId")
+		if errJ != nil {
+			return sdk.WrapError(errJ, "getWorkflowNodeRunJobStepLogsStreamHandler> runJobId: invalid number")
+		}
+		stepOrder, errS := requestVarInt(r, "stepOrder")
+		if errS != nil {
+			return sdk.WrapError(errS, "getWorkflowNodeRunJobStepLogsStreamHandler> stepOrder: invalid number")
+		}
+
+		if _, ok := w.(http.Flusher); !ok {
+			return sdk.WrapError(fmt.Errorf("streaming unsupported"), "getWorkflowNodeRunJobStepLogsStreamHandler>")
+		}
+
+		loadStatus := func() (string, error) {
+			nodeRun, err := workflow.LoadNodeRun(api.mustDB(), projectKey, workflowName, number, nodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+			if err != nil {
+				return "", sdk.WrapError(err, "getWorkflowNodeRunJobStepLogsStreamHandler> Cannot find nodeRun %d/%d for workflow %s in project %s", nodeRunID, number, workflowName, projectKey)
+			}
+			status := nodeRunJobStepStatus(nodeRun, runJobID, stepOrder)
+			if status == "" {
+				return "", sdk.WrapError(sdk.ErrStepNotFound, "getWorkflowNodeRunJobStepLogsStreamHandler> Cannot find step %d on job %d in nodeRun %d", stepOrder, runJobID, nodeRunID)
+			}
+			return status, nil
+		}
+
+		stepStatus, err := loadStatus()
+		if err != nil {
+			return err
+		}
+
+		// Subscribe before loading existing logs to not miss any line, lines received twice are skipped with their date
+		streamCtx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		events := workflow.SubscribeStepLogs(streamCtx, api.Cache, runJobID, stepOrder)
+
+		logs, errL := workflow.LoadStepLogs(api.mustDB(), runJobID, stepOrder)
+		if errL != nil {
+			return sdk.WrapError(errL, "getWorkflowNodeRunJobStepLogsStreamHandler> Cannot load log for runJob %d on step %d", runJobID, stepOrder)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+
+		var sent int
+		var lastModified time.Time
+		if logs != nil {
+			if logs.LastModified != nil {
+				lastModified, _ = ptypes.Timestamp(logs.LastModified)
+			}
+			if logs.Val != "" {
+				if err := writeStepLogsEvent(w, "log", sdk.StepLogsMessage{Val: logs.Val}); err != nil {
+					return err
+				}
+				sent = len(logs.Val)
+			}
+		}
+
+		// finish sends the lines that could have been missed and the final status of the step
+		finish := func(status string) error {
+			logs, err := workflow.LoadStepLogs(api.mustDB(), runJobID, stepOrder)
+			if err != nil {
+				return sdk.WrapError(err, "getWorkflowNodeRunJobStepLogsStreamHandler> Cannot load log for runJob %d on step %d", runJobID, stepOrder)
+			}
+			if logs != nil && len(logs.Val) > sent {
+				if err := writeStepLogsEvent(w, "log", sdk.StepLogsMessage{Val: logs.Val[sent:]}); err != nil {
+					return err
+				}
+			}
+			return writeStepLogsEvent(w, "status", sdk.StepLogsMessage{Status: status})
+		}
+
+		if sdk.StatusIsTerminated(stepStatus) {
+			return finish(stepStatus)
+		}
+
+		tick := time.NewTicker(5 * time.Second)
+		defer tick.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return nil
+			case e, ok := <-events:
+				if !ok {
+					return nil
+				}
+				if e.Log != nil && e.Log.Val != "" {
+					if e.Log.LastModified != nil {
+						if t, err := ptypes.Timestamp(e.Log.LastModified); err == nil && !t.After(lastModified) {
+							continue
+						}
+					}
+					if err := writeStepLogsEvent(w, "log", sdk.StepLogsMessage{Val: e.Log.Val}); err != nil {
+						return err
+					}
+					sent += len(e.Log.Val)
+				}
+				if e.Status != "" && sdk.StatusIsTerminated(e.Status) {
+					return finish(e.Status)
+				}
+			case <-tick.C:
+				// The job could have been stopped without sending the final status of its steps
+				status, err := loadStatus()
+				if err != nil {
+					return err
+				}
+				if sdk.StatusIsTerminated(status) {
+					return finish(status)
+				}
+				if _, err := w.Write([]byte(": ping\n\n")); err != nil {
+					return nil
+				}
+				w.(http.Flusher).Flush()
+			}
+		}
+	}
+}
+
 func (api *API) getWorkflowRunTagsHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -1462,7 +1793,7 @@ func (api *API) postResyncVCSWorkflowRunHandler() service.Handler {
 			return sdk.WrapError(errP, "postResyncVCSWorkflowRunHandler> Cannot load project")
 		}
 
-		wfr, errW := workflow.LoadRun(db, key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		wfr, errW := workflow.LoadRun(db, key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true, WithTests: true})
 		if errW != nil {
 			return sdk.WrapError(errW, "postResyncVCSWorkflowRunHandler> Cannot load workflow run")
 		}
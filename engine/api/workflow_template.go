@@ -0,0 +1,572 @@
+package api
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/environment"
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/pipeline"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// isGroupAdmin returns true if the user is admin of the group or CDS admin
+func isGroupAdmin(u *sdk.User, groupID int64) bool {
+	if u.Admin {
+		return true
+	}
+	for _, g := range u.Groups {
+		if g.ID != groupID {
+			continue
+		}
+		for _, a := range g.Admins {
+			if a.ID == u.ID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (api *API) loadWorkflowTemplate(r *http.Request) (*sdk.WorkflowTemplate, error) {
+	vars := mux.Vars(r)
+	wt, err := workflowtemplate.LoadByGroupAndName(api.mustDB(), vars["groupName"], vars["templateName"])
+	if err != nil {
+		return nil, sdk.WrapError(err, "Cannot load workflow template %s/%s", vars["groupName"], vars["templateName"])
+	}
+	return wt, nil
+}
+
+func (api *API) getWorkflowTemplatesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wts, err := workflowtemplate.LoadAll(api.mustDB())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, wts, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowTemplateHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var wt sdk.WorkflowTemplate
+		if err := service.UnmarshalBody(r, &wt); err != nil {
+			return sdk.WrapError(err, "Cannot unmarshal body")
+		}
+
+		db := api.mustDB()
+		var g *sdk.Group
+		var err error
+		if wt.GroupID == 0 {
+			if wt.GroupName == "" && wt.Group != nil {
+				wt.GroupName = wt.Group.Name
+			}
+			g, err = group.LoadGroup(db, wt.GroupName)
+		} else {
+			g, err = group.LoadGroupByID(db, wt.GroupID)
+		}
+		if err != nil {
+			return sdk.WrapError(err, "Cannot load group of workflow template %s", wt.Name)
+		}
+		wt.GroupID = g.ID
+
+		// User must be admin of the group of the template
+		if !isGroupAdmin(getUser(ctx), wt.GroupID) {
+			return sdk.WrapError(sdk.ErrForbidden, "User %s is not admin of group %s", getUser(ctx).Username, g.Name)
+		}
+
+		if err := wt.IsValid(); err != nil {
+			return err
+		}
+
+		if _, err := workflowtemplate.LoadByGroupAndName(db, g.Name, wt.Name); err == nil {
+			return sdk.ErrWorkflowTemplateAlreadyExists
+		} else if !sdk.ErrorIs(err, sdk.ErrWorkflowTemplateNotFound) {
+			return err
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return sdk.WrapError(err, "Cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		wt.Version = 1
+		if err := workflowtemplate.Insert(tx, &wt); err != nil {
+			return err
+		}
+		if err := workflowtemplate.InsertVersion(tx, &wt); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WrapError(err, "Cannot commit transaction")
+		}
+
+		res, err := workflowtemplate.LoadByID(db, wt.ID)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, res, http.StatusCreated)
+	}
+}
+
+func (api *API) getWorkflowTemplateHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wt, err := api.loadWorkflowTemplate(r)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, wt, http.StatusOK)
+	}
+}
+
+func (api *API) putWorkflowTemplateHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		old, err := api.loadWorkflowTemplate(r)
+		if err != nil {
+			return err
+		}
+		if !isGroupAdmin(getUser(ctx), old.GroupID) {
+			return sdk.WrapError(sdk.ErrForbidden, "User %s is not admin of group %s", getUser(ctx).Username, old.GroupName)
+		}
+
+		var wt sdk.WorkflowTemplate
+		if err := service.UnmarshalBody(r, &wt); err != nil {
+			return sdk.WrapError(err, "Cannot unmarshal body")
+		}
+
+		// The group of a template can't be changed, each update creates a new version
+		wt.ID = old.ID
+		wt.GroupID = old.GroupID
+		wt.Version = old.Version + 1
+		if err := wt.IsValid(); err != nil {
+			return err
+		}
+
+		db := api.mustDB()
+		if wt.Name != old.Name {
+			if _, err := workflowtemplate.LoadByGroupAndName(db, old.GroupName, wt.Name); err == nil {
+				return sdk.ErrWorkflowTemplateAlreadyExists
+			} else if !sdk.ErrorIs(err, sdk.ErrWorkflowTemplateNotFound) {
+				return err
+			}
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return sdk.WrapError(err, "Cannot start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		// The previous versions are kept to render and diff the instances which use them
+		if err := workflowtemplate.Update(tx, &wt); err != nil {
+			return err
+		}
+		if err := workflowtemplate.InsertVersion(tx, &wt); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WrapError(err, "Cannot commit transaction")
+		}
+
+		res, err := workflowtemplate.LoadByID(db, wt.ID)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowTemplateVersionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wt, err := api.loadWorkflowTemplate(r)
+		if err != nil {
+			return err
+		}
+
+		version, err := requestVarInt(r, "version")
+		if err != nil {
+			return err
+		}
+
+		res, err := workflowtemplate.LoadVersion(api.mustDB(), wt, version)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+func (api *API) deleteWorkflowTemplateHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wt, err := api.loadWorkflowTemplate(r)
+		if err != nil {
+			return err
+		}
+		if !isGroupAdmin(getUser(ctx), wt.GroupID) {
+			return sdk.WrapError(sdk.ErrForbidden, "User %s is not admin of group %s", getUser(ctx).Username, wt.GroupName)
+		}
+
+		if err := workflowtemplate.Delete(api.mustDB(), wt); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+func (api *API) postApplyWorkflowTemplateHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wt, err := api.loadWorkflowTemplate(r)
+		if err != nil {
+			return err
+		}
+
+		var req sdk.WorkflowTemplateRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return sdk.WrapError(err, "Cannot unmarshal body")
+		}
+
+		u := getUser(ctx)
+		if !permission.AccessToProject(req.ProjectKey, u, permission.PermissionReadWriteExecute) {
+			return sdk.WrapError(sdk.ErrForbidden, "User %s cannot apply template on project %s", u.Username, req.ProjectKey)
+		}
+
+		_, msgs, err := applyWorkflowTemplate(ctx, api.mustDB(), api.Cache, wt, req, u)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, translate(r, msgs), http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowTemplateInstancesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wt, err := api.loadWorkflowTemplate(r)
+		if err != nil {
+			return err
+		}
+
+		is, err := workflowtemplate.LoadInstancesByTemplateID(api.mustDB(), wt.ID)
+		if err != nil {
+			return err
+		}
+
+		// Only returns the instances of the projects readable by the user
+		u := getUser(ctx)
+		res := make([]sdk.WorkflowTemplateInstance, 0, len(is))
+		for _, i := range is {
+			if permission.AccessToProject(i.ProjectKey, u, permission.PermissionRead) {
+				res = append(res, i)
+			}
+		}
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowTemplatePreviewHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wt, err := api.loadWorkflowTemplate(r)
+		if err != nil {
+			return err
+		}
+
+		var bulk sdk.WorkflowTemplateBulk
+		if err := service.UnmarshalBody(r, &bulk); err != nil {
+			return sdk.WrapError(err, "Cannot unmarshal body")
+		}
+
+		u := getUser(ctx)
+		is, err := api.loadWorkflowTemplateBulkInstances(wt, bulk, u, permission.PermissionRead)
+		if err != nil {
+			return err
+		}
+
+		db := api.mustDB()
+		res := make([]sdk.WorkflowTemplateInstanceDiff, len(is))
+		for idx, i := range is {
+			res[idx].Instance = i
+			diff, versionDiff, err := previewWorkflowTemplate(ctx, db, api.Cache, wt, i, u)
+			if err != nil {
+				res[idx].Error = sdk.Cause(err).Error()
+				continue
+			}
+			res[idx].Diff = diff
+			res[idx].VersionDiff = versionDiff
+		}
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowTemplateBulkHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		wt, err := api.loadWorkflowTemplate(r)
+		if err != nil {
+			return err
+		}
+
+		var bulk sdk.WorkflowTemplateBulk
+		if err := service.UnmarshalBody(r, &bulk); err != nil {
+			return sdk.WrapError(err, "Cannot unmarshal body")
+		}
+
+		u := getUser(ctx)
+		is, err := api.loadWorkflowTemplateBulkInstances(wt, bulk, u, permission.PermissionReadWriteExecute)
+		if err != nil {
+			return err
+		}
+
+		db := api.mustDB()
+		res := make([]sdk.WorkflowTemplateBulkResult, len(is))
+		for idx, i := range is {
+			req := i.Request
+			req.ProjectKey = i.ProjectKey
+			req.WorkflowName = i.WorkflowName
+
+			instance, msgs, err := applyWorkflowTemplate(ctx, db, api.Cache, wt, req, u)
+			if err != nil {
+				res[idx].Instance = i
+				res[idx].Error = sdk.Cause(err).Error()
+				continue
+			}
+			res[idx].Instance = *instance
+			res[idx].Messages = translate(r, msgs)
+		}
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+// loadWorkflowTemplateBulkInstances returns the instances of a template given by the bulk request, all if empty.
+// The user must have the given permission on the project of each instance.
+func (api *API) loadWorkflowTemplateBulkInstances(wt *sdk.WorkflowTemplate, bulk sdk.WorkflowTemplateBulk, u *sdk.User, access int) ([]sdk.WorkflowTemplateInstance, error) {
+	is, err := workflowtemplate.LoadInstancesByTemplateID(api.mustDB(), wt.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bulk.InstanceIDs) > 0 {
+		byID := make(map[int64]sdk.WorkflowTemplateInstance, len(is))
+		for _, i := range is {
+			byID[i.ID] = i
+		}
+		is = make([]sdk.WorkflowTemplateInstance, 0, len(bulk.InstanceIDs))
+		for _, id := range bulk.InstanceIDs {
+			i, has := byID[id]
+			if !has {
+				return nil, sdk.NewError(sdk.ErrNotFound, fmt.Errorf("Instance %d of workflow template %s not found", id, wt.Name))
+			}
+			is = append(is, i)
+		}
+	}
+
+	for _, i := range is {
+		if !permission.AccessToProject(i.ProjectKey, u, access) {
+			return nil, sdk.WrapError(sdk.ErrForbidden, "User %s has no access to project %s of instance %d", u.Username, i.ProjectKey, i.ID)
+		}
+	}
+	return is, nil
+}
+
+// applyWorkflowTemplate generates the files of a template, pushes them in the project of the request
+// and saves the instance with the version of the template. An existing workflow is only overwritten if it's
+// an instance of the template, and existing applications, pipelines and environments only if they were
+// generated by the previous version of the instance.
+func applyWorkflowTemplate(ctx context.Context, db *gorp.DbMap, store cache.Store, wt *sdk.WorkflowTemplate, req sdk.WorkflowTemplateRequest, u *sdk.User) (*sdk.WorkflowTemplateInstance, []sdk.Message, error) {
+	proj, err := project.Load(db, store, req.ProjectKey, u,
+		project.LoadOptions.WithGroups,
+		project.LoadOptions.WithApplications,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithPipelines,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithPlatforms)
+	if err != nil {
+		return nil, nil, sdk.WrapError(err, "Cannot load project %s", req.ProjectKey)
+	}
+
+	res, err := workflowtemplate.Execute(*wt, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	buf := new(bytes.Buffer)
+	if err := workflowtemplate.Tar(res, buf); err != nil {
+		return nil, nil, err
+	}
+
+	var i *sdk.WorkflowTemplateInstance
+	hooks := workflow.PushHooks{
+		BeforeImport: func(tx gorp.SqlExecutor, apps, pips, envs []string) error {
+			var errI error
+			i, errI = checkWorkflowTemplateInstanceOwnership(tx, proj, wt, req, apps, pips, envs)
+			return errI
+		},
+		AfterImport: func(tx gorp.SqlExecutor, wf *sdk.Workflow) error {
+			if i == nil {
+				i = &sdk.WorkflowTemplateInstance{
+					WorkflowTemplateID: wt.ID,
+					ProjectID:          proj.ID,
+					WorkflowID:         wf.ID,
+				}
+			}
+			i.WorkflowTemplateVersion = wt.Version
+			i.Request = req
+			i.ProjectKey = proj.Key
+			i.WorkflowName = wf.Name
+
+			if i.ID == 0 {
+				return workflowtemplate.InsertInstance(tx, i)
+			}
+			return workflowtemplate.UpdateInstance(tx, i)
+		},
+	}
+
+	msgs, _, err := workflow.PushWithHooks(ctx, db, store, proj, tar.NewReader(buf), nil, hooks, u, project.DecryptWithBuiltinKey)
+	if err != nil {
+		return nil, msgs, sdk.WrapError(err, "Cannot push workflow %s generated from template %s", req.WorkflowName, wt.Name)
+	}
+	return i, msgs, nil
+}
+
+// checkWorkflowTemplateInstanceOwnership returns the instance of the template for the workflow of the request, nil if the
+// workflow doesn't exist. It fails if the workflow exists and is not an instance of the template, or if any of the given
+// applications, pipelines and environments exists in the project and was not generated by the previous version of the instance.
+func checkWorkflowTemplateInstanceOwnership(db gorp.SqlExecutor, proj *sdk.Project, wt *sdk.WorkflowTemplate, req sdk.WorkflowTemplateRequest, apps, pips, envs []string) (*sdk.WorkflowTemplateInstance, error) {
+	exists, err := workflow.Exists(db, proj.Key, req.WorkflowName)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Cannot check if workflow %s exists", req.WorkflowName)
+	}
+
+	var i *sdk.WorkflowTemplateInstance
+	generated := map[string]string{}
+	if exists {
+		i, err = workflowtemplate.LoadInstanceByWorkflowName(db, proj.ID, req.WorkflowName)
+		if err != nil {
+			return nil, err
+		}
+		if i == nil {
+			return nil, sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Workflow %s was not generated from template %s", req.WorkflowName, wt.Name))
+		}
+		if i.WorkflowTemplateID != wt.ID {
+			return nil, sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Workflow %s was generated from another template", req.WorkflowName))
+		}
+
+		old := wt
+		if i.WorkflowTemplateVersion != wt.Version {
+			old, err = workflowtemplate.LoadVersion(db, wt, i.WorkflowTemplateVersion)
+			if err != nil {
+				return nil, err
+			}
+		}
+		oldReq := i.Request
+		oldReq.ProjectKey = proj.Key
+		oldReq.WorkflowName = req.WorkflowName
+		oldRes, err := workflowtemplate.Execute(*old, oldReq)
+		if err != nil {
+			return nil, err
+		}
+		generated = oldRes.Files
+	}
+
+	var conflicts []string
+	for _, name := range apps {
+		if _, has := generated[fmt.Sprintf("%s.app.yml", name)]; has {
+			continue
+		}
+		exists, err := application.Exists(db, proj.Key, name)
+		if err != nil {
+			return nil, sdk.WrapError(err, "Cannot check if application %s exists", name)
+		}
+		if exists {
+			conflicts = append(conflicts, fmt.Sprintf("application %s", name))
+		}
+	}
+	for _, name := range pips {
+		if _, has := generated[fmt.Sprintf("%s.pip.yml", name)]; has {
+			continue
+		}
+		exists, err := pipeline.ExistPipeline(db, proj.ID, name)
+		if err != nil {
+			return nil, sdk.WrapError(err, "Cannot check if pipeline %s exists", name)
+		}
+		if exists {
+			conflicts = append(conflicts, fmt.Sprintf("pipeline %s", name))
+		}
+	}
+	for _, name := range envs {
+		if _, has := generated[fmt.Sprintf("%s.env.yml", name)]; has {
+			continue
+		}
+		exists, err := environment.Exists(db, proj.Key, name)
+		if err != nil {
+			return nil, sdk.WrapError(err, "Cannot check if environment %s exists", name)
+		}
+		if exists {
+			conflicts = append(conflicts, fmt.Sprintf("environment %s", name))
+		}
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, sdk.NewError(sdk.ErrConflict, fmt.Errorf("Template %s would overwrite existing resources not generated by workflow %s: %s",
+			wt.Name, req.WorkflowName, strings.Join(conflicts, ", ")))
+	}
+
+	return i, nil
+}
+
+// previewWorkflowTemplate returns the diff between the current files of the workflow of an instance
+// and the files generated by the last version of the template, and the diff between the files generated
+// by the version of the instance and by the last version
+func previewWorkflowTemplate(ctx context.Context, db *gorp.DbMap, store cache.Store, wt *sdk.WorkflowTemplate, i sdk.WorkflowTemplateInstance, u *sdk.User) (string, string, error) {
+	proj, err := project.Load(db, store, i.ProjectKey, u, project.LoadOptions.WithPlatforms)
+	if err != nil {
+		return "", "", sdk.WrapError(err, "Cannot load project %s", i.ProjectKey)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := workflow.Pull(ctx, db, store, proj, i.WorkflowName, exportentities.FormatYAML, project.EncryptWithBuiltinKey, u, buf); err != nil {
+		return "", "", sdk.WrapError(err, "Cannot pull workflow %s", i.WorkflowName)
+	}
+	current, err := workflowtemplate.ReadTar(buf)
+	if err != nil {
+		return "", "", err
+	}
+
+	req := i.Request
+	req.ProjectKey = i.ProjectKey
+	req.WorkflowName = i.WorkflowName
+	res, err := workflowtemplate.Execute(*wt, req)
+	if err != nil {
+		return "", "", err
+	}
+
+	var versionDiff string
+	if i.WorkflowTemplateVersion != wt.Version {
+		old, err := workflowtemplate.LoadVersion(db, wt, i.WorkflowTemplateVersion)
+		if err != nil {
+			return "", "", err
+		}
+		oldRes, err := workflowtemplate.Execute(*old, req)
+		if err != nil {
+			return "", "", err
+		}
+		versionDiff = workflowtemplate.Diff(oldRes.Files, res.Files)
+	}
+
+	return workflowtemplate.Diff(current, res.Files), versionDiff, nil
+}
@@ -0,0 +1,150 @@
+package workflowtemplate
+
+import (
+	"database/sql"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadAll returns all the workflow templates with their group
+func LoadAll(db gorp.SqlExecutor) ([]sdk.WorkflowTemplate, error) {
+	var wts []workflowTemplate
+	if _, err := db.Select(&wts, "SELECT * FROM workflow_template ORDER BY name"); err != nil {
+		return nil, sdk.WrapError(err, "Cannot select workflow templates")
+	}
+
+	res := make([]sdk.WorkflowTemplate, len(wts))
+	for i := range wts {
+		res[i] = sdk.WorkflowTemplate(wts[i])
+	}
+	return res, nil
+}
+
+// LoadByGroupAndName returns a workflow template by the name of its group and its name
+func LoadByGroupAndName(db gorp.SqlExecutor, groupName, name string) (*sdk.WorkflowTemplate, error) {
+	var wt workflowTemplate
+	query := `SELECT workflow_template.*
+	FROM workflow_template
+	JOIN "group" ON "group".id = workflow_template.group_id
+	WHERE "group".name = $1 AND workflow_template.name = $2`
+	if err := db.SelectOne(&wt, query, groupName, name); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.WrapError(sdk.ErrWorkflowTemplateNotFound, "workflow template %s/%s not found", groupName, name)
+		}
+		return nil, sdk.WrapError(err, "Cannot select workflow template %s/%s", groupName, name)
+	}
+	res := sdk.WorkflowTemplate(wt)
+	return &res, nil
+}
+
+// LoadByID returns a workflow template by its ID
+func LoadByID(db gorp.SqlExecutor, id int64) (*sdk.WorkflowTemplate, error) {
+	var wt workflowTemplate
+	if err := db.SelectOne(&wt, "SELECT * FROM workflow_template WHERE id = $1", id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.WrapError(sdk.ErrWorkflowTemplateNotFound, "workflow template %d not found", id)
+		}
+		return nil, sdk.WrapError(err, "Cannot select workflow template %d", id)
+	}
+	res := sdk.WorkflowTemplate(wt)
+	return &res, nil
+}
+
+// Insert a workflow template in database
+func Insert(db gorp.SqlExecutor, wt *sdk.WorkflowTemplate) error {
+	dbwt := workflowTemplate(*wt)
+	if err := db.Insert(&dbwt); err != nil {
+		return sdk.WrapError(err, "Unable to insert workflow template %s", wt.Name)
+	}
+	*wt = sdk.WorkflowTemplate(dbwt)
+	return nil
+}
+
+// Update a workflow template in database
+func Update(db gorp.SqlExecutor, wt *sdk.WorkflowTemplate) error {
+	dbwt := workflowTemplate(*wt)
+	if n, err := db.Update(&dbwt); err != nil {
+		return sdk.WrapError(err, "Unable to update workflow template %s", wt.Name)
+	} else if n == 0 {
+		return sdk.WrapError(sdk.ErrWorkflowTemplateNotFound, "Unable to update workflow template %s", wt.Name)
+	}
+	return nil
+}
+
+// Delete a workflow template and its instances in database, the generated workflows are kept
+func Delete(db gorp.SqlExecutor, wt *sdk.WorkflowTemplate) error {
+	dbwt := workflowTemplate(*wt)
+	if _, err := db.Delete(&dbwt); err != nil {
+		return sdk.WrapError(err, "Unable to delete workflow template %s", wt.Name)
+	}
+	return nil
+}
+
+// PostGet is a db hook
+func (wt *workflowTemplate) PostGet(db gorp.SqlExecutor) error {
+	var res = struct {
+		Parameters   sql.NullString `db:"parameters"`
+		Pipelines    sql.NullString `db:"pipelines"`
+		Applications sql.NullString `db:"applications"`
+		Environments sql.NullString `db:"environments"`
+		GroupName    string         `db:"group_name"`
+	}{}
+
+	query := `SELECT parameters, pipelines, applications, environments, "group".name AS "group_name"
+	FROM workflow_template
+	JOIN "group" ON "group".id = workflow_template.group_id
+	WHERE workflow_template.id = $1`
+	if err := db.SelectOne(&res, query, wt.ID); err != nil {
+		return sdk.WrapError(err, "Unable to load workflow template %d", wt.ID)
+	}
+
+	if err := gorpmapping.JSONNullString(res.Parameters, &wt.Parameters); err != nil {
+		return sdk.WrapError(err, "Unable to unmarshal parameters")
+	}
+	if err := gorpmapping.JSONNullString(res.Pipelines, &wt.Pipelines); err != nil {
+		return sdk.WrapError(err, "Unable to unmarshal pipelines")
+	}
+	if err := gorpmapping.JSONNullString(res.Applications, &wt.Applications); err != nil {
+		return sdk.WrapError(err, "Unable to unmarshal applications")
+	}
+	if err := gorpmapping.JSONNullString(res.Environments, &wt.Environments); err != nil {
+		return sdk.WrapError(err, "Unable to unmarshal environments")
+	}
+	wt.Group = &sdk.Group{ID: wt.GroupID, Name: res.GroupName}
+	wt.GroupName = res.GroupName
+	return nil
+}
+
+// PostInsert is a db hook
+func (wt *workflowTemplate) PostInsert(db gorp.SqlExecutor) error {
+	return wt.PostUpdate(db)
+}
+
+// PostUpdate is a db hook
+func (wt *workflowTemplate) PostUpdate(db gorp.SqlExecutor) error {
+	parameters, err := gorpmapping.JSONToNullString(wt.Parameters)
+	if err != nil {
+		return sdk.WrapError(err, "Unable to marshal parameters")
+	}
+	pipelines, err := gorpmapping.JSONToNullString(wt.Pipelines)
+	if err != nil {
+		return sdk.WrapError(err, "Unable to marshal pipelines")
+	}
+	applications, err := gorpmapping.JSONToNullString(wt.Applications)
+	if err != nil {
+		return sdk.WrapError(err, "Unable to marshal applications")
+	}
+	environments, err := gorpmapping.JSONToNullString(wt.Environments)
+	if err != nil {
+		return sdk.WrapError(err, "Unable to marshal environments")
+	}
+
+	query := "UPDATE workflow_template SET parameters = $2, pipelines = $3, applications = $4, environments = $5 WHERE id = $1"
+	if _, err := db.Exec(query, wt.ID, parameters, pipelines, applications, environments); err != nil {
+		return sdk.WrapError(err, "Unable to update workflow template %d", wt.ID)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package workflowtemplate
+
+import (
+	"database/sql"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadInstancesByTemplateID returns the instances of a workflow template
+func LoadInstancesByTemplateID(db gorp.SqlExecutor, templateID int64) ([]sdk.WorkflowTemplateInstance, error) {
+	var wtis []workflowTemplateInstance
+	if _, err := db.Select(&wtis, "SELECT * FROM workflow_template_instance WHERE workflow_template_id = $1 ORDER BY id", templateID); err != nil {
+		return nil, sdk.WrapError(err, "Cannot select instances of workflow template %d", templateID)
+	}
+
+	res := make([]sdk.WorkflowTemplateInstance, len(wtis))
+	for i := range wtis {
+		res[i] = sdk.WorkflowTemplateInstance(wtis[i])
+	}
+	return res, nil
+}
+
+// LoadInstanceByWorkflowName returns the instance of the template which generated a workflow of a project, nil if none
+func LoadInstanceByWorkflowName(db gorp.SqlExecutor, projectID int64, workflowName string) (*sdk.WorkflowTemplateInstance, error) {
+	var wti workflowTemplateInstance
+	query := `SELECT workflow_template_instance.*
+	FROM workflow_template_instance
+	JOIN workflow ON workflow.id = workflow_template_instance.workflow_id
+	WHERE workflow.project_id = $1 AND workflow.name = $2`
+	if err := db.SelectOne(&wti, query, projectID, workflowName); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, sdk.WrapError(err, "Cannot select workflow template instance of workflow %s", workflowName)
+	}
+	res := sdk.WorkflowTemplateInstance(wti)
+	return &res, nil
+}
+
+// InsertInstance inserts a workflow template instance in database
+func InsertInstance(db gorp.SqlExecutor, wti *sdk.WorkflowTemplateInstance) error {
+	dbwti := workflowTemplateInstance(*wti)
+	if err := db.Insert(&dbwti); err != nil {
+		return sdk.WrapError(err, "Unable to insert workflow template instance")
+	}
+	*wti = sdk.WorkflowTemplateInstance(dbwti)
+	return nil
+}
+
+// UpdateInstance updates a workflow template instance in database
+func UpdateInstance(db gorp.SqlExecutor, wti *sdk.WorkflowTemplateInstance) error {
+	dbwti := workflowTemplateInstance(*wti)
+	if _, err := db.Update(&dbwti); err != nil {
+		return sdk.WrapError(err, "Unable to update workflow template instance %d", wti.ID)
+	}
+	*wti = sdk.WorkflowTemplateInstance(dbwti)
+	return nil
+}
+
+// PostGet is a db hook
+func (wti *workflowTemplateInstance) PostGet(db gorp.SqlExecutor) error {
+	var res = struct {
+		Request      sql.NullString `db:"request"`
+		ProjectKey   string         `db:"projectkey"`
+		WorkflowName string         `db:"workflow_name"`
+	}{}
+
+	query := `SELECT workflow_template_instance.request, project.projectkey, workflow.name AS "workflow_name"
+	FROM workflow_template_instance
+	JOIN project ON project.id = workflow_template_instance.project_id
+	JOIN workflow ON workflow.id = workflow_template_instance.workflow_id
+	WHERE workflow_template_instance.id = $1`
+	if err := db.SelectOne(&res, query, wti.ID); err != nil {
+		return sdk.WrapError(err, "Unable to load workflow template instance %d", wti.ID)
+	}
+
+	if err := gorpmapping.JSONNullString(res.Request, &wti.Request); err != nil {
+		return sdk.WrapError(err, "Unable to unmarshal request")
+	}
+	wti.ProjectKey = res.ProjectKey
+	wti.WorkflowName = res.WorkflowName
+	return nil
+}
+
+// PostInsert is a db hook
+func (wti *workflowTemplateInstance) PostInsert(db gorp.SqlExecutor) error {
+	return wti.PostUpdate(db)
+}
+
+// PostUpdate is a db hook
+func (wti *workflowTemplateInstance) PostUpdate(db gorp.SqlExecutor) error {
+	request, err := gorpmapping.JSONToNullString(wti.Request)
+	if err != nil {
+		return sdk.WrapError(err, "Unable to marshal request")
+	}
+	if _, err := db.Exec("UPDATE workflow_template_instance SET request = $2 WHERE id = $1", wti.ID, request); err != nil {
+		return sdk.WrapError(err, "Unable to update workflow template instance %d", wti.ID)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package workflowtemplate
+
+import (
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// InsertVersion saves the current version of a workflow template in its history
+func InsertVersion(db gorp.SqlExecutor, wt *sdk.WorkflowTemplate) error {
+	t, err := gorpmapping.JSONToNullString(wt)
+	if err != nil {
+		return sdk.WrapError(err, "Unable to marshal workflow template %s", wt.Name)
+	}
+	query := "INSERT INTO workflow_template_version (workflow_template_id, version, template) VALUES ($1, $2, $3)"
+	if _, err := db.Exec(query, wt.ID, wt.Version, t); err != nil {
+		return sdk.WrapError(err, "Unable to insert version %d of workflow template %s", wt.Version, wt.Name)
+	}
+	return nil
+}
+
+// LoadVersion returns a version of a workflow template from its history, with the current group of the template
+func LoadVersion(db gorp.SqlExecutor, wt *sdk.WorkflowTemplate, version int64) (*sdk.WorkflowTemplate, error) {
+	t, err := db.SelectNullStr("SELECT template FROM workflow_template_version WHERE workflow_template_id = $1 AND version = $2", wt.ID, version)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Cannot select version %d of workflow template %s", version, wt.Name)
+	}
+	if !t.Valid {
+		return nil, sdk.WrapError(sdk.ErrWorkflowTemplateNotFound, "version %d of workflow template %s not found", version, wt.Name)
+	}
+
+	var res sdk.WorkflowTemplate
+	if err := gorpmapping.JSONNullString(t, &res); err != nil {
+		return nil, sdk.WrapError(err, "Unable to unmarshal version %d of workflow template %s", version, wt.Name)
+	}
+	res.ID = wt.ID
+	res.GroupID = wt.GroupID
+	res.Group = wt.Group
+	res.GroupName = wt.GroupName
+	res.Version = version
+	return &res, nil
+}
@@ -0,0 +1,47 @@
+package workflowtemplate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestLoadVersion(t *testing.T) {
+	db, _, end := test.SetupPG(t)
+	defer end()
+
+	g := sdk.Group{Name: sdk.RandomString(10)}
+	test.NoError(t, group.InsertGroup(db, &g))
+
+	wt := sdk.WorkflowTemplate{
+		GroupID: g.ID,
+		Name:    sdk.RandomString(10),
+		Version: 1,
+		Value:   "name: first",
+	}
+	test.NoError(t, workflowtemplate.Insert(db, &wt))
+	test.NoError(t, workflowtemplate.InsertVersion(db, &wt))
+
+	wt.Version = 2
+	wt.Value = "name: second"
+	test.NoError(t, workflowtemplate.Update(db, &wt))
+	test.NoError(t, workflowtemplate.InsertVersion(db, &wt))
+
+	last, err := workflowtemplate.LoadByID(db, wt.ID)
+	test.NoError(t, err)
+	assert.Equal(t, "name: second", last.Value)
+
+	first, err := workflowtemplate.LoadVersion(db, last, 1)
+	test.NoError(t, err)
+	assert.Equal(t, int64(1), first.Version)
+	assert.Equal(t, "name: first", first.Value)
+	assert.Equal(t, g.Name, first.GroupName)
+
+	_, err = workflowtemplate.LoadVersion(db, last, 3)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrWorkflowTemplateNotFound))
+}
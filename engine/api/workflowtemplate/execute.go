@@ -0,0 +1,271 @@
+package workflowtemplate
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// Execute renders the files of a workflow template with the parameters of a request.
+// The generated files are normalized as the workflow pull exports them.
+func Execute(wt sdk.WorkflowTemplate, r sdk.WorkflowTemplateRequest) (sdk.WorkflowTemplateResult, error) {
+	res := sdk.WorkflowTemplateResult{Files: make(map[string]string)}
+
+	params, err := wt.CheckParameters(r)
+	if err != nil {
+		return res, err
+	}
+	data := map[string]interface{}{
+		"name":    r.WorkflowName,
+		"project": r.ProjectKey,
+		"params":  params,
+	}
+
+	// Workflow
+	var w exportentities.Workflow
+	if err := render(wt.Value, data, &w); err != nil {
+		return res, sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Unable to render workflow of template %s: %v", wt.Name, err))
+	}
+	w.Name = r.WorkflowName
+	if err := addFile(res.Files, fmt.Sprintf("%s.yml", w.Name), w); err != nil {
+		return res, err
+	}
+
+	// Pipelines
+	for i, p := range wt.Pipelines {
+		var pip exportentities.PipelineV1
+		if err := render(p.Value, data, &pip); err != nil {
+			return res, sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Unable to render pipeline %d of template %s: %v", i, wt.Name, err))
+		}
+		if err := addFile(res.Files, fmt.Sprintf("%s.pip.yml", pip.Name), pip); err != nil {
+			return res, err
+		}
+	}
+
+	// Applications
+	for i, a := range wt.Applications {
+		var app exportentities.Application
+		if err := render(a.Value, data, &app); err != nil {
+			return res, sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Unable to render application %d of template %s: %v", i, wt.Name, err))
+		}
+		if err := addFile(res.Files, fmt.Sprintf("%s.app.yml", app.Name), app); err != nil {
+			return res, err
+		}
+	}
+
+	// Environments
+	for i, e := range wt.Environments {
+		var env exportentities.Environment
+		if err := render(e.Value, data, &env); err != nil {
+			return res, sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Unable to render environment %d of template %s: %v", i, wt.Name, err))
+		}
+		if err := addFile(res.Files, fmt.Sprintf("%s.env.yml", env.Name), env); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+func render(value string, data interface{}, i interface{}) error {
+	t, err := sdk.ParseWorkflowTemplateValue(value)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return err
+	}
+	return yaml.Unmarshal(buf.Bytes(), i)
+}
+
+func addFile(files map[string]string, name string, i interface{}) error {
+	if strings.HasPrefix(name, ".") {
+		return sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Invalid generated file %s: name is required", name))
+	}
+	if _, has := files[name]; has {
+		return sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Invalid generated file %s: duplicate name", name))
+	}
+	b, err := exportentities.Marshal(i, exportentities.FormatYAML)
+	if err != nil {
+		return sdk.WrapError(err, "Unable to marshal %s", name)
+	}
+	files[name] = string(b)
+	return nil
+}
+
+// Tar writes the generated files as an archive for the workflow push
+func Tar(res sdk.WorkflowTemplateResult, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	for _, name := range sortedNames(res.Files) {
+		hdr := &tar.Header{
+			Name: name,
+			Mode: 0644,
+			Size: int64(len(res.Files[name])),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			tw.Close()
+			return sdk.WrapError(err, "Unable to write header %+v", hdr)
+		}
+		if _, err := io.WriteString(tw, res.Files[name]); err != nil {
+			tw.Close()
+			return sdk.WrapError(err, "Unable to write file %s", name)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return sdk.WrapError(err, "Unable to close tar writer")
+	}
+	return nil
+}
+
+// ReadTar returns the files of an archive from the workflow pull, by file name
+func ReadTar(r io.Reader) (map[string]string, error) {
+	files := make(map[string]string)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, sdk.WrapError(err, "Unable to read tar file")
+		}
+		buf := new(bytes.Buffer)
+		if _, err := io.Copy(buf, tr); err != nil {
+			return nil, sdk.WrapError(err, "Unable to read tar file %s", hdr.Name)
+		}
+		files[hdr.Name] = buf.String()
+	}
+	return files, nil
+}
+
+// Diff returns the unified diff between the current files of a workflow and the generated ones.
+// The current files not generated by the template are ignored: the workflow push keeps them as is.
+func Diff(current, generated map[string]string) string {
+	var buf bytes.Buffer
+	for _, name := range sortedNames(generated) {
+		if current[name] == generated[name] {
+			continue
+		}
+		fmt.Fprintf(&buf, "--- a/%s\n+++ b/%s\n", name, name)
+		writeHunks(&buf, diffLines(splitLines(current[name]), splitLines(generated[name])), 3)
+	}
+	return buf.String()
+}
+
+type diffLine struct {
+	op   byte
+	text string
+	a, b int
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.SplitAfter(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes the edit script between two lists of lines with their longest common subsequence
+func diffLines(a, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{op: ' ', text: a[i], a: i, b: j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{op: '-', text: a[i], a: i, b: j})
+			i++
+		default:
+			lines = append(lines, diffLine{op: '+', text: b[j], a: i, b: j})
+			j++
+		}
+	}
+	return lines
+}
+
+// writeHunks writes the changed lines of an edit script in unified format, with some lines of context
+func writeHunks(w io.Writer, lines []diffLine, context int) {
+	for start := 0; start < len(lines); {
+		// Find the next change
+		for start < len(lines) && lines[start].op == ' ' {
+			start++
+		}
+		if start == len(lines) {
+			return
+		}
+
+		// Extend the hunk while the changes are close enough
+		end := start
+		for k := start; k < len(lines); k++ {
+			if lines[k].op != ' ' {
+				end = k + 1
+			} else if k-end >= 2*context {
+				break
+			}
+		}
+		from := start - context
+		if from < 0 {
+			from = 0
+		}
+		to := end + context
+		if to > len(lines) {
+			to = len(lines)
+		}
+
+		var countA, countB int
+		for _, l := range lines[from:to] {
+			if l.op != '+' {
+				countA++
+			}
+			if l.op != '-' {
+				countB++
+			}
+		}
+		fmt.Fprintf(w, "@@ -%d,%d +%d,%d @@\n", lines[from].a+1, countA, lines[from].b+1, countB)
+		for _, l := range lines[from:to] {
+			text := l.text
+			if !strings.HasSuffix(text, "\n") {
+				text += "\n"
+			}
+			fmt.Fprintf(w, "%c%s", l.op, text)
+		}
+		start = to
+	}
+}
+
+func sortedNames(files map[string]string) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,96 @@
+package workflowtemplate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestExecute(t *testing.T) {
+	wt := sdk.WorkflowTemplate{
+		Name: "go-service",
+		Parameters: []sdk.WorkflowTemplateParameter{
+			{Key: "app", Type: sdk.WorkflowTemplateParameterTypeString, Required: true},
+			{Key: "deploy", Type: sdk.WorkflowTemplateParameterTypeBoolean},
+		},
+		Value: `name: any
+version: v1.0
+workflow:
+  build:
+    pipeline: build-[[ .params.app ]]
+    application: [[ .params.app ]]
+[[- if .params.deploy ]]
+  deploy:
+    depends_on:
+    - build
+    pipeline: deploy
+[[- end ]]
+`,
+		Pipelines: []sdk.PipelineTemplate{{Value: `version: v1.0
+name: build-[[ .params.app ]]
+jobs:
+- job: build
+  steps:
+  - script: make VERSION={{.cds.version}} [[ .name ]]
+`}},
+	}
+
+	_, err := Execute(wt, sdk.WorkflowTemplateRequest{WorkflowName: "my-service"})
+	assert.Error(t, err, "app is required")
+
+	res, err := Execute(wt, sdk.WorkflowTemplateRequest{
+		ProjectKey:   "PROJ",
+		WorkflowName: "my-service",
+		Parameters:   map[string]string{"app": "my-app"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, res.Files, 2)
+	assert.Contains(t, res.Files["my-service.yml"], "name: my-service")
+	assert.Contains(t, res.Files["my-service.yml"], "pipeline: build-my-app")
+	assert.NotContains(t, res.Files["my-service.yml"], "deploy")
+	assert.Contains(t, res.Files["build-my-app.pip.yml"], "make VERSION={{.cds.version}} my-service")
+
+	res, err = Execute(wt, sdk.WorkflowTemplateRequest{
+		WorkflowName: "my-service",
+		Parameters:   map[string]string{"app": "my-app", "deploy": "true"},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, res.Files["my-service.yml"], "pipeline: deploy")
+
+	// the files can be read back from the push archive
+	buf := new(bytes.Buffer)
+	assert.NoError(t, Tar(res, buf))
+	files, err := ReadTar(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, res.Files, files)
+}
+
+func TestDiff(t *testing.T) {
+	current := map[string]string{
+		"w.yml":       "name: w\nversion: v1.0\npipeline: build\napplication: app\n",
+		"old.pip.yml": "name: old\n",
+	}
+	generated := map[string]string{
+		"w.yml":       "name: w\nversion: v1.0\npipeline: build\napplication: my-app\n",
+		"new.pip.yml": "name: new\n",
+	}
+
+	assert.Equal(t, `--- a/new.pip.yml
++++ b/new.pip.yml
+@@ -1,0 +1,1 @@
++name: new
+--- a/w.yml
++++ b/w.yml
+@@ -1,4 +1,4 @@
+ name: w
+ version: v1.0
+ pipeline: build
+-application: app
++application: my-app
+`, Diff(current, generated))
+
+	assert.Empty(t, Diff(generated, generated))
+}
@@ -0,0 +1,17 @@
+package workflowtemplate
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// workflowTemplate is a gorp wrapper around sdk.WorkflowTemplate
+type workflowTemplate sdk.WorkflowTemplate
+
+// workflowTemplateInstance is a gorp wrapper around sdk.WorkflowTemplateInstance
+type workflowTemplateInstance sdk.WorkflowTemplateInstance
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(workflowTemplate{}, "workflow_template", true, "id"))
+	gorpmapping.Register(gorpmapping.New(workflowTemplateInstance{}, "workflow_template_instance", true, "id"))
+}
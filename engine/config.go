@@ -13,6 +13,7 @@ import (
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/api/secret"
 	"github.com/ovh/cds/engine/elasticsearch"
+	"github.com/ovh/cds/engine/hatchery/docker"
 	"github.com/ovh/cds/engine/hatchery/kubernetes"
 	"github.com/ovh/cds/engine/hatchery/local"
 	"github.com/ovh/cds/engine/hatchery/marathon"
@@ -42,6 +43,9 @@ func configSetDefaults() {
 	if conf.Hatchery != nil && conf.Hatchery.Local != nil {
 		defaults.SetDefaults(conf.Hatchery.Local)
 	}
+	if conf.Hatchery != nil && conf.Hatchery.Docker != nil {
+		defaults.SetDefaults(conf.Hatchery.Docker)
+	}
 	if conf.Hatchery != nil && conf.Hatchery.Kubernetes != nil {
 		defaults.SetDefaults(conf.Hatchery.Kubernetes)
 	}
@@ -95,6 +99,10 @@ func configBootstrap(args []string) {
 			if conf.Hatchery.Local == nil {
 				conf.Hatchery.Local = &local.HatcheryConfiguration{}
 			}
+		case "hatchery:docker":
+			if conf.Hatchery.Docker == nil {
+				conf.Hatchery.Docker = &docker.HatcheryConfiguration{}
+			}
 		case "hatchery:kubernetes":
 			if conf.Hatchery.Kubernetes == nil {
 				conf.Hatchery.Kubernetes = &kubernetes.HatcheryConfiguration{}
@@ -144,6 +152,7 @@ func configBootstrap(args []string) {
 		conf.DatabaseMigrate = &migrateservice.Configuration{}
 		conf.Hatchery = &HatcheryConfiguration{}
 		conf.Hatchery.Local = &local.HatcheryConfiguration{}
+		conf.Hatchery.Docker = &docker.HatcheryConfiguration{}
 		conf.Hatchery.Kubernetes = &kubernetes.HatcheryConfiguration{}
 		conf.Hatchery.Marathon = &marathon.HatcheryConfiguration{}
 		conf.Hatchery.Openstack = &openstack.HatcheryConfiguration{}
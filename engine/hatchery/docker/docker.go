@@ -0,0 +1,400 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+	"time"
+
+	types "github.com/docker/docker/api/types"
+	docker "github.com/docker/docker/client"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/namesgenerator"
+)
+
+// New instanciates a new Hatchery Docker
+func New() *HatcheryDocker {
+	s := new(HatcheryDocker)
+	s.Router = &api.Router{
+		Mux: mux.NewRouter(),
+	}
+	return s
+}
+
+//Init connects the hatchery to the docker api
+func (h *HatcheryDocker) Init() error {
+	opts := []func(*docker.Client) error{docker.FromEnv}
+	if h.Config.Host != "" {
+		opts = append(opts, docker.WithHost(h.Config.Host))
+	}
+	if h.Config.APIVersion != "" {
+		opts = append(opts, docker.WithVersion(h.Config.APIVersion))
+	}
+	d, err := docker.NewClientWithOpts(opts...)
+	if err != nil {
+		log.Error("hatchery> docker> unable to connect to a docker client: %v", err)
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := d.Ping(ctx); err != nil {
+		log.Error("hatchery> docker> unable to ping docker host %s: %v", d.DaemonHost(), err)
+		return err
+	}
+	h.dockerClient = d
+	log.Info("hatchery> docker> connected to %s", d.DaemonHost())
+
+	sdk.GoRoutine(context.Background(), "hatchery docker routines", func(ctx context.Context) { h.routines(ctx) })
+
+	return nil
+}
+
+// SpawnWorker starts a new worker container, the services required by the job are started in containers
+// on a dedicated network where the worker is reachable with the name "worker"
+func (h *HatcheryDocker) SpawnWorker(ctx context.Context, spawnArgs hatchery.SpawnArguments) (string, error) {
+	ctx, end := observability.Span(ctx, "docker.SpawnWorker")
+	defer end()
+
+	//name is the name of the worker and the name of the container
+	name := fmt.Sprintf("docker-%s-%s", strings.ToLower(spawnArgs.Model.Name), strings.Replace(namesgenerator.GetRandomNameCDS(0), "_", "-", -1))
+	if spawnArgs.RegisterOnly {
+		name = "register-" + name
+	}
+
+	observability.Current(ctx, observability.Tag(observability.TagWorker, name))
+	log.Debug("hatchery> docker> SpawnWorker> Spawning worker %s - %s", name, spawnArgs.LogInfo)
+
+	//Memory for the worker
+	memory := int64(h.Config.DefaultMemory)
+	if spawnArgs.Model.ModelDocker.Memory != 0 {
+		memory = spawnArgs.Model.ModelDocker.Memory
+	}
+
+	var network, networkAlias string
+	services := []string{}
+
+	if spawnArgs.JobID > 0 {
+		for _, r := range spawnArgs.Requirements {
+			switch r.Type {
+			case sdk.MemoryRequirement:
+				var err error
+				memory, err = strconv.ParseInt(r.Value, 10, 64)
+				if err != nil {
+					log.Warning("hatchery> docker> SpawnWorker> Unable to parse memory requirement %s: %v", r.Value, err)
+					return "", err
+				}
+			case sdk.ServiceRequirement:
+				//Create a network if not already created
+				if network == "" {
+					network = name + "-net"
+					networkAlias = "worker"
+					if err := h.createNetwork(ctx, network); err != nil {
+						log.Warning("hatchery> docker> SpawnWorker> Unable to create network %s for jobID %d: %v", network, spawnArgs.JobID, err)
+						return "", err
+					}
+				}
+
+				service, err := parseServiceRequirement(r.Value)
+				if err != nil {
+					return "", sdk.WrapError(err, "Unable to start service %s", r.Name)
+				}
+				serviceName := r.Name + "-" + name
+
+				//labels are used to make container cleanup easier. We "link" the service to its worker this way.
+				labels := map[string]string{
+					labelServiceWorker: name,
+					labelHatchery:      h.Config.Name,
+				}
+				if spawnArgs.IsWorkflowJob {
+					labels[labelServiceJobID] = fmt.Sprintf("%d", spawnArgs.JobID)
+					labels[labelServiceID] = fmt.Sprintf("%d", r.ID)
+					labels[labelServiceReqName] = r.Name
+				}
+
+				//name= <alias> => the name of the host put in /etc/hosts of the worker
+				args := containerArgs{
+					name:         serviceName,
+					image:        service.image,
+					network:      network,
+					networkAlias: r.Name,
+					cmd:          []string{},
+					env:          service.env,
+					labels:       labels,
+					memory:       service.memory,
+				}
+				if err := h.createAndStartContainer(ctx, args); err != nil {
+					log.Warning("hatchery> docker> SpawnWorker> Unable to start required container: %s", err)
+					return "", err
+				}
+				services = append(services, serviceName)
+			}
+		}
+	}
+
+	if spawnArgs.RegisterOnly {
+		spawnArgs.Model.ModelDocker.Cmd += " register"
+		memory = hatchery.MemoryRegisterContainer
+	}
+
+	//labels are used to make container cleanup easier
+	labels := map[string]string{
+		labelWorkerModel:      strconv.FormatInt(spawnArgs.Model.ID, 10),
+		labelWorkerName:       name,
+		"worker_requirements": strings.Join(services, ","),
+		labelHatchery:         h.Config.Name,
+	}
+
+	isSharedInfra := h.Service() != nil && h.Service().IsSharedInfra
+	dockerOpts, err := h.computeDockerOpts(isSharedInfra, spawnArgs.Requirements)
+	if err != nil {
+		return name, err
+	}
+
+	udataParam := sdk.WorkerArgs{
+		API:               h.Configuration().API.HTTP.URL,
+		Token:             h.Configuration().API.Token,
+		HTTPInsecure:      h.Config.API.HTTP.Insecure,
+		Name:              name,
+		Model:             spawnArgs.Model.ID,
+		TTL:               h.Config.WorkerTTL,
+		HatcheryName:      h.Config.Name,
+		GraylogHost:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Host,
+		GraylogPort:       h.Configuration().Provision.WorkerLogsOptions.Graylog.Port,
+		GraylogExtraKey:   h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraKey,
+		GraylogExtraValue: h.Configuration().Provision.WorkerLogsOptions.Graylog.ExtraValue,
+		GrpcAPI:           h.Configuration().API.GRPC.URL,
+		GrpcInsecure:      h.Configuration().API.GRPC.Insecure,
+	}
+
+	if spawnArgs.JobID > 0 {
+		if spawnArgs.IsWorkflowJob {
+			udataParam.WorkflowJobID = spawnArgs.JobID
+		} else {
+			udataParam.PipelineBuildJobID = spawnArgs.JobID
+		}
+	}
+
+	tmpl, errt := template.New("cmd").Parse(spawnArgs.Model.ModelDocker.Cmd)
+	if errt != nil {
+		return "", errt
+	}
+	var buffer bytes.Buffer
+	if errTmpl := tmpl.Execute(&buffer, udataParam); errTmpl != nil {
+		return "", errTmpl
+	}
+	cmds := strings.Fields(spawnArgs.Model.ModelDocker.Shell)
+	cmds = append(cmds, buffer.String())
+
+	// copy envs to avoid data race
+	modelEnvs := make(map[string]string, len(spawnArgs.Model.ModelDocker.Envs))
+	for k, v := range spawnArgs.Model.ModelDocker.Envs {
+		modelEnvs[k] = v
+	}
+
+	envsWm := map[string]string{}
+	envsWm["CDS_FORCE_EXIT"] = "1"
+	envsWm["CDS_API"] = udataParam.API
+	envsWm["CDS_TOKEN"] = udataParam.Token
+	envsWm["CDS_NAME"] = udataParam.Name
+	envsWm["CDS_MODEL"] = fmt.Sprintf("%d", udataParam.Model)
+	envsWm["CDS_HATCHERY_NAME"] = udataParam.HatcheryName
+	envsWm["CDS_FROM_WORKER_IMAGE"] = fmt.Sprintf("%v", udataParam.FromWorkerImage)
+	envsWm["CDS_INSECURE"] = fmt.Sprintf("%v", udataParam.HTTPInsecure)
+
+	if spawnArgs.JobID > 0 {
+		if spawnArgs.IsWorkflowJob {
+			envsWm["CDS_BOOKED_WORKFLOW_JOB_ID"] = fmt.Sprintf("%d", spawnArgs.JobID)
+		} else {
+			envsWm["CDS_BOOKED_PB_JOB_ID"] = fmt.Sprintf("%d", spawnArgs.JobID)
+		}
+	}
+
+	if udataParam.GrpcAPI != "" && spawnArgs.Model.Communication == sdk.GRPC {
+		envsWm["CDS_GRPC_API"] = udataParam.GrpcAPI
+		envsWm["CDS_GRPC_INSECURE"] = fmt.Sprintf("%v", udataParam.GrpcInsecure)
+	}
+
+	envTemplated, errEnv := sdk.TemplateEnvs(udataParam, modelEnvs)
+	if errEnv != nil {
+		return "", errEnv
+	}
+
+	for envName, envValue := range envTemplated {
+		envsWm[envName] = envValue
+	}
+
+	envs := make([]string, 0, len(envsWm))
+	for envName, envValue := range envsWm {
+		envs = append(envs, envName+"="+envValue)
+	}
+
+	args := containerArgs{
+		name:         name,
+		image:        spawnArgs.Model.ModelDocker.Image,
+		network:      network,
+		networkAlias: networkAlias,
+		cmd:          cmds,
+		labels:       labels,
+		memory:       memory,
+		dockerOpts:   *dockerOpts,
+		entryPoint:   []string{},
+		env:          envs,
+	}
+
+	//start the worker
+	if err := h.createAndStartContainer(ctx, args); err != nil {
+		log.Warning("hatchery> docker> SpawnWorker> Unable to start container %s with image %s: %v", args.name, spawnArgs.Model.ModelDocker.Image, err)
+		return "", err
+	}
+
+	return name, nil
+}
+
+// CanSpawn checks if there is enough room on the docker host for the worker and its services
+func (h *HatcheryDocker) CanSpawn(model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
+	cs, err := h.getContainers(types.ContainerListOptions{All: true})
+	if err != nil {
+		log.Error("hatchery> docker> CanSpawn> Unable to list containers: %s", err)
+		return false
+	}
+
+	needed := 1
+	for _, r := range requirements {
+		if r.Type == sdk.ServiceRequirement {
+			needed++
+		}
+	}
+
+	if len(cs)+needed > h.Config.MaxContainers {
+		log.Debug("hatchery> docker> CanSpawn> max containers reached. current:%d needed:%d max:%d", len(cs), needed, h.Config.MaxContainers)
+		return false
+	}
+	return true
+}
+
+// getWorkerContainers returns the running workers containers
+func (h *HatcheryDocker) getWorkerContainers() ([]types.Container, error) {
+	cs, err := h.getContainers(types.ContainerListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	res := []types.Container{}
+	for _, c := range cs {
+		if _, ok := c.Labels[labelWorkerName]; ok {
+			res = append(res, c)
+		}
+	}
+	return res, nil
+}
+
+// WorkersStarted returns the number of instances started but
+// not necessarily register on CDS yet
+func (h *HatcheryDocker) WorkersStarted() []string {
+	workers, err := h.getWorkerContainers()
+	if err != nil {
+		log.Error("hatchery> docker> WorkersStarted> Unable to list containers: %s", err)
+		return nil
+	}
+	res := make([]string, 0, len(workers))
+	for _, w := range workers {
+		res = append(res, w.Labels[labelWorkerName])
+	}
+	return res
+}
+
+// WorkersStartedByModel returns the number of started workers
+func (h *HatcheryDocker) WorkersStartedByModel(model *sdk.Model) int {
+	workers, err := h.getWorkerContainers()
+	if err != nil {
+		log.Error("hatchery> docker> WorkersStartedByModel> Unable to list containers: %s", err)
+		return 0
+	}
+	var nb int
+	for _, w := range workers {
+		if w.Labels[labelWorkerModel] == strconv.FormatInt(model.ID, 10) {
+			nb++
+		}
+	}
+	log.Debug("hatchery> docker> WorkersStartedByModel> %s \t %d", model.Name, nb)
+	return nb
+}
+
+// ModelType returns type of hatchery
+func (*HatcheryDocker) ModelType() string {
+	return sdk.Docker
+}
+
+// Hatchery returns Hatchery instances
+func (h *HatcheryDocker) Hatchery() *sdk.Hatchery {
+	return h.hatch
+}
+
+// Serve start the hatchery server
+func (h *HatcheryDocker) Serve(ctx context.Context) error {
+	return h.CommonServe(ctx, h)
+}
+
+//Configuration returns Hatchery CommonConfiguration
+func (h *HatcheryDocker) Configuration() hatchery.CommonConfiguration {
+	return h.Config.CommonConfiguration
+}
+
+// ID returns ID of the Hatchery
+func (h *HatcheryDocker) ID() int64 {
+	if h.CDSClient().GetService() == nil {
+		return 0
+	}
+	return h.CDSClient().GetService().ID
+}
+
+//Service returns service instance
+func (h *HatcheryDocker) Service() *sdk.Service {
+	return h.CDSClient().GetService()
+}
+
+// WorkerModelsEnabled returns Worker model enabled
+func (h *HatcheryDocker) WorkerModelsEnabled() ([]sdk.Model, error) {
+	return h.CDSClient().WorkerModelsEnabled()
+}
+
+// NeedRegistration return true if worker model need regsitration
+func (h *HatcheryDocker) NeedRegistration(m *sdk.Model) bool {
+	return m.NeedRegistration || m.LastRegistration.Unix() < m.UserLastModified.Unix()
+}
+
+func (h *HatcheryDocker) routines(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			sdk.GoRoutine(ctx, "getServicesLogs", func(ctx context.Context) {
+				if err := h.getServicesLogs(); err != nil {
+					log.Error("hatchery> docker> Cannot get service logs: %v", err)
+				}
+			})
+
+			sdk.GoRoutine(ctx, "killAwolWorkers", func(ctx context.Context) {
+				if err := h.killAwolWorkers(); err != nil {
+					log.Warning("hatchery> docker> Cannot kill awol workers: %v", err)
+				}
+			})
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error("hatchery> docker> Exiting routines")
+			}
+			return
+		}
+	}
+}
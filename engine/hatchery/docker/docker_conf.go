@@ -0,0 +1,95 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	types "github.com/docker/docker/api/types"
+
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// ApplyConfiguration apply an object of type HatcheryConfiguration after checking it
+func (h *HatcheryDocker) ApplyConfiguration(cfg interface{}) error {
+	if err := h.CheckConfiguration(cfg); err != nil {
+		return err
+	}
+
+	var ok bool
+	h.Config, ok = cfg.(HatcheryConfiguration)
+	if !ok {
+		return fmt.Errorf("Invalid configuration")
+	}
+
+	h.hatch = &sdk.Hatchery{}
+
+	h.Client = cdsclient.NewService(h.Config.API.HTTP.URL, 60*time.Second, h.Config.API.HTTP.Insecure)
+	h.API = h.Config.API.HTTP.URL
+	h.Name = h.Config.Name
+	h.HTTPURL = h.Config.URL
+	h.Token = h.Config.API.Token
+	h.Type = services.TypeHatchery
+	h.MaxHeartbeatFailures = h.Config.API.MaxHeartbeatFailures
+	h.Common.Common.ServiceName = "cds-hatchery-docker"
+
+	return nil
+}
+
+// Status returns sdk.MonitoringStatus, implements interface service.Service
+func (h *HatcheryDocker) Status() sdk.MonitoringStatus {
+	m := h.CommonMonitoring()
+	if h.IsInitialized() {
+		m.Lines = append(m.Lines, sdk.MonitoringStatusLine{Component: "Workers", Value: fmt.Sprintf("%d/%d", len(h.WorkersStarted()), h.Config.Provision.MaxWorker), Status: sdk.MonitoringStatusOK})
+
+		status := sdk.MonitoringStatusOK
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if _, err := h.dockerClient.Ping(ctx); err != nil {
+			log.Warning("hatchery> docker> %s> Status> Unable to ping docker host: %s", h.Name, err)
+			status = sdk.MonitoringStatusAlert
+		}
+		cs, err := h.getContainers(types.ContainerListOptions{All: true})
+		if err != nil {
+			log.Warning("hatchery> docker> %s> Status> Unable to list containers: %s", h.Name, err)
+			status = sdk.MonitoringStatusAlert
+		}
+		m.Lines = append(m.Lines, sdk.MonitoringStatusLine{Component: "Containers", Value: fmt.Sprintf("%d/%d", len(cs), h.Config.MaxContainers), Status: status})
+	}
+	return m
+}
+
+// CheckConfiguration checks the validity of the configuration object
+func (h *HatcheryDocker) CheckConfiguration(cfg interface{}) error {
+	hconfig, ok := cfg.(HatcheryConfiguration)
+	if !ok {
+		return fmt.Errorf("Invalid configuration")
+	}
+
+	if hconfig.API.HTTP.URL == "" {
+		return fmt.Errorf("API HTTP(s) URL is mandatory")
+	}
+
+	if hconfig.API.Token == "" {
+		return fmt.Errorf("API Token URL is mandatory")
+	}
+
+	if hconfig.WorkerTTL <= 0 {
+		return fmt.Errorf("worker-ttl must be > 0")
+	}
+	if hconfig.DefaultMemory <= 1 {
+		return fmt.Errorf("worker-memory must be > 1")
+	}
+	if hconfig.MaxContainers <= 0 {
+		return fmt.Errorf("max-containers must be > 0")
+	}
+
+	if hconfig.Name == "" {
+		return fmt.Errorf("please enter a name in your docker hatchery configuration")
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	types "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/strslice"
+
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const timeoutPullImage = 10 * time.Minute
+
+// getContainers returns the containers created by this hatchery
+func (h *HatcheryDocker) getContainers(options types.ContainerListOptions) ([]types.Container, error) {
+	options.Filters = filters.NewArgs()
+	options.Filters.Add("label", labelHatchery+"="+h.Config.Name)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cs, err := h.dockerClient.ContainerList(ctx, options)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to list containers")
+	}
+	return cs, nil
+}
+
+//create the docker bridge of a job, the worker reaches its services through this network
+func (h *HatcheryDocker) createNetwork(ctx context.Context, name string) error {
+	ctx, end := observability.Span(ctx, "docker.createNetwork", observability.Tag("network", name))
+	defer end()
+	log.Debug("hatchery> docker> createNetwork> Create network %s", name)
+	_, err := h.dockerClient.NetworkCreate(ctx, name, types.NetworkCreate{
+		Driver:         "bridge",
+		CheckDuplicate: true,
+		Labels: map[string]string{
+			labelWorkerNetwork: name,
+			labelHatchery:      h.Config.Name,
+		},
+	})
+	return err
+}
+
+type containerArgs struct {
+	name, image, network, networkAlias string
+	cmd, env                           []string
+	labels                             map[string]string
+	memory                             int64
+	dockerOpts                         dockerOpts
+	entryPoint                         strslice.StrSlice
+}
+
+//shortcut to create+start(=run) a container
+func (h *HatcheryDocker) createAndStartContainer(ctx context.Context, cArgs containerArgs) error {
+	ctx, end := observability.Span(ctx, "docker.createAndStartContainer", observability.Tag(observability.TagWorker, cArgs.name))
+	defer end()
+
+	//Memory is set to 1GB by default
+	if cArgs.memory <= 4 {
+		cArgs.memory = 1024
+	}
+	log.Info("hatchery> docker> createAndStartContainer> Create container %s from %s (memory=%dMB)", cArgs.name, cArgs.image, cArgs.memory)
+
+	config := &container.Config{
+		Image:      cArgs.image,
+		Env:        cArgs.env,
+		Cmd:        cArgs.cmd,
+		Labels:     cArgs.labels,
+		Entrypoint: cArgs.entryPoint,
+	}
+
+	hostConfig := &container.HostConfig{
+		Privileged: cArgs.dockerOpts.privileged,
+		Mounts:     cArgs.dockerOpts.mounts,
+		ExtraHosts: cArgs.dockerOpts.extraHosts,
+	}
+	hostConfig.Resources = container.Resources{
+		Memory:     cArgs.memory * 1024 * 1024, //from MB to B
+		MemorySwap: -1,
+	}
+
+	networkingConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{},
+	}
+	if cArgs.network != "" {
+		networkingConfig.EndpointsConfig[cArgs.network] = &network.EndpointSettings{
+			Aliases: []string{cArgs.networkAlias, cArgs.name},
+		}
+	}
+
+	if err := h.pullImageIfNeeded(ctx, cArgs.image); err != nil {
+		return err
+	}
+
+	c, err := h.dockerClient.ContainerCreate(ctx, config, hostConfig, networkingConfig, cArgs.name)
+	if err != nil {
+		return sdk.WrapError(err, "Unable to create container %s", cArgs.name)
+	}
+
+	if err := h.dockerClient.ContainerStart(ctx, c.ID, types.ContainerStartOptions{}); err != nil {
+		return sdk.WrapError(err, "Unable to start container %s", c.ID[:12])
+	}
+	return nil
+}
+
+// pullImageIfNeeded pulls the image if it's not on the host, images with the latest tag are always pulled
+func (h *HatcheryDocker) pullImageIfNeeded(ctx context.Context, img string) error {
+	if !strings.HasSuffix(img, ":latest") {
+		images, err := h.dockerClient.ImageList(ctx, types.ImageListOptions{All: true})
+		if err != nil {
+			log.Warning("hatchery> docker> pullImageIfNeeded> Unable to list images: %s", err)
+		}
+		for _, i := range images {
+			for _, t := range i.RepoTags {
+				if t == img {
+					return nil
+				}
+			}
+		}
+	}
+
+	t0 := time.Now()
+	ctxPull, cancel := context.WithTimeout(ctx, timeoutPullImage)
+	defer cancel()
+	res, err := h.dockerClient.ImageCreate(ctxPull, img, types.ImageCreateOptions{})
+	if err != nil {
+		return sdk.WrapError(err, "Unable to pull image %s", img)
+	}
+	defer res.Close() // nolint
+
+	// The pull is over when the progress stream is closed
+	buff := new(bytes.Buffer)
+	if _, err := io.Copy(buff, res); err != nil {
+		return sdk.WrapError(err, "Unable to pull image %s", img)
+	}
+	log.Debug(buff.String())
+	log.Info("hatchery> docker> pullImageIfNeeded> pulling image %s - %.3f seconds elapsed", img, time.Since(t0).Seconds())
+	return nil
+}
+
+// serviceRequirement is a container started next to the worker for a service requirement
+type serviceRequirement struct {
+	image  string
+	env    []string
+	memory int64
+}
+
+// parseServiceRequirement reads the value of a service requirement
+//value= "postgres:latest env_1=blabla env_2=blabla" => we can add env variables in requirement name
+//option for power user : set the service memory with CDS_SERVICE_MEMORY=1024
+func parseServiceRequirement(value string) (serviceRequirement, error) {
+	tuple := strings.Fields(value)
+	if len(tuple) == 0 {
+		return serviceRequirement{}, fmt.Errorf("invalid service requirement: image is mandatory")
+	}
+
+	s := serviceRequirement{image: tuple[0], memory: 1024}
+	for _, t := range tuple[1:] {
+		kv := strings.SplitN(t, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		val := strings.Trim(kv[1], "\"")
+		if kv[0] == "CDS_SERVICE_MEMORY" {
+			m, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return s, fmt.Errorf("invalid service memory %s: %v", val, err)
+			}
+			s.memory = m
+			continue
+		}
+		s.env = append(s.env, kv[0]+"="+val)
+	}
+	return s, nil
+}
+
+type dockerOpts struct {
+	privileged bool
+	mounts     []mount.Mount
+	extraHosts []string
+}
+
+// computeDockerOpts returns the options of the worker container from the hatchery configuration and the volume requirements
+func (h *HatcheryDocker) computeDockerOpts(isSharedInfra bool, requirements []sdk.Requirement) (*dockerOpts, error) {
+	opts := &dockerOpts{}
+
+	for _, opt := range strings.Fields(h.Config.DockerOpts) {
+		if strings.HasPrefix(opt, "--add-host=") {
+			opts.extraHosts = append(opts.extraHosts, strings.TrimPrefix(opt, "--add-host="))
+		} else if opt == "--privileged" {
+			opts.privileged = true
+		}
+	}
+
+	for _, r := range requirements {
+		if r.Type != sdk.VolumeRequirement {
+			continue
+		}
+		if isSharedInfra {
+			return nil, fmt.Errorf("you could not use the volume requirement '%s' with a 'shared.infra' hatchery. Please use you own hatchery or remove this requirement", r.Value)
+		}
+		m, err := parseMount(strings.Fields(r.Value)[0])
+		if err != nil {
+			return nil, err
+		}
+		opts.mounts = append(opts.mounts, m)
+	}
+	return opts, nil
+}
+
+// parseMount reads a mount option as in docker run --mount
+// example: type=bind,source=/hostDir/sourceDir,destination=/dirInJob,readonly
+func parseMount(opt string) (mount.Mount, error) {
+	var m mount.Mount
+	var bindPropagation string
+	for _, o := range strings.Split(opt, ",") {
+		kv := strings.SplitN(o, "=", 2)
+		switch {
+		case len(kv) == 1 && kv[0] == "readonly":
+			m.ReadOnly = true
+		case len(kv) != 2:
+			continue
+		case kv[0] == "type":
+			m.Type = mount.Type(kv[1])
+		case kv[0] == "source":
+			m.Source = kv[1]
+		case kv[0] == "destination":
+			m.Target = kv[1]
+		case kv[0] == "bind-propagation":
+			bindPropagation = kv[1]
+		}
+	}
+	if m.Type == "" || m.Source == "" || m.Target == "" {
+		return m, fmt.Errorf("Invalid mount option. Example:type=bind,source=/hostDir/sourceDir,destination=/dirInJob current:%s", opt)
+	}
+	// rprivate is the default value
+	if bindPropagation != "" {
+		m.BindOptions = &mount.BindOptions{Propagation: mount.Propagation(bindPropagation)}
+	}
+	return m, nil
+}
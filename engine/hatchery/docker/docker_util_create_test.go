@@ -0,0 +1,54 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/mount"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_parseServiceRequirement(t *testing.T) {
+	s, err := parseServiceRequirement("postgres:9.5.3 POSTGRES_USER=myuser POSTGRES_PASSWORD=\"mypassword\" CDS_SERVICE_MEMORY=512")
+	assert.NoError(t, err)
+	assert.Equal(t, "postgres:9.5.3", s.image)
+	assert.Equal(t, []string{"POSTGRES_USER=myuser", "POSTGRES_PASSWORD=mypassword"}, s.env)
+	assert.Equal(t, int64(512), s.memory)
+
+	s, err = parseServiceRequirement("redis")
+	assert.NoError(t, err)
+	assert.Equal(t, "redis", s.image)
+	assert.Equal(t, int64(1024), s.memory)
+
+	_, err = parseServiceRequirement("redis CDS_SERVICE_MEMORY=a lot")
+	assert.Error(t, err)
+	_, err = parseServiceRequirement(" ")
+	assert.Error(t, err)
+}
+
+func Test_computeDockerOpts(t *testing.T) {
+	h := &HatcheryDocker{}
+	h.Config.DockerOpts = "--add-host=myhost:1.2.3.4 --privileged"
+
+	reqs := []sdk.Requirement{
+		{Name: "go", Type: sdk.ModelRequirement, Value: "golang:1.11"},
+		{Name: "cache", Type: sdk.VolumeRequirement, Value: "type=bind,source=/var/cache/go,destination=/go/pkg,readonly"},
+		{Name: "socket", Type: sdk.VolumeRequirement, Value: "type=bind,source=/run/my.sock,destination=/run/my.sock,bind-propagation=rslave"},
+	}
+	opts, err := h.computeDockerOpts(false, reqs)
+	assert.NoError(t, err)
+	assert.True(t, opts.privileged)
+	assert.Equal(t, []string{"myhost:1.2.3.4"}, opts.extraHosts)
+	assert.Equal(t, []mount.Mount{
+		{Type: mount.TypeBind, Source: "/var/cache/go", Target: "/go/pkg", ReadOnly: true},
+		{Type: mount.TypeBind, Source: "/run/my.sock", Target: "/run/my.sock", BindOptions: &mount.BindOptions{Propagation: mount.PropagationRSlave}},
+	}, opts.mounts)
+
+	// Volumes are not allowed on a shared infrastructure
+	_, err = h.computeDockerOpts(true, reqs)
+	assert.Error(t, err)
+
+	_, err = h.computeDockerOpts(false, []sdk.Requirement{{Name: "cache", Type: sdk.VolumeRequirement, Value: "type=bind,destination=/go/pkg"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,170 @@
+package docker
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	types "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// awolDelay is the delay before considering a container without registered worker as awol, the worker needs some time to register
+const awolDelay = time.Minute
+
+func (h *HatcheryDocker) killAwolWorkers() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	apiWorkers, err := h.CDSClient().WorkerList(ctx)
+	if err != nil {
+		return sdk.WrapError(err, "Cannot get workers")
+	}
+
+	containers, err := h.getContainers(types.ContainerListOptions{All: true})
+	if err != nil {
+		return err
+	}
+
+	for _, c := range awolContainers(containers, apiWorkers, time.Now()) {
+		log.Debug("hatchery> docker> killAwolWorkers> Delete container %s", containerName(c))
+		if err := h.killAndRemove(c); err != nil {
+			log.Error("hatchery> docker> killAwolWorkers> %v", err)
+		}
+	}
+
+	return h.killAwolNetworks()
+}
+
+// awolContainers returns the worker containers which are exited or not known by the API, and the services containers of these workers
+func awolContainers(containers []types.Container, apiWorkers []sdk.Worker, now time.Time) []types.Container {
+	isYoung := func(c types.Container) bool {
+		return c.State != "exited" && now.Add(-awolDelay).Unix() < c.Created
+	}
+
+	awol := []types.Container{}
+	aliveWorkers := map[string]bool{}
+	for _, c := range containers {
+		name, isWorker := c.Labels[labelWorkerName]
+		if !isWorker {
+			continue
+		}
+		if isYoung(c) {
+			aliveWorkers[name] = true
+			continue
+		}
+
+		var found bool
+		for _, w := range apiWorkers {
+			if w.Name == name && w.Status != sdk.StatusDisabled {
+				found = true
+				break
+			}
+		}
+		if found && c.State != "exited" {
+			aliveWorkers[name] = true
+			continue
+		}
+		awol = append(awol, c)
+	}
+
+	for _, c := range containers {
+		worker, isService := c.Labels[labelServiceWorker]
+		if !isService || aliveWorkers[worker] {
+			continue
+		}
+		// the worker could be still being created
+		if isYoung(c) {
+			continue
+		}
+		awol = append(awol, c)
+	}
+	return awol
+}
+
+func containerName(c types.Container) string {
+	if len(c.Names) == 0 {
+		return c.ID
+	}
+	return strings.TrimPrefix(c.Names[0], "/")
+}
+
+func (h *HatcheryDocker) killAndRemove(c types.Container) error {
+	// If its a worker "register", check registration before deleting it
+	if strings.HasPrefix(c.Labels[labelWorkerName], "register-") {
+		modelID, err := strconv.ParseInt(c.Labels[labelWorkerModel], 10, 64)
+		if err != nil {
+			log.Error("hatchery> docker> killAndRemove> unable to get model from registering container %s", containerName(c))
+		} else if err := hatchery.CheckWorkerModelRegister(h, modelID); err != nil {
+			var spawnErr = sdk.SpawnErrorForm{
+				Error: err.Error(),
+			}
+			if err := h.CDSClient().WorkerModelSpawnError(modelID, spawnErr); err != nil {
+				log.Error("hatchery> docker> killAndRemove> error on call client.WorkerModelSpawnError on worker model %d for register: %s", modelID, err)
+			}
+		}
+	}
+	return h.killAndRemoveContainer(c.ID)
+}
+
+func (h *HatcheryDocker) killAndRemoveContainer(ID string) error {
+	log.Debug("hatchery> docker> killAndRemoveContainer> remove container %s", ID)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	if err := h.dockerClient.ContainerKill(ctx, ID, "SIGKILL"); err != nil {
+		if !strings.Contains(err.Error(), "is not running") && !strings.Contains(err.Error(), "No such container") {
+			return sdk.WrapError(err, "Unable to kill container %s", ID)
+		}
+	}
+
+	ctxRemove, cancelRemove := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancelRemove()
+	if err := h.dockerClient.ContainerRemove(ctxRemove, ID, types.ContainerRemoveOptions{Force: true}); err != nil {
+		// container could be already removed by a previous call to docker
+		if !strings.Contains(err.Error(), "No such container") {
+			return sdk.WrapError(err, "Unable to remove container %s", ID)
+		}
+	}
+	return nil
+}
+
+// killAwolNetworks removes the networks of the jobs once all their containers are removed
+func (h *HatcheryDocker) killAwolNetworks() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	args := filters.NewArgs()
+	args.Add("label", labelHatchery+"="+h.Config.Name)
+	nets, err := h.dockerClient.NetworkList(ctx, types.NetworkListOptions{Filters: args})
+	if err != nil {
+		return sdk.WrapError(err, "Cannot get networks")
+	}
+
+	for _, n := range nets {
+		if _, ok := n.Labels[labelWorkerNetwork]; !ok {
+			continue
+		}
+		// the containers of the job could be still being created
+		if time.Since(n.Created) < awolDelay {
+			continue
+		}
+		// the containers are not always listed with the networks
+		netInfo, err := h.dockerClient.NetworkInspect(ctx, n.ID, types.NetworkInspectOptions{})
+		if err != nil {
+			log.Warning("hatchery> docker> killAwolNetworks> Unable to get network %s: %v", n.Name, err)
+			continue
+		}
+		if len(netInfo.Containers) > 0 {
+			continue
+		}
+
+		log.Info("hatchery> docker> killAwolNetworks> remove network %s (created on %v)", n.Name, n.Created)
+		if err := h.dockerClient.NetworkRemove(ctx, n.ID); err != nil {
+			log.Warning("hatchery> docker> killAwolNetworks> Unable to delete network %s: %v", n.Name, err)
+		}
+	}
+	return nil
+}
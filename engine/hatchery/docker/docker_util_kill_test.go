@@ -0,0 +1,47 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	types "github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_awolContainers(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-10 * time.Minute).Unix()
+	young := now.Add(-10 * time.Second).Unix()
+
+	containers := []types.Container{
+		// registered worker and its service
+		{ID: "1", State: "running", Created: old, Labels: map[string]string{labelWorkerName: "w1"}},
+		{ID: "2", State: "running", Created: old, Labels: map[string]string{labelServiceWorker: "w1"}},
+		// worker still registering and its service
+		{ID: "3", State: "running", Created: young, Labels: map[string]string{labelWorkerName: "w2"}},
+		{ID: "4", State: "running", Created: young, Labels: map[string]string{labelServiceWorker: "w2"}},
+		// unknown worker and its service
+		{ID: "5", State: "running", Created: old, Labels: map[string]string{labelWorkerName: "w3"}},
+		{ID: "6", State: "running", Created: old, Labels: map[string]string{labelServiceWorker: "w3"}},
+		// exited worker
+		{ID: "7", State: "exited", Created: young, Labels: map[string]string{labelWorkerName: "w4"}},
+		// disabled worker
+		{ID: "8", State: "running", Created: old, Labels: map[string]string{labelWorkerName: "w5"}},
+		// service of a worker which has not been created
+		{ID: "9", State: "running", Created: young, Labels: map[string]string{labelServiceWorker: "w6"}},
+		{ID: "10", State: "exited", Created: young, Labels: map[string]string{labelServiceWorker: "w6"}},
+	}
+	workers := []sdk.Worker{
+		{Name: "w1", Status: sdk.StatusBuilding},
+		{Name: "w4", Status: sdk.StatusBuilding},
+		{Name: "w5", Status: sdk.StatusDisabled},
+	}
+
+	ids := []string{}
+	for _, c := range awolContainers(containers, workers, now) {
+		ids = append(ids, c.ID)
+	}
+	assert.Equal(t, []string{"5", "7", "8", "6", "10"}, ids)
+}
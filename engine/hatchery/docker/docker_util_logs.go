@@ -0,0 +1,78 @@
+package docker
+
+import (
+	"context"
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	types "github.com/docker/docker/api/types"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// getServicesLogs sends the last logs of the services containers to the API
+func (h *HatcheryDocker) getServicesLogs() error {
+	containers, err := h.getContainers(types.ContainerListOptions{All: true})
+	if err != nil {
+		return sdk.WrapError(err, "Cannot get containers list")
+	}
+
+	servicesLogs := make([]sdk.ServiceLog, 0, len(containers))
+	for _, cnt := range containers {
+		serviceJobIDStr, isWorkflowService := cnt.Labels[labelServiceJobID]
+		if !isWorkflowService {
+			continue
+		}
+		serviceJobID, err := strconv.ParseInt(serviceJobIDStr, 10, 64)
+		if err != nil {
+			log.Error("hatchery> docker> getServicesLogs> cannot parse service job id for containers service %s: %v", containerName(cnt), err)
+			continue
+		}
+		reqServiceID, err := strconv.ParseInt(cnt.Labels[labelServiceID], 10, 64)
+		if err != nil {
+			log.Error("hatchery> docker> getServicesLogs> cannot parse service id for containers service %s: %v", containerName(cnt), err)
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		logsReader, err := h.dockerClient.ContainerLogs(ctx, cnt.ID, types.ContainerLogsOptions{
+			Details:    true,
+			ShowStderr: true,
+			ShowStdout: true,
+			Timestamps: true,
+			Since:      "10s",
+		})
+		if err != nil {
+			log.Error("hatchery> docker> getServicesLogs> cannot get logs from docker for containers service %s: %v", containerName(cnt), err)
+			cancel()
+			continue
+		}
+		logs, err := ioutil.ReadAll(logsReader)
+		logsReader.Close() // nolint
+		cancel()
+		if err != nil {
+			log.Error("hatchery> docker> getServicesLogs> cannot read logs for containers service %s: %v", containerName(cnt), err)
+			continue
+		}
+
+		if len(logs) > 0 {
+			servicesLogs = append(servicesLogs, sdk.ServiceLog{
+				WorkflowNodeJobRunID:   serviceJobID,
+				ServiceRequirementID:   reqServiceID,
+				ServiceRequirementName: cnt.Labels[labelServiceReqName],
+				Val: string(logs),
+			})
+		}
+	}
+
+	if len(servicesLogs) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := h.Client.QueueServiceLogs(ctx, servicesLogs); err != nil {
+			return sdk.WrapError(err, "Cannot send service logs")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package docker
+
+import (
+	docker "github.com/docker/docker/client"
+
+	hatcheryCommon "github.com/ovh/cds/engine/hatchery"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+)
+
+// Labels are used to find the containers and the networks managed by the hatchery
+const (
+	labelHatchery       = "hatchery"
+	labelWorkerName     = "worker_name"
+	labelWorkerModel    = "worker_model"
+	labelWorkerNetwork  = "worker_net"
+	labelServiceWorker  = "service_worker"
+	labelServiceJobID   = "service_job_id"
+	labelServiceID      = "service_id"
+	labelServiceReqName = "service_req_name"
+)
+
+// HatcheryConfiguration is the configuration for docker hatchery
+type HatcheryConfiguration struct {
+	hatchery.CommonConfiguration `mapstructure:"commonConfiguration" toml:"commonConfiguration" json:"commonConfiguration"`
+
+	// Host is the docker API endpoint
+	Host string `mapstructure:"host" toml:"host" default:"" commented:"true" comment:"Docker API endpoint, DOCKER_HOST is used if empty. With Podman, use the socket of the Docker compatible API. Example: unix:///var/run/docker.sock or unix:///run/podman/podman.sock" json:"host"`
+
+	// APIVersion is the version of the docker API
+	APIVersion string `mapstructure:"APIVersion" toml:"APIVersion" default:"" commented:"true" comment:"Docker API version, DOCKER_API_VERSION is used if empty" json:"APIVersion"`
+
+	// MaxContainers is the maximum number of containers (workers and services) on the host
+	MaxContainers int `mapstructure:"maxContainers" toml:"maxContainers" default:"10" commented:"false" comment:"Max Containers on Host managed by this Hatchery, services containers included" json:"maxContainers"`
+
+	// DefaultMemory Worker default memory
+	DefaultMemory int `mapstructure:"defaultMemory" toml:"defaultMemory" default:"1024" commented:"false" comment:"Worker default memory in Mo" json:"defaultMemory"`
+
+	// WorkerTTL Worker TTL (minutes)
+	WorkerTTL int `mapstructure:"workerTTL" toml:"workerTTL" default:"10" commented:"false" comment:"Worker TTL (minutes)" json:"workerTTL"`
+
+	// DockerOpts Docker options
+	DockerOpts string `mapstructure:"dockerOpts" toml:"dockerOpts" default:"" commented:"true" comment:"Docker Options. --add-host and --privileged supported. Example: dockerOpts=\"--add-host=myhost:x.x.x.x,myhost2:y.y.y.y --privileged\"" json:"dockerOpts,omitempty"`
+}
+
+// HatcheryDocker spawns workers as docker containers on a single docker or podman host
+type HatcheryDocker struct {
+	hatcheryCommon.Common
+	Config       HatcheryConfiguration
+	hatch        *sdk.Hatchery
+	dockerClient *docker.Client
+}
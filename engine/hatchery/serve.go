@@ -28,10 +28,20 @@ type Common struct {
 	Router      *api.Router
 	initialized bool
 	stats       hatchery.Stats
+	idleWorkers map[string]time.Time
 }
 
 const panicDumpDir = "panic_dumps"
 
+// IdleWorkers returns the date since the waiting workers of the hatchery have no job,
+// it's only used by the provisioning ticker
+func (c *Common) IdleWorkers() map[string]time.Time {
+	if c.idleWorkers == nil {
+		c.idleWorkers = map[string]time.Time{}
+	}
+	return c.idleWorkers
+}
+
 func (c *Common) servePanicDumpList() ([]string, error) {
 	dir, _ := os.Getwd()
 	path := filepath.Join(dir, panicDumpDir)
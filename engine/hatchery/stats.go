@@ -45,9 +45,16 @@ func (c *Common) initStats(hatcheryName string) error {
 	label = fmt.Sprintf("cds/%s/%s/disabled_workers", c.ServiceName(), hatcheryName)
 	c.stats.DisabledWorkers = stats.Int64(label, "number of disabled workers", stats.UnitDimensionless)
 
+	label = fmt.Sprintf("cds/%s/%s/desired_workers", c.ServiceName(), hatcheryName)
+	c.stats.DesiredWorkers = stats.Int64(label, "number of workers wanted by the demand based provisioning", stats.UnitDimensionless)
+
+	label = fmt.Sprintf("cds/%s/%s/actual_workers", c.ServiceName(), hatcheryName)
+	c.stats.ActualWorkers = stats.Int64(label, "number of started workers seen by the demand based provisioning", stats.UnitDimensionless)
+
 	log.Info("hatchery> Stats initialized on %s", c.ServiceName())
 
 	tags := []tag.Key{hatchery.TagHatchery, hatchery.TagHatcheryName}
+	modelTags := []tag.Key{hatchery.TagHatchery, hatchery.TagHatcheryName, hatchery.TagWorkerModel}
 
 	return observability.RegisterView(
 		&view.View{
@@ -113,5 +120,19 @@ func (c *Common) initStats(hatcheryName string) error {
 			Aggregation: view.LastValue(),
 			TagKeys:     tags,
 		},
+		&view.View{
+			Name:        "desired_workers",
+			Description: c.stats.DesiredWorkers.Description(),
+			Measure:     c.stats.DesiredWorkers,
+			Aggregation: view.LastValue(),
+			TagKeys:     modelTags,
+		},
+		&view.View{
+			Name:        "actual_workers",
+			Description: c.stats.ActualWorkers.Description(),
+			Measure:     c.stats.ActualWorkers,
+			Aggregation: view.LastValue(),
+			TagKeys:     modelTags,
+		},
 	)
 }
@@ -0,0 +1,320 @@
+package hooks
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fsamin/go-dump"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// gerritReconnectDelay is the delay before listening again the stream-events of a gerrit server after a failure
+const gerritReconnectDelay = 10 * time.Second
+
+// startGerritHookTask starts to listen the stream-events of the gerrit server of the task, a single connection is shared by all the tasks of a server
+func (s *Service) startGerritHookTask(t *sdk.Task) error {
+	vcsServer := t.Config["vcsServer"].Value
+
+	s.gerritStreamsMutex.Lock()
+	defer s.gerritStreamsMutex.Unlock()
+	if _, has := s.gerritStreams[vcsServer]; has {
+		return nil
+	}
+
+	cfgs, err := s.Client.VCSGerritConfiguration()
+	if err != nil {
+		return sdk.WrapError(err, "Cannot get gerrit configuration")
+	}
+	cfg, ok := cfgs[vcsServer]
+	if !ok {
+		// The stream lock is held, the task is stopped without going through stopTask
+		t.Stopped = true
+		s.Dao.SaveTask(t)
+		return fmt.Errorf("startGerritHookTask> gerrit stream-events are not enabled on %s", vcsServer)
+	}
+	// The private key is not shared by the api, it is set in the hooks configuration
+	privateKey := s.Cfg.Gerrit[vcsServer].SSHPrivateKey
+	if privateKey == "" {
+		t.Stopped = true
+		s.Dao.SaveTask(t)
+		return fmt.Errorf("startGerritHookTask> there is no ssh private key for %s in the hooks configuration", vcsServer)
+	}
+
+	if s.gerritStreams == nil {
+		s.gerritStreams = map[string]context.CancelFunc{}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.gerritStreams[vcsServer] = cancel
+
+	go func() {
+		for {
+			if err := s.listenGerritStreamEvents(ctx, vcsServer, cfg, privateKey); err != nil && ctx.Err() == nil {
+				log.Error("Hooks> Unable to listen gerrit stream-events on %s: %v", vcsServer, err)
+			}
+			select {
+			case <-ctx.Done():
+				log.Info("Hooks> Stop listening gerrit stream-events on %s", vcsServer)
+				return
+			case <-time.After(gerritReconnectDelay):
+			}
+		}
+	}()
+	return nil
+}
+
+// stopGerritHookTask stops to listen the stream-events of the gerrit server of the task when no other task of this server is running
+func (s *Service) stopGerritHookTask(t *sdk.Task) error {
+	vcsServer := t.Config["vcsServer"].Value
+
+	tasks, err := s.Dao.FindAllTasks()
+	if err != nil {
+		return sdk.WrapError(err, "Unable to find all tasks")
+	}
+	for i := range tasks {
+		if tasks[i].Type == TypeGerrit && !tasks[i].Stopped && tasks[i].UUID != t.UUID && tasks[i].Config["vcsServer"].Value == vcsServer {
+			return nil
+		}
+	}
+
+	s.gerritStreamsMutex.Lock()
+	defer s.gerritStreamsMutex.Unlock()
+	if cancel, has := s.gerritStreams[vcsServer]; has {
+		cancel()
+		delete(s.gerritStreams, vcsServer)
+	}
+	return nil
+}
+
+// stopGerritStreams stops to listen the stream-events of all the gerrit servers
+func (s *Service) stopGerritStreams() {
+	s.gerritStreamsMutex.Lock()
+	defer s.gerritStreamsMutex.Unlock()
+	for vcsServer, cancel := range s.gerritStreams {
+		cancel()
+		delete(s.gerritStreams, vcsServer)
+	}
+}
+
+// listenGerritStreamEvents runs the gerrit stream-events command over SSH and dispatches the events until the connection is closed
+// https://gerrit-review.googlesource.com/Documentation/cmd-stream-events.html
+func (s *Service) listenGerritStreamEvents(ctx context.Context, vcsServer string, cfg sdk.VCSGerritConfiguration, privateKey string) error {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return sdk.WrapError(err, "invalid gerrit url %s", cfg.URL)
+	}
+	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		return sdk.WrapError(err, "invalid ssh private key")
+	}
+	hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(cfg.SSHHostKey))
+	if err != nil {
+		return sdk.WrapError(err, "invalid ssh host key")
+	}
+
+	addr := net.JoinHostPort(u.Hostname(), strconv.Itoa(cfg.SSHPort))
+	client, err := ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:            cfg.SSHUsername,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: ssh.FixedHostKey(hostKey),
+		Timeout:         30 * time.Second,
+	})
+	if err != nil {
+		return sdk.WrapError(err, "unable to connect to %s", addr)
+	}
+	defer client.Close() // nolint
+
+	// Closing the connection unblocks the reading of the events when the listener is stopped
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			client.Close() // nolint
+		case <-done:
+		}
+	}()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return sdk.WrapError(err, "unable to open ssh session on %s", addr)
+	}
+	defer session.Close() // nolint
+
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := session.Start("gerrit stream-events"); err != nil {
+		return sdk.WrapError(err, "unable to start gerrit stream-events")
+	}
+	log.Info("Hooks> Listening gerrit stream-events on %s", vcsServer)
+
+	scanner := bufio.NewScanner(stdout)
+	// Events contain the commit message of the change, they can be larger than the default buffer
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		msg := make([]byte, len(scanner.Bytes()))
+		copy(msg, scanner.Bytes())
+		if err := s.dispatchGerritEvent(vcsServer, cfg, msg); err != nil {
+			log.Error("Hooks> Unable to dispatch gerrit event from %s: %v", vcsServer, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return session.Wait()
+}
+
+// dispatchGerritEvent creates an execution for each task listening the project of the event
+func (s *Service) dispatchGerritEvent(vcsServer string, cfg sdk.VCSGerritConfiguration, msg []byte) error {
+	var event sdk.GerritEvent
+	if err := json.Unmarshal(msg, &event); err != nil {
+		return sdk.WrapError(err, "unable to read gerrit event %s", string(msg))
+	}
+	if event.Change == nil || !sdk.IsInArray(event.Type, sdk.GerritEventTypes) {
+		return nil
+	}
+	if isGerritReviewFromCDS(event, cfg) {
+		log.Debug("Hooks> Ignoring CDS review on change %d", event.Change.Number)
+		return nil
+	}
+
+	tasks, err := s.Dao.FindAllTasks()
+	if err != nil {
+		return sdk.WrapError(err, "Unable to find all tasks")
+	}
+	for i := range tasks {
+		t := &tasks[i]
+		if t.Type != TypeGerrit || t.Stopped ||
+			t.Config["vcsServer"].Value != vcsServer ||
+			t.Config["repoFullName"].Value != event.Change.Project ||
+			!gerritEventFiltered(t.Config[sdk.GerritHookModelEventFilter].Value, event.Type) {
+			continue
+		}
+
+		exec := sdk.TaskExecution{
+			Timestamp:   time.Now().UnixNano(),
+			Config:      t.Config,
+			Type:        TypeGerrit,
+			UUID:        t.UUID,
+			GerritEvent: &sdk.GerritEventExecution{Message: msg},
+		}
+		s.Dao.SaveTaskExecution(&exec)
+		s.Dao.EnqueueTaskExecution(&exec)
+	}
+	return nil
+}
+
+// gerritEventFiltered returns true if the event type is in the semicolon separated list of types, all the types are accepted if the list is empty
+func gerritEventFiltered(filter, eventType string) bool {
+	if strings.TrimSpace(filter) == "" {
+		return true
+	}
+	for _, f := range strings.Split(filter, ";") {
+		if strings.TrimSpace(f) == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// isGerritReviewFromCDS returns true for the comments posted by CDS when a workflow node run is over, they must not trigger workflows
+func isGerritReviewFromCDS(event sdk.GerritEvent, cfg sdk.VCSGerritConfiguration) bool {
+	if event.Type != sdk.GerritEventTypeCommentAdded {
+		return false
+	}
+	if cfg.Reviewer != "" && event.Author != nil && event.Author.Username == cfg.Reviewer {
+		return true
+	}
+	for _, line := range strings.Split(event.Comment, "\n") {
+		if strings.HasPrefix(line, "CDS/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Service) doGerritExecution(t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEvent, error) {
+	log.Debug("Hooks> Processing gerrit %s %s", t.UUID, t.Type)
+
+	var event sdk.GerritEvent
+	if err := json.Unmarshal(t.GerritEvent.Message, &event); err != nil {
+		return nil, sdk.WrapError(err, "unable to read gerrit event %s", string(t.GerritEvent.Message))
+	}
+	if event.Change == nil || event.PatchSet == nil {
+		return nil, fmt.Errorf("gerrit event %s has no change or patchset", event.Type)
+	}
+
+	// Prepare a struct to send to CDS API
+	h := sdk.WorkflowNodeRunHookEvent{
+		WorkflowNodeHookUUID: t.UUID,
+	}
+
+	payload := make(map[string]interface{})
+	payload["git.repository"] = event.Change.Project
+	payload["git.branch"] = event.Change.Branch
+	payload["git.hash"] = event.PatchSet.Revision
+	payload["git.message"] = event.Change.CommitMessage
+	payload["git.change"] = event.Change.Number
+	payload["git.patchset"] = event.PatchSet.Number
+	payload["gerrit.event"] = event.Type
+	payload["gerrit.change.id"] = event.Change.ID
+	payload["gerrit.change.url"] = event.Change.URL
+	payload["gerrit.change.subject"] = event.Change.Subject
+
+	author := event.PatchSet.Author
+	var triggeredBy *sdk.GerritAccount
+	switch event.Type {
+	case sdk.GerritEventTypePatchsetCreated:
+		// The commit of a patchset is only reachable from the change ref until the change is merged
+		payload["git.change.ref"] = event.PatchSet.Ref
+		triggeredBy = event.Uploader
+	case sdk.GerritEventTypeCommentAdded:
+		payload["git.change.ref"] = event.PatchSet.Ref
+		payload["gerrit.comment"] = event.Comment
+		triggeredBy = event.Author
+	case sdk.GerritEventTypeChangeMerged:
+		// The merged commit can differ from the patchset revision depending on the submit type of the project
+		if event.NewRev != "" {
+			payload["git.hash"] = event.NewRev
+		}
+		triggeredBy = event.Submitter
+	}
+
+	if author == nil {
+		author = triggeredBy
+	}
+	if author != nil {
+		payload["git.author"] = author.Username
+		payload["git.author.email"] = author.Email
+	}
+	if triggeredBy != nil {
+		payload["cds.triggered_by.username"] = triggeredBy.Username
+		payload["cds.triggered_by.fullname"] = triggeredBy.Name
+		payload["cds.triggered_by.email"] = triggeredBy.Email
+	}
+
+	d := dump.NewDefaultEncoder(&bytes.Buffer{})
+	d.ExtraFields.Type = false
+	d.ExtraFields.Len = false
+	d.ExtraFields.DetailedMap = false
+	d.ExtraFields.DetailedStruct = false
+	d.Formatters = []dump.KeyFormatterFunc{dump.WithDefaultLowerCaseFormatter()}
+	payloadValues, errDump := d.ToStringMap(payload)
+	if errDump != nil {
+		return nil, sdk.WrapError(errDump, "doGerritExecution> Cannot dump payload %+v ", payload)
+	}
+	h.Payload = payloadValues
+	return &h, nil
+}
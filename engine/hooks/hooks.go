@@ -68,6 +68,7 @@ func (s *Service) CheckConfiguration(config interface{}) error {
 func (s *Service) Serve(c context.Context) error {
 	ctx, cancel := context.WithCancel(c)
 	defer cancel()
+	defer s.stopGerritStreams()
 
 	//Init the cache
 	var errCache error
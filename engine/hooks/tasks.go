@@ -21,6 +21,7 @@ const (
 	TypeRepoPoller         = "RepoPoller"
 	TypeKafka              = "Kafka"
 	TypeRabbitMQ           = "RabbitMQ"
+	TypeGerrit             = "Gerrit"
 	TypeWorkflowHook       = "Workflow"
 	TypeOutgoingWebHook    = "OutgoingWebhook"
 	TypeOutgoingWorkflow   = "OutgoingWorkflow"
@@ -28,6 +29,7 @@ const (
 	GithubHeader    = "X-Github-Event"
 	GitlabHeader    = "X-Gitlab-Event"
 	BitbucketHeader = "X-Event-Key"
+	GiteaHeader     = "X-Gitea-Event"
 
 	ConfigNumber    = "Number"
 	ConfigSubNumber = "SubNumber"
@@ -126,6 +128,12 @@ func (s *Service) hookToTask(h *sdk.WorkflowNodeHook) (*sdk.Task, error) {
 			Type:   TypeRabbitMQ,
 			Config: h.Config,
 		}, nil
+	case sdk.GerritHookModelName:
+		return &sdk.Task{
+			UUID:   h.UUID,
+			Type:   TypeGerrit,
+			Config: h.Config,
+		}, nil
 	case sdk.WebHookModelName:
 		h.Config["webHookURL"] = sdk.WorkflowNodeHookConfigValue{
 			Value:        fmt.Sprintf("%s/webhook/%s", s.Cfg.URLPublic, h.UUID),
@@ -223,6 +231,8 @@ func (s *Service) startTask(ctx context.Context, t *sdk.Task) (*sdk.TaskExecutio
 		return nil, s.startKafkaHook(t)
 	case TypeRabbitMQ:
 		return nil, s.startRabbitMQHook(t)
+	case TypeGerrit:
+		return nil, s.startGerritHookTask(t)
 	case TypeOutgoingWebHook:
 		return s.startOutgoingWebHookTask(t)
 	case TypeOutgoingWorkflow:
@@ -311,6 +321,9 @@ func (s *Service) stopTask(t *sdk.Task) error {
 	case TypeWebHook, TypeScheduler, TypeRepoManagerWebHook, TypeRepoPoller, TypeKafka, TypeWorkflowHook:
 		log.Debug("Hooks> Tasks %s has been stopped", t.UUID)
 		return nil
+	case TypeGerrit:
+		log.Debug("Hooks> Tasks %s has been stopped", t.UUID)
+		return s.stopGerritHookTask(t)
 	default:
 		return fmt.Errorf("Unsupported task type %s", t.Type)
 	}
@@ -344,6 +357,8 @@ func (s *Service) doTask(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution)
 		h, err = s.doKafkaTaskExecution(e)
 	case e.RabbitMQ != nil && e.Type == TypeRabbitMQ:
 		h, err = s.doRabbitMQTaskExecution(e)
+	case e.GerritEvent != nil && e.Type == TypeGerrit:
+		h, err = s.doGerritExecution(e)
 	default:
 		err = fmt.Errorf("Unsupported task type %s", e.Type)
 	}
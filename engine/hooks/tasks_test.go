@@ -1,6 +1,7 @@
 package hooks
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -125,6 +126,104 @@ func Test_doWebHookExecutionBitbucket(t *testing.T) {
 	assert.Equal(t, "9f4fac7ec5642099982a86f584f2c4a362adb670", h.Payload["git.hash"])
 }
 
+func Test_doWebHookExecutionGitea(t *testing.T) {
+	log.SetLogger(t)
+	s := Service{}
+	task := &sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypeRepoManagerWebHook,
+		WebHook: &sdk.WebHookExecution{
+			RequestBody: []byte(giteaPushEvent),
+			RequestHeader: map[string][]string{
+				GiteaHeader:  {"push"},
+				GithubHeader: {"push"},
+			},
+			RequestURL: "",
+		},
+	}
+	h, err := s.doWebHookExecution(task)
+	test.NoError(t, err)
+
+	assert.Equal(t, "develop", h.Payload["git.branch"])
+	assert.Equal(t, "gitea", h.Payload["git.author"])
+	assert.Equal(t, "gitea/webhooks", h.Payload["git.repository"])
+	assert.Equal(t, "Webhooks Yay!", h.Payload["git.message"])
+	assert.Equal(t, "bffeb74224043ba2feb48d137756c8a9331c449a", h.Payload["git.hash"])
+
+	// The commit author is not a gitea user
+	task.WebHook.RequestBody = []byte(strings.Replace(giteaPushEvent, `"username": "gitea"
+      },
+      "committer"`, `"username": ""
+      },
+      "committer"`, 1))
+	h, err = s.doWebHookExecution(task)
+	test.NoError(t, err)
+	assert.Equal(t, "Gitea", h.Payload["git.author"])
+	assert.Equal(t, "someone@gitea.io", h.Payload["git.author.email"])
+}
+
+func Test_doGerritExecution(t *testing.T) {
+	log.SetLogger(t)
+	s := Service{}
+	task := &sdk.TaskExecution{
+		UUID:        sdk.RandomString(10),
+		Type:        TypeGerrit,
+		GerritEvent: &sdk.GerritEventExecution{Message: []byte(gerritPatchsetCreatedEvent)},
+	}
+	h, err := s.doGerritExecution(task)
+	test.NoError(t, err)
+
+	assert.Equal(t, "master", h.Payload["git.branch"])
+	assert.Equal(t, "cds/demo", h.Payload["git.repository"])
+	assert.Equal(t, "0f3c6e8ac3b7e2b1b1d8d4e7a4d1f9c2a0b5e6d7", h.Payload["git.hash"])
+	assert.Equal(t, "1234", h.Payload["git.change"])
+	assert.Equal(t, "2", h.Payload["git.patchset"])
+	assert.Equal(t, "refs/changes/34/1234/2", h.Payload["git.change.ref"])
+	assert.Equal(t, "john", h.Payload["git.author"])
+	assert.Equal(t, "jane", h.Payload["cds.triggered_by.username"])
+	assert.Equal(t, "patchset-created", h.Payload["gerrit.event"])
+
+	// CDS reviews must not trigger workflows
+	cfg := sdk.VCSGerritConfiguration{Reviewer: "cds-bot"}
+	assert.True(t, isGerritReviewFromCDS(sdk.GerritEvent{Type: sdk.GerritEventTypeCommentAdded, Author: &sdk.GerritAccount{Username: "cds-bot"}}, cfg))
+	assert.True(t, isGerritReviewFromCDS(sdk.GerritEvent{Type: sdk.GerritEventTypeCommentAdded, Comment: "Patch Set 2: Verified+1\n\nCDS/KEY-demo-build: Success"}, cfg))
+	assert.False(t, isGerritReviewFromCDS(sdk.GerritEvent{Type: sdk.GerritEventTypeCommentAdded, Comment: "Patch Set 2: Code-Review+2\n\nLGTM"}, cfg))
+
+	assert.True(t, gerritEventFiltered("patchset-created;change-merged", "change-merged"))
+	assert.False(t, gerritEventFiltered("patchset-created;change-merged", "comment-added"))
+	assert.True(t, gerritEventFiltered("", "comment-added"))
+}
+
+var gerritPatchsetCreatedEvent = `{
+  "uploader": {"name": "Jane Doe", "email": "jane@example.com", "username": "jane"},
+  "patchSet": {
+    "number": 2,
+    "revision": "0f3c6e8ac3b7e2b1b1d8d4e7a4d1f9c2a0b5e6d7",
+    "parents": ["a1b2c3d4e5f60718293a4b5c6d7e8f9012345678"],
+    "ref": "refs/changes/34/1234/2",
+    "uploader": {"name": "Jane Doe", "email": "jane@example.com", "username": "jane"},
+    "createdOn": 1539856800,
+    "author": {"name": "John Doe", "email": "john@example.com", "username": "john"},
+    "kind": "REWORK"
+  },
+  "change": {
+    "project": "cds/demo",
+    "branch": "master",
+    "id": "I8473b95934b5732ac55d26311a706c9c2bde9940",
+    "number": 1234,
+    "subject": "Add the demo",
+    "owner": {"name": "John Doe", "email": "john@example.com", "username": "john"},
+    "url": "https://gerrit.example.com/c/cds/demo/+/1234",
+    "commitMessage": "Add the demo\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940\n",
+    "status": "NEW"
+  },
+  "project": "cds/demo",
+  "refName": "refs/heads/master",
+  "changeKey": {"id": "I8473b95934b5732ac55d26311a706c9c2bde9940"},
+  "type": "patchset-created",
+  "eventCreatedOn": 1539856800
+}`
+
 var bitbucketPushEvent = `
 	{
     "eventKey": "repo:refs_changed",
@@ -581,3 +680,67 @@ var githubTagEvent = `
   }
 }
 `
+
+var giteaPushEvent = `
+{
+  "secret": "",
+  "ref": "refs/heads/develop",
+  "before": "28e1879d029cb852e4844d9c718537df08844e03",
+  "after": "bffeb74224043ba2feb48d137756c8a9331c449a",
+  "compare_url": "http://localhost:3000/gitea/webhooks/compare/28e1879d029cb852e4844d9c718537df08844e03...bffeb74224043ba2feb48d137756c8a9331c449a",
+  "commits": [
+    {
+      "id": "bffeb74224043ba2feb48d137756c8a9331c449a",
+      "message": "Webhooks Yay!",
+      "url": "http://localhost:3000/gitea/webhooks/commit/bffeb74224043ba2feb48d137756c8a9331c449a",
+      "author": {
+        "name": "Gitea",
+        "email": "someone@gitea.io",
+        "username": "gitea"
+      },
+      "committer": {
+        "name": "Gitea",
+        "email": "someone@gitea.io",
+        "username": "gitea"
+      },
+      "timestamp": "2017-03-13T13:52:11-04:00"
+    }
+  ],
+  "repository": {
+    "id": 140,
+    "owner": {
+      "id": 1,
+      "login": "gitea",
+      "full_name": "Gitea",
+      "email": "someone@gitea.io",
+      "avatar_url": "https://localhost:3000/avatars/1",
+      "username": "gitea"
+    },
+    "name": "webhooks",
+    "full_name": "gitea/webhooks",
+    "description": "",
+    "private": false,
+    "fork": false,
+    "html_url": "http://localhost:3000/gitea/webhooks",
+    "ssh_url": "ssh://gitea@localhost:2222/gitea/webhooks.git",
+    "clone_url": "http://localhost:3000/gitea/webhooks.git",
+    "default_branch": "master"
+  },
+  "pusher": {
+    "id": 1,
+    "login": "gitea",
+    "full_name": "Gitea",
+    "email": "someone@gitea.io",
+    "avatar_url": "https://localhost:3000/avatars/1",
+    "username": "gitea"
+  },
+  "sender": {
+    "id": 1,
+    "login": "gitea",
+    "full_name": "Gitea",
+    "email": "someone@gitea.io",
+    "avatar_url": "https://localhost:3000/avatars/1",
+    "username": "gitea"
+  }
+}
+`
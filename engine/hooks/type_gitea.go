@@ -0,0 +1,55 @@
+package hooks
+
+import (
+	"time"
+)
+
+// GiteaPushEvent represents payload send by gitea on a push event, it is also sent by forgejo
+type GiteaPushEvent struct {
+	Ref        string          `json:"ref"`
+	Before     string          `json:"before"`
+	After      string          `json:"after"`
+	CompareURL string          `json:"compare_url"`
+	Commits    []GiteaCommit   `json:"commits"`
+	HeadCommit *GiteaCommit    `json:"head_commit"`
+	Repository GiteaRepository `json:"repository"`
+	Pusher     GiteaUser       `json:"pusher"`
+	Sender     GiteaUser       `json:"sender"`
+}
+
+// GiteaCommit represents a commit in a gitea push event
+type GiteaCommit struct {
+	ID        string          `json:"id"`
+	Message   string          `json:"message"`
+	URL       string          `json:"url"`
+	Author    GiteaCommitUser `json:"author"`
+	Committer GiteaCommitUser `json:"committer"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// GiteaCommitUser represents the author or the committer of a commit in a gitea push event
+type GiteaCommitUser struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+}
+
+// GiteaRepository represents the repository of a gitea push event
+type GiteaRepository struct {
+	ID       int64     `json:"id"`
+	Owner    GiteaUser `json:"owner"`
+	Name     string    `json:"name"`
+	FullName string    `json:"full_name"`
+	HTMLURL  string    `json:"html_url"`
+	CloneURL string    `json:"clone_url"`
+	SSHURL   string    `json:"ssh_url"`
+}
+
+// GiteaUser represents a gitea user
+type GiteaUser struct {
+	ID       int64  `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+}
@@ -1,6 +1,9 @@
 package hooks
 
 import (
+	"context"
+	"sync"
+
 	"github.com/ovh/cds/engine/api"
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/service"
@@ -20,6 +23,9 @@ type Service struct {
 	Router *api.Router
 	Cache  cache.Store
 	Dao    dao
+	// gerritStreams are the gerrit servers whose stream-events are listened
+	gerritStreamsMutex sync.Mutex
+	gerritStreams      map[string]context.CancelFunc
 }
 
 // Configuration is the hooks configuration structure
@@ -43,4 +49,10 @@ type Configuration struct {
 			Password string `toml:"password" json:"-"`
 		} `toml:"redis" comment:"Connect CDS to a redis cache If you more than one CDS instance and to avoid losing data at startup" json:"redis"`
 	} `toml:"cache" comment:"######################\n CDS Hooks Cache Settings \n######################" json:"cache"`
+	Gerrit map[string]GerritConfiguration `toml:"gerrit" comment:"######################\n CDS Hooks Gerrit Settings, by VCS server name \n######################" json:"-"`
+}
+
+// GerritConfiguration is the configuration used to listen the stream-events of a gerrit server
+type GerritConfiguration struct {
+	SSHPrivateKey string `toml:"sshPrivateKey" comment:"Private key of the gerrit account used to listen the stream-events" json:"-"`
 }
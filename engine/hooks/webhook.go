@@ -24,7 +24,10 @@ func (s *Service) doWebHookExecution(e *sdk.TaskExecution) (*sdk.WorkflowNodeRun
 }
 
 func getRepositoryHeader(whe *sdk.WebHookExecution) string {
-	if v, ok := whe.RequestHeader[GithubHeader]; ok && v[0] == "push" {
+	// Gitea also sends the github header, it has to be checked first
+	if v, ok := whe.RequestHeader[GiteaHeader]; ok && v[0] == "push" {
+		return GiteaHeader
+	} else if v, ok := whe.RequestHeader[GithubHeader]; ok && v[0] == "push" {
 		return GithubHeader
 	} else if v, ok := whe.RequestHeader[GitlabHeader]; ok && v[0] == "Push Hook" {
 		return GitlabHeader
@@ -119,6 +122,48 @@ func executeRepositoryWebHook(t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEve
 		payload["cds.triggered_by.username"] = pushEvent.Actor.Name
 		payload["cds.triggered_by.fullname"] = pushEvent.Actor.DisplayName
 		payload["cds.triggered_by.email"] = pushEvent.Actor.EmailAddress
+	case GiteaHeader:
+		var pushEvent GiteaPushEvent
+		if err := json.Unmarshal(t.WebHook.RequestBody, &pushEvent); err != nil {
+			return nil, sdk.WrapError(err, "unable ro read gitea request: %s", string(t.WebHook.RequestBody))
+		}
+		// Branch deletion (gitea return an empty hash full of 0)
+		if strings.Trim(pushEvent.After, "0") == "" {
+			return nil, nil
+		}
+		// The commits are sorted from the newest to the oldest, the head commit is not sent by old gitea versions
+		headCommit := pushEvent.HeadCommit
+		if headCommit == nil && len(pushEvent.Commits) > 0 {
+			headCommit = &pushEvent.Commits[0]
+		}
+
+		payload["git.author"] = pushEvent.Pusher.Login
+		payload["git.author.email"] = pushEvent.Pusher.Email
+		if headCommit != nil {
+			// The username is empty when the commit author is not a gitea user
+			payload["git.author"] = headCommit.Author.Username
+			if headCommit.Author.Username == "" {
+				payload["git.author"] = headCommit.Author.Name
+			}
+			if headCommit.Author.Username == "" && headCommit.Author.Name == "" {
+				payload["git.author"] = headCommit.Author.Email
+			}
+			payload["git.author.email"] = headCommit.Author.Email
+			payload["git.message"] = headCommit.Message
+		}
+
+		if !strings.HasPrefix(pushEvent.Ref, "refs/tags/") {
+			payload["git.branch"] = strings.TrimPrefix(pushEvent.Ref, "refs/heads/")
+		} else {
+			payload["git.tag"] = strings.TrimPrefix(pushEvent.Ref, "refs/tags/")
+		}
+		payload["git.hash.before"] = pushEvent.Before
+		payload["git.hash"] = pushEvent.After
+		payload["git.repository"] = pushEvent.Repository.FullName
+
+		payload["cds.triggered_by.username"] = pushEvent.Pusher.Login
+		payload["cds.triggered_by.fullname"] = pushEvent.Pusher.FullName
+		payload["cds.triggered_by.email"] = pushEvent.Pusher.Email
 	default:
 		log.Warning("executeRepositoryWebHook> Repository manager not found. Cannot read %s", string(t.WebHook.RequestBody))
 		return nil, fmt.Errorf("Repository manager not found. Cannot read request body")
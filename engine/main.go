@@ -19,6 +19,7 @@ import (
 	"github.com/ovh/cds/engine/api/database"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/elasticsearch"
+	"github.com/ovh/cds/engine/hatchery/docker"
 	"github.com/ovh/cds/engine/hatchery/kubernetes"
 	"github.com/ovh/cds/engine/hatchery/local"
 	"github.com/ovh/cds/engine/hatchery/marathon"
@@ -145,7 +146,7 @@ var configNewCmd = &cobra.Command{
 	$ engine config new debug tracing [µService(s)...]
 
 # All options
-	$ engine config new [debug] [tracing] [api] [hatchery:local] [hatchery:marathon] [hatchery:openstack] [hatchery:swarm] [hatchery:vsphere] [elasticsearch] [hooks] [vcs] [repositories] [migrate]
+	$ engine config new [debug] [tracing] [api] [hatchery:local] [hatchery:docker] [hatchery:marathon] [hatchery:openstack] [hatchery:swarm] [hatchery:vsphere] [elasticsearch] [hooks] [vcs] [repositories] [migrate]
 
 `,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -179,6 +180,9 @@ var configNewCmd = &cobra.Command{
 			if h.Local != nil {
 				h.Local.API.Token = sharedInfraToken
 			}
+			if h.Docker != nil {
+				h.Docker.API.Token = sharedInfraToken
+			}
 			if h.Openstack != nil {
 				h.Openstack.API.Token = sharedInfraToken
 			}
@@ -200,6 +204,9 @@ var configNewCmd = &cobra.Command{
 
 		if conf.Hooks != nil {
 			conf.Hooks.API.Token = sharedInfraToken
+			conf.Hooks.Gerrit = map[string]hooks.GerritConfiguration{
+				"Gerrit": {SSHPrivateKey: "xxxx"},
+			}
 		}
 
 		if conf.Repositories != nil {
@@ -234,6 +241,21 @@ var configNewCmd = &cobra.Command{
 					Secret: "xxxx",
 				},
 			}
+			conf.VCS.Servers["Gitea"] = vcs.ServerConfiguration{
+				URL: "https://mygitea.com",
+				Gitea: &vcs.GiteaServerConfiguration{
+					ClientID:     "xxxx",
+					ClientSecret: "xxxx",
+				},
+			}
+			conf.VCS.Servers["Gerrit"] = vcs.ServerConfiguration{
+				URL: "https://mygerrit.com",
+				Gerrit: &vcs.GerritServerConfiguration{
+					SSHUsername: "cds",
+					SSHPort:     29418,
+					SSHHostKey:  "xxxx",
+				},
+			}
 		}
 
 		if !configNewAsEnvFlag {
@@ -298,6 +320,14 @@ var configCheckCmd = &cobra.Command{
 			}
 		}
 
+		if conf.Hatchery != nil && conf.Hatchery.Docker != nil && conf.Hatchery.Docker.API.HTTP.URL != "" {
+			fmt.Printf("checking hatchery:docker configuration...\n")
+			if err := docker.New().CheckConfiguration(*conf.Hatchery.Docker); err != nil {
+				fmt.Printf("hatchery:docker Configuration: %v\n", err)
+				hasError = true
+			}
+		}
+
 		if conf.Hatchery != nil && conf.Hatchery.Marathon != nil && conf.Hatchery.Marathon.API.HTTP.URL != "" {
 			fmt.Printf("checking hatchery:marathon configuration...\n")
 			if err := marathon.New().CheckConfiguration(*conf.Hatchery.Marathon); err != nil {
@@ -377,6 +407,7 @@ They are the components responsible for spawning workers. Supported platforms/or
 
 * Local machine
 * Openstack
+* Docker
 * Docker Swarm
 * Openstack
 * Vsphere
@@ -392,7 +423,7 @@ This component operates CDS VCS connectivity
 
 Start all of this with a single command:
 
-	$ engine start [api] [hatchery:local] [hatchery:marathon] [hatchery:openstack] [hatchery:swarm] [hatchery:vsphere] [elasticsearch] [hooks] [vcs] [repositories] [migrate]
+	$ engine start [api] [hatchery:local] [hatchery:docker] [hatchery:marathon] [hatchery:openstack] [hatchery:swarm] [hatchery:vsphere] [elasticsearch] [hooks] [vcs] [repositories] [migrate]
 
 All the services are using the same configuration file format.
 
@@ -462,6 +493,9 @@ See $ engine config command for more details.
 			case "hatchery:local":
 				services = append(services, serviceConf{arg: a, service: local.New(), cfg: *conf.Hatchery.Local})
 				names = append(names, conf.Hatchery.Local.Name)
+			case "hatchery:docker":
+				services = append(services, serviceConf{arg: a, service: docker.New(), cfg: *conf.Hatchery.Docker})
+				names = append(names, conf.Hatchery.Docker.Name)
 			case "hatchery:kubernetes":
 				services = append(services, serviceConf{arg: a, service: kubernetes.New(), cfg: *conf.Hatchery.Kubernetes})
 				names = append(names, conf.Hatchery.Kubernetes.Name)
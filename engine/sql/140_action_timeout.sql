@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE action ADD COLUMN timeout BIGINT DEFAULT 0;
+ALTER TABLE action_edge ADD COLUMN timeout BIGINT DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE action DROP COLUMN timeout;
+ALTER TABLE action_edge DROP COLUMN timeout;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE pipeline_action ADD COLUMN retry_policy JSONB;
+ALTER TABLE workflow_node_run_job_logs ADD COLUMN archived_attempt INT;
+
+-- +migrate Down
+ALTER TABLE pipeline_action DROP COLUMN retry_policy;
+ALTER TABLE workflow_node_run_job_logs DROP COLUMN archived_attempt;
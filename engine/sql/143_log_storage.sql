@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE workflow_node_run_job_logs ADD COLUMN storage VARCHAR(64);
+
+-- +migrate Down
+ALTER TABLE workflow_node_run_job_logs DROP COLUMN storage;
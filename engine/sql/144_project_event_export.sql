@@ -0,0 +1,23 @@
+-- +migrate Up
+ALTER TABLE platform_model ADD COLUMN event BOOLEAN default false;
+
+CREATE TABLE project_event_export (
+  id BIGSERIAL PRIMARY KEY,
+  project_id BIGINT NOT NULL,
+  project_platform_id BIGINT NOT NULL,
+  event_types JSONB,
+  topic VARCHAR(256) NOT NULL default '',
+  enabled BOOLEAN default true,
+  last_delivery TIMESTAMP WITH TIME ZONE,
+  last_status VARCHAR(32) NOT NULL default '',
+  last_error TEXT NOT NULL default '',
+  delivered BIGINT NOT NULL default 0,
+  failed BIGINT NOT NULL default 0
+);
+
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_EVENT_EXPORT_PROJECT', 'project_event_export', 'project', 'project_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_EVENT_EXPORT_PROJECT_PLATFORM', 'project_event_export', 'project_platform', 'project_platform_id', 'id');
+
+-- +migrate Down
+DROP TABLE project_event_export;
+ALTER TABLE platform_model DROP COLUMN event;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE workflow_node_run_static_files ADD COLUMN workflow_node_run_job_id BIGINT NOT NULL default 0;
+
+-- +migrate Down
+ALTER TABLE workflow_node_run_static_files DROP COLUMN workflow_node_run_job_id;
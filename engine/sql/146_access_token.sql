@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE access_token (
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  name VARCHAR(256) NOT NULL,
+  hash VARCHAR(128) NOT NULL,
+  scopes JSONB,
+  projects JSONB,
+  created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+  expire_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  last_used TIMESTAMP WITH TIME ZONE
+);
+
+SELECT create_foreign_key_idx_cascade('FK_ACCESS_TOKEN_USER', 'access_token', 'user', 'user_id', 'id');
+SELECT create_unique_index('access_token', 'IDX_ACCESS_TOKEN_HASH', 'hash');
+
+-- +migrate Down
+DROP TABLE access_token;
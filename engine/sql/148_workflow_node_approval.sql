@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE workflow_node_context ADD COLUMN approval JSONB;
+ALTER TABLE w_node_context ADD COLUMN approval JSONB;
+ALTER TABLE workflow_node_run ADD COLUMN approvals JSONB;
+
+-- +migrate Down
+ALTER TABLE workflow_node_context DROP COLUMN approval;
+ALTER TABLE w_node_context DROP COLUMN approval;
+ALTER TABLE workflow_node_run DROP COLUMN approvals;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE workflow ADD COLUMN auto_cancel_superseded BOOLEAN DEFAULT false;
+
+-- +migrate Down
+ALTER TABLE workflow DROP COLUMN auto_cancel_superseded;
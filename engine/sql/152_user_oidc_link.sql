@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE user_oidc_link (
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  issuer VARCHAR(512) NOT NULL,
+  subject VARCHAR(256) NOT NULL
+);
+
+SELECT create_foreign_key_idx_cascade('FK_USER_OIDC_LINK_USER', 'user_oidc_link', 'user', 'user_id', 'id');
+SELECT create_unique_index('user_oidc_link', 'IDX_USER_OIDC_LINK_SUBJECT', 'issuer,subject');
+SELECT create_unique_index('user_oidc_link', 'IDX_USER_OIDC_LINK_USER', 'user_id,issuer');
+
+-- +migrate Down
+DROP TABLE user_oidc_link;
@@ -0,0 +1,28 @@
+-- +migrate Up
+CREATE TABLE workflow_template_version (
+  id BIGSERIAL PRIMARY KEY,
+  workflow_template_id BIGINT NOT NULL,
+  version BIGINT NOT NULL,
+  template JSONB,
+  created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_TEMPLATE_VERSION_TEMPLATE', 'workflow_template_version', 'workflow_template', 'workflow_template_id', 'id');
+SELECT create_unique_index('workflow_template_version', 'IDX_WORKFLOW_TEMPLATE_VERSION_TEMPLATE_VERSION', 'workflow_template_id,version');
+
+INSERT INTO workflow_template_version (workflow_template_id, version, template)
+SELECT id, version, json_build_object(
+  'id', id,
+  'group_id', group_id,
+  'name', name,
+  'description', description,
+  'version', version,
+  'parameters', parameters,
+  'value', value,
+  'pipelines', pipelines,
+  'applications', applications,
+  'environments', environments
+) FROM workflow_template;
+
+-- +migrate Down
+DROP TABLE workflow_template_version;
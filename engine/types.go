@@ -10,6 +10,7 @@ import (
 	"github.com/ovh/cds/engine/api"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/elasticsearch"
+	"github.com/ovh/cds/engine/hatchery/docker"
 	"github.com/ovh/cds/engine/hatchery/kubernetes"
 	"github.com/ovh/cds/engine/hatchery/local"
 	"github.com/ovh/cds/engine/hatchery/marathon"
@@ -54,6 +55,7 @@ type DebugConfiguration struct {
 // HatcheryConfiguration contains subsection of Hatchery configuration
 type HatcheryConfiguration struct {
 	Local      *local.HatcheryConfiguration      `toml:"local" comment:"Hatchery Local." json:"local"`
+	Docker     *docker.HatcheryConfiguration     `toml:"docker" comment:"Hatchery Docker. Doc: https://ovh.github.io/cds/hatchery/docker/" json:"docker"`
 	Kubernetes *kubernetes.HatcheryConfiguration `toml:"kubernetes" comment:"Hatchery Kubernetes." json:"kubernetes"`
 	Marathon   *marathon.HatcheryConfiguration   `toml:"marathon" comment:"Hatchery Marathon." json:"marathon"`
 	Openstack  *openstack.HatcheryConfiguration  `toml:"openstack" comment:"Hatchery OpenStack. Doc: https://ovh.github.io/cds/advanced/advanced.hatcheries.openstack/" json:"openstack"`
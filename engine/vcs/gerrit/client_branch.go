@@ -0,0 +1,78 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Branches returns the branches of a project
+// https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#list-branches
+func (c *gerritClient) Branches(ctx context.Context, fullname string) ([]sdk.VCSBranch, error) {
+	defaultBranch, err := c.defaultBranch(ctx, fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	var gerritBranches []BranchInfo
+	if err := c.do(ctx, http.MethodGet, projectPath(fullname)+"/branches/", nil, nil, &gerritBranches); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, sdk.WrapError(sdk.ErrRepoNotFound, "gerritClient.Branches> %s not found", fullname)
+		}
+		return nil, sdk.WrapError(err, "gerritClient.Branches> Unable to list branches of %s", fullname)
+	}
+
+	branches := []sdk.VCSBranch{}
+	for _, b := range gerritBranches {
+		// HEAD and refs/meta/config are not branches
+		if !strings.HasPrefix(b.Ref, "refs/heads/") {
+			continue
+		}
+		branches = append(branches, toVCSBranch(b, defaultBranch))
+	}
+	return branches, nil
+}
+
+// Branch returns only detail of a branch
+func (c *gerritClient) Branch(ctx context.Context, fullname, theBranch string) (*sdk.VCSBranch, error) {
+	defaultBranch, err := c.defaultBranch(ctx, fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	var branch BranchInfo
+	if err := c.do(ctx, http.MethodGet, projectPath(fullname)+"/branches/"+url.PathEscape(theBranch), nil, nil, &branch); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, sdk.WrapError(sdk.ErrNoBranch, "gerritClient.Branch> Branch not found %s on %s", theBranch, fullname)
+		}
+		return nil, sdk.WrapError(err, "gerritClient.Branch> Unable to get branch %s on %s", theBranch, fullname)
+	}
+
+	b := toVCSBranch(branch, defaultBranch)
+	return &b, nil
+}
+
+// defaultBranch returns the branch HEAD of a project points to
+func (c *gerritClient) defaultBranch(ctx context.Context, fullname string) (string, error) {
+	var head string
+	if err := c.do(ctx, http.MethodGet, projectPath(fullname)+"/HEAD", nil, nil, &head); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return "", sdk.WrapError(sdk.ErrRepoNotFound, "gerritClient.defaultBranch> %s not found", fullname)
+		}
+		return "", sdk.WrapError(err, "gerritClient.defaultBranch> Unable to get HEAD of %s", fullname)
+	}
+	return strings.TrimPrefix(head, "refs/heads/"), nil
+}
+
+func toVCSBranch(b BranchInfo, defaultBranch string) sdk.VCSBranch {
+	name := strings.TrimPrefix(b.Ref, "refs/heads/")
+	return sdk.VCSBranch{
+		ID:           b.Ref,
+		DisplayID:    name,
+		LatestCommit: b.Revision,
+		Default:      name == defaultBranch,
+	}
+}
@@ -0,0 +1,93 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// PullRequests returns the open changes of a project
+// https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#list-changes
+func (c *gerritClient) PullRequests(ctx context.Context, fullname string) ([]sdk.VCSPullRequest, error) {
+	changes, err := c.queryChanges(ctx, fmt.Sprintf("project:%s status:open", fullname), "CURRENT_REVISION", "CURRENT_COMMIT", "DETAILED_ACCOUNTS")
+	if err != nil {
+		return nil, sdk.WrapError(err, "gerritClient.PullRequests> Unable to list changes of %s", fullname)
+	}
+
+	prs := make([]sdk.VCSPullRequest, len(changes))
+	for i := range changes {
+		prs[i] = c.toVCSPullRequest(changes[i])
+	}
+	return prs, nil
+}
+
+// PullRequestComment posts a review message on the current patchset of a change
+func (c *gerritClient) PullRequestComment(ctx context.Context, repo string, id int, text string) error {
+	if c.consumer.disableStatus {
+		log.Warning("gerrit.PullRequestComment>  ⚠ Gerrit statuses are disabled")
+		return nil
+	}
+
+	path := fmt.Sprintf("/changes/%s~%d/revisions/current/review", url.PathEscape(repo), id)
+	if err := c.reviewer().do(ctx, http.MethodPost, path, nil, ReviewInput{Message: text, Tag: reviewTag}, nil); err != nil {
+		return sdk.WrapError(err, "gerritClient.PullRequestComment> Unable to comment change %d on %s", id, repo)
+	}
+	return nil
+}
+
+// queryChanges returns the changes matching a query, the options add fields to the changes
+func (c *gerritClient) queryChanges(ctx context.Context, query string, options ...string) ([]ChangeInfo, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	for _, o := range options {
+		params.Add("o", o)
+	}
+
+	var changes []ChangeInfo
+	if err := c.do(ctx, http.MethodGet, "/changes/", params, nil, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (c *gerritClient) toVCSPullRequest(change ChangeInfo) sdk.VCSPullRequest {
+	pr := sdk.VCSPullRequest{
+		ID:  int(change.Number),
+		URL: fmt.Sprintf("%s/c/%s/+/%d", c.consumer.URL, change.Project, change.Number),
+		Base: sdk.VCSPushEvent{
+			Repo: change.Project,
+			Branch: sdk.VCSBranch{
+				ID:        "refs/heads/" + change.Branch,
+				DisplayID: change.Branch,
+			},
+		},
+	}
+	if change.Owner != nil {
+		pr.User = toVCSAuthor(*change.Owner)
+	}
+
+	// The head of a change is its current patchset, it is built on the target branch
+	pr.Head = pr.Base
+	if rev, ok := change.Revisions[change.CurrentRevision]; ok {
+		pr.Head.Branch.ID = rev.Ref
+		pr.Head.Branch.LatestCommit = change.CurrentRevision
+		pr.Head.Commit.Hash = change.CurrentRevision
+		if rev.Commit != nil {
+			rev.Commit.Commit = change.CurrentRevision
+			pr.Head.Commit = c.toVCSCommit(*rev.Commit)
+		}
+	}
+	return pr
+}
+
+func toVCSAuthor(a AccountInfo) sdk.VCSAuthor {
+	return sdk.VCSAuthor{
+		Name:        a.Username,
+		DisplayName: a.Name,
+		Email:       a.Email,
+	}
+}
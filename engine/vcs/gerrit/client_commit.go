@@ -0,0 +1,106 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// maxCommits limits the number of commits read while looking for the since commit, gerrit has no API to list the history of a branch
+const maxCommits = 50
+
+// Commits returns the commits list on a branch between a commit SHA (since) until another commit SHA (until)
+func (c *gerritClient) Commits(ctx context.Context, repo, theBranch, since, until string) ([]sdk.VCSCommit, error) {
+	log.Debug("gerritClient.Commits> Looking for commits on repo %s since = %s until = %s", repo, since, until)
+	head := until
+	if head == "" {
+		b, err := c.Branch(ctx, repo, theBranch)
+		if err != nil {
+			return nil, err
+		}
+		head = b.LatestCommit
+	}
+
+	// Without since commit, only the last commit of the branch is returned
+	if since == "" {
+		commit, err := c.Commit(ctx, repo, head)
+		if err != nil {
+			return nil, err
+		}
+		return []sdk.VCSCommit{commit}, nil
+	}
+	return c.firstParents(ctx, repo, since, head)
+}
+
+// Commit returns a single commit
+// https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-commit
+func (c *gerritClient) Commit(ctx context.Context, repo, hash string) (sdk.VCSCommit, error) {
+	commit, err := c.commit(ctx, repo, hash)
+	if err != nil {
+		return sdk.VCSCommit{}, err
+	}
+	return c.toVCSCommit(*commit), nil
+}
+
+// CommitsBetweenRefs returns the commits of head which are not in base, following the first parents of head
+func (c *gerritClient) CommitsBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSCommit, error) {
+	return c.firstParents(ctx, repo, base, head)
+}
+
+func (c *gerritClient) commit(ctx context.Context, repo, hash string) (*CommitInfo, error) {
+	commit := &CommitInfo{}
+	if err := c.do(ctx, http.MethodGet, projectPath(repo)+"/commits/"+hash, nil, nil, commit); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, sdk.WrapError(sdk.ErrRepoNotFound, "gerritClient.commit> commit %s not found on %s", hash, repo)
+		}
+		return nil, sdk.WrapError(err, "gerritClient.commit> Unable to get commit %s on %s", hash, repo)
+	}
+	if commit.Commit == "" {
+		commit.Commit = hash
+	}
+	return commit, nil
+}
+
+// firstParents walks the first parents from head until the base commit is found
+func (c *gerritClient) firstParents(ctx context.Context, repo, base, head string) ([]sdk.VCSCommit, error) {
+	commits := []sdk.VCSCommit{}
+	sha := head
+	for i := 0; i < maxCommits && sha != "" && sha != base; i++ {
+		commit, err := c.commit(ctx, repo, sha)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, c.toVCSCommit(*commit))
+
+		sha = ""
+		if len(commit.Parents) > 0 {
+			sha = commit.Parents[0].Commit
+		}
+	}
+	if sha != base {
+		log.Debug("gerritClient.firstParents> commit %s not found on %s from %s", base, repo, head)
+	}
+	return commits, nil
+}
+
+func (c *gerritClient) toVCSCommit(commit CommitInfo) sdk.VCSCommit {
+	vcsCommit := sdk.VCSCommit{
+		Hash:    commit.Commit,
+		Message: commit.Message,
+		URL:     c.consumer.URL + "/q/" + commit.Commit,
+	}
+	if vcsCommit.Message == "" {
+		vcsCommit.Message = commit.Subject
+	}
+	if commit.Author != nil {
+		vcsCommit.Timestamp = parseTimestamp(commit.Author.Date).Unix() * 1000
+		vcsCommit.Author = sdk.VCSAuthor{
+			Name:        commit.Author.Name,
+			DisplayName: commit.Author.Name,
+			Email:       commit.Author.Email,
+		}
+	}
+	return vcsCommit
+}
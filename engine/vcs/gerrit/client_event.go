@@ -0,0 +1,35 @@
+package gerrit
+
+import (
+	"context"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Polling is not supported, gerrit events are consumed by the hooks service with the stream-events command
+
+// GetEvents is not implemented
+func (c *gerritClient) GetEvents(ctx context.Context, repo string, dateRef time.Time) ([]interface{}, time.Duration, error) {
+	return nil, 0, sdk.ErrNotImplemented
+}
+
+// PushEvents is not implemented
+func (c *gerritClient) PushEvents(ctx context.Context, repo string, events []interface{}) ([]sdk.VCSPushEvent, error) {
+	return nil, sdk.ErrNotImplemented
+}
+
+// CreateEvents is not implemented
+func (c *gerritClient) CreateEvents(ctx context.Context, repo string, events []interface{}) ([]sdk.VCSCreateEvent, error) {
+	return nil, sdk.ErrNotImplemented
+}
+
+// DeleteEvents is not implemented
+func (c *gerritClient) DeleteEvents(ctx context.Context, repo string, events []interface{}) ([]sdk.VCSDeleteEvent, error) {
+	return nil, sdk.ErrNotImplemented
+}
+
+// PullRequestEvents is not implemented
+func (c *gerritClient) PullRequestEvents(ctx context.Context, repo string, events []interface{}) ([]sdk.VCSPullRequestEvent, error) {
+	return nil, sdk.ErrNotImplemented
+}
@@ -0,0 +1,29 @@
+package gerrit
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Gerrit events are consumed by the hooks service with the stream-events command, webhooks are not supported
+
+// CreateHook is not implemented
+func (c *gerritClient) CreateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	return sdk.ErrNotImplemented
+}
+
+// GetHook is not implemented
+func (c *gerritClient) GetHook(ctx context.Context, repo, url string) (sdk.VCSHook, error) {
+	return sdk.VCSHook{}, sdk.ErrNotImplemented
+}
+
+// UpdateHook is not implemented
+func (c *gerritClient) UpdateHook(ctx context.Context, repo, url string, hook sdk.VCSHook) error {
+	return sdk.ErrNotImplemented
+}
+
+// DeleteHook is not implemented
+func (c *gerritClient) DeleteHook(ctx context.Context, repo string, hook sdk.VCSHook) error {
+	return sdk.ErrNotImplemented
+}
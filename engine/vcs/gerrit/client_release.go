@@ -0,0 +1,18 @@
+package gerrit
+
+import (
+	"context"
+	"io"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Release is not implemented, there is no release on gerrit
+func (c *gerritClient) Release(ctx context.Context, repo, tagName, releaseTitle, releaseDescription string) (*sdk.VCSRelease, error) {
+	return nil, sdk.ErrNotImplemented
+}
+
+// UploadReleaseFile is not implemented, there is no release on gerrit
+func (c *gerritClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.ReadCloser) error {
+	return sdk.ErrNotImplemented
+}
@@ -0,0 +1,76 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Repos list the projects visible by the authenticated user
+// Only the projects named namespace/name can be used by CDS, others are ignored
+// https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#list-projects
+func (c *gerritClient) Repos(ctx context.Context) ([]sdk.VCSRepo, error) {
+	projects := map[string]ProjectInfo{}
+	params := url.Values{}
+	params.Set("d", "")
+	params.Set("type", "CODE")
+	if err := c.do(ctx, http.MethodGet, "/projects/", params, nil, &projects); err != nil {
+		return nil, sdk.WrapError(err, "gerritClient.Repos> Unable to list projects")
+	}
+
+	repos := []sdk.VCSRepo{}
+	for name, p := range projects {
+		if strings.Count(name, "/") != 1 {
+			continue
+		}
+		p.Name = name
+		repos = append(repos, c.toVCSRepo(p))
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Fullname < repos[j].Fullname })
+	return repos, nil
+}
+
+// RepoByFullname returns the project namespace/name
+func (c *gerritClient) RepoByFullname(ctx context.Context, fullname string) (sdk.VCSRepo, error) {
+	var p ProjectInfo
+	if err := c.do(ctx, http.MethodGet, projectPath(fullname), nil, nil, &p); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return sdk.VCSRepo{}, sdk.WrapError(sdk.ErrRepoNotFound, "gerritClient.RepoByFullname> %s not found", fullname)
+		}
+		return sdk.VCSRepo{}, sdk.WrapError(err, "gerritClient.RepoByFullname> Unable to get project %s", fullname)
+	}
+	if p.Name == "" {
+		p.Name = fullname
+	}
+	return c.toVCSRepo(p), nil
+}
+
+func (c *gerritClient) toVCSRepo(p ProjectInfo) sdk.VCSRepo {
+	r := sdk.VCSRepo{
+		ID:           p.ID,
+		Name:         p.Name,
+		Slug:         strings.SplitN(p.Name, "/", 2)[0],
+		Fullname:     p.Name,
+		URL:          c.consumer.URL + "/admin/repos/" + p.Name,
+		HTTPCloneURL: c.consumer.URL + "/" + p.Name,
+	}
+	if u, err := url.Parse(c.consumer.URL); err == nil && c.consumer.sshPort > 0 {
+		r.SSHCloneURL = fmt.Sprintf("ssh://%s:%d/%s", u.Hostname(), c.consumer.sshPort, p.Name)
+	}
+	return r
+}
+
+// ListForks returns an empty list, there is no fork on gerrit
+func (c *gerritClient) ListForks(ctx context.Context, repo string) ([]sdk.VCSRepo, error) {
+	return []sdk.VCSRepo{}, nil
+}
+
+// GrantReadPermission does nothing, the access rights are inherited from the parent projects on gerrit
+func (c *gerritClient) GrantReadPermission(ctx context.Context, repo string) error {
+	return nil
+}
@@ -0,0 +1,133 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+//SetStatus posts a review message on the changes of the commit once the workflow node run is over
+//The label Verified is voted once the workflow run is over: +1 on success, -1 on failure, so that
+//a node run succeeding after another one failed doesn't overwrite the vote
+//https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#set-review
+func (c *gerritClient) SetStatus(ctx context.Context, event sdk.Event) error {
+	if c.consumer.disableStatus {
+		log.Warning("gerrit.SetStatus>  ⚠ Gerrit statuses are disabled")
+		return nil
+	}
+
+	if event.EventType != fmt.Sprintf("%T", sdk.EventRunWorkflowNode{}) {
+		log.Debug("gerrit.SetStatus> Unsupported event %s", event.EventType)
+		return nil
+	}
+
+	var eventNR sdk.EventRunWorkflowNode
+	if err := mapstructure.Decode(event.Payload, &eventNR); err != nil {
+		return sdk.WrapError(err, "Error durring consumption")
+	}
+
+	//We only comment final statuses
+	switch eventNR.Status {
+	case sdk.StatusSuccess.String(), sdk.StatusFail.String():
+	default:
+		log.Debug("gerrit.SetStatus> Do not process event for current status: %v", event)
+		return nil
+	}
+
+	//We only vote on the final status of the workflow run
+	var vote int
+	switch eventNR.WorkflowRunStatus {
+	case sdk.StatusSuccess.String():
+		vote = 1
+	case sdk.StatusFail.String():
+		vote = -1
+	}
+
+	changes, err := c.queryChanges(ctx, fmt.Sprintf("commit:%s project:%s", eventNR.Hash, eventNR.RepositoryFullName))
+	if err != nil {
+		return sdk.WrapError(err, "gerrit.SetStatus> Unable to find changes of commit %s on %s", eventNR.Hash, eventNR.RepositoryFullName)
+	}
+	if len(changes) == 0 {
+		log.Debug("gerrit.SetStatus> No change for commit %s on %s", eventNR.Hash, eventNR.RepositoryFullName)
+		return nil
+	}
+
+	message := fmt.Sprintf("%s: %s", sdk.VCSCommitStatusDescription(event.ProjectKey, event.WorkflowName, eventNR), eventNR.Status)
+	//CDS can avoid sending the run url in the review message, if it's disable
+	if !c.consumer.disableStatusDetail {
+		message += fmt.Sprintf("\n%s/project/%s/workflow/%s/run/%d", c.consumer.uiURL, event.ProjectKey, event.WorkflowName, eventNR.Number)
+	}
+
+	reviewer := c.reviewer()
+	for _, change := range changes {
+		review := ReviewInput{Message: message, Tag: reviewTag}
+		// Labels can't be voted anymore on merged or abandoned changes
+		if vote != 0 && change.Status == "NEW" {
+			review.Labels = map[string]int{c.consumer.verifiedLabel: vote}
+		}
+		path := fmt.Sprintf("/changes/%s/revisions/%s/review", change.ID, eventNR.Hash)
+		if err := reviewer.do(ctx, http.MethodPost, path, nil, review, nil); err != nil {
+			return sdk.WrapError(err, "gerrit.SetStatus> Unable to review change %d with %s", change.Number, eventNR.Hash)
+		}
+	}
+	return nil
+}
+
+// ListStatuses returns the statuses posted by CDS on the patchset of a commit
+func (c *gerritClient) ListStatuses(ctx context.Context, repo string, ref string) ([]sdk.VCSCommitStatus, error) {
+	changes, err := c.queryChanges(ctx, fmt.Sprintf("commit:%s project:%s", ref, repo), "ALL_REVISIONS")
+	if err != nil {
+		return nil, sdk.WrapError(err, "gerritClient.ListStatuses> Unable to find changes of commit %s on %s", ref, repo)
+	}
+
+	statuses := []sdk.VCSCommitStatus{}
+	for _, change := range changes {
+		rev, ok := change.Revisions[ref]
+		if !ok {
+			continue
+		}
+
+		var messages []ChangeMessageInfo
+		if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/changes/%s/messages", change.ID), nil, nil, &messages); err != nil {
+			return nil, sdk.WrapError(err, "gerritClient.ListStatuses> Unable to list messages of change %d", change.Number)
+		}
+		for _, m := range messages {
+			if m.Tag != reviewTag || m.RevisionNumber != rev.Number {
+				continue
+			}
+			context, state, ok := parseReviewMessage(m.Message)
+			if !ok {
+				continue
+			}
+			statuses = append(statuses, sdk.VCSCommitStatus{
+				CreatedAt:  parseTimestamp(m.Date),
+				Decription: context,
+				Ref:        ref,
+				State:      state,
+			})
+		}
+	}
+	return statuses, nil
+}
+
+// parseReviewMessage returns the context and the status of a review message posted by SetStatus
+// Gerrit prefixes the message with the patchset number and the votes
+func parseReviewMessage(message string) (string, string, bool) {
+	for _, line := range strings.Split(message, "\n") {
+		if !strings.HasPrefix(line, "CDS/") {
+			continue
+		}
+		i := strings.LastIndex(line, ": ")
+		if i < 0 {
+			return "", "", false
+		}
+		return line[:i], line[i+2:], true
+	}
+	return "", "", false
+}
@@ -0,0 +1,41 @@
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Tags returns the tags of a project
+// https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#list-tags
+func (c *gerritClient) Tags(ctx context.Context, fullname string) ([]sdk.VCSTag, error) {
+	var gerritTags []TagInfo
+	if err := c.do(ctx, http.MethodGet, projectPath(fullname)+"/tags/", nil, nil, &gerritTags); err != nil {
+		return nil, sdk.WrapError(err, "gerritClient.Tags> Unable to list tags of %s", fullname)
+	}
+
+	tags := make([]sdk.VCSTag, len(gerritTags))
+	for i, t := range gerritTags {
+		tag := sdk.VCSTag{
+			Tag:     strings.TrimPrefix(t.Ref, "refs/tags/"),
+			Sha:     t.Revision,
+			Message: t.Message,
+			Hash:    t.Revision,
+		}
+		// The object of an annotated tag is the tagged commit
+		if t.Object != "" {
+			tag.Hash = t.Object
+		}
+		if t.Tagger != nil {
+			tag.Tagger = sdk.VCSAuthor{
+				Name:        t.Tagger.Name,
+				DisplayName: t.Tagger.Name,
+				Email:       t.Tagger.Email,
+			}
+		}
+		tags[i] = tag
+	}
+	return tags, nil
+}
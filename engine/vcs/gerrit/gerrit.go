@@ -0,0 +1,49 @@
+package gerrit
+
+import (
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+var (
+	_ sdk.VCSAuthorizedClient = &gerritClient{}
+	_ sdk.VCSServer           = &gerritConsumer{}
+)
+
+// gerritClient implements VCSAuthorizedClient interface, it uses the HTTP credentials of a gerrit account
+type gerritClient struct {
+	consumer *gerritConsumer
+	username string
+	password string
+}
+
+// gerritConsumer implements vcs.Server and it's used to instanciate a gerritClient
+type gerritConsumer struct {
+	URL                 string `json:"url"`
+	cache               cache.Store
+	uiURL               string
+	sshPort             int
+	reviewerName        string
+	reviewerToken       string
+	verifiedLabel       string
+	disableStatus       bool
+	disableStatusDetail bool
+}
+
+// New instanciate a new gerrit consumer, the reviewer account is used to vote on changes if it is set
+func New(URL, uiURL string, sshPort int, reviewerName, reviewerToken, verifiedLabel string, store cache.Store, disableStatus, disableStatusDetail bool) sdk.VCSServer {
+	if verifiedLabel == "" {
+		verifiedLabel = defaultVerifiedLabel
+	}
+	return &gerritConsumer{
+		URL:                 URL,
+		cache:               store,
+		uiURL:               uiURL,
+		sshPort:             sshPort,
+		reviewerName:        reviewerName,
+		reviewerToken:       reviewerToken,
+		verifiedLabel:       verifiedLabel,
+		disableStatus:       disableStatus,
+		disableStatusDetail: disableStatusDetail,
+	}
+}
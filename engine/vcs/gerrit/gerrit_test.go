@@ -0,0 +1,213 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// fakeGerrit serves a project cds/demo with a few commits and an open change
+type fakeGerrit struct {
+	*httptest.Server
+	reviews  map[string]ReviewInput
+	reviewer string
+}
+
+func newFakeGerrit(t *testing.T) *fakeGerrit {
+	f := &fakeGerrit{reviews: map[string]ReviewInput{}}
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, ")]}'\n")      // nolint
+		json.NewEncoder(w).Encode(v) // nolint
+	}
+	commits := map[string]CommitInfo{}
+	for i := 1; i <= 5; i++ {
+		c := CommitInfo{
+			Commit:  fmt.Sprintf("sha%d", i),
+			Subject: fmt.Sprintf("commit %d", i),
+			Message: fmt.Sprintf("commit %d\n", i),
+			Author:  &GitPersonInfo{Name: "John", Email: "john@example.com", Date: "2018-10-18 10:00:00.000000000"},
+		}
+		if i > 1 {
+			c.Parents = []CommitInfo{{Commit: fmt.Sprintf("sha%d", i-1)}}
+		}
+		commits[c.Commit] = c
+	}
+	change := ChangeInfo{
+		ID:              "cds%2Fdemo~master~I1234",
+		Project:         "cds/demo",
+		Branch:          "master",
+		Status:          "NEW",
+		Number:          42,
+		Owner:           &AccountInfo{Username: "john", Name: "John", Email: "john@example.com"},
+		CurrentRevision: "sha6",
+		Revisions:       map[string]RevisionInfo{"sha6": {Number: 2, Ref: "refs/changes/42/42/2", Commit: &CommitInfo{Subject: "change", Message: "change\n"}}},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a/", func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		if password != "http-password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		path := r.URL.EscapedPath()
+		switch {
+		case path == "/a/accounts/self":
+			writeJSON(w, AccountInfo{Username: user})
+		case path == "/a/projects/":
+			writeJSON(w, map[string]ProjectInfo{"cds/demo": {ID: "cds%2Fdemo"}, "All-Projects": {ID: "All-Projects"}})
+		case path == "/a/projects/cds%2Fdemo":
+			writeJSON(w, ProjectInfo{ID: "cds%2Fdemo", Name: "cds/demo"})
+		case path == "/a/projects/cds%2Fdemo/HEAD":
+			writeJSON(w, "refs/heads/master")
+		case path == "/a/projects/cds%2Fdemo/branches/":
+			writeJSON(w, []BranchInfo{{Ref: "HEAD", Revision: "master"}, {Ref: "refs/meta/config", Revision: "cfg"}, {Ref: "refs/heads/master", Revision: "sha5"}, {Ref: "refs/heads/feat/a", Revision: "sha3"}})
+		case path == "/a/projects/cds%2Fdemo/branches/master":
+			writeJSON(w, BranchInfo{Ref: "refs/heads/master", Revision: "sha5"})
+		case len(path) > len("/a/projects/cds%2Fdemo/commits/") && path[:len("/a/projects/cds%2Fdemo/commits/")] == "/a/projects/cds%2Fdemo/commits/":
+			c, ok := commits[path[len("/a/projects/cds%2Fdemo/commits/"):]]
+			if !ok {
+				http.Error(w, "Not found", http.StatusNotFound)
+				return
+			}
+			writeJSON(w, c)
+		case path == "/a/changes/":
+			switch r.URL.Query().Get("q") {
+			case "project:cds/demo status:open", "commit:sha6 project:cds/demo":
+				writeJSON(w, []ChangeInfo{change})
+			default:
+				writeJSON(w, []ChangeInfo{})
+			}
+		case path == "/a/changes/cds%2Fdemo~master~I1234/revisions/sha6/review":
+			var review ReviewInput
+			json.NewDecoder(r.Body).Decode(&review) // nolint
+			f.reviews[change.CurrentRevision] = review
+			f.reviewer = user
+			writeJSON(w, map[string]interface{}{})
+		case path == "/a/changes/cds%2Fdemo~master~I1234/messages":
+			writeJSON(w, []ChangeMessageInfo{
+				{Message: "Uploaded patch set 2.", RevisionNumber: 2},
+				{Message: "Patch Set 2: Verified+1\n\nCDS/KEY-wf-build: Success\nhttp://ui", Tag: reviewTag, RevisionNumber: 2, Date: "2018-10-18 10:00:00.000000000"},
+				{Message: "Patch Set 1: Verified-1\n\nCDS/KEY-wf-build: Fail", Tag: reviewTag, RevisionNumber: 1},
+			})
+		default:
+			t.Logf("unexpected request %s %s", r.Method, path)
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	})
+	f.Server = httptest.NewServer(mux)
+	return f
+}
+
+func TestGerritClient(t *testing.T) {
+	f := newFakeGerrit(t)
+	defer f.Close()
+	ctx := context.Background()
+
+	consumer := New(f.URL, "http://ui", 29418, "", "", "", nil, false, false)
+	_, _, err := consumer.AuthorizeToken(ctx, "token", "john")
+	assert.Error(t, err)
+	_, _, err = consumer.AuthorizeToken(ctx, "token", "john:wrong")
+	assert.Error(t, err)
+	username, password, err := consumer.AuthorizeToken(ctx, "token", "john:http-password")
+	assert.NoError(t, err)
+	assert.Equal(t, "john", username)
+
+	client, err := consumer.GetAuthorizedClient(ctx, username, password)
+	assert.NoError(t, err)
+
+	repos, err := client.Repos(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, repos, 1) {
+		assert.Equal(t, "cds/demo", repos[0].Fullname)
+		assert.Equal(t, f.URL+"/cds/demo", repos[0].HTTPCloneURL)
+	}
+
+	branches, err := client.Branches(ctx, "cds/demo")
+	assert.NoError(t, err)
+	if assert.Len(t, branches, 2) {
+		assert.Equal(t, "master", branches[0].DisplayID)
+		assert.True(t, branches[0].Default)
+		assert.Equal(t, "feat/a", branches[1].DisplayID)
+		assert.False(t, branches[1].Default)
+	}
+
+	_, err = client.Branch(ctx, "cds/demo", "unknown")
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrNoBranch))
+
+	commits, err := client.Commits(ctx, "cds/demo", "master", "sha2", "")
+	assert.NoError(t, err)
+	if assert.Len(t, commits, 3) {
+		assert.Equal(t, "sha5", commits[0].Hash)
+		assert.Equal(t, "sha3", commits[2].Hash)
+		assert.Equal(t, "John", commits[0].Author.Name)
+	}
+
+	prs, err := client.PullRequests(ctx, "cds/demo")
+	assert.NoError(t, err)
+	if assert.Len(t, prs, 1) {
+		assert.Equal(t, 42, prs[0].ID)
+		assert.Equal(t, "master", prs[0].Head.Branch.DisplayID)
+		assert.Equal(t, "sha6", prs[0].Head.Branch.LatestCommit)
+	}
+}
+
+func TestGerritClientSetStatus(t *testing.T) {
+	f := newFakeGerrit(t)
+	defer f.Close()
+	ctx := context.Background()
+
+	consumer := New(f.URL, "http://ui", 29418, "cds-bot", "http-password", "", nil, false, false)
+	client, err := consumer.GetAuthorizedClient(ctx, "john", "http-password")
+	assert.NoError(t, err)
+
+	newEvent := func(status, runStatus string) sdk.Event {
+		return sdk.Event{
+			EventType:    fmt.Sprintf("%T", sdk.EventRunWorkflowNode{}),
+			ProjectKey:   "KEY",
+			WorkflowName: "wf",
+			Payload: map[string]interface{}{
+				"Number":             int64(1),
+				"Status":             status,
+				"Hash":               "sha6",
+				"RepositoryFullName": "cds/demo",
+				"NodeName":           "build",
+				"WorkflowRunStatus":  runStatus,
+			},
+		}
+	}
+
+	// Only final statuses are commented
+	assert.NoError(t, client.SetStatus(ctx, newEvent(sdk.StatusBuilding.String(), "")))
+	assert.Len(t, f.reviews, 0)
+
+	// The vote waits for the end of the workflow run
+	assert.NoError(t, client.SetStatus(ctx, newEvent(sdk.StatusFail.String(), sdk.StatusBuilding.String())))
+	review := f.reviews["sha6"]
+	assert.Nil(t, review.Labels)
+	assert.Equal(t, reviewTag, review.Tag)
+	assert.Equal(t, "CDS/KEY-wf-build: Fail\nhttp://ui/project/KEY/workflow/wf/run/1", review.Message)
+	assert.Equal(t, "cds-bot", f.reviewer)
+
+	// A node run succeeding in a failed workflow run doesn't vote +1
+	assert.NoError(t, client.SetStatus(ctx, newEvent(sdk.StatusSuccess.String(), sdk.StatusFail.String())))
+	assert.Equal(t, map[string]int{"Verified": -1}, f.reviews["sha6"].Labels)
+
+	assert.NoError(t, client.SetStatus(ctx, newEvent(sdk.StatusSuccess.String(), sdk.StatusSuccess.String())))
+	assert.Equal(t, map[string]int{"Verified": 1}, f.reviews["sha6"].Labels)
+
+	statuses, err := client.ListStatuses(ctx, "cds/demo", "sha6")
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 1) {
+		assert.Equal(t, "CDS/KEY-wf-build", statuses[0].Decription)
+		assert.Equal(t, sdk.StatusSuccess.String(), statuses[0].State)
+	}
+}
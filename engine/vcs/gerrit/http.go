@@ -0,0 +1,85 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var (
+	httpClient = cdsclient.NewHTTPClient(time.Second*30, false)
+	// xssiPrefix prevents JSON responses of the gerrit REST API to be used as javascript
+	xssiPrefix = []byte(")]}'")
+)
+
+// projectPath returns the path of a gerrit project, the slashes of the project name have to be encoded
+func projectPath(project string) string {
+	return "/projects/" + url.PathEscape(project)
+}
+
+// do sends an authenticated request to the gerrit REST API, in is marshalled in JSON and the response is unmarshalled in out
+// https://gerrit-review.googlesource.com/Documentation/rest-api.html#authentication
+func (c *gerritClient) do(ctx context.Context, method, path string, params url.Values, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return sdk.WrapError(err, "Cannot marshal body %+v", in)
+		}
+		body = b
+	}
+
+	uri := c.consumer.URL + "/a" + path
+	if len(params) > 0 {
+		uri += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequest(method, uri, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.SetBasicAuth(c.username, c.password)
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return sdk.WrapError(err, "HTTP Error")
+	}
+	defer res.Body.Close()
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return sdk.WrapError(sdk.ErrNotFound, "gerritClient.do> %s %s not found", method, path)
+	case res.StatusCode == http.StatusUnauthorized:
+		return sdk.WrapError(sdk.ErrUnauthorized, "gerritClient.do> %s %s unauthorized", method, path)
+	case res.StatusCode == http.StatusForbidden:
+		return sdk.WrapError(sdk.ErrForbidden, "gerritClient.do> %s %s forbidden", method, path)
+	case res.StatusCode >= 400:
+		// Gerrit errors are sent as plain text
+		return fmt.Errorf("Gerrit error (%d) on %s %s: %s", res.StatusCode, method, path, string(bytes.TrimSpace(resBody)))
+	}
+
+	resBody = bytes.TrimPrefix(resBody, xssiPrefix)
+	if out != nil && len(bytes.TrimSpace(resBody)) > 0 {
+		if err := json.Unmarshal(resBody, out); err != nil {
+			return sdk.WrapError(err, "Unable to parse gerrit response on %s %s: %s", method, path, string(resBody))
+		}
+	}
+	return nil
+}
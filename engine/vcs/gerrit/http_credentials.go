@@ -0,0 +1,69 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/hex"
+	"math/rand"
+	"net/http"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func generateHash() (string, error) {
+	size := 64
+	bs := make([]byte, size)
+	if _, err := rand.Read(bs); err != nil {
+		log.Error("vcs> gerrit> generateID: rand.Read failed: %s\n", err)
+		return "", err
+	}
+	str := hex.EncodeToString(bs)
+	return str[0:size], nil
+}
+
+//AuthorizeRedirect returns a request token and the URL of the gerrit page where the user generates its HTTP credentials
+//Gerrit has no OAuth provider, the user has to copy "username:password" as verifier
+func (g *gerritConsumer) AuthorizeRedirect(ctx context.Context) (string, string, error) {
+	requestToken, err := generateHash()
+	if err != nil {
+		return "", "", err
+	}
+	return requestToken, g.URL + "/settings/#HTTPCredentials", nil
+}
+
+//AuthorizeToken checks the HTTP credentials given as verifier and returns the username and the password as token and secret
+func (g *gerritConsumer) AuthorizeToken(ctx context.Context, requestToken, verifier string) (string, string, error) {
+	credentials := strings.SplitN(strings.TrimSpace(verifier), ":", 2)
+	if len(credentials) != 2 || credentials[0] == "" || credentials[1] == "" {
+		return "", "", sdk.WrapError(sdk.ErrWrongRequest, "gerrit.AuthorizeToken> verifier must be username:password")
+	}
+
+	c := &gerritClient{consumer: g, username: credentials[0], password: credentials[1]}
+	var account AccountInfo
+	if err := c.do(ctx, http.MethodGet, "/accounts/self", nil, nil, &account); err != nil {
+		return "", "", sdk.WrapError(err, "gerrit.AuthorizeToken> invalid HTTP credentials for %s", credentials[0])
+	}
+	return credentials[0], credentials[1], nil
+}
+
+//GetAuthorizedClient returns an authorized client
+func (g *gerritConsumer) GetAuthorizedClient(ctx context.Context, username, password string) (sdk.VCSAuthorizedClient, error) {
+	return &gerritClient{
+		consumer: g,
+		username: username,
+		password: password,
+	}, nil
+}
+
+// reviewer returns the client used to vote on changes
+func (c *gerritClient) reviewer() *gerritClient {
+	if c.consumer.reviewerName == "" || c.consumer.reviewerToken == "" {
+		return c
+	}
+	return &gerritClient{
+		consumer: c.consumer,
+		username: c.consumer.reviewerName,
+		password: c.consumer.reviewerToken,
+	}
+}
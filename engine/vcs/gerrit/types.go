@@ -0,0 +1,108 @@
+package gerrit
+
+import (
+	"time"
+)
+
+const (
+	defaultVerifiedLabel = "Verified"
+	// reviewTag is set on the review messages posted by CDS, gerrit hides them with the "Only comments" filter
+	reviewTag = "autogenerated:cds"
+	// timestampLayout is the format of the timestamps sent by the gerrit REST API, they are always in UTC
+	timestampLayout = "2006-01-02 15:04:05.000000000"
+)
+
+// ProjectInfo represents a gerrit project
+type ProjectInfo struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Parent      string `json:"parent"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+}
+
+// BranchInfo represents a branch of a gerrit project
+type BranchInfo struct {
+	Ref      string `json:"ref"`
+	Revision string `json:"revision"`
+}
+
+// TagInfo represents a tag of a gerrit project
+type TagInfo struct {
+	Ref      string         `json:"ref"`
+	Revision string         `json:"revision"`
+	Object   string         `json:"object"`
+	Message  string         `json:"message"`
+	Tagger   *GitPersonInfo `json:"tagger"`
+}
+
+// GitPersonInfo represents the author or the committer of a commit
+type GitPersonInfo struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Date  string `json:"date"`
+}
+
+// CommitInfo represents a commit
+type CommitInfo struct {
+	Commit    string         `json:"commit"`
+	Parents   []CommitInfo   `json:"parents"`
+	Author    *GitPersonInfo `json:"author"`
+	Committer *GitPersonInfo `json:"committer"`
+	Subject   string         `json:"subject"`
+	Message   string         `json:"message"`
+}
+
+// AccountInfo represents a gerrit account
+type AccountInfo struct {
+	AccountID int64  `json:"_account_id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Username  string `json:"username"`
+}
+
+// RevisionInfo represents a patchset of a change
+type RevisionInfo struct {
+	Number int64       `json:"_number"`
+	Ref    string      `json:"ref"`
+	Commit *CommitInfo `json:"commit"`
+}
+
+// ChangeInfo represents a gerrit change, its ID is project~branch~Change-Id with the project already URL encoded
+type ChangeInfo struct {
+	ID              string                  `json:"id"`
+	Project         string                  `json:"project"`
+	Branch          string                  `json:"branch"`
+	ChangeID        string                  `json:"change_id"`
+	Subject         string                  `json:"subject"`
+	Status          string                  `json:"status"`
+	Number          int64                   `json:"_number"`
+	Owner           *AccountInfo            `json:"owner"`
+	CurrentRevision string                  `json:"current_revision"`
+	Revisions       map[string]RevisionInfo `json:"revisions"`
+}
+
+// ReviewInput is the body sent to review a patchset
+type ReviewInput struct {
+	Message string         `json:"message,omitempty"`
+	Tag     string         `json:"tag,omitempty"`
+	Labels  map[string]int `json:"labels,omitempty"`
+}
+
+// ChangeMessageInfo represents a message of a change
+type ChangeMessageInfo struct {
+	ID             string       `json:"id"`
+	Author         *AccountInfo `json:"author"`
+	Date           string       `json:"date"`
+	Message        string       `json:"message"`
+	Tag            string       `json:"tag"`
+	RevisionNumber int64        `json:"_revision_number"`
+}
+
+func parseTimestamp(s string) time.Time {
+	t, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,63 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Branches returns the branches of a repository
+func (c *giteaClient) Branches(ctx context.Context, fullname string) ([]sdk.VCSBranch, error) {
+	repo, err := c.repository(ctx, fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := []sdk.VCSBranch{}
+	if err := c.getPages(ctx, "/repos/"+fullname+"/branches", nil, func(page []byte) (int, error) {
+		var nextBranches []Branch
+		if err := json.Unmarshal(page, &nextBranches); err != nil {
+			return 0, err
+		}
+		for _, b := range nextBranches {
+			branches = append(branches, toVCSBranch(b, repo.DefaultBranch))
+		}
+		return len(nextBranches), nil
+	}); err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.Branches> Unable to list branches of %s", fullname)
+	}
+	return branches, nil
+}
+
+// Branch returns only detail of a branch
+func (c *giteaClient) Branch(ctx context.Context, fullname, theBranch string) (*sdk.VCSBranch, error) {
+	repo, err := c.repository(ctx, fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	var branch Branch
+	if _, err := c.do(ctx, http.MethodGet, "/repos/"+fullname+"/branches/"+theBranch, nil, nil, &branch, nil); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, sdk.WrapError(sdk.ErrNoBranch, "giteaClient.Branch> Branch not found %s on %s", theBranch, fullname)
+		}
+		return nil, sdk.WrapError(err, "giteaClient.Branch> Unable to get branch %s on %s", theBranch, fullname)
+	}
+
+	b := toVCSBranch(branch, repo.DefaultBranch)
+	return &b, nil
+}
+
+func toVCSBranch(b Branch, defaultBranch string) sdk.VCSBranch {
+	branch := sdk.VCSBranch{
+		ID:        b.Name,
+		DisplayID: b.Name,
+		Default:   b.Name == defaultBranch,
+	}
+	if b.Commit != nil {
+		branch.LatestCommit = b.Commit.ID
+	}
+	return branch
+}
@@ -0,0 +1,108 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// maxCommitPages limits the number of pages read while looking for the since commit
+const maxCommitPages = 10
+
+// Commits returns the commits list on a branch between a commit SHA (since) until another commit SHA (until)
+func (c *giteaClient) Commits(ctx context.Context, repo, theBranch, since, until string) ([]sdk.VCSCommit, error) {
+	log.Debug("giteaClient.Commits> Looking for commits on repo %s since = %s until = %s", repo, since, until)
+	head := until
+	if head == "" {
+		b, err := c.Branch(ctx, repo, theBranch)
+		if err != nil {
+			return nil, err
+		}
+		head = b.LatestCommit
+	}
+
+	// Without since commit, only the last commit of the branch is returned
+	if since == "" {
+		commit, err := c.Commit(ctx, repo, head)
+		if err != nil {
+			return nil, err
+		}
+		return []sdk.VCSCommit{commit}, nil
+	}
+
+	commits := []sdk.VCSCommit{}
+	params := url.Values{}
+	params.Set("sha", head)
+	params.Set("stat", "false")
+	params.Set("files", "false")
+	params.Set("limit", fmt.Sprintf("%d", pageSize))
+	for page := 1; page <= maxCommitPages; page++ {
+		params.Set("page", fmt.Sprintf("%d", page))
+		var nextCommits []Commit
+		if _, err := c.do(ctx, http.MethodGet, "/repos/"+repo+"/commits", params, nil, &nextCommits, nil); err != nil {
+			return nil, sdk.WrapError(err, "giteaClient.Commits> Unable to list commits of %s", repo)
+		}
+		for _, commit := range nextCommits {
+			if commit.SHA == since {
+				return commits, nil
+			}
+			commits = append(commits, toVCSCommit(commit))
+		}
+		if len(nextCommits) < pageSize {
+			break
+		}
+	}
+	log.Debug("giteaClient.Commits> commit %s not found on %s from %s", since, repo, head)
+	return commits, nil
+}
+
+// Commit returns a single commit
+func (c *giteaClient) Commit(ctx context.Context, repo, hash string) (sdk.VCSCommit, error) {
+	var commit Commit
+	if _, err := c.do(ctx, http.MethodGet, "/repos/"+repo+"/git/commits/"+hash, nil, nil, &commit, nil); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return sdk.VCSCommit{}, sdk.WrapError(sdk.ErrRepoNotFound, "giteaClient.Commit> commit %s not found on %s", hash, repo)
+		}
+		return sdk.VCSCommit{}, sdk.WrapError(err, "giteaClient.Commit> Unable to get commit %s on %s", hash, repo)
+	}
+	return toVCSCommit(commit), nil
+}
+
+// CommitsBetweenRefs returns the commits of head which are not in base
+func (c *giteaClient) CommitsBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSCommit, error) {
+	var diff Compare
+	if _, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/compare/%s...%s", repo, base, head), nil, nil, &diff, nil); err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.CommitsBetweenRefs> Unable to compare %s and %s on %s", base, head, repo)
+	}
+
+	commits := make([]sdk.VCSCommit, len(diff.Commits))
+	for i := range diff.Commits {
+		commits[i] = toVCSCommit(diff.Commits[i])
+	}
+	return commits, nil
+}
+
+func toVCSCommit(c Commit) sdk.VCSCommit {
+	commit := sdk.VCSCommit{
+		Hash: c.SHA,
+		URL:  c.HTMLURL,
+	}
+	if c.RepoCommit != nil {
+		commit.Message = c.RepoCommit.Message
+		if c.RepoCommit.Author != nil {
+			commit.Timestamp = c.RepoCommit.Author.Date.Unix() * 1000
+			commit.Author.Name = c.RepoCommit.Author.Name
+			commit.Author.DisplayName = c.RepoCommit.Author.Name
+			commit.Author.Email = c.RepoCommit.Author.Email
+		}
+	}
+	if c.Author != nil {
+		commit.Author.Name = c.Author.Login
+		commit.Author.Avatar = c.Author.AvatarURL
+	}
+	return commit
+}
@@ -0,0 +1,251 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// pollingInterval is the delay between two polls of the activity feed
+const pollingInterval = 60 * time.Second
+
+//GetEvents returns the activities of a repository after the reference date as []interface{}
+//the activity feed is available since gitea 1.20
+func (c *giteaClient) GetEvents(ctx context.Context, fullname string, dateRef time.Time) ([]interface{}, time.Duration, error) {
+	log.Debug("giteaClient.GetEvents> loading events for %s after %v", fullname, dateRef)
+
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(pageSize))
+	var activities []Activity
+	if _, err := c.do(ctx, http.MethodGet, "/repos/"+fullname+"/activities/feeds", params, nil, &activities, nil); err != nil {
+		return nil, pollingInterval, sdk.WrapError(err, "giteaClient.GetEvents> Unable to get activities of %s", fullname)
+	}
+
+	events := []interface{}{}
+	for _, a := range activities {
+		if a.Created.After(dateRef) {
+			events = append(events, a)
+		}
+	}
+	log.Debug("giteaClient.GetEvents> Found %d events...", len(events))
+	return events, pollingInterval, nil
+}
+
+//PushEvents returns push events as commits
+func (c *giteaClient) PushEvents(ctx context.Context, fullname string, iEvents []interface{}) ([]sdk.VCSPushEvent, error) {
+	activities, err := toActivities(iEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	lastCommitPerBranch := map[string]sdk.VCSCommit{}
+	for _, a := range activities {
+		branch, ok := branchName(a)
+		if a.OpType != activityPush || !ok {
+			continue
+		}
+		content, err := pushContent(a)
+		if err != nil {
+			return nil, err
+		}
+		// The commits of a push activity are sorted from the newest to the oldest
+		head := content.HeadCommit
+		if head == nil && len(content.Commits) > 0 {
+			head = &content.Commits[0]
+		}
+		if head == nil {
+			continue
+		}
+
+		commit := sdk.VCSCommit{
+			Hash:      head.Sha1,
+			Message:   head.Message,
+			Timestamp: a.Created.Unix() * 1000,
+			Author: sdk.VCSAuthor{
+				DisplayName: head.AuthorName,
+				Email:       head.AuthorEmail,
+				Name:        head.AuthorName,
+			},
+		}
+		if a.ActUser != nil {
+			commit.Author.Name = a.ActUser.Login
+			commit.Author.Avatar = a.ActUser.AvatarURL
+		}
+		if l, has := lastCommitPerBranch[branch]; !has || l.Timestamp < commit.Timestamp {
+			lastCommitPerBranch[branch] = commit
+		}
+	}
+
+	res := []sdk.VCSPushEvent{}
+	for b, commit := range lastCommitPerBranch {
+		branch, err := c.Branch(ctx, fullname, b)
+		if err != nil {
+			log.Debug("giteaClient.PushEvents> Unable to find branch %s in %s : %s", b, fullname, err)
+			continue
+		}
+		res = append(res, sdk.VCSPushEvent{
+			Repo:   fullname,
+			Branch: *branch,
+			Commit: commit,
+		})
+	}
+	return res, nil
+}
+
+//CreateEvents returns the branches created without new commits
+func (c *giteaClient) CreateEvents(ctx context.Context, fullname string, iEvents []interface{}) ([]sdk.VCSCreateEvent, error) {
+	activities, err := toActivities(iEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []sdk.VCSCreateEvent{}
+	for _, a := range activities {
+		b, ok := branchName(a)
+		if a.OpType != activityPush || !ok {
+			continue
+		}
+		content, err := pushContent(a)
+		if err != nil {
+			return nil, err
+		}
+		if len(content.Commits) > 0 {
+			continue
+		}
+
+		branch, err := c.Branch(ctx, fullname, b)
+		if err != nil {
+			log.Debug("giteaClient.CreateEvents> Unable to find branch %s in %s : %s", b, fullname, err)
+			continue
+		}
+		commit, err := c.Commit(ctx, fullname, branch.LatestCommit)
+		if err != nil {
+			log.Warning("giteaClient.CreateEvents> Unable to find commit %s in %s : %s", branch.LatestCommit, fullname, err)
+			continue
+		}
+		res = append(res, sdk.VCSCreateEvent{
+			Repo:   fullname,
+			Branch: *branch,
+			Commit: commit,
+		})
+	}
+	return res, nil
+}
+
+//DeleteEvents returns the deleted branches
+func (c *giteaClient) DeleteEvents(ctx context.Context, fullname string, iEvents []interface{}) ([]sdk.VCSDeleteEvent, error) {
+	activities, err := toActivities(iEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []sdk.VCSDeleteEvent{}
+	for _, a := range activities {
+		b, ok := branchName(a)
+		if a.OpType != activityDeleteBranch || !ok {
+			continue
+		}
+		res = append(res, sdk.VCSDeleteEvent{
+			Branch: sdk.VCSBranch{
+				ID:        b,
+				DisplayID: b,
+			},
+		})
+	}
+	return res, nil
+}
+
+//PullRequestEvents returns the opened and reopened pull requests
+func (c *giteaClient) PullRequestEvents(ctx context.Context, fullname string, iEvents []interface{}) ([]sdk.VCSPullRequestEvent, error) {
+	activities, err := toActivities(iEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []sdk.VCSPullRequestEvent{}
+	for _, a := range activities {
+		var action string
+		switch a.OpType {
+		case activityCreatePullRequest:
+			action = "opened"
+		case activityReopenPullRequest:
+			action = "reopened"
+		default:
+			continue
+		}
+
+		// The content of a pull request activity is "index|title"
+		index, err := strconv.ParseInt(strings.SplitN(a.Content, "|", 2)[0], 10, 64)
+		if err != nil {
+			log.Warning("giteaClient.PullRequestEvents> Unable to parse pull request activity %d content %s", a.ID, a.Content)
+			continue
+		}
+		pr, err := c.pullRequest(ctx, fullname, index)
+		if err != nil {
+			log.Warning("giteaClient.PullRequestEvents> Unable to get pull request %d of %s: %s", index, fullname, err)
+			continue
+		}
+		if pr.State != "open" {
+			continue
+		}
+
+		vcsPR := toVCSPullRequest(pr)
+		res = append(res, sdk.VCSPullRequestEvent{
+			Action: action,
+			URL:    vcsPR.URL,
+			Repo:   fullname,
+			User:   vcsPR.User,
+			Head:   vcsPR.Head,
+			Base:   vcsPR.Base,
+			Branch: vcsPR.Head.Branch,
+		})
+	}
+	return res, nil
+}
+
+func toActivities(iEvents []interface{}) ([]Activity, error) {
+	activities := make([]Activity, 0, len(iEvents))
+	for _, i := range iEvents {
+		if a, ok := i.(Activity); ok {
+			activities = append(activities, a)
+			continue
+		}
+		// Events which have been serialized are decoded again
+		b, err := json.Marshal(i)
+		if err != nil {
+			return nil, sdk.WrapError(err, "giteaClient> Unable to marshal event")
+		}
+		var a Activity
+		if err := json.Unmarshal(b, &a); err != nil {
+			return nil, sdk.WrapError(err, "giteaClient> Unable to unmarshal event")
+		}
+		activities = append(activities, a)
+	}
+	return activities, nil
+}
+
+// branchName returns the branch of an activity, tags are ignored
+func branchName(a Activity) (string, bool) {
+	if strings.HasPrefix(a.RefName, "refs/tags/") {
+		return "", false
+	}
+	return strings.TrimPrefix(a.RefName, "refs/heads/"), a.RefName != ""
+}
+
+func pushContent(a Activity) (PushActionContent, error) {
+	var content PushActionContent
+	if a.Content == "" {
+		return content, nil
+	}
+	if err := json.Unmarshal([]byte(a.Content), &content); err != nil {
+		return content, sdk.WrapError(err, "giteaClient> Unable to parse activity %d content", a.ID)
+	}
+	return content, nil
+}
@@ -0,0 +1,16 @@
+package gitea
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// ListForks returns the forks of a repository
+func (c *giteaClient) ListForks(ctx context.Context, repo string) ([]sdk.VCSRepo, error) {
+	repos, err := c.listRepos(ctx, "/repos/"+repo+"/forks")
+	if err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.ListForks> Unable to list forks of %s", repo)
+	}
+	return repos, nil
+}
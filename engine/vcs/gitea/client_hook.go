@@ -0,0 +1,111 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// CreateHook creates a gitea webhook on push events
+func (c *giteaClient) CreateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	if c.consumer.proxyURL != "" {
+		lastIndexSlash := strings.LastIndex(hook.URL, "/")
+		if c.consumer.proxyURL[len(c.consumer.proxyURL)-1] == '/' {
+			lastIndexSlash++
+		}
+		hook.URL = c.consumer.proxyURL + hook.URL[lastIndexSlash:]
+	}
+
+	r := Hook{
+		Type:   "gitea",
+		Active: true,
+		Events: []string{"push"},
+		Config: map[string]string{
+			"url":          hook.URL,
+			"content_type": "json",
+		},
+	}
+	if _, err := c.do(ctx, http.MethodPost, "/repos/"+repo+"/hooks", nil, r, &r, nil); err != nil {
+		return sdk.WrapError(err, "giteaClient.CreateHook> Unable to create webhook on %s", repo)
+	}
+	hook.ID = strconv.FormatInt(r.ID, 10)
+	return nil
+}
+
+// GetHook returns the webhook sending payloads to the given url
+func (c *giteaClient) GetHook(ctx context.Context, repo, url string) (sdk.VCSHook, error) {
+	hooks, err := c.hooks(ctx, repo)
+	if err != nil {
+		return sdk.VCSHook{}, err
+	}
+	for _, h := range hooks {
+		if h.Config["url"] == url {
+			return toVCSHook(h), nil
+		}
+	}
+	return sdk.VCSHook{}, sdk.ErrHookNotFound
+}
+
+// UpdateHook updates the webhook sending payloads to the given url
+func (c *giteaClient) UpdateHook(ctx context.Context, repo, url string, hook sdk.VCSHook) error {
+	h, err := c.GetHook(ctx, repo, url)
+	if err != nil {
+		return err
+	}
+
+	active := !hook.Disable
+	edit := EditHook{
+		Config: map[string]string{
+			"url":          hook.URL,
+			"content_type": "json",
+		},
+		Active: &active,
+	}
+	if _, err := c.do(ctx, http.MethodPatch, "/repos/"+repo+"/hooks/"+h.ID, nil, edit, nil, nil); err != nil {
+		return sdk.WrapError(err, "giteaClient.UpdateHook> Unable to update webhook %s on %s", h.ID, repo)
+	}
+	return nil
+}
+
+// DeleteHook deletes a webhook
+func (c *giteaClient) DeleteHook(ctx context.Context, repo string, hook sdk.VCSHook) error {
+	if _, err := c.do(ctx, http.MethodDelete, "/repos/"+repo+"/hooks/"+hook.ID, nil, nil, nil, nil); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil
+		}
+		return sdk.WrapError(err, "giteaClient.DeleteHook> Unable to delete webhook %s on %s", hook.ID, repo)
+	}
+	return nil
+}
+
+func (c *giteaClient) hooks(ctx context.Context, repo string) ([]Hook, error) {
+	hooks := []Hook{}
+	if err := c.getPages(ctx, "/repos/"+repo+"/hooks", nil, func(page []byte) (int, error) {
+		var nextHooks []Hook
+		if err := json.Unmarshal(page, &nextHooks); err != nil {
+			return 0, err
+		}
+		hooks = append(hooks, nextHooks...)
+		return len(nextHooks), nil
+	}); err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.hooks> Unable to list webhooks of %s", repo)
+	}
+	return hooks, nil
+}
+
+func toVCSHook(h Hook) sdk.VCSHook {
+	return sdk.VCSHook{
+		ID:          strconv.FormatInt(h.ID, 10),
+		Name:        fmt.Sprintf("%s %d", h.Type, h.ID),
+		Events:      h.Events,
+		Method:      http.MethodPost,
+		URL:         h.Config["url"],
+		ContentType: h.Config["content_type"],
+		Disable:     !h.Active,
+	}
+}
@@ -0,0 +1,99 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// PullRequests fetch all the opened pull requests of a repository
+func (c *giteaClient) PullRequests(ctx context.Context, fullname string) ([]sdk.VCSPullRequest, error) {
+	params := url.Values{}
+	params.Set("state", "open")
+
+	prs := []sdk.VCSPullRequest{}
+	if err := c.getPages(ctx, "/repos/"+fullname+"/pulls", params, func(page []byte) (int, error) {
+		var nextPullRequests []PullRequest
+		if err := json.Unmarshal(page, &nextPullRequests); err != nil {
+			return 0, err
+		}
+		for _, pr := range nextPullRequests {
+			prs = append(prs, toVCSPullRequest(pr))
+		}
+		return len(nextPullRequests), nil
+	}); err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.PullRequests> Unable to list pull requests of %s", fullname)
+	}
+	return prs, nil
+}
+
+// PullRequestComment push a new comment on a pull request
+func (c *giteaClient) PullRequestComment(ctx context.Context, repo string, id int, text string) error {
+	if c.consumer.disableStatus {
+		log.Warning("gitea.PullRequestComment>  ⚠ Gitea statuses are disabled")
+		return nil
+	}
+
+	path := fmt.Sprintf("/repos/%s/issues/%d/comments", repo, id)
+	if _, err := c.do(ctx, http.MethodPost, path, nil, CreateIssueComment{Body: text}, nil, &options{asUser: true}); err != nil {
+		return sdk.WrapError(err, "giteaClient.PullRequestComment> Unable to comment pull request %d on %s", id, repo)
+	}
+	return nil
+}
+
+func (c *giteaClient) pullRequest(ctx context.Context, repo string, index int64) (PullRequest, error) {
+	var pr PullRequest
+	_, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/pulls/%d", repo, index), nil, nil, &pr, nil)
+	return pr, err
+}
+
+func toVCSPullRequest(pr PullRequest) sdk.VCSPullRequest {
+	res := sdk.VCSPullRequest{
+		ID:   int(pr.Index),
+		URL:  pr.HTMLURL,
+		User: toVCSAuthor(pr.User),
+	}
+	if pr.Base != nil {
+		res.Base = toVCSPushEvent(*pr.Base)
+	}
+	if pr.Head != nil {
+		res.Head = toVCSPushEvent(*pr.Head)
+	}
+	return res
+}
+
+func toVCSPushEvent(b PRBranchInfo) sdk.VCSPushEvent {
+	e := sdk.VCSPushEvent{
+		Branch: sdk.VCSBranch{
+			ID:           b.Ref,
+			DisplayID:    b.Ref,
+			LatestCommit: b.Sha,
+		},
+		Commit: sdk.VCSCommit{
+			Hash:    b.Sha,
+			Message: b.Name,
+		},
+	}
+	if b.Repository != nil {
+		e.Repo = b.Repository.FullName
+		e.CloneURL = b.Repository.CloneURL
+	}
+	return e
+}
+
+func toVCSAuthor(u *User) sdk.VCSAuthor {
+	if u == nil {
+		return sdk.VCSAuthor{}
+	}
+	return sdk.VCSAuthor{
+		Name:        u.Login,
+		DisplayName: u.FullName,
+		Email:       u.Email,
+		Avatar:      u.AvatarURL,
+	}
+}
@@ -0,0 +1,63 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Release creates a release on a tag
+func (c *giteaClient) Release(ctx context.Context, fullname string, tagName string, title string, releaseNote string) (*sdk.VCSRelease, error) {
+	req := ReleaseRequest{
+		TagName: tagName,
+		Name:    title,
+		Body:    releaseNote,
+	}
+	var release Release
+	if _, err := c.do(ctx, http.MethodPost, "/repos/"+fullname+"/releases", nil, req, &release, nil); err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.Release> Unable to create release %s on %s", tagName, fullname)
+	}
+
+	// The upload url is not returned by gitea before 1.22
+	uploadURL := release.UploadURL
+	if uploadURL == "" {
+		uploadURL = c.apiURL(fmt.Sprintf("/repos/%s/releases/%d/assets", fullname, release.ID))
+	}
+	return &sdk.VCSRelease{
+		ID:        release.ID,
+		UploadURL: uploadURL,
+	}, nil
+}
+
+// UploadReleaseFile attaches a file to a release
+func (c *giteaClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.ReadCloser) error {
+	defer r.Close() // nolint
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("attachment", artifactName)
+	if err != nil {
+		return sdk.WrapError(err, "giteaClient.UploadReleaseFile> Unable to create form file")
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return sdk.WrapError(err, "giteaClient.UploadReleaseFile> Unable to read %s", artifactName)
+	}
+	if err := writer.Close(); err != nil {
+		return sdk.WrapError(err, "giteaClient.UploadReleaseFile> Unable to close form")
+	}
+
+	params := url.Values{}
+	params.Set("name", artifactName)
+	path := strings.Split(uploadURL, "{")[0]
+	if _, err := c.do(ctx, http.MethodPost, path, params, body.Bytes(), nil, &options{contentType: writer.FormDataContentType()}); err != nil {
+		return sdk.WrapError(err, "giteaClient.UploadReleaseFile> Unable to upload %s on release %s of %s", artifactName, releaseName, repo)
+	}
+	return nil
+}
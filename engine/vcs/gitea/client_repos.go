@@ -0,0 +1,87 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// Repos list repositories that are accessible to the authenticated user
+// https://try.gitea.io/api/swagger#/user/userCurrentListRepos
+func (c *giteaClient) Repos(ctx context.Context) ([]sdk.VCSRepo, error) {
+	repos, err := c.listRepos(ctx, "/user/repos")
+	if err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.Repos> Unable to list repositories")
+	}
+	return repos, nil
+}
+
+// RepoByFullname returns the repository owner/name
+func (c *giteaClient) RepoByFullname(ctx context.Context, fullname string) (sdk.VCSRepo, error) {
+	repo, err := c.repository(ctx, fullname)
+	if err != nil {
+		return sdk.VCSRepo{}, err
+	}
+	return toVCSRepo(*repo), nil
+}
+
+func (c *giteaClient) repository(ctx context.Context, fullname string) (*Repository, error) {
+	repo := &Repository{}
+	if _, err := c.do(ctx, http.MethodGet, "/repos/"+fullname, nil, nil, repo, nil); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, sdk.WrapError(sdk.ErrRepoNotFound, "giteaClient.repository> %s not found", fullname)
+		}
+		return nil, sdk.WrapError(err, "giteaClient.repository> Unable to get repository %s", fullname)
+	}
+	return repo, nil
+}
+
+func (c *giteaClient) listRepos(ctx context.Context, path string) ([]sdk.VCSRepo, error) {
+	repos := []sdk.VCSRepo{}
+	err := c.getPages(ctx, path, nil, func(page []byte) (int, error) {
+		var nextRepos []Repository
+		if err := json.Unmarshal(page, &nextRepos); err != nil {
+			return 0, err
+		}
+		for _, r := range nextRepos {
+			repos = append(repos, toVCSRepo(r))
+		}
+		return len(nextRepos), nil
+	})
+	return repos, err
+}
+
+func toVCSRepo(repo Repository) sdk.VCSRepo {
+	r := sdk.VCSRepo{
+		ID:           strconv.FormatInt(repo.ID, 10),
+		Name:         repo.Name,
+		Fullname:     repo.FullName,
+		URL:          repo.HTMLURL,
+		HTTPCloneURL: repo.CloneURL,
+		SSHCloneURL:  repo.SSHURL,
+	}
+	if repo.Owner != nil {
+		r.Slug = repo.Owner.Login
+	}
+	return r
+}
+
+// GrantReadPermission adds the user set in the configuration as a collaborator of the repository
+func (c *giteaClient) GrantReadPermission(ctx context.Context, fullname string) error {
+	owner := strings.SplitN(fullname, "/", 2)[0]
+	if c.consumer.username == "" || owner == c.consumer.username {
+		log.Debug("giteaClient.GrantReadPermission> nothing to do")
+		return nil
+	}
+
+	path := "/repos/" + fullname + "/collaborators/" + c.consumer.username
+	if _, err := c.do(ctx, http.MethodPut, path, nil, map[string]string{"permission": "read"}, nil, nil); err != nil {
+		return sdk.WrapError(err, "giteaClient.GrantReadPermission> Unable to add %s as collaborator of %s", c.consumer.username, fullname)
+	}
+	return nil
+}
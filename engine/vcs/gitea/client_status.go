@@ -0,0 +1,191 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+type statusData struct {
+	desc         string
+	status       string
+	repoFullName string
+	hash         string
+	urlPipeline  string
+	context      string
+}
+
+//SetStatus creates a commit status
+//https://try.gitea.io/api/swagger#/repository/repoCreateStatus
+func (c *giteaClient) SetStatus(ctx context.Context, event sdk.Event) error {
+	if c.consumer.disableStatus {
+		log.Warning("gitea.SetStatus>  ⚠ Gitea statuses are disabled")
+		return nil
+	}
+
+	var data statusData
+	var err error
+	switch event.EventType {
+	case fmt.Sprintf("%T", sdk.EventPipelineBuild{}):
+		data, err = processEventPipelineBuild(event, c.consumer.uiURL, c.consumer.disableStatusDetail)
+	case fmt.Sprintf("%T", sdk.EventRunWorkflowNode{}):
+		data, err = processEventWorkflowNodeRun(event, c.consumer.uiURL, c.consumer.disableStatusDetail)
+	default:
+		log.Error("gitea.SetStatus> Unknown event %v", event)
+		return nil
+	}
+	if err != nil {
+		return sdk.WrapError(err, "Cannot process Event")
+	}
+
+	if data.status == "" {
+		log.Debug("gitea.SetStatus> Do not process event for current status: %v", event)
+		return nil
+	}
+
+	status := CreateStatus{
+		Description: data.desc,
+		TargetURL:   data.urlPipeline,
+		State:       data.status,
+		Context:     data.context,
+	}
+	path := fmt.Sprintf("/repos/%s/statuses/%s", data.repoFullName, data.hash)
+	if _, err := c.do(ctx, http.MethodPost, path, nil, status, nil, nil); err != nil {
+		return sdk.WrapError(err, "Unable to create status on %s for %s", data.repoFullName, data.hash)
+	}
+	return nil
+}
+
+// ListStatuses returns the CDS statuses of a commit
+func (c *giteaClient) ListStatuses(ctx context.Context, repo string, ref string) ([]sdk.VCSCommitStatus, error) {
+	statuses := []Status{}
+	if err := c.getPages(ctx, "/repos/"+repo+"/commits/"+ref+"/statuses", nil, func(page []byte) (int, error) {
+		var nextStatuses []Status
+		if err := json.Unmarshal(page, &nextStatuses); err != nil {
+			return 0, err
+		}
+		statuses = append(statuses, nextStatuses...)
+		return len(nextStatuses), nil
+	}); err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.ListStatuses> Unable to list statuses of %s on %s", ref, repo)
+	}
+
+	vcsStatuses := []sdk.VCSCommitStatus{}
+	for _, s := range statuses {
+		if !strings.HasPrefix(s.Context, "CDS/") {
+			continue
+		}
+		vcsStatuses = append(vcsStatuses, sdk.VCSCommitStatus{
+			CreatedAt:  s.Created,
+			Decription: s.Context,
+			Ref:        ref,
+			State:      processGiteaState(s),
+		})
+	}
+	return vcsStatuses, nil
+}
+
+func processGiteaState(s Status) string {
+	switch s.State {
+	case "success":
+		return sdk.StatusSuccess.String()
+	case "error", "failure":
+		return sdk.StatusFail.String()
+	default:
+		return sdk.StatusBuilding.String()
+	}
+}
+
+func processEventWorkflowNodeRun(event sdk.Event, uiURL string, disabledStatusDetail bool) (statusData, error) {
+	data := statusData{}
+	var eventNR sdk.EventRunWorkflowNode
+	if err := mapstructure.Decode(event.Payload, &eventNR); err != nil {
+		return data, sdk.WrapError(err, "Error durring consumption")
+	}
+	//We only manage status Success and Failure
+	if eventNR.Status == sdk.StatusChecking.String() ||
+		eventNR.Status == sdk.StatusDisabled.String() ||
+		eventNR.Status == sdk.StatusNeverBuilt.String() ||
+		eventNR.Status == sdk.StatusSkipped.String() ||
+		eventNR.Status == sdk.StatusUnknown.String() ||
+		eventNR.Status == sdk.StatusWaiting.String() {
+		return data, nil
+	}
+
+	switch eventNR.Status {
+	case sdk.StatusFail.String():
+		data.status = "failure"
+	case sdk.StatusSuccess.String():
+		data.status = "success"
+	default:
+		data.status = "pending"
+	}
+	data.hash = eventNR.Hash
+	data.repoFullName = eventNR.RepositoryFullName
+	data.urlPipeline = fmt.Sprintf("%s/project/%s/workflow/%s/run/%d",
+		uiURL,
+		event.ProjectKey,
+		event.WorkflowName,
+		eventNR.Number,
+	)
+
+	//CDS can avoid sending gitea target url in status, if it's disable
+	if disabledStatusDetail {
+		data.urlPipeline = ""
+	}
+
+	data.context = sdk.VCSCommitStatusDescription(event.ProjectKey, event.WorkflowName, eventNR)
+	data.desc = eventNR.NodeName + ": " + eventNR.Status
+	return data, nil
+}
+
+func processEventPipelineBuild(event sdk.Event, uiURL string, disabledStatusDetail bool) (statusData, error) {
+	data := statusData{}
+	var eventpb sdk.EventPipelineBuild
+	if err := mapstructure.Decode(event.Payload, &eventpb); err != nil {
+		return data, sdk.WrapError(err, "Error durring consumption")
+	}
+	//We only manage status Success and Failure
+	if eventpb.Status == sdk.StatusChecking ||
+		eventpb.Status == sdk.StatusDisabled ||
+		eventpb.Status == sdk.StatusNeverBuilt ||
+		eventpb.Status == sdk.StatusSkipped ||
+		eventpb.Status == sdk.StatusUnknown ||
+		eventpb.Status == sdk.StatusWaiting {
+		return data, nil
+	}
+
+	switch eventpb.Status {
+	case sdk.StatusFail:
+		data.status = "failure"
+	case sdk.StatusSuccess:
+		data.status = "success"
+	default:
+		data.status = "pending"
+	}
+	data.urlPipeline = fmt.Sprintf("%s/project/%s/application/%s/pipeline/%s/build/%d?envName=%s",
+		uiURL,
+		eventpb.ProjectKey,
+		eventpb.ApplicationName,
+		eventpb.PipelineName,
+		eventpb.BuildNumber,
+		url.QueryEscape(eventpb.EnvironmentName),
+	)
+	data.hash = eventpb.Hash
+	data.repoFullName = eventpb.RepositoryFullname
+	//CDS can avoid sending gitea target url in status, if it's disable
+	if disabledStatusDetail {
+		data.urlPipeline = ""
+	}
+	data.context = fmt.Sprintf("CDS/%s/%s/%s", eventpb.ProjectKey, eventpb.ApplicationName, eventpb.PipelineName)
+	data.desc = fmt.Sprintf("Pipeline %s: %s", eventpb.PipelineName, eventpb.Status.String())
+	return data, nil
+}
@@ -0,0 +1,34 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Tags returns the tags of a repository
+func (c *giteaClient) Tags(ctx context.Context, fullname string) ([]sdk.VCSTag, error) {
+	tags := []sdk.VCSTag{}
+	if err := c.getPages(ctx, "/repos/"+fullname+"/tags", nil, func(page []byte) (int, error) {
+		var nextTags []Tag
+		if err := json.Unmarshal(page, &nextTags); err != nil {
+			return 0, err
+		}
+		for _, t := range nextTags {
+			tag := sdk.VCSTag{
+				Tag:     t.Name,
+				Sha:     t.ID,
+				Message: t.Message,
+			}
+			if t.Commit != nil {
+				tag.Hash = t.Commit.SHA
+			}
+			tags = append(tags, tag)
+		}
+		return len(nextTags), nil
+	}); err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.Tags> Unable to list tags of %s", fullname)
+	}
+	return tags, nil
+}
@@ -0,0 +1,58 @@
+package gitea
+
+import (
+	"sync"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+var (
+	_ sdk.VCSAuthorizedClient = &giteaClient{}
+	_ sdk.VCSServer           = &giteaConsumer{}
+)
+
+// giteaClient implements VCSAuthorizedClient interface
+type giteaClient struct {
+	consumer *giteaConsumer
+	// origToken is the access token given by the api, the tokens refreshed for it are cached
+	origToken string
+	// tokenMutex protects the OAuth2 tokens which are refreshed when they expire
+	tokenMutex   sync.Mutex
+	accessToken  string
+	refreshToken string
+	refreshed    bool
+	onRefresh    func(accessToken, refreshToken string)
+}
+
+// giteaConsumer implements vcs.Server and it's used to instanciate a giteaClient
+type giteaConsumer struct {
+	URL                      string `json:"url"`
+	ClientID                 string `json:"client-id"`
+	ClientSecret             string `json:"-"`
+	AuthorizationCallbackURL string
+	cache                    cache.Store
+	uiURL                    string
+	proxyURL                 string
+	username                 string
+	token                    string
+	disableStatus            bool
+	disableStatusDetail      bool
+}
+
+// New instanciate a new gitea consumer, it is compatible with Forgejo
+func New(clientID, clientSecret, URL, callbackURL, uiURL, proxyURL, username, token string, store cache.Store, disableStatus, disableStatusDetail bool) sdk.VCSServer {
+	return &giteaConsumer{
+		URL:                      URL,
+		ClientID:                 clientID,
+		ClientSecret:             clientSecret,
+		AuthorizationCallbackURL: callbackURL,
+		cache:                    store,
+		uiURL:                    uiURL,
+		proxyURL:                 proxyURL,
+		username:                 username,
+		token:                    token,
+		disableStatus:            disableStatus,
+		disableStatusDetail:      disableStatusDetail,
+	}
+}
@@ -0,0 +1,289 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// fakeGitea serves a repository cds/repo with a few branches, commits and activities
+type fakeGitea struct {
+	*httptest.Server
+	accessToken string
+	statuses    []CreateStatus
+	hooks       []Hook
+	assets      map[string]string
+}
+
+func newFakeGitea(t *testing.T) *fakeGitea {
+	f := &fakeGitea{accessToken: "valid", assets: map[string]string{}}
+	writeJSON := func(w http.ResponseWriter, status int, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(v) // nolint
+	}
+	repo := Repository{ID: 1, Owner: &User{Login: "cds"}, Name: "repo", FullName: "cds/repo", DefaultBranch: "master"}
+	commits := []Commit{}
+	for i := 5; i > 0; i-- {
+		commits = append(commits, Commit{
+			CommitMeta: CommitMeta{SHA: fmt.Sprintf("sha%d", i)},
+			RepoCommit: &RepoCommit{Message: fmt.Sprintf("commit %d", i), Author: &CommitUser{Name: "John", Date: time.Unix(int64(i), 0)}},
+			Author:     &User{Login: "john"},
+		})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != "refresh_token" || r.FormValue("refresh_token") != "refresh" || r.FormValue("client_secret") != "secret" {
+			writeJSON(w, http.StatusBadRequest, oauthError{Error: "invalid_grant"})
+			return
+		}
+		writeJSON(w, http.StatusOK, authorizeResponse{AccessToken: "valid", RefreshToken: "refresh2"})
+	})
+	mux.HandleFunc("/api/v1/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+f.accessToken && r.Header.Get("Authorization") != "token user-token" {
+			writeJSON(w, http.StatusUnauthorized, apiError{Message: "token is required"})
+			return
+		}
+		path := strings.TrimPrefix(r.URL.Path, "/api/v1")
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		switch {
+		case path == "/user/repos":
+			// 60 repositories on two pages
+			repos := []Repository{}
+			for i := (page - 1) * pageSize; i < 60 && i < page*pageSize; i++ {
+				repos = append(repos, Repository{ID: int64(i), Owner: &User{Login: "cds"}, FullName: fmt.Sprintf("cds/repo%d", i)})
+			}
+			w.Header().Set("X-Total-Count", "60")
+			writeJSON(w, http.StatusOK, repos)
+		case path == "/repos/cds/repo":
+			writeJSON(w, http.StatusOK, repo)
+		case path == "/repos/cds/repo/branches":
+			writeJSON(w, http.StatusOK, []Branch{{Name: "master", Commit: &PayloadCommit{ID: "sha5"}}, {Name: "feat/a", Commit: &PayloadCommit{ID: "sha3"}}})
+		case path == "/repos/cds/repo/branches/master":
+			writeJSON(w, http.StatusOK, Branch{Name: "master", Commit: &PayloadCommit{ID: "sha5"}})
+		case path == "/repos/cds/repo/branches/feat/a":
+			writeJSON(w, http.StatusOK, Branch{Name: "feat/a", Commit: &PayloadCommit{ID: "sha3"}})
+		case strings.HasPrefix(path, "/repos/cds/repo/branches/"):
+			writeJSON(w, http.StatusNotFound, apiError{Message: "branch not found"})
+		case path == "/repos/cds/repo/commits":
+			if page > 1 {
+				writeJSON(w, http.StatusOK, []Commit{})
+				return
+			}
+			writeJSON(w, http.StatusOK, commits)
+		case strings.HasPrefix(path, "/repos/cds/repo/git/commits/"):
+			sha := strings.TrimPrefix(path, "/repos/cds/repo/git/commits/")
+			for _, c := range commits {
+				if c.SHA == sha {
+					writeJSON(w, http.StatusOK, c)
+					return
+				}
+			}
+			writeJSON(w, http.StatusNotFound, apiError{Message: "commit not found"})
+		case path == "/repos/cds/repo/statuses/sha5" && r.Method == http.MethodPost:
+			var s CreateStatus
+			json.NewDecoder(r.Body).Decode(&s) // nolint
+			f.statuses = append(f.statuses, s)
+			writeJSON(w, http.StatusCreated, s)
+		case path == "/repos/cds/repo/commits/sha5/statuses":
+			writeJSON(w, http.StatusOK, []Status{{State: "success", Context: "CDS/PRJ/wf/node"}, {State: "failure", Context: "other"}})
+		case path == "/repos/cds/repo/hooks" && r.Method == http.MethodPost:
+			var h Hook
+			json.NewDecoder(r.Body).Decode(&h) // nolint
+			h.ID = int64(len(f.hooks) + 1)
+			f.hooks = append(f.hooks, h)
+			writeJSON(w, http.StatusCreated, h)
+		case path == "/repos/cds/repo/hooks":
+			writeJSON(w, http.StatusOK, f.hooks)
+		case path == "/repos/cds/repo/releases" && r.Method == http.MethodPost:
+			writeJSON(w, http.StatusCreated, Release{ID: 7})
+		case path == "/repos/cds/repo/releases/7/assets":
+			file, header, err := r.FormFile("attachment")
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, apiError{Message: err.Error()})
+				return
+			}
+			b, _ := ioutil.ReadAll(file)
+			f.assets[r.URL.Query().Get("name")+"/"+header.Filename] = string(b)
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"id": 1})
+		case path == "/repos/cds/repo/activities/feeds":
+			push, _ := json.Marshal(PushActionContent{Commits: []PushActionCommit{{Sha1: "sha5", Message: "commit 5"}, {Sha1: "sha4"}}})
+			writeJSON(w, http.StatusOK, []Activity{
+				{ID: 5, OpType: activityCreatePullRequest, Content: "3|My PR", Created: time.Unix(200, 0)},
+				{ID: 4, OpType: activityDeleteBranch, RefName: "refs/heads/old", Created: time.Unix(200, 0)},
+				{ID: 3, OpType: activityPush, RefName: "refs/heads/feat/a", Created: time.Unix(200, 0)},
+				{ID: 2, OpType: activityPush, RefName: "refs/heads/master", Content: string(push), ActUser: &User{Login: "john"}, Created: time.Unix(200, 0)},
+				{ID: 1, OpType: activityPush, RefName: "refs/heads/master", Content: string(push), Created: time.Unix(50, 0)},
+			})
+		case path == "/repos/cds/repo/pulls/3":
+			writeJSON(w, http.StatusOK, PullRequest{Index: 3, State: "open", HTMLURL: "http://gitea/cds/repo/pulls/3",
+				Head: &PRBranchInfo{Ref: "feat/a", Sha: "sha3", Repository: &repo},
+				Base: &PRBranchInfo{Ref: "master", Sha: "sha5", Repository: &repo},
+			})
+		default:
+			writeJSON(w, http.StatusNotFound, apiError{Message: "not found"})
+		}
+	})
+	f.Server = httptest.NewServer(mux)
+	return f
+}
+
+func newTestClient(t *testing.T, f *fakeGitea, accessToken string) *giteaClient {
+	consumer := New("cds", "secret", f.URL, "", "http://cds.local", "https://proxy.local/", "cds-bot", "user-token", nil, false, false)
+	client, err := consumer.GetAuthorizedClient(context.Background(), accessToken, "refresh")
+	if err != nil {
+		t.Fatalf("unable to get client: %v", err)
+	}
+	return client.(*giteaClient)
+}
+
+func TestGiteaClient(t *testing.T) {
+	f := newFakeGitea(t)
+	defer f.Close()
+	ctx := context.Background()
+
+	// An expired access token is refreshed, the new tokens are given back to be stored
+	c := newTestClient(t, f, "expired")
+	var refreshedToken, refreshedSecret string
+	c.OnTokensRefreshed(func(accessToken, refreshToken string) {
+		refreshedToken, refreshedSecret = accessToken, refreshToken
+	})
+	repos, err := c.Repos(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, repos, 60)
+	assert.Equal(t, "valid", c.accessToken)
+	assert.Equal(t, "refresh2", c.refreshToken)
+	assert.Equal(t, "valid", refreshedToken)
+	assert.Equal(t, "refresh2", refreshedSecret)
+
+	branches, err := c.Branches(ctx, "cds/repo")
+	if assert.NoError(t, err) && assert.Len(t, branches, 2) {
+		assert.True(t, branches[0].Default)
+		assert.Equal(t, "sha5", branches[0].LatestCommit)
+		assert.False(t, branches[1].Default)
+	}
+	_, err = c.Branch(ctx, "cds/repo", "unknown")
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrNoBranch))
+
+	commits, err := c.Commits(ctx, "cds/repo", "master", "sha2", "sha5")
+	if assert.NoError(t, err) && assert.Len(t, commits, 3) {
+		assert.Equal(t, "sha5", commits[0].Hash)
+		assert.Equal(t, "john", commits[0].Author.Name)
+		assert.Equal(t, int64(5000), commits[0].Timestamp)
+	}
+	commits, err = c.Commits(ctx, "cds/repo", "master", "", "")
+	if assert.NoError(t, err) && assert.Len(t, commits, 1) {
+		assert.Equal(t, "sha5", commits[0].Hash)
+	}
+
+	// Webhooks are sent to the proxy
+	hook := sdk.VCSHook{URL: "http://cds-hooks.local/webhook/uuid"}
+	assert.NoError(t, c.CreateHook(ctx, "cds/repo", &hook))
+	assert.Equal(t, "1", hook.ID)
+	assert.Equal(t, "https://proxy.local/uuid", hook.URL)
+	h, err := c.GetHook(ctx, "cds/repo", "https://proxy.local/uuid")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1", h.ID)
+		assert.Equal(t, []string{"push"}, h.Events)
+	}
+	_, err = c.GetHook(ctx, "cds/repo", "https://unknown")
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrHookNotFound))
+
+	release, err := c.Release(ctx, "cds/repo", "v1.0.0", "v1.0.0", "notes")
+	if assert.NoError(t, err) {
+		assert.Equal(t, f.URL+"/api/v1/repos/cds/repo/releases/7/assets", release.UploadURL)
+		assert.NoError(t, c.UploadReleaseFile(ctx, "cds/repo", "v1.0.0", release.UploadURL, "bin.tar.gz", ioutil.NopCloser(strings.NewReader("content"))))
+		assert.Equal(t, "content", f.assets["bin.tar.gz/bin.tar.gz"])
+	}
+}
+
+func TestGiteaClientStatus(t *testing.T) {
+	f := newFakeGitea(t)
+	defer f.Close()
+	ctx := context.Background()
+	c := newTestClient(t, f, "valid")
+
+	evt := sdk.Event{
+		EventType:    fmt.Sprintf("%T", sdk.EventRunWorkflowNode{}),
+		ProjectKey:   "PRJ",
+		WorkflowName: "wf",
+		Payload: map[string]interface{}{
+			"Number":             int64(1),
+			"Status":             sdk.StatusFail.String(),
+			"Hash":               "sha5",
+			"RepositoryFullName": "cds/repo",
+			"NodeName":           "node",
+		},
+	}
+	assert.NoError(t, c.SetStatus(ctx, evt))
+	if assert.Len(t, f.statuses, 1) {
+		assert.Equal(t, "failure", f.statuses[0].State)
+		assert.Equal(t, "http://cds.local/project/PRJ/workflow/wf/run/1", f.statuses[0].TargetURL)
+	}
+
+	// Waiting nodes are not sent
+	evt.Payload["Status"] = sdk.StatusWaiting.String()
+	assert.NoError(t, c.SetStatus(ctx, evt))
+	assert.Len(t, f.statuses, 1)
+
+	statuses, err := c.ListStatuses(ctx, "cds/repo", "sha5")
+	if assert.NoError(t, err) && assert.Len(t, statuses, 1) {
+		assert.Equal(t, sdk.StatusSuccess.String(), statuses[0].State)
+	}
+}
+
+func TestGiteaClientEvents(t *testing.T) {
+	f := newFakeGitea(t)
+	defer f.Close()
+	ctx := context.Background()
+	c := newTestClient(t, f, "valid")
+
+	events, interval, err := c.GetEvents(ctx, "cds/repo", time.Unix(100, 0))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, pollingInterval, interval)
+	assert.Len(t, events, 4)
+
+	// Events may have been serialized by the poller
+	b, _ := json.Marshal(events)
+	var decoded []interface{}
+	json.Unmarshal(b, &decoded) // nolint
+
+	pushs, err := c.PushEvents(ctx, "cds/repo", decoded)
+	if assert.NoError(t, err) && assert.Len(t, pushs, 1) {
+		assert.Equal(t, "master", pushs[0].Branch.DisplayID)
+		assert.Equal(t, "sha5", pushs[0].Commit.Hash)
+		assert.Equal(t, "john", pushs[0].Commit.Author.Name)
+	}
+
+	creates, err := c.CreateEvents(ctx, "cds/repo", events)
+	if assert.NoError(t, err) && assert.Len(t, creates, 1) {
+		assert.Equal(t, "feat/a", creates[0].Branch.DisplayID)
+		assert.Equal(t, "sha3", creates[0].Commit.Hash)
+	}
+
+	deletes, err := c.DeleteEvents(ctx, "cds/repo", events)
+	if assert.NoError(t, err) && assert.Len(t, deletes, 1) {
+		assert.Equal(t, "old", deletes[0].Branch.DisplayID)
+	}
+
+	prs, err := c.PullRequestEvents(ctx, "cds/repo", events)
+	if assert.NoError(t, err) && assert.Len(t, prs, 1) {
+		assert.Equal(t, "opened", prs[0].Action)
+		assert.Equal(t, "feat/a", prs[0].Branch.DisplayID)
+		assert.Equal(t, "cds/repo", prs[0].Base.Repo)
+	}
+}
@@ -0,0 +1,170 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/log"
+)
+
+var (
+	httpClient = cdsclient.NewHTTPClient(time.Second*30, false)
+)
+
+// pageSize is the number of items requested on each page, gitea limits it to 50 by default
+const pageSize = 50
+
+type options struct {
+	// asUser uses the token of the user set in the configuration instead of the OAuth2 token
+	asUser      bool
+	contentType string
+}
+
+// apiError match Gitea API error format
+type apiError struct {
+	Message string `json:"message"`
+	URL     string `json:"url"`
+}
+
+func (c *giteaClient) apiURL(path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return c.consumer.URL + "/api/v1" + path
+}
+
+// do sends a request to the gitea API, in is marshalled in JSON unless it is a []byte and the response is unmarshalled in out
+func (c *giteaClient) do(ctx context.Context, method, path string, params url.Values, in, out interface{}, opts *options) (http.Header, error) {
+	if opts == nil {
+		opts = &options{}
+	}
+
+	var body []byte
+	switch v := in.(type) {
+	case nil:
+	case []byte:
+		body = v
+	default:
+		b, err := json.Marshal(in)
+		if err != nil {
+			return nil, sdk.WrapError(err, "Cannot marshal body %+v", in)
+		}
+		body = b
+		opts.contentType = "application/json"
+	}
+
+	uri := c.apiURL(path)
+	if len(params) > 0 {
+		uri += "?" + params.Encode()
+	}
+
+	status, headers, resBody, err := c.send(ctx, method, uri, body, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case status == http.StatusNotFound:
+		return headers, sdk.WrapError(sdk.ErrNotFound, "giteaClient.do> %s %s not found", method, path)
+	case status == http.StatusUnauthorized:
+		return headers, sdk.WrapError(sdk.ErrUnauthorized, "giteaClient.do> %s %s unauthorized", method, path)
+	case status == http.StatusForbidden:
+		return headers, sdk.WrapError(sdk.ErrForbidden, "giteaClient.do> %s %s forbidden", method, path)
+	case status >= 400:
+		giteaErr := apiError{}
+		if err := json.Unmarshal(resBody, &giteaErr); err == nil && giteaErr.Message != "" {
+			return headers, fmt.Errorf("Gitea error (%d) on %s %s: %s", status, method, path, giteaErr.Message)
+		}
+		return headers, fmt.Errorf("Gitea error (%d) on %s %s: %s", status, method, path, string(resBody))
+	}
+
+	if out != nil && len(bytes.TrimSpace(resBody)) > 0 {
+		if err := json.Unmarshal(resBody, out); err != nil {
+			return headers, sdk.WrapError(err, "Unable to parse gitea response on %s %s: %s", method, path, string(resBody))
+		}
+	}
+	return headers, nil
+}
+
+// send signs and sends a request, the request is sent again once the access token has been refreshed if it has expired
+func (c *giteaClient) send(ctx context.Context, method, uri string, body []byte, opts *options) (int, http.Header, []byte, error) {
+	var retried bool
+	for {
+		req, err := http.NewRequest(method, uri, bytes.NewReader(body))
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		req = req.WithContext(ctx)
+		req.Header.Set("Accept", "application/json")
+		if opts.contentType != "" {
+			req.Header.Set("Content-Type", opts.contentType)
+		}
+
+		c.tokenMutex.Lock()
+		token := c.accessToken
+		c.tokenMutex.Unlock()
+		if opts.asUser && c.consumer.token != "" {
+			req.Header.Set("Authorization", "token "+c.consumer.token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		res, err := httpClient.Do(req)
+		if err != nil {
+			return 0, nil, nil, sdk.WrapError(err, "HTTP Error")
+		}
+		resBody, err := ioutil.ReadAll(res.Body)
+		res.Body.Close() // nolint
+		if err != nil {
+			return res.StatusCode, res.Header, nil, err
+		}
+
+		if res.StatusCode == http.StatusUnauthorized && !retried && !opts.asUser {
+			log.Debug("giteaClient.send> access token has expired, refreshing it")
+			if err := c.refresh(token); err != nil {
+				return 0, nil, nil, err
+			}
+			retried = true
+			continue
+		}
+		return res.StatusCode, res.Header, resBody, nil
+	}
+}
+
+// getPages requests all the pages of a gitea list, each page is given to the add function which returns the number of items it contains
+func (c *giteaClient) getPages(ctx context.Context, path string, params url.Values, add func(page []byte) (int, error)) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("limit", strconv.Itoa(pageSize))
+	var count int
+	for page := 1; ; page++ {
+		params.Set("page", strconv.Itoa(page))
+		var raw json.RawMessage
+		headers, err := c.do(ctx, http.MethodGet, path, params, nil, &raw, nil)
+		if err != nil {
+			return err
+		}
+		n, err := add(raw)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to parse gitea response on %s", path)
+		}
+		count += n
+
+		// The total is not sent by old gitea versions, the page size can also be lowered by the gitea configuration
+		total, errT := strconv.Atoi(headers.Get("X-Total-Count"))
+		if n == 0 || (errT == nil && count >= total) || (errT != nil && n < pageSize) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package gitea
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+type authorizeResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// oauthError match Gitea OAuth2 error format
+type oauthError struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func generateHash() (string, error) {
+	size := 128
+	bs := make([]byte, size)
+	if _, err := rand.Read(bs); err != nil {
+		log.Error("vcs> gitea> generateID: rand.Read failed: %s\n", err)
+		return "", err
+	}
+	str := hex.EncodeToString(bs)
+	token := []byte(str)[0:size]
+
+	log.Debug("vcs> gitea> generateID: new generated id: %s\n", token)
+	return string(token), nil
+}
+
+//AuthorizeRedirect returns the request token, the Authorize URL
+//doc: https://docs.gitea.io/en-us/oauth2-provider/
+func (g *giteaConsumer) AuthorizeRedirect(ctx context.Context) (string, string, error) {
+	requestToken, err := generateHash()
+	if err != nil {
+		return "", "", err
+	}
+
+	val := url.Values{}
+	val.Add("client_id", g.ClientID)
+	val.Add("redirect_uri", g.AuthorizationCallbackURL)
+	val.Add("response_type", "code")
+	val.Add("state", requestToken)
+
+	authorizeURL := fmt.Sprintf("%s/login/oauth/authorize?%s", g.URL, val.Encode())
+	return requestToken, authorizeURL, nil
+}
+
+//AuthorizeToken returns the authorized token and the refresh token as its secret
+//from the request token and the code got on authorize url
+func (g *giteaConsumer) AuthorizeToken(ctx context.Context, state, code string) (string, string, error) {
+	log.Debug("AuthorizeToken> Gitea send code %s for state %s", code, state)
+
+	params := url.Values{}
+	params.Add("grant_type", "authorization_code")
+	params.Add("code", code)
+	params.Add("redirect_uri", g.AuthorizationCallbackURL)
+
+	res, err := g.accessToken(params)
+	if err != nil {
+		return "", "", err
+	}
+	return res.AccessToken, res.RefreshToken, nil
+}
+
+// accessToken requests an OAuth2 access token to gitea
+func (g *giteaConsumer) accessToken(params url.Values) (*authorizeResponse, error) {
+	params.Set("client_id", g.ClientID)
+	params.Set("client_secret", g.ClientSecret)
+
+	req, err := http.NewRequest(http.MethodPost, g.URL+"/login/oauth/access_token", strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= 400 {
+		giteaErr := oauthError{}
+		if err := json.Unmarshal(body, &giteaErr); err == nil && giteaErr.Error != "" {
+			return nil, fmt.Errorf("Gitea error (%d) %s: %s", res.StatusCode, giteaErr.Error, giteaErr.Description)
+		}
+		return nil, fmt.Errorf("Gitea error (%d) %s", res.StatusCode, string(body))
+	}
+
+	giteaResponse := authorizeResponse{}
+	if err := json.Unmarshal(body, &giteaResponse); err != nil {
+		return nil, fmt.Errorf("Unable to parse gitea response (%d) %s", res.StatusCode, string(body))
+	}
+	return &giteaResponse, nil
+}
+
+// refreshedTokensTTL is the time during which the tokens refreshed for an access token are kept,
+// it leaves time to the api to store them
+const refreshedTokensTTL = 24 * 3600
+
+// refreshedTokens are the OAuth2 tokens which replace an expired access token
+type refreshedTokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// refreshedTokensKey returns the cache key of the tokens refreshed for an access token
+func (g *giteaConsumer) refreshedTokensKey(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return cache.Key("vcs", "gitea", "tokens", g.URL, hex.EncodeToString(sum[:]))
+}
+
+//GetAuthorizedClient returns an authorized client, the access token secret is the OAuth2 refresh token
+func (g *giteaConsumer) GetAuthorizedClient(ctx context.Context, accessToken, accessTokenSecret string) (sdk.VCSAuthorizedClient, error) {
+	c := &giteaClient{
+		consumer:     g,
+		origToken:    accessToken,
+		accessToken:  accessToken,
+		refreshToken: accessTokenSecret,
+	}
+
+	// The access token may have been refreshed by a previous request before the api has stored the new tokens
+	var tokens refreshedTokens
+	if g.cache != nil && g.cache.Get(g.refreshedTokensKey(accessToken), &tokens) {
+		c.accessToken = tokens.AccessToken
+		c.refreshToken = tokens.RefreshToken
+		c.refreshed = true
+	}
+	return c, nil
+}
+
+// OnTokensRefreshed registers the function called with the new tokens once they have been refreshed
+func (c *giteaClient) OnTokensRefreshed(f func(accessToken, refreshToken string)) {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+
+	c.onRefresh = f
+	if c.refreshed {
+		f(c.accessToken, c.refreshToken)
+	}
+}
+
+// refresh gets a new access token when the current one has expired, gitea access tokens are valid one hour by default
+func (c *giteaClient) refresh(expiredToken string) error {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+
+	// The token has already been refreshed by a concurrent request
+	if c.accessToken != expiredToken {
+		return nil
+	}
+	if c.refreshToken == "" {
+		return sdk.WrapError(sdk.ErrUnauthorized, "giteaClient.refresh> access token has expired and there is no refresh token")
+	}
+
+	params := url.Values{}
+	params.Add("grant_type", "refresh_token")
+	params.Add("refresh_token", c.refreshToken)
+	res, err := c.consumer.accessToken(params)
+	if err != nil {
+		return sdk.WrapError(sdk.ErrUnauthorized, "giteaClient.refresh> unable to refresh access token: %v", err)
+	}
+
+	c.accessToken = res.AccessToken
+	if res.RefreshToken != "" {
+		c.refreshToken = res.RefreshToken
+	}
+	c.refreshed = true
+
+	// The tokens are shared with the other instances until the api stores them
+	if c.consumer.cache != nil {
+		c.consumer.cache.SetWithTTL(c.consumer.refreshedTokensKey(c.origToken), refreshedTokens{
+			AccessToken:  c.accessToken,
+			RefreshToken: c.refreshToken,
+		}, refreshedTokensTTL)
+	}
+	if c.onRefresh != nil {
+		c.onRefresh(c.accessToken, c.refreshToken)
+	}
+	return nil
+}
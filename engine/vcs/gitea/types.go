@@ -0,0 +1,207 @@
+package gitea
+
+import (
+	"time"
+)
+
+// User represents a gitea user
+type User struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// Repository represents a gitea repository
+type Repository struct {
+	ID            int64  `json:"id"`
+	Owner         *User  `json:"owner"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	HTMLURL       string `json:"html_url"`
+	CloneURL      string `json:"clone_url"`
+	SSHURL        string `json:"ssh_url"`
+	DefaultBranch string `json:"default_branch"`
+	Fork          bool   `json:"fork"`
+}
+
+// PayloadUser represents the author or the committer of a commit in a branch or a webhook
+type PayloadUser struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	UserName string `json:"username"`
+}
+
+// PayloadCommit represents a commit in a branch or a webhook
+type PayloadCommit struct {
+	ID        string       `json:"id"`
+	Message   string       `json:"message"`
+	URL       string       `json:"url"`
+	Author    *PayloadUser `json:"author"`
+	Committer *PayloadUser `json:"committer"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// Branch represents a repository branch
+type Branch struct {
+	Name      string         `json:"name"`
+	Commit    *PayloadCommit `json:"commit"`
+	Protected bool           `json:"protected"`
+}
+
+// CommitMeta contains the meta information of a commit
+type CommitMeta struct {
+	URL string `json:"url"`
+	SHA string `json:"sha"`
+}
+
+// CommitUser contains the information of a commit author or committer
+type CommitUser struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+// RepoCommit contains the git information of a commit
+type RepoCommit struct {
+	URL       string      `json:"url"`
+	Author    *CommitUser `json:"author"`
+	Committer *CommitUser `json:"committer"`
+	Message   string      `json:"message"`
+}
+
+// Commit represents a commit
+type Commit struct {
+	CommitMeta
+	HTMLURL    string       `json:"html_url"`
+	RepoCommit *RepoCommit  `json:"commit"`
+	Author     *User        `json:"author"`
+	Committer  *User        `json:"committer"`
+	Parents    []CommitMeta `json:"parents"`
+}
+
+// Compare represents the commits between two refs
+type Compare struct {
+	TotalCommits int      `json:"total_commits"`
+	Commits      []Commit `json:"commits"`
+}
+
+// Tag represents a repository tag
+type Tag struct {
+	Name    string      `json:"name"`
+	Message string      `json:"message"`
+	ID      string      `json:"id"`
+	Commit  *CommitMeta `json:"commit"`
+}
+
+// PRBranchInfo represents the head or the base of a pull request
+type PRBranchInfo struct {
+	Name       string      `json:"label"`
+	Ref        string      `json:"ref"`
+	Sha        string      `json:"sha"`
+	RepoID     int64       `json:"repo_id"`
+	Repository *Repository `json:"repo"`
+}
+
+// PullRequest represents a pull request
+type PullRequest struct {
+	ID      int64         `json:"id"`
+	Index   int64         `json:"number"`
+	User    *User         `json:"user"`
+	Title   string        `json:"title"`
+	Body    string        `json:"body"`
+	State   string        `json:"state"`
+	HTMLURL string        `json:"html_url"`
+	Head    *PRBranchInfo `json:"head"`
+	Base    *PRBranchInfo `json:"base"`
+}
+
+// CreateIssueComment is the body sent to comment a pull request
+type CreateIssueComment struct {
+	Body string `json:"body"`
+}
+
+// Hook represents a repository webhook
+type Hook struct {
+	ID     int64             `json:"id"`
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+	Events []string          `json:"events"`
+	Active bool              `json:"active"`
+}
+
+// EditHook is the body sent to update a webhook
+type EditHook struct {
+	Config map[string]string `json:"config,omitempty"`
+	Events []string          `json:"events,omitempty"`
+	Active *bool             `json:"active,omitempty"`
+}
+
+// Status represents a commit status
+type Status struct {
+	ID          int64     `json:"id"`
+	State       string    `json:"status"`
+	TargetURL   string    `json:"target_url"`
+	Description string    `json:"description"`
+	Context     string    `json:"context"`
+	Created     time.Time `json:"created_at"`
+}
+
+// CreateStatus is the body sent to create a commit status
+type CreateStatus struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url"`
+	Description string `json:"description"`
+	Context     string `json:"context"`
+}
+
+// ReleaseRequest is the body sent to create a release
+type ReleaseRequest struct {
+	TagName string `json:"tag_name"`
+	Name    string `json:"name"`
+	Body    string `json:"body"`
+}
+
+// Release represents a repository release
+type Release struct {
+	ID        int64  `json:"id"`
+	TagName   string `json:"tag_name"`
+	Name      string `json:"name"`
+	UploadURL string `json:"upload_url"`
+}
+
+// Activity represents an action on a repository, as returned by the activity feed
+type Activity struct {
+	ID      int64       `json:"id"`
+	OpType  string      `json:"op_type"`
+	ActUser *User       `json:"act_user"`
+	Repo    *Repository `json:"repo"`
+	RefName string      `json:"ref_name"`
+	Content string      `json:"content"`
+	Created time.Time   `json:"created"`
+}
+
+// PushActionContent is the content of a commit_repo activity
+type PushActionContent struct {
+	Commits    []PushActionCommit `json:"Commits"`
+	HeadCommit *PushActionCommit  `json:"HeadCommit"`
+	Len        int                `json:"Len"`
+}
+
+// PushActionCommit is a commit of a commit_repo activity
+type PushActionCommit struct {
+	Sha1        string    `json:"Sha1"`
+	Message     string    `json:"Message"`
+	AuthorEmail string    `json:"AuthorEmail"`
+	AuthorName  string    `json:"AuthorName"`
+	Timestamp   time.Time `json:"Timestamp"`
+}
+
+// Activity operation types used by the poller
+const (
+	activityPush              = "commit_repo"
+	activityDeleteBranch      = "delete_branch"
+	activityCreatePullRequest = "create_pull_request"
+	activityReopenPullRequest = "reopen_pull_request"
+)
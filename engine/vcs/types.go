@@ -49,6 +49,8 @@ type ServerConfiguration struct {
 	Github    *GithubServerConfiguration    `toml:"github" json:"github,omitempty" json:"github"`
 	Gitlab    *GitlabServerConfiguration    `toml:"gitlab" json:"gitlab,omitempty" json:"gitlab"`
 	Bitbucket *BitbucketServerConfiguration `toml:"bitbucket" json:"bitbucket,omitempty" json:"bitbucket"`
+	Gitea     *GiteaServerConfiguration     `toml:"gitea" json:"gitea,omitempty"`
+	Gerrit    *GerritServerConfiguration    `toml:"gerrit" json:"gerrit,omitempty"`
 }
 
 // GithubServerConfiguration represents the github configuration
@@ -122,6 +124,65 @@ func (s BitbucketServerConfiguration) check() error {
 	return nil
 }
 
+// GiteaServerConfiguration represents the gitea configuration, it is also used for Forgejo
+type GiteaServerConfiguration struct {
+	ClientID     string `toml:"clientId" json:"-" comment:"#######\n CDS <-> Gitea. Documentation on https://ovh.github.io/cds/hosting/repositories-manager/gitea/ \n#######\n Gitea OAuth2 Application Client ID"`
+	ClientSecret string `toml:"clientSecret" json:"-" comment:"Gitea OAuth2 Application Client Secret"`
+	Status       struct {
+		Disable    bool `toml:"disable" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push statuses on the VCS server" json:"disable"`
+		ShowDetail bool `toml:"showDetail" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push CDS URL in statuses on the VCS server" json:"show_detail"`
+	}
+	DisableWebHooks bool   `toml:"disableWebHooks" comment:"Does webhooks are supported by VCS Server" json:"disable_web_hook"`
+	DisablePolling  bool   `toml:"disablePolling" comment:"Does polling is supported by VCS Server, the activity feed is available since Gitea 1.20" json:"disable_polling"`
+	ProxyWebhook    string `toml:"proxyWebhook" default:"https://myproxy.com" commented:"true" comment:"If you want to have a reverse proxy url for your repository webhook, for example if you put https://myproxy.com it will generate a webhook URL like this https://myproxy.com/UUID_OF_YOUR_WEBHOOK" json:"proxy_webhook"`
+	Username        string `toml:"username" comment:"optional. Gitea username, used to add comment on Pull Request on failed build." json:"username"`
+	Token           string `toml:"token" comment:"optional, Gitea Token associated to username, used to add comment on Pull Request" json:"-"`
+}
+
+func (s GiteaServerConfiguration) check() error {
+	if s.ClientID == "" {
+		return errGiteaConfigurationError
+	}
+	if s.ClientSecret == "" {
+		return errGiteaConfigurationError
+	}
+	if s.ProxyWebhook != "" && !strings.Contains(s.ProxyWebhook, "://") {
+		return fmt.Errorf("Gitea proxy webhook must have the HTTP scheme")
+	}
+	return nil
+}
+
+var errGiteaConfigurationError = fmt.Errorf("Gitea configuration Error")
+
+// GerritServerConfiguration represents the gerrit configuration
+type GerritServerConfiguration struct {
+	SSHUsername string `toml:"sshUsername" comment:"#######\n CDS <-> Gerrit. Documentation on https://ovh.github.io/cds/hosting/repositories-manager/gerrit/ \n#######\n Gerrit account used by the hooks service to listen the stream-events, it needs the Stream Events capability, its private key is set in the hooks configuration" json:"ssh_username"`
+	SSHPort     int    `toml:"sshPort" default:"29418" json:"ssh_port"`
+	SSHHostKey  string `toml:"sshHostKey" comment:"Host key of the gerrit SSH daemon, you can get it with ssh-keyscan -p 29418 <host>" json:"ssh_host_key"`
+	Status      struct {
+		Disable    bool `toml:"disable" default:"false" commented:"true" comment:"Set to true if you don't want CDS to vote on the changes" json:"disable"`
+		ShowDetail bool `toml:"showDetail" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push CDS URL in the review messages" json:"show_detail"`
+	}
+	DisableGerritEvent bool   `toml:"disableGerritEvent" comment:"Disable gerrit hook (stream-events)" json:"disable_gerrit_event"`
+	VerifiedLabel      string `toml:"verifiedLabel" default:"Verified" comment:"Label voted by CDS when a workflow node run is over: +1 on success, -1 on failure" json:"verified_label"`
+	Reviewer           struct {
+		User  string `toml:"user" comment:"optional. Gerrit account used to vote on changes, the account which has linked the project is used if it's empty" json:"user"`
+		Token string `toml:"token" comment:"optional. HTTP password of the reviewer account" json:"-"`
+	} `toml:"reviewer" json:"reviewer"`
+}
+
+func (s GerritServerConfiguration) check() error {
+	if !s.DisableGerritEvent && (s.SSHUsername == "" || s.SSHHostKey == "") {
+		return errGerritConfigurationError
+	}
+	if (s.Reviewer.User == "") != (s.Reviewer.Token == "") {
+		return fmt.Errorf("Gerrit reviewer user and token must be set together")
+	}
+	return nil
+}
+
+var errGerritConfigurationError = fmt.Errorf("Gerrit configuration Error")
+
 func (s *Service) addServerConfiguration(name string, c ServerConfiguration) error {
 	if name == "" {
 		return fmt.Errorf("Invalid VCS server name")
@@ -165,5 +226,17 @@ func (s ServerConfiguration) check() error {
 		}
 	}
 
+	if s.Gitea != nil {
+		if err := s.Gitea.check(); err != nil {
+			return err
+		}
+	}
+
+	if s.Gerrit != nil {
+		if err := s.Gerrit.check(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -71,3 +71,24 @@ func getAccessTokens(ctx context.Context) (string, string, bool) {
 	}
 	return string(accessToken), string(accessTokenSecret), len(accessToken) > 0
 }
+
+// tokensRefresher is implemented by the authorized clients whose access token is refreshed when it expires
+type tokensRefresher interface {
+	OnTokensRefreshed(f func(accessToken, accessTokenSecret string))
+}
+
+// getAuthorizedClient returns an authorized client of the consumer, the tokens refreshed by the client
+// are sent back in the response headers so the api stores them
+func getAuthorizedClient(ctx context.Context, w http.ResponseWriter, consumer sdk.VCSServer, accessToken, accessTokenSecret string) (sdk.VCSAuthorizedClient, error) {
+	client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+	if err != nil {
+		return nil, err
+	}
+	if r, ok := client.(tokensRefresher); ok {
+		r.OnTokensRefreshed(func(accessToken, accessTokenSecret string) {
+			w.Header().Set(HeaderXAccessToken, base64.StdEncoding.EncodeToString([]byte(accessToken)))
+			w.Header().Set(HeaderXAccessTokenSecret, base64.StdEncoding.EncodeToString([]byte(accessTokenSecret)))
+		})
+	}
+	return client, nil
+}
@@ -38,6 +38,25 @@ func (s *Service) getVCSServersHandler() service.Handler {
 	}
 }
 
+func (s *Service) getVCSGerritHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		res := map[string]sdk.VCSGerritConfiguration{}
+		for name, cfg := range s.Cfg.Servers {
+			if cfg.Gerrit == nil || cfg.Gerrit.DisableGerritEvent {
+				continue
+			}
+			res[name] = sdk.VCSGerritConfiguration{
+				URL:         cfg.URL,
+				SSHUsername: cfg.Gerrit.SSHUsername,
+				SSHPort:     cfg.Gerrit.SSHPort,
+				SSHHostKey:  cfg.Gerrit.SSHHostKey,
+				Reviewer:    cfg.Gerrit.Reviewer.User,
+			}
+		}
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
 func (s *Service) getVCSServersHooksHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		name := muxVar(r, "name")
@@ -46,10 +65,11 @@ func (s *Service) getVCSServersHooksHandler() service.Handler {
 			return sdk.ErrNotFound
 		}
 		res := struct {
-			WebhooksSupported bool   `json:"webhooks_supported"`
-			WebhooksDisabled  bool   `json:"webhooks_disabled"`
-			WebhooksIcon      string `json:"webhooks_icon"`
-		}{}
+			WebhooksSupported  bool   `json:"webhooks_supported"`
+			WebhooksDisabled   bool   `json:"webhooks_disabled"`
+			WebhooksIcon       string `json:"webhooks_icon"`
+			GerritHookDisabled bool   `json:"gerrithook_disabled"`
+		}{GerritHookDisabled: true}
 
 		switch {
 		case cfg.Bitbucket != nil:
@@ -64,6 +84,13 @@ func (s *Service) getVCSServersHooksHandler() service.Handler {
 			res.WebhooksSupported = true
 			res.WebhooksDisabled = cfg.Gitlab.DisableWebHooks
 			res.WebhooksIcon = sdk.GitlabIcon
+		case cfg.Gitea != nil:
+			res.WebhooksSupported = true
+			res.WebhooksDisabled = cfg.Gitea.DisableWebHooks
+			res.WebhooksIcon = sdk.GiteaIcon
+		case cfg.Gerrit != nil:
+			res.WebhooksSupported = false
+			res.GerritHookDisabled = cfg.Gerrit.DisableGerritEvent
 		}
 
 		return service.WriteJSON(w, res, http.StatusOK)
@@ -92,6 +119,12 @@ func (s *Service) getVCSServersPollingHandler() service.Handler {
 		case cfg.Gitlab != nil:
 			res.PollingSupported = false
 			res.PollingDisabled = cfg.Gitlab.DisablePolling
+		case cfg.Gitea != nil:
+			res.PollingSupported = true
+			res.PollingDisabled = cfg.Gitea.DisablePolling
+		case cfg.Gerrit != nil:
+			res.PollingSupported = false
+			res.PollingDisabled = true
 		}
 
 		return service.WriteJSON(w, res, http.StatusOK)
@@ -157,7 +190,7 @@ func (s *Service) getReposHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable")
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client")
 		}
@@ -187,7 +220,7 @@ func (s *Service) getRepoHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -217,7 +250,7 @@ func (s *Service) getBranchesHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -247,7 +280,7 @@ func (s *Service) getBranchHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -278,7 +311,7 @@ func (s *Service) getTagsHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -312,7 +345,7 @@ func (s *Service) getCommitsHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -345,7 +378,7 @@ func (s *Service) getCommitsBetweenRefsHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -375,7 +408,7 @@ func (s *Service) getCommitHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -405,7 +438,7 @@ func (s *Service) getCommitStatusHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -435,7 +468,7 @@ func (s *Service) getPullRequestsHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -474,7 +507,7 @@ func (s *Service) postPullRequestCommentHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -513,7 +546,7 @@ func (s *Service) getEventsHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -554,7 +587,7 @@ func (s *Service) postFilterEventsHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -611,7 +644,7 @@ func (s *Service) postStatusHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable")
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client")
 		}
@@ -640,7 +673,7 @@ func (s *Service) postReleaseHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -691,7 +724,7 @@ func (s *Service) postUploadReleaseFileHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -725,7 +758,7 @@ func (s *Service) getHookHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -755,7 +788,7 @@ func (s *Service) postHookHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -795,7 +828,7 @@ func (s *Service) deleteHookHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -834,7 +867,7 @@ func (s *Service) getListForks() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -882,7 +915,7 @@ func (s *Service) postRepoGrantHandler() service.Handler {
 			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
 		}
 
-		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		client, err := getAuthorizedClient(ctx, w, consumer, accessToken, accessTokenSecret)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
 		}
@@ -20,6 +20,7 @@ func (s *Service) initRouter(ctx context.Context) {
 	r.Handle("/mon/status", r.GET(s.statusHandler, api.Auth(false)))
 
 	r.Handle("/vcs", r.GET(s.getAllVCSServersHandler))
+	r.Handle("/vcsgerrit", r.GET(s.getVCSGerritHandler))
 	r.Handle("/vcs/{name}", r.GET(s.getVCSServersHandler))
 	r.Handle("/vcs/{name}/webhooks", r.GET(s.getVCSServersHooksHandler))
 	r.Handle("/vcs/{name}/polling", r.GET(s.getVCSServersPollingHandler))
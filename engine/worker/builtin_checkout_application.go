@@ -53,6 +53,10 @@ func runCheckoutApplication(w *currentWorker) BuiltInAction {
 		r := regexp.MustCompile("{{.*}}")
 		if commit != nil && commit.Value != "" && !r.MatchString(commit.Value) {
 			opts.CheckoutCommit = commit.Value
+			// The commits of gerrit changes are only reachable from the change ref
+			if changeRef := sdk.ParameterValue(*params, "git.change.ref"); changeRef != "" && !r.MatchString(changeRef) {
+				opts.FetchRef = changeRef
+			}
 		}
 
 		var dir string
@@ -187,6 +187,10 @@ func runGitClone(w *currentWorker) BuiltInAction {
 		r, _ := regexp.Compile("{{.*}}")
 		if commit != nil && commit.Value != "" && !r.MatchString(commit.Value) {
 			opts.CheckoutCommit = commit.Value
+			// The commits of gerrit changes are only reachable from the change ref
+			if changeRef := sdk.ParameterValue(*params, "git.change.ref"); changeRef != "" && !r.MatchString(changeRef) {
+				opts.FetchRef = changeRef
+			}
 		}
 
 		var dir string
@@ -120,6 +120,7 @@ func runScriptAction(w *currentWorker) BuiltInAction {
 
 			log.Info("runScriptAction> %s %s", shell, strings.Trim(fmt.Sprint(opts), "[]"))
 			cmd := exec.CommandContext(ctx, shell, opts...)
+			setProcessGroup(cmd)
 			res.Status = sdk.StatusUnknown.String()
 
 			env := os.Environ()
@@ -239,12 +240,32 @@ func runScriptAction(w *currentWorker) BuiltInAction {
 				chanRes <- res
 			}
 
+			// Kill the whole process tree when the step is canceled or timed out,
+			// otherwise children processes keep stdout and stderr opened
+			cmdDone := make(chan struct{})
+			defer close(cmdDone)
+			go func() {
+				select {
+				case <-ctx.Done():
+					log.Info("runScriptAction> Killing process tree of %s: %v", scriptPath, ctx.Err())
+					if err := killProcessTree(cmd); err != nil {
+						log.Warning("runScriptAction> Cannot kill process tree of %s: %v", scriptPath, err)
+					}
+				case <-cmdDone:
+				}
+			}()
+
 			<-outchan
 			<-errchan
 			if err := cmd.Wait(); err != nil {
 				res.Reason = fmt.Sprintf("%s\n", err)
 				sendLog(res.Reason)
 				res.Status = sdk.StatusFail.String()
+				if exitErr, ok := err.(*exec.ExitError); ok {
+					if status, ok := exitErr.Sys().(interface{ ExitStatus() int }); ok {
+						res.ExitCode = int64(status.ExitStatus())
+					}
+				}
 				chanRes <- res
 			}
 
@@ -259,10 +280,14 @@ func runScriptAction(w *currentWorker) BuiltInAction {
 		select {
 		case <-ctx.Done():
 			log.Error("CDS Worker execution canceled: %v", ctx.Err())
-			sendLog("CDS Worker execution canceled")
+			reason := "CDS Worker execution canceled"
+			if ctx.Err() == context.DeadlineExceeded {
+				reason = "CDS Worker execution timed out"
+			}
+			sendLog(reason)
 			res = sdk.Result{
 				Status: sdk.StatusFail.String(),
-				Reason: "CDS Worker execution canceled",
+				Reason: reason,
 			}
 			break
 
@@ -221,6 +221,7 @@ func (w *currentWorker) runSteps(ctx context.Context, steps []sdk.Action, a *sdk
 	}()
 	var criticalStepFailed bool
 	var nbDisabledChildren int
+	var exitCode int64
 
 	// Nothing to do, success !
 	if len(steps) == 0 {
@@ -267,8 +268,21 @@ func (w *currentWorker) runSteps(ctx context.Context, steps []sdk.Action, a *sdk
 			}
 			_ = w.sendLog(buildID, fmt.Sprintf("Starting step \"%s\"\n", childName), w.currentJob.currentStep, false)
 
-			r = w.startAction(ctx, &child, buildID, params, secrets, w.currentJob.currentStep, childName)
+			stepCtx, cancel := ctx, context.CancelFunc(func() {})
+			if child.Timeout > 0 {
+				stepCtx, cancel = context.WithTimeout(ctx, child.TimeoutDuration())
+			}
+			r = w.startAction(stepCtx, &child, buildID, params, secrets, w.currentJob.currentStep, childName)
+			if stepCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+				r.Status = sdk.StatusFail.String()
+				r.Reason = fmt.Sprintf("step timed out after %s", child.TimeoutDuration())
+			}
+			cancel()
 			if r.Status != sdk.StatusSuccess.String() && !child.Optional {
+				// Keep the exit code of the first critical step which failed
+				if !criticalStepFailed {
+					exitCode = r.ExitCode
+				}
 				criticalStepFailed = true
 			}
 
@@ -292,8 +306,10 @@ func (w *currentWorker) runSteps(ctx context.Context, steps []sdk.Action, a *sdk
 
 	if criticalStepFailed {
 		r.Status = sdk.StatusFail.String()
+		r.ExitCode = exitCode
 	} else {
 		r.Status = sdk.StatusSuccess.String()
+		r.ExitCode = 0
 	}
 
 	return r, nbDisabledChildren
@@ -376,7 +392,11 @@ func workingDirectory(basedir, jobPath string) string {
 
 func (w *currentWorker) processJob(ctx context.Context, jobInfo *sdk.WorkflowNodeJobRunData) sdk.Result {
 	t0 := time.Now()
-	ctx, cancel := context.WithTimeout(ctx, 6*time.Hour)
+	timeout := 6 * time.Hour
+	if jobInfo.NodeJobRun.Job.Action.Timeout > 0 {
+		timeout = jobInfo.NodeJobRun.Job.Action.TimeoutDuration()
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 
 	defer func() { log.Info("processJob> Process Job Done (%s)", sdk.Round(time.Since(t0), time.Second).String()) }()
 	defer cancel()
@@ -466,6 +486,10 @@ func (w *currentWorker) processJob(ctx context.Context, jobInfo *sdk.WorkflowNod
 	logsecrets = jobInfo.Secrets
 	res := w.startAction(ctx, &jobInfo.NodeJobRun.Job.Action, jobInfo.NodeJobRun.ID, &jobInfo.NodeJobRun.Parameters, logsecrets, -1, "")
 	logsecrets = nil
+	if ctx.Err() == context.DeadlineExceeded {
+		res.Status = sdk.StatusFail.String()
+		res.Reason = fmt.Sprintf("Job timed out after %s", timeout)
+	}
 
 	if err := teardownBuildDirectory(wd); err != nil {
 		log.Error("Cannot remove build directory: %s", err)
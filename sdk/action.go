@@ -20,9 +20,15 @@ type Action struct {
 	Deprecated     bool          `json:"deprecated" yaml:"-"`
 	Optional       bool          `json:"optional" yaml:"-"`
 	AlwaysExecuted bool          `json:"always_executed" yaml:"-"`
+	Timeout        int64         `json:"timeout,omitempty" yaml:"-"`
 	LastModified   int64         `json:"last_modified" cli:"modified"`
 }
 
+// TimeoutDuration returns the execution timeout of the action, zero means no timeout
+func (a Action) TimeoutDuration() time.Duration {
+	return time.Duration(a.Timeout) * time.Second
+}
+
 // ActionSummary is the light representation of an action for CDS event
 type ActionSummary struct {
 	Name string `json:"name"`
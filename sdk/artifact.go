@@ -47,6 +47,21 @@ type ArtifactsStore struct {
 	TemporaryURLSupported bool   `json:"temporary_url_supported"`
 }
 
+// ObjectStoreMigration represents the progress of a copy of artifacts and static files between two objectstore drivers
+type ObjectStoreMigration struct {
+	From       string     `json:"from" cli:"from"`
+	To         string     `json:"to" cli:"to"`
+	Status     Status     `json:"status" cli:"status"`
+	Total      int64      `json:"total" cli:"total"`
+	Copied     int64      `json:"copied" cli:"copied"`
+	Failed     int64      `json:"failed" cli:"failed"`
+	Skipped    int64      `json:"skipped" cli:"skipped"`
+	LastErrors []string   `json:"last_errors,omitempty" cli:"-"`
+	Started    time.Time  `json:"started" cli:"started"`
+	Updated    time.Time  `json:"updated" cli:"updated"`
+	Done       *time.Time `json:"done,omitempty"`
+}
+
 //GetName returns the name the artifact
 func (a *Artifact) GetName() string {
 	return a.Name
@@ -1,6 +1,9 @@
 package sdk
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Auth Authentifaction Struct for user
 type Auth struct {
@@ -20,6 +23,63 @@ type UserToken struct {
 	UserID             int64     `json:"-" db:"user_id"`
 }
 
+// AccessTokenPrefix prefixes the value of personal access tokens to distinguish them from sessions
+const AccessTokenPrefix = "cdspat_"
+
+// Scopes of personal access tokens
+const (
+	AccessTokenScopeRead    = "read"
+	AccessTokenScopeRun     = "run"
+	AccessTokenScopeProject = "project"
+	AccessTokenScopeAdmin   = "admin"
+)
+
+// AccessTokenScopes lists all the scopes of personal access tokens
+var AccessTokenScopes = []string{AccessTokenScopeRead, AccessTokenScopeRun, AccessTokenScopeProject, AccessTokenScopeAdmin}
+
+// AccessToken is a personal access token owned by a user. Its value is only returned at creation.
+type AccessToken struct {
+	ID       int64      `json:"id" db:"id" cli:"id,key"`
+	UserID   int64      `json:"-" db:"user_id" cli:"-"`
+	Name     string     `json:"name" db:"name" cli:"name"`
+	Hash     string     `json:"-" db:"hash" cli:"-"`
+	Scopes   []string   `json:"scopes" db:"-" cli:"scopes"`
+	Projects []string   `json:"projects,omitempty" db:"-" cli:"projects"`
+	Created  time.Time  `json:"created" db:"created" cli:"created"`
+	ExpireAt time.Time  `json:"expire_at" db:"expire_at" cli:"expire_at"`
+	LastUsed *time.Time `json:"last_used,omitempty" db:"last_used" cli:"last_used"`
+	Token    string     `json:"token,omitempty" db:"-" cli:"token"`
+}
+
+// HasScope returns true if the token is granted the given scope
+func (t AccessToken) HasScope(scope string) bool {
+	return IsInArray(scope, t.Scopes)
+}
+
+// IsExpired returns true if the token cannot be used anymore
+func (t AccessToken) IsExpired() bool {
+	return !t.ExpireAt.After(time.Now())
+}
+
+// IsValid checks the name, the scopes and the expiration of a new token
+func (t AccessToken) IsValid() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return NewErrorFrom(ErrWrongRequest, "access token name is mandatory")
+	}
+	if len(t.Scopes) == 0 {
+		return NewErrorFrom(ErrWrongRequest, "access token needs at least one scope")
+	}
+	for _, s := range t.Scopes {
+		if !IsInArray(s, AccessTokenScopes) {
+			return NewErrorFrom(ErrWrongRequest, "invalid access token scope %s, available scopes are %s", s, strings.Join(AccessTokenScopes, ", "))
+		}
+	}
+	if t.IsExpired() {
+		return NewErrorFrom(ErrWrongRequest, "access token expiration must be in the future")
+	}
+	return nil
+}
+
 // NewAuth instanciate a new Authentification struct
 func NewAuth(hashedToken string) *Auth {
 	a := &Auth{
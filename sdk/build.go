@@ -44,6 +44,26 @@ type ExecutedJob struct {
 	Reason     string       `json:"reason" db:"-"`
 	WorkerName string       `json:"worker_name" db:"-"`
 	WorkerID   string       `json:"worker_id" db:"-"`
+	// PreviousAttempts contains all attempts of the job which have been retried
+	PreviousAttempts []ExecutedJobAttempt `json:"previous_attempts,omitempty" db:"-"`
+}
+
+// CurrentAttempt returns the number of the current attempt of the job, starting from 1
+func (j ExecutedJob) CurrentAttempt() int {
+	return len(j.PreviousAttempts) + 1
+}
+
+// ExecutedJobAttempt represents a previous attempt of a job which has been retried
+type ExecutedJobAttempt struct {
+	Attempt    int          `json:"attempt" db:"-"`
+	Status     string       `json:"status" db:"-"`
+	Reason     string       `json:"reason,omitempty" db:"-"`
+	ExitCode   int64        `json:"exit_code,omitempty" db:"-"`
+	WorkerName string       `json:"worker_name,omitempty" db:"-"`
+	Start      time.Time    `json:"start" db:"-"`
+	Done       time.Time    `json:"done" db:"-"`
+	StepStatus []StepStatus `json:"step_status" db:"-"`
+	SpawnInfos []SpawnInfo  `json:"spawninfos" db:"-"`
 }
 
 // ExecutedJobSummary is a light representation of ExecutedJob for CDS event
@@ -114,6 +134,12 @@ type BuildState struct {
 	Status   Status  `json:"status"`
 }
 
+// StepLogsMessage is pushed on the live log stream of a job step: new log lines or the final status of the step
+type StepLogsMessage struct {
+	Val    string `json:"val,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
 // Status reprensents a Build Action or Build Pipeline Status
 type Status string
 
@@ -142,6 +168,8 @@ func StatusFromString(in string) Status {
 		return StatusWorkerPending
 	case StatusWorkerRegistering.String():
 		return StatusWorkerRegistering
+	case StatusWaitingApproval.String():
+		return StatusWaitingApproval
 	default:
 		return StatusUnknown
 	}
@@ -172,6 +200,7 @@ const (
 	StatusStopped           Status = "Stopped"
 	StatusWorkerPending     Status = "Pending"
 	StatusWorkerRegistering Status = "Registering"
+	StatusWaitingApproval   Status = "WaitingApproval"
 )
 
 // Translate translates messages in pipelineBuildJob
@@ -185,7 +214,7 @@ func (p *PipelineBuildJob) Translate(lang string) {
 // StatusIsTerminated returns if status is terminated (nothing related to building or waiting, ...)
 func StatusIsTerminated(status string) bool {
 	switch status {
-	case StatusBuilding.String(), StatusWaiting.String():
+	case StatusBuilding.String(), StatusWaiting.String(), StatusWaitingApproval.String():
 		return false
 	default:
 		return true
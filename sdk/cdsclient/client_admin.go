@@ -58,3 +58,19 @@ func (c *client) ServiceCallDELETE(stype string, query string) error {
 	_, _, _, err := c.Request(context.Background(), "DELETE", "/admin/services/call?type="+stype+"&query="+url.QueryEscape(query), nil)
 	return err
 }
+
+func (c *client) ObjectStoreMigrationStart(from, to string) (*sdk.ObjectStoreMigration, error) {
+	m := sdk.ObjectStoreMigration{From: from, To: to}
+	if _, err := c.PostJSON(context.Background(), "/admin/objectstore/migration", m, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (c *client) ObjectStoreMigrationStatus() (*sdk.ObjectStoreMigration, error) {
+	m := sdk.ObjectStoreMigration{}
+	if _, err := c.GetJSON(context.Background(), "/admin/objectstore/migration", &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
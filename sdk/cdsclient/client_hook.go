@@ -1,6 +1,7 @@
 package cdsclient
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"time"
@@ -25,3 +26,11 @@ func (c *client) PollVCSEvents(uuid string, workflowID int64, vcsServer string,
 
 	return events, interval, nil
 }
+
+func (c *client) VCSGerritConfiguration() (map[string]sdk.VCSGerritConfiguration, error) {
+	var res map[string]sdk.VCSGerritConfiguration
+	if _, err := c.GetJSON(context.Background(), "/config/vcsgerrit", &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
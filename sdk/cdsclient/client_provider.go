@@ -122,3 +122,28 @@ func (c *client) ProjectPlatformImport(projectKey string, content io.Reader, for
 	}
 	return pf, nil
 }
+
+func (c *client) ProjectEventExportList(projectKey string) ([]sdk.ProjectEventExport, error) {
+	path := fmt.Sprintf("/project/%s/events/export", projectKey)
+	var exports []sdk.ProjectEventExport
+	if _, err := c.GetJSON(context.Background(), path, &exports); err != nil {
+		return exports, err
+	}
+	return exports, nil
+}
+
+func (c *client) ProjectEventExportAdd(projectKey string, export *sdk.ProjectEventExport) error {
+	path := fmt.Sprintf("/project/%s/events/export", projectKey)
+	if _, err := c.PostJSON(context.Background(), path, export, export); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *client) ProjectEventExportDelete(projectKey string, id int64) error {
+	path := fmt.Sprintf("/project/%s/events/export/%d", projectKey, id)
+	if _, err := c.DeleteJSON(context.Background(), path, nil); err != nil {
+		return err
+	}
+	return nil
+}
@@ -332,35 +332,8 @@ func (c *client) QueueArtifactUpload(ctx context.Context, id int64, tag, filePat
 	t0 := time.Now()
 	store := new(sdk.ArtifactsStore)
 	_, _ = c.GetJSON(ctx, "/artifact/store", store)
-	if store.TemporaryURLSupported {
-		err := c.queueIndirectArtifactUpload(ctx, id, tag, filePath)
-		return true, time.Since(t0), err
-	}
-	err := c.queueDirectArtifactUpload(id, tag, filePath)
-	return false, time.Since(t0), err
-}
-
-func (c *client) queueIndirectArtifactTempURL(ctx context.Context, id int64, art *sdk.WorkflowNodeRunArtifact) error {
-	var retryURL = 10
-	var globalURLErr error
-	uri := fmt.Sprintf("/queue/workflows/%d/artifact/%s/url", id, art.Ref)
-
-	for i := 0; i < retryURL; i++ {
-		var code int
-		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-		code, globalURLErr = c.PostJSON(ctx, uri, art, art)
-		if code < 300 {
-			cancel()
-			break
-		}
-		cancel()
-		time.Sleep(500 * time.Millisecond)
-	}
-
-	if globalURLErr != nil {
-		return globalURLErr
-	}
-	return nil
+	err := c.queueChunkedArtifactUpload(id, tag, filePath, store.TemporaryURLSupported)
+	return store.TemporaryURLSupported, time.Since(t0), err
 }
 
 func (c *client) queueIndirectArtifactTempURLPost(url string, content []byte) error {
@@ -399,13 +372,14 @@ func (c *client) queueIndirectArtifactTempURLPost(url string, content []byte) er
 	return globalErr
 }
 
-func (c *client) queueIndirectArtifactUpload(ctx context.Context, id int64, tag, filePath string) error {
+// queueChunkedArtifactUpload sends an artifact by chunks, on error the upload is resumed from the offset known by the API.
+// With objectstores supporting temporary urls, the chunks are sent directly to the objectstore.
+func (c *client) queueChunkedArtifactUpload(id int64, tag, filePath string, tempURL bool) error {
 	f, errop := os.Open(filePath)
 	if errop != nil {
 		return errop
 	}
 	defer f.Close()
-
 	//File stat
 	stat, errst := f.Stat()
 	if errst != nil {
@@ -423,128 +397,74 @@ func (c *client) queueIndirectArtifactUpload(ctx context.Context, id int64, tag,
 	}
 
 	_, name := filepath.Split(filePath)
-
 	ref := base64.RawURLEncoding.EncodeToString([]byte(tag))
-	art := sdk.WorkflowNodeRunArtifact{
-		Name:      name,
-		Tag:       tag,
-		Ref:       ref,
-		Size:      stat.Size(),
-		Perm:      uint32(stat.Mode().Perm()),
-		MD5sum:    md5sum,
-		SHA512sum: sha512sum,
-		Created:   time.Now(),
-	}
-
-	if err := c.queueIndirectArtifactTempURL(ctx, id, &art); err != nil {
+	uri := fmt.Sprintf("/queue/workflows/%d/artifact/%s/upload", id, ref)
+	upload := sdk.WorkflowNodeRunArtifactUpload{
+		Artifact: sdk.WorkflowNodeRunArtifact{
+			Name:      name,
+			Tag:       tag,
+			Ref:       ref,
+			Size:      stat.Size(),
+			Perm:      uint32(stat.Mode().Perm()),
+			MD5sum:    md5sum,
+			SHA512sum: sha512sum,
+		},
+	}
+	if _, err := c.PostJSON(context.Background(), uri, &upload, &upload); err != nil {
 		return err
 	}
 
-	if c.config.Verbose {
-		fmt.Printf("Uploading %s with to %s\n", art.Name, art.TempURL)
-	}
-
-	//Read the file once
-	fileContent, errFileContent := ioutil.ReadAll(f)
-	if errFileContent != nil {
-		return errFileContent
-	}
-
-	if err := c.queueIndirectArtifactTempURLPost(art.TempURL, fileContent); err != nil {
-		// If we got a 401 error from the objectstore, ask for a fresh temporary url and repost the artifact
-		if strings.Contains(err.Error(), "401 Unauthorized: Temp URL invalid") {
-			if err := c.queueIndirectArtifactTempURL(ctx, id, &art); err != nil {
-				return err
-			}
+	chunk := make([]byte, sdk.ArtifactUploadChunkSize)
+	var err error
+	for retry := 0; upload.Offset < upload.Artifact.Size && retry <= c.config.Retry; {
+		n, errR := f.ReadAt(chunk, upload.Offset)
+		if errR != nil && errR != io.EOF {
+			return errR
+		}
 
-			if err := c.queueIndirectArtifactTempURLPost(art.TempURL, fileContent); err != nil {
-				return err
+		if tempURL {
+			err = c.queueArtifactChunkTempURLUpload(uri, &upload, chunk[:n])
+		} else {
+			var res []byte
+			res, _, _, err = c.Request(context.Background(), "POST", fmt.Sprintf("%s/%s?offset=%d", uri, upload.ID, upload.Offset), bytes.NewReader(chunk[:n]),
+				SetHeader("Content-Type", "application/octet-stream"))
+			if err == nil {
+				err = json.Unmarshal(res, &upload)
 			}
 		}
-		return err
-	}
-
-	//Try 50 times to make the callback
-	var callbackErr error
-	retry := 50
-	for i := 0; i < retry; i++ {
-		uri := fmt.Sprintf("/queue/workflows/%d/artifact/%s/url/callback", id, art.Ref)
-		ctxt, cancel := context.WithTimeout(ctx, 5*time.Second)
-		_, callbackErr = c.PostJSON(ctxt, uri, &art, nil)
-		if callbackErr == nil {
-			cancel()
-			return nil
+		if err != nil {
+			retry++
+			time.Sleep(3 * time.Second)
+			if _, errS := c.PostJSON(context.Background(), uri, &upload, &upload); errS != nil {
+				err = errS
+			}
+			continue
 		}
-		cancel()
-	}
-
-	return callbackErr
-}
-
-func (c *client) queueDirectArtifactUpload(id int64, tag, filePath string) error {
-	f, errop := os.Open(filePath)
-	if errop != nil {
-		return errop
-	}
-	defer f.Close()
-	//File stat
-	stat, errst := f.Stat()
-	if errst != nil {
-		return errst
+		retry = 0
 	}
-
-	sha512sum, err512 := sdk.FileSHA512sum(filePath)
-	if err512 != nil {
-		return err512
-	}
-
-	md5sum, errmd5 := sdk.FileMd5sum(filePath)
-	if errmd5 != nil {
-		return errmd5
-	}
-
-	//Read the file once
-	fileContent, errFileContent := ioutil.ReadAll(f)
-	if errFileContent != nil {
-		return errFileContent
+	if upload.Offset < upload.Artifact.Size {
+		return fmt.Errorf("x%d: %v", c.config.Retry, err)
 	}
 
-	_, name := filepath.Split(filePath)
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	part, errc := writer.CreateFormFile(name, filepath.Base(filePath))
-	if errc != nil {
-		return errc
+	if _, err := c.PostJSON(context.Background(), fmt.Sprintf("%s/%s/complete", uri, upload.ID), nil, nil); err != nil {
+		return err
 	}
+	return nil
+}
 
-	if _, err := io.Copy(part, bytes.NewBuffer(fileContent)); err != nil {
+// queueArtifactChunkTempURLUpload sends a chunk of an artifact to a temporary url of the objectstore, then declares it to the API
+func (c *client) queueArtifactChunkTempURLUpload(uri string, upload *sdk.WorkflowNodeRunArtifactUpload, content []byte) error {
+	if _, err := c.PostJSON(context.Background(), fmt.Sprintf("%s/%s/url?offset=%d", uri, upload.ID, upload.Offset), nil, upload); err != nil {
 		return err
 	}
-
-	writer.WriteField("size", strconv.FormatInt(stat.Size(), 10))
-	writer.WriteField("perm", strconv.FormatUint(uint64(stat.Mode().Perm()), 10))
-	writer.WriteField("md5sum", md5sum)
-	writer.WriteField("sha512sum", sha512sum)
-
-	if errclose := writer.Close(); errclose != nil {
-		return errclose
+	if c.config.Verbose {
+		fmt.Printf("Uploading %d bytes of %s to %s\n", len(content), upload.Artifact.Name, upload.TempURL)
 	}
-
-	var err error
-	ref := base64.RawURLEncoding.EncodeToString([]byte(tag))
-	uri := fmt.Sprintf("/queue/workflows/%d/artifact/%s", id, ref)
-	for i := 0; i <= c.config.Retry; i++ {
-		var code int
-		_, code, err = c.UploadMultiPart("POST", uri, body,
-			SetHeader("Content-Disposition", "attachment; filename="+name),
-			SetHeader("Content-Type", writer.FormDataContentType()))
-		if err == nil && code < 300 {
-			return nil
-		}
-		time.Sleep(3 * time.Second)
+	if err := c.queueIndirectArtifactTempURLPost(upload.TempURL, content); err != nil {
+		return err
 	}
-
-	return fmt.Errorf("x%d: %v", c.config.Retry, err)
+	_, err := c.PostJSON(context.Background(), fmt.Sprintf("%s/%s/url/callback?offset=%d&size=%d", uri, upload.ID, upload.Offset, len(content)), nil, upload)
+	return err
 }
 
 func (c *client) QueueJobTag(ctx context.Context, jobID int64, tags []sdk.WorkflowRunTag) error {
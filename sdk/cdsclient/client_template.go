@@ -0,0 +1,80 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// TemplateGetAll returns all the workflow templates
+func (c *client) TemplateGetAll() ([]sdk.WorkflowTemplate, error) {
+	wts := []sdk.WorkflowTemplate{}
+	if _, err := c.GetJSON(context.Background(), "/template", &wts); err != nil {
+		return nil, err
+	}
+	return wts, nil
+}
+
+// TemplateGet returns a workflow template by its group and name
+func (c *client) TemplateGet(groupName, templateName string) (*sdk.WorkflowTemplate, error) {
+	var wt sdk.WorkflowTemplate
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/template/%s/%s", groupName, templateName), &wt); err != nil {
+		return nil, err
+	}
+	return &wt, nil
+}
+
+// TemplatePost creates a workflow template, wt is updated with the created template
+func (c *client) TemplatePost(wt *sdk.WorkflowTemplate) error {
+	_, err := c.PostJSON(context.Background(), "/template", wt, wt)
+	return err
+}
+
+// TemplatePut updates a workflow template with a new version, wt is updated with the new version
+func (c *client) TemplatePut(groupName, templateName string, wt *sdk.WorkflowTemplate) error {
+	_, err := c.PutJSON(context.Background(), fmt.Sprintf("/template/%s/%s", groupName, templateName), wt, wt)
+	return err
+}
+
+// TemplateDelete deletes a workflow template
+func (c *client) TemplateDelete(groupName, templateName string) error {
+	_, err := c.DeleteJSON(context.Background(), fmt.Sprintf("/template/%s/%s", groupName, templateName), nil)
+	return err
+}
+
+// TemplateApply generates a workflow from a template in a project and returns the messages of the push
+func (c *client) TemplateApply(groupName, templateName string, req sdk.WorkflowTemplateRequest) ([]string, error) {
+	msgs := []string{}
+	if _, err := c.PostJSON(context.Background(), fmt.Sprintf("/template/%s/%s/apply", groupName, templateName), req, &msgs); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// TemplateInstances returns the workflows generated from a template
+func (c *client) TemplateInstances(groupName, templateName string) ([]sdk.WorkflowTemplateInstance, error) {
+	is := []sdk.WorkflowTemplateInstance{}
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/template/%s/%s/instance", groupName, templateName), &is); err != nil {
+		return nil, err
+	}
+	return is, nil
+}
+
+// TemplatePreview returns the diff of the instances of a template with its last version
+func (c *client) TemplatePreview(groupName, templateName string, bulk sdk.WorkflowTemplateBulk) ([]sdk.WorkflowTemplateInstanceDiff, error) {
+	diffs := []sdk.WorkflowTemplateInstanceDiff{}
+	if _, err := c.PostJSON(context.Background(), fmt.Sprintf("/template/%s/%s/preview", groupName, templateName), bulk, &diffs); err != nil {
+		return nil, err
+	}
+	return diffs, nil
+}
+
+// TemplateBulk applies the last version of a template on its instances
+func (c *client) TemplateBulk(groupName, templateName string, bulk sdk.WorkflowTemplateBulk) ([]sdk.WorkflowTemplateBulkResult, error) {
+	res := []sdk.WorkflowTemplateBulkResult{}
+	if _, err := c.PostJSON(context.Background(), fmt.Sprintf("/template/%s/%s/bulk", groupName, templateName), bulk, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
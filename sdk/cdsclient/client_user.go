@@ -34,6 +34,26 @@ func (c *client) UserLogin(username, password string) (bool, string, error) {
 	return true, response.Password, nil
 }
 
+// UserLoginOIDCDevice starts a login with an OpenID Connect device authorization
+func (c *client) UserLoginOIDCDevice() (*sdk.UserOIDCDeviceAuthorization, error) {
+	res := sdk.UserOIDCDeviceAuthorization{}
+	if _, err := c.PostJSON(context.Background(), "/login/oidc/device", nil, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// UserLoginOIDCDeviceToken returns the session token once the device authorization is approved,
+// sdk.ErrOIDCAuthorizationPending is returned until then
+func (c *client) UserLoginOIDCDeviceToken(deviceCode string) (*sdk.UserAPIResponse, error) {
+	res := sdk.UserAPIResponse{}
+	req := sdk.UserOIDCDeviceLoginRequest{DeviceCode: deviceCode}
+	if _, err := c.PostJSON(context.Background(), "/login/oidc/device/token", req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
 func (c *client) UserList() ([]sdk.User, error) {
 	res := []sdk.User{}
 	if _, err := c.GetJSON(context.Background(), "/user", &res); err != nil {
@@ -125,6 +145,27 @@ func (c *client) FindToken(tokenValue string) (sdk.Token, error) {
 	return token, nil
 }
 
+// UserAccessTokenList returns the personal access tokens of the current user
+func (c *client) UserAccessTokenList() ([]sdk.AccessToken, error) {
+	tokens := []sdk.AccessToken{}
+	if _, err := c.GetJSON(context.Background(), "/user/accesstoken", &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// UserAccessTokenCreate creates a personal access token for the current user, its value is set in t.Token
+func (c *client) UserAccessTokenCreate(t *sdk.AccessToken) error {
+	_, err := c.PostJSON(context.Background(), "/user/accesstoken", t, t)
+	return err
+}
+
+// UserAccessTokenDelete revokes a personal access token of the current user
+func (c *client) UserAccessTokenDelete(id int64) error {
+	_, err := c.DeleteJSON(context.Background(), fmt.Sprintf("/user/accesstoken/%d", id), nil)
+	return err
+}
+
 // UpdateFavorite Update favorites (add or delete) return updated workflow or project
 func (c *client) UpdateFavorite(params sdk.FavoriteParams) (interface{}, error) {
 	switch params.Type {
@@ -3,6 +3,7 @@ package cdsclient
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -130,6 +131,30 @@ func (c *client) WorkflowNodeRunJobStep(projectKey string, workflowName string,
 	return &buildState, nil
 }
 
+// WorkflowNodeRunJobStepLogsStream sends existing and new logs of a job step to msgs, until the step is over or ctx is done
+func (c *client) WorkflowNodeRunJobStepLogsStream(ctx context.Context, projectKey string, workflowName string, number int64, nodeRunID, job int64, step int, msgs chan<- sdk.StepLogsMessage) error {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d/job/%d/step/%d/stream", projectKey, workflowName, number, nodeRunID, job, step)
+
+	evts := make(chan SSEvent)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- c.RequestSSEGet(ctx, url, evts)
+		close(evts)
+	}()
+
+	for evt := range evts {
+		var msg sdk.StepLogsMessage
+		if err := json.NewDecoder(evt.Data).Decode(&msg); err != nil {
+			continue
+		}
+		select {
+		case msgs <- msg:
+		case <-ctx.Done():
+		}
+	}
+	return <-errs
+}
+
 func (c *client) WorkflowNodeRunArtifactDownload(projectKey string, workflowName string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error {
 	var url = fmt.Sprintf("/project/%s/workflows/%s/artifact/%d", projectKey, workflowName, a.ID)
 	var reader io.ReadCloser
@@ -234,6 +259,21 @@ func (c *client) WorkflowNodeStop(projectKey string, workflowName string, number
 	return nodeRun, nil
 }
 
+func (c *client) WorkflowNodeApproval(projectKey string, workflowName string, number, nodeRunID int64, req sdk.WorkflowNodeRunApprovalRequest) (*sdk.WorkflowNodeRun, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d/approval", projectKey, workflowName, number, nodeRunID)
+
+	nodeRun := &sdk.WorkflowNodeRun{}
+	code, err := c.PostJSON(context.Background(), url, req, nodeRun)
+	if err != nil {
+		return nil, err
+	}
+	if code >= 300 {
+		return nil, fmt.Errorf("Cannot approve workflow node run %d. HTTP code error: %d", nodeRunID, code)
+	}
+
+	return nodeRun, nil
+}
+
 func (c *client) WorkflowCachePush(projectKey, ref string, tarContent io.Reader) error {
 	store := new(sdk.ArtifactsStore)
 	_, _ = c.GetJSON(context.Background(), "/artifact/store", store)
@@ -5,10 +5,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"runtime/pprof"
 	"strings"
+
+	"github.com/ovh/cds/sdk"
 )
 
 const (
@@ -73,9 +77,17 @@ func (c *client) RequestSSEGet(ctx context.Context, path string, evCh chan<- SSE
 	if err != nil {
 		return err
 	}
-	br := bufio.NewReader(resp.Body)
 	defer resp.Body.Close() // nolint
 
+	if resp.StatusCode >= 400 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		if err := sdk.DecodeError(body); err != nil {
+			return err
+		}
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	br := bufio.NewReader(resp.Body)
+
 	delim := []byte{':', ' '}
 
 	var currEvent *SSEvent
@@ -101,19 +113,23 @@ func (c *client) RequestSSEGet(ctx context.Context, path string, evCh chan<- SSE
 			continue
 		}
 
-		spl := bytes.Split(bs, delim)
+		spl := bytes.SplitN(bs, delim, 2)
 
 		if len(spl) < 2 {
 			continue
 		}
 
-		currEvent = &SSEvent{URI: uri}
+		// The event type is given on the line before the data of the event
+		if currEvent == nil {
+			currEvent = &SSEvent{URI: uri}
+		}
 		switch string(spl[0]) {
 		case sseEvent:
 			currEvent.Type = string(bytes.TrimSpace(spl[1]))
 		case sseData:
 			currEvent.Data = bytes.NewBuffer(bytes.TrimSpace(spl[1]))
 			evCh <- *currEvent
+			currEvent = nil
 		}
 
 	}
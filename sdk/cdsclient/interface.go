@@ -24,6 +24,8 @@ type AdminService interface {
 	ServiceCallPOST(stype string, url string, body []byte) ([]byte, error)
 	ServiceCallPUT(stype string, url string, body []byte) ([]byte, error)
 	ServiceCallDELETE(stype string, url string) error
+	ObjectStoreMigrationStart(from, to string) (*sdk.ObjectStoreMigration, error)
+	ObjectStoreMigrationStatus() (*sdk.ObjectStoreMigration, error)
 }
 
 // ExportImportInterface exposes pipeline and application export and import function
@@ -179,6 +181,9 @@ type ProjectClient interface {
 	ProjectPlatformGet(projectKey string, platformName string, clearPassword bool) (sdk.ProjectPlatform, error)
 	ProjectPlatformList(projectKey string) ([]sdk.ProjectPlatform, error)
 	ProjectPlatformDelete(projectKey string, platformName string) error
+	ProjectEventExportList(projectKey string) ([]sdk.ProjectEventExport, error)
+	ProjectEventExportAdd(projectKey string, export *sdk.ProjectEventExport) error
+	ProjectEventExportDelete(projectKey string, id int64) error
 }
 
 // ProjectKeysClient exposes project keys related functions
@@ -217,6 +222,19 @@ type QueueClient interface {
 	QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error
 }
 
+// TemplateClient exposes workflow templates functions
+type TemplateClient interface {
+	TemplateGetAll() ([]sdk.WorkflowTemplate, error)
+	TemplateGet(groupName, templateName string) (*sdk.WorkflowTemplate, error)
+	TemplatePost(wt *sdk.WorkflowTemplate) error
+	TemplatePut(groupName, templateName string, wt *sdk.WorkflowTemplate) error
+	TemplateDelete(groupName, templateName string) error
+	TemplateApply(groupName, templateName string, req sdk.WorkflowTemplateRequest) ([]string, error)
+	TemplateInstances(groupName, templateName string) ([]sdk.WorkflowTemplateInstance, error)
+	TemplatePreview(groupName, templateName string, bulk sdk.WorkflowTemplateBulk) ([]sdk.WorkflowTemplateInstanceDiff, error)
+	TemplateBulk(groupName, templateName string, bulk sdk.WorkflowTemplateBulk) ([]sdk.WorkflowTemplateBulkResult, error)
+}
+
 // UserClient exposes users functions
 type UserClient interface {
 	UserConfirm(username, token string) (bool, string, error)
@@ -224,11 +242,16 @@ type UserClient interface {
 	UserGet(username string) (*sdk.User, error)
 	UserGetGroups(username string) (map[string][]sdk.Group, error)
 	UserLogin(username, password string) (bool, string, error)
+	UserLoginOIDCDevice() (*sdk.UserOIDCDeviceAuthorization, error)
+	UserLoginOIDCDeviceToken(deviceCode string) (*sdk.UserAPIResponse, error)
 	UserReset(username, email, callback string) error
 	UserSignup(username, fullname, email, callback string) error
 	ListAllTokens() ([]sdk.Token, error)
 	FindToken(token string) (sdk.Token, error)
 	UpdateFavorite(params sdk.FavoriteParams) (interface{}, error)
+	UserAccessTokenList() ([]sdk.AccessToken, error)
+	UserAccessTokenCreate(t *sdk.AccessToken) error
+	UserAccessTokenDelete(id int64) error
 }
 
 // WorkerClient exposes workers functions
@@ -253,6 +276,7 @@ type WorkerClient interface {
 // HookClient exposes functions used for hooks services
 type HookClient interface {
 	PollVCSEvents(uuid string, workflowID int64, vcsServer string, timestamp int64) (events sdk.RepositoryEvents, interval time.Duration, err error)
+	VCSGerritConfiguration() (map[string]sdk.VCSGerritConfiguration, error)
 }
 
 // WorkflowClient exposes workflows functions
@@ -270,9 +294,11 @@ type WorkflowClient interface {
 	WorkflowRunNumberSet(projectKey string, workflowName string, number int64) error
 	WorkflowStop(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error)
 	WorkflowNodeStop(projectKey string, workflowName string, number, fromNodeID int64) (*sdk.WorkflowNodeRun, error)
+	WorkflowNodeApproval(projectKey string, workflowName string, number, nodeRunID int64, req sdk.WorkflowNodeRunApprovalRequest) (*sdk.WorkflowNodeRun, error)
 	WorkflowNodeRun(projectKey string, name string, number int64, nodeRunID int64) (*sdk.WorkflowNodeRun, error)
 	WorkflowNodeRunArtifactDownload(projectKey string, name string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error
 	WorkflowNodeRunJobStep(projectKey string, workflowName string, number int64, nodeRunID, job int64, step int) (*sdk.BuildState, error)
+	WorkflowNodeRunJobStepLogsStream(ctx context.Context, projectKey string, workflowName string, number int64, nodeRunID, job int64, step int, msgs chan<- sdk.StepLogsMessage) error
 	WorkflowNodeRunRelease(projectKey string, workflowName string, runNumber int64, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error
 	WorkflowAllHooksList() ([]sdk.WorkflowNodeHook, error)
 	WorkflowCachePush(projectKey, ref string, tarContent io.Reader) error
@@ -315,6 +341,7 @@ type Interface interface {
 	PlatformClient
 	ProjectClient
 	QueueClient
+	TemplateClient
 	Navbar() ([]sdk.NavbarProjectData, error)
 	Requirements() ([]sdk.Requirement, error)
 	RepositoriesManagerInterface
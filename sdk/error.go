@@ -163,6 +163,14 @@ var (
 	ErrWorkerModelDeploymentFailed            = Error{ID: 146, Status: http.StatusBadRequest}
 	ErrJobLocked                              = Error{ID: 147, Status: http.StatusConflict}
 	ErrWorkflowNodeRunLocked                  = Error{ID: 148, Status: http.StatusConflict}
+	ErrWorkflowConditionBadExpression         = Error{ID: 149, Status: http.StatusBadRequest}
+	ErrOIDCAuthorizationPending               = Error{ID: 150, Status: http.StatusBadRequest}
+	ErrInvalidVaultReference                  = Error{ID: 151, Status: http.StatusBadRequest}
+	ErrWorkflowNodeRunNotWaitingApproval      = Error{ID: 152, Status: http.StatusBadRequest}
+	ErrWorkflowNodeRunAlreadyApproved         = Error{ID: 153, Status: http.StatusConflict}
+	ErrWorkflowTemplateNotFound               = Error{ID: 154, Status: http.StatusNotFound}
+	ErrWorkflowTemplateAlreadyExists          = Error{ID: 155, Status: http.StatusConflict}
+	ErrOIDCUserNotLinked                      = Error{ID: 156, Status: http.StatusForbidden}
 )
 
 var errorsAmericanEnglish = map[int]string{
@@ -312,6 +320,14 @@ var errorsAmericanEnglish = map[int]string{
 	ErrWorkerModelDeploymentFailed.ID:            "Worker deployment failed",
 	ErrJobLocked.ID:                              "Job already locked",
 	ErrWorkflowNodeRunLocked.ID:                  "Workflow node run already locked",
+	ErrWorkflowConditionBadExpression.ID:         "Your run condition expression is invalid",
+	ErrOIDCAuthorizationPending.ID:               "Authorization is pending on the identity provider",
+	ErrInvalidVaultReference.ID:                  "Invalid vault reference, it should be formatted as path#key",
+	ErrWorkflowNodeRunNotWaitingApproval.ID:      "Workflow node run is not waiting for approval",
+	ErrWorkflowNodeRunAlreadyApproved.ID:         "You have already approved or rejected this workflow node run",
+	ErrWorkflowTemplateNotFound.ID:               "Workflow template not found",
+	ErrWorkflowTemplateAlreadyExists.ID:          "Workflow template already exists",
+	ErrOIDCUserNotLinked.ID:                      "This username is used by an account which is not linked to your OpenID Connect identity",
 }
 
 var errorsFrench = map[int]string{
@@ -461,6 +477,14 @@ var errorsFrench = map[int]string{
 	ErrWorkerModelDeploymentFailed.ID:            "Échec de déploiement du modèle de worker",
 	ErrJobLocked.ID:                              "Job déjà verrouillé",
 	ErrWorkflowNodeRunLocked.ID:                  "Noeud de workflow run déjà verrouillé",
+	ErrWorkflowConditionBadExpression.ID:         "Expression de condition de lancement invalide",
+	ErrOIDCAuthorizationPending.ID:               "L'autorisation est en attente sur le fournisseur d'identité",
+	ErrInvalidVaultReference.ID:                  "Référence vault invalide, elle doit être de la forme chemin#clé",
+	ErrWorkflowNodeRunNotWaitingApproval.ID:      "L'exécution du pipeline n'est pas en attente d'approbation",
+	ErrWorkflowNodeRunAlreadyApproved.ID:         "Vous avez déjà approuvé ou rejeté l'exécution de ce pipeline",
+	ErrWorkflowTemplateNotFound.ID:               "Modèle de workflow introuvable",
+	ErrWorkflowTemplateAlreadyExists.ID:          "Le modèle de workflow existe déjà",
+	ErrOIDCUserNotLinked.ID:                      "Ce nom d'utilisateur est utilisé par un compte qui n'est pas lié à votre identité OpenID Connect",
 }
 
 var errorsLanguages = []map[int]string{
@@ -78,6 +78,8 @@ type EventRunWorkflowNode struct {
 	StagesSummary         []StageSummary            `json:"stages_summary"`
 	HookUUID              string                    `json:"hook_uuid"`
 	HookLog               string                    `json:"log,omitempty"`
+	StatusContext         string                    `json:"status_context,omitempty"`
+	WorkflowRunStatus     string                    `json:"workflow_run_status,omitempty"`
 }
 
 type EventRunWorkflowOutgoingHook struct {
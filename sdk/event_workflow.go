@@ -37,6 +37,17 @@ type EventWorkflowPermissionDelete struct {
 	Permission GroupPermission `json:"group_permission"`
 }
 
+// EventWorkflowNodeRunApproval represents the event when a node run waiting for approval is approved or rejected
+type EventWorkflowNodeRunApproval struct {
+	WorkflowID int64  `json:"workflow_id"`
+	Number     int64  `json:"num"`
+	NodeRunID  int64  `json:"node_run_id"`
+	NodeName   string `json:"node_name"`
+	Approved   bool   `json:"approved"`
+	Comment    string `json:"comment"`
+	Status     string `json:"status"`
+}
+
 // ToEventWorkflowPermissionAdd get the payload as EventWorkflowPermissionAdd
 func (e Event) ToEventWorkflowPermissionAdd() (EventWorkflowPermissionAdd, error) {
 	var permEvent EventWorkflowPermissionAdd
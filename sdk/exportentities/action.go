@@ -75,6 +75,9 @@ func newSteps(a sdk.Action) []Step {
 		if act.AlwaysExecuted {
 			s["always_executed"] = act.AlwaysExecuted
 		}
+		if act.Timeout > 0 {
+			s["timeout"] = newTimeout(act.Timeout)
+		}
 
 		switch act.Type {
 		case sdk.BuiltinAction:
@@ -704,6 +707,19 @@ func (s Step) Name() (string, error) {
 	return "", nil
 }
 
+// Timeout returns the step timeout in seconds if exist
+func (s Step) Timeout() (int64, error) {
+	stepAttr, ok := s["timeout"]
+	if !ok {
+		return 0, nil
+	}
+	timeout, ok := stepAttr.(string)
+	if !ok {
+		return 0, fmt.Errorf("Malformatted Step : timeout must be a duration string (ie. 30m)")
+	}
+	return computeTimeout(timeout)
+}
+
 // Action returns an sdk.Action
 func (act *Action) Action() (*sdk.Action, error) {
 	a := new(sdk.Action)
@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ovh/cds/sdk"
 )
@@ -59,6 +60,30 @@ type Job struct {
 	Requirements   []Requirement `json:"requirements,omitempty" yaml:"requirements,omitempty"`
 	Optional       *bool         `json:"optional,omitempty" yaml:"optional,omitempty"`
 	AlwaysExecuted *bool         `json:"always_executed,omitempty" yaml:"always_executed,omitempty"`
+	Timeout        string        `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Retry          *JobRetry     `json:"retry,omitempty" yaml:"retry,omitempty"`
+	Matrix         *JobMatrix    `json:"matrix,omitempty" yaml:"matrix,omitempty"`
+}
+
+// JobMatrix represents exported matrix of a job
+type JobMatrix struct {
+	Axes    []JobMatrixAxis     `json:"axes" yaml:"axes"`
+	Include []map[string]string `json:"include,omitempty" yaml:"include,omitempty"`
+	Exclude []map[string]string `json:"exclude,omitempty" yaml:"exclude,omitempty"`
+}
+
+// JobMatrixAxis represents exported axis of a job matrix
+type JobMatrixAxis struct {
+	Name   string   `json:"name" yaml:"name"`
+	Values []string `json:"values" yaml:"values"`
+}
+
+// JobRetry represents exported retry policy of a job
+type JobRetry struct {
+	MaxAttempts int      `json:"max_attempts" yaml:"max_attempts"`
+	Statuses    []string `json:"statuses,omitempty" yaml:"statuses,omitempty"`
+	ExitCodes   []int64  `json:"exit_codes,omitempty" yaml:"exit_codes,omitempty"`
+	Backoff     string   `json:"backoff,omitempty" yaml:"backoff,omitempty"`
 }
 
 // Step represents exported step used in a job
@@ -68,7 +93,7 @@ type Step map[string]interface{}
 func (s Step) IsValid() bool {
 	keys := []string{}
 	for k := range s {
-		if k != "enabled" && k != "optional" && k != "always_executed" && k != "name" {
+		if k != "enabled" && k != "optional" && k != "always_executed" && k != "name" && k != "timeout" {
 			keys = append(keys, k)
 		}
 	}
@@ -78,7 +103,7 @@ func (s Step) IsValid() bool {
 func (s Step) key() string {
 	keys := []string{}
 	for k := range s {
-		if k != "enabled" && k != "optional" && k != "always_executed" && k != "name" {
+		if k != "enabled" && k != "optional" && k != "always_executed" && k != "name" && k != "timeout" {
 			keys = append(keys, k)
 		}
 	}
@@ -293,9 +318,104 @@ func newJob(j sdk.Job) Job {
 	jo.Steps = newSteps(j.Action)
 	jo.Description = j.Action.Description
 	jo.Requirements = newRequirements(j.Action.Requirements)
+	jo.Timeout = newTimeout(j.Action.Timeout)
+	jo.Retry = newJobRetry(j.RetryPolicy)
+	jo.Matrix = newJobMatrix(j.Matrix)
 	return jo
 }
 
+// newJobMatrix returns the exported representation of a job matrix
+func newJobMatrix(m *sdk.JobMatrix) *JobMatrix {
+	if m == nil {
+		return nil
+	}
+	jm := &JobMatrix{
+		Axes:    make([]JobMatrixAxis, len(m.Axes)),
+		Include: m.Include,
+		Exclude: m.Exclude,
+	}
+	for i, a := range m.Axes {
+		jm.Axes[i] = JobMatrixAxis{Name: a.Name, Values: a.Values}
+	}
+	return jm
+}
+
+// computeJobMatrix returns a job matrix from its exported representation
+func computeJobMatrix(jm *JobMatrix) (*sdk.JobMatrix, error) {
+	if jm == nil {
+		return nil, nil
+	}
+	m := &sdk.JobMatrix{
+		Axes:    make([]sdk.JobMatrixAxis, len(jm.Axes)),
+		Include: jm.Include,
+		Exclude: jm.Exclude,
+	}
+	for i, a := range jm.Axes {
+		m.Axes[i] = sdk.JobMatrixAxis{Name: a.Name, Values: a.Values}
+	}
+	if err := m.IsValid(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// newJobRetry returns the exported representation of a job retry policy
+func newJobRetry(p *sdk.JobRetryPolicy) *JobRetry {
+	if p == nil {
+		return nil
+	}
+	return &JobRetry{
+		MaxAttempts: p.MaxAttempts,
+		Statuses:    p.Statuses,
+		ExitCodes:   p.ExitCodes,
+		Backoff:     newTimeout(p.Backoff),
+	}
+}
+
+// computeJobRetry returns a job retry policy from its exported representation
+func computeJobRetry(r *JobRetry) (*sdk.JobRetryPolicy, error) {
+	if r == nil {
+		return nil, nil
+	}
+	backoff, err := computeTimeout(r.Backoff)
+	if err != nil {
+		return nil, err
+	}
+	p := &sdk.JobRetryPolicy{
+		MaxAttempts: r.MaxAttempts,
+		Statuses:    r.Statuses,
+		ExitCodes:   r.ExitCodes,
+		Backoff:     backoff,
+	}
+	if err := p.IsValid(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// newTimeout returns the exported representation of a timeout in seconds
+func newTimeout(timeout int64) string {
+	if timeout <= 0 {
+		return ""
+	}
+	return (time.Duration(timeout) * time.Second).String()
+}
+
+// computeTimeout returns a timeout in seconds from its exported representation
+func computeTimeout(timeout string) (int64, error) {
+	if timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return 0, fmt.Errorf("Malformatted timeout %s: %v", timeout, err)
+	}
+	if d < time.Second {
+		return 0, fmt.Errorf("Malformatted timeout %s: must be at least 1s", timeout)
+	}
+	return int64(d / time.Second), nil
+}
+
 func newJobs(jobs []sdk.Job) map[string]Job {
 	res := map[string]Job{}
 	for i := range jobs {
@@ -455,6 +575,9 @@ func computeSteps(steps []Step) ([]sdk.Action, error) {
 		if err != nil {
 			return nil, err
 		}
+		if err := applyStepTimeout(s, a); err != nil {
+			return nil, err
+		}
 		res[i] = *a
 	}
 	return res, nil
@@ -520,6 +643,19 @@ func computeStep(s Step) (a *sdk.Action, e error) {
 	return
 }
 
+// applyStepTimeout sets the step timeout on the computed action
+func applyStepTimeout(s Step, a *sdk.Action) error {
+	if a == nil {
+		return nil
+	}
+	timeout, err := s.Timeout()
+	if err != nil {
+		return err
+	}
+	a.Timeout = timeout
+	return nil
+}
+
 func computeJobRequirements(req []Requirement) []sdk.Requirement {
 	res := make([]sdk.Requirement, len(req))
 	for i, r := range req {
@@ -578,6 +714,24 @@ func computeJob(name string, j Job) (*sdk.Job, error) {
 	job.Action.Enabled = job.Enabled
 	job.Action.Requirements = computeJobRequirements(j.Requirements)
 
+	timeout, err := computeTimeout(j.Timeout)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Invalid timeout on job %s", name)
+	}
+	job.Action.Timeout = timeout
+
+	retryPolicy, err := computeJobRetry(j.Retry)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Invalid retry on job %s", name)
+	}
+	job.RetryPolicy = retryPolicy
+
+	matrix, err := computeJobMatrix(j.Matrix)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Invalid matrix on job %s", name)
+	}
+	job.Matrix = matrix
+
 	//Compute steps for the jobs
 	children, err := computeSteps(j.Steps)
 	if err != nil {
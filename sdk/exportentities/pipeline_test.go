@@ -760,3 +760,145 @@ func TestExportAndImportPipelineV1_YAML(t *testing.T) {
 		}
 	}
 }
+
+func Test_ImportPipelineV1WithTimeout(t *testing.T) {
+	in := `version: v1.0
+name: build
+jobs:
+- job: compile
+  timeout: 1h30m
+  steps:
+  - script: make
+    timeout: 10m
+  - script: make test
+`
+
+	payload := &PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	p, err := payload.Pipeline()
+	test.NoError(t, err)
+
+	job := p.Stages[0].Jobs[0]
+	assert.Equal(t, int64(5400), job.Action.Timeout)
+	assert.Len(t, job.Action.Actions, 2)
+	assert.Equal(t, int64(600), job.Action.Actions[0].Timeout)
+	assert.Equal(t, int64(0), job.Action.Actions[1].Timeout)
+
+	exported := NewPipelineV1(*p, false)
+	assert.Equal(t, "1h30m0s", exported.Jobs[0].Timeout)
+	assert.Equal(t, "10m0s", exported.Jobs[0].Steps[0]["timeout"])
+	_, has := exported.Jobs[0].Steps[1]["timeout"]
+	assert.False(t, has)
+}
+
+func Test_ImportPipelineV1WithInvalidTimeout(t *testing.T) {
+	in := `version: v1.0
+name: build
+jobs:
+- job: compile
+  steps:
+  - script: make
+    timeout: forever
+`
+
+	payload := &PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	_, err := payload.Pipeline()
+	assert.Error(t, err)
+}
+
+func Test_ImportPipelineV1WithRetry(t *testing.T) {
+	in := `version: v1.0
+name: build
+jobs:
+- job: compile
+  retry:
+    max_attempts: 3
+    exit_codes: [137]
+    backoff: 30s
+  steps:
+  - script: make
+`
+
+	payload := &PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	p, err := payload.Pipeline()
+	test.NoError(t, err)
+
+	job := p.Stages[0].Jobs[0]
+	assert.NotNil(t, job.RetryPolicy)
+	assert.Equal(t, 3, job.RetryPolicy.MaxAttempts)
+	assert.Equal(t, []int64{137}, job.RetryPolicy.ExitCodes)
+	assert.Equal(t, int64(30), job.RetryPolicy.Backoff)
+
+	exported := NewPipelineV1(*p, false)
+	assert.NotNil(t, exported.Jobs[0].Retry)
+	assert.Equal(t, 3, exported.Jobs[0].Retry.MaxAttempts)
+	assert.Equal(t, "30s", exported.Jobs[0].Retry.Backoff)
+}
+
+func Test_ImportPipelineV1WithInvalidRetry(t *testing.T) {
+	in := `version: v1.0
+name: build
+jobs:
+- job: compile
+  retry:
+    max_attempts: 3
+    statuses: [Success]
+  steps:
+  - script: make
+`
+
+	payload := &PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	_, err := payload.Pipeline()
+	assert.Error(t, err)
+}
+
+func Test_ImportPipelineV1WithMatrix(t *testing.T) {
+	in := `version: v1.0
+name: build
+jobs:
+- job: test
+  matrix:
+    axes:
+    - name: go
+      values: ["1.10", "1.11"]
+    - name: os
+      values: [linux, windows]
+    exclude:
+    - go: "1.10"
+      os: windows
+  requirements:
+  - model: go-{{.cds.matrix.os}}
+  steps:
+  - script: go test ./...
+`
+
+	payload := &PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	p, err := payload.Pipeline()
+	test.NoError(t, err)
+
+	job := p.Stages[0].Jobs[0]
+	assert.NotNil(t, job.Matrix)
+	assert.Len(t, job.Matrix.Axes, 2)
+	assert.Equal(t, "go", job.Matrix.Axes[0].Name)
+	assert.Equal(t, []string{"linux", "windows"}, job.Matrix.Axes[1].Values)
+	assert.Len(t, job.Matrix.Combinations(), 3)
+
+	exported := NewPipelineV1(*p, false)
+	b, err := yaml.Marshal(exported)
+	test.NoError(t, err)
+
+	reimported := &PipelineV1{}
+	test.NoError(t, yaml.Unmarshal(b, reimported))
+	p2, err := reimported.Pipeline()
+	test.NoError(t, err)
+	assert.Equal(t, job.Matrix, p2.Stages[0].Jobs[0].Matrix)
+}
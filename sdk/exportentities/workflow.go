@@ -19,39 +19,45 @@ type Workflow struct {
 	Workflow map[string]NodeEntry   `json:"workflow,omitempty" yaml:"workflow,omitempty"`
 	Hooks    map[string][]HookEntry `json:"hooks,omitempty" yaml:"hooks,omitempty"`
 	// This will be filled for simple workflows
-	DependsOn           []string                       `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
-	Conditions          *sdk.WorkflowNodeConditions    `json:"conditions,omitempty" yaml:"conditions,omitempty"`
-	When                []string                       `json:"when,omitempty" yaml:"when,omitempty"` //This is used only for manual and success condition
-	PipelineName        string                         `json:"pipeline,omitempty" yaml:"pipeline,omitempty"`
-	Payload             map[string]interface{}         `json:"payload,omitempty" yaml:"payload,omitempty"`
-	Parameters          map[string]string              `json:"parameters,omitempty" yaml:"parameters,omitempty"`
-	ApplicationName     string                         `json:"application,omitempty" yaml:"application,omitempty"`
-	EnvironmentName     string                         `json:"environment,omitempty" yaml:"environment,omitempty"`
-	ProjectPlatformName string                         `json:"platform,omitempty" yaml:"platform,omitempty"`
-	PipelineHooks       []HookEntry                    `json:"pipeline_hooks,omitempty" yaml:"pipeline_hooks,omitempty"`
-	Permissions         map[string]int                 `json:"permissions,omitempty" yaml:"permissions,omitempty"`
-	Metadata            map[string]string              `json:"metadata,omitempty" yaml:"metadata,omitempty"`
-	PurgeTags           []string                       `json:"purge_tags,omitempty" yaml:"purge_tags,omitempty"`
-	HistoryLength       *int64                         `json:"history_length,omitempty" yaml:"history_length,omitempty"`
-	Notifications       []NotificationEntry            `json:"notify,omitempty" yaml:"notify,omitempty"`               // This is used when the workflow have only one pipeline
-	MapNotifications    map[string][]NotificationEntry `json:"notifications,omitempty" yaml:"notifications,omitempty"` // This is used when the workflow have more than one pipeline
+	DependsOn            []string                       `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	Conditions           *sdk.WorkflowNodeConditions    `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	When                 []string                       `json:"when,omitempty" yaml:"when,omitempty"` //This is used only for manual and success condition
+	PipelineName         string                         `json:"pipeline,omitempty" yaml:"pipeline,omitempty"`
+	Payload              map[string]interface{}         `json:"payload,omitempty" yaml:"payload,omitempty"`
+	Parameters           map[string]string              `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	ApplicationName      string                         `json:"application,omitempty" yaml:"application,omitempty"`
+	EnvironmentName      string                         `json:"environment,omitempty" yaml:"environment,omitempty"`
+	ProjectPlatformName  string                         `json:"platform,omitempty" yaml:"platform,omitempty"`
+	Approval             *sdk.WorkflowNodeApproval      `json:"approval,omitempty" yaml:"approval,omitempty"`
+	Concurrency          *sdk.WorkflowNodeConcurrency   `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+	PipelineHooks        []HookEntry                    `json:"pipeline_hooks,omitempty" yaml:"pipeline_hooks,omitempty"`
+	Permissions          map[string]int                 `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+	Metadata             map[string]string              `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	PurgeTags            []string                       `json:"purge_tags,omitempty" yaml:"purge_tags,omitempty"`
+	HistoryLength        *int64                         `json:"history_length,omitempty" yaml:"history_length,omitempty"`
+	RetentionPolicy      *sdk.WorkflowRetentionPolicy   `json:"retention_policy,omitempty" yaml:"retention_policy,omitempty"`
+	AutoCancelSuperseded bool                           `json:"auto_cancel_superseded,omitempty" yaml:"auto_cancel_superseded,omitempty"`
+	Notifications        []NotificationEntry            `json:"notify,omitempty" yaml:"notify,omitempty"`               // This is used when the workflow have only one pipeline
+	MapNotifications     map[string][]NotificationEntry `json:"notifications,omitempty" yaml:"notifications,omitempty"` // This is used when the workflow have more than one pipeline
 }
 
 // NodeEntry represents a node as code
 type NodeEntry struct {
-	ID                    int64                       `json:"-" yaml:"-"`
-	DependsOn             []string                    `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
-	Conditions            *sdk.WorkflowNodeConditions `json:"conditions,omitempty" yaml:"conditions,omitempty"`
-	When                  []string                    `json:"when,omitempty" yaml:"when,omitempty"` //This is used only for manual and success condition
-	PipelineName          string                      `json:"pipeline,omitempty" yaml:"pipeline,omitempty"`
-	ApplicationName       string                      `json:"application,omitempty" yaml:"application,omitempty"`
-	EnvironmentName       string                      `json:"environment,omitempty" yaml:"environment,omitempty"`
-	ProjectPlatformName   string                      `json:"platform,omitempty" yaml:"platform,omitempty"`
-	OneAtATime            *bool                       `json:"one_at_a_time,omitempty" yaml:"one_at_a_time,omitempty"`
-	Payload               map[string]interface{}      `json:"payload,omitempty" yaml:"payload,omitempty"`
-	Parameters            map[string]string           `json:"parameters,omitempty" yaml:"parameters,omitempty"`
-	OutgoingHookModelName string                      `json:"trigger,omitempty" yaml:"trigger,omitempty"`
-	OutgoingHookConfig    map[string]string           `json:"config,omitempty" yaml:"config,omitempty"`
+	ID                    int64                        `json:"-" yaml:"-"`
+	DependsOn             []string                     `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	Conditions            *sdk.WorkflowNodeConditions  `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	When                  []string                     `json:"when,omitempty" yaml:"when,omitempty"` //This is used only for manual and success condition
+	PipelineName          string                       `json:"pipeline,omitempty" yaml:"pipeline,omitempty"`
+	ApplicationName       string                       `json:"application,omitempty" yaml:"application,omitempty"`
+	EnvironmentName       string                       `json:"environment,omitempty" yaml:"environment,omitempty"`
+	ProjectPlatformName   string                       `json:"platform,omitempty" yaml:"platform,omitempty"`
+	OneAtATime            *bool                        `json:"one_at_a_time,omitempty" yaml:"one_at_a_time,omitempty"`
+	Approval              *sdk.WorkflowNodeApproval    `json:"approval,omitempty" yaml:"approval,omitempty"`
+	Concurrency           *sdk.WorkflowNodeConcurrency `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+	Payload               map[string]interface{}       `json:"payload,omitempty" yaml:"payload,omitempty"`
+	Parameters            map[string]string            `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	OutgoingHookModelName string                       `json:"trigger,omitempty" yaml:"trigger,omitempty"`
+	OutgoingHookConfig    map[string]string            `json:"config,omitempty" yaml:"config,omitempty"`
 }
 
 // HookEntry represents a hook as code
@@ -120,9 +126,10 @@ func craftNodeEntry(w sdk.Workflow, n sdk.Node) (NodeEntry, error) {
 			}
 		}
 
-		if len(conditions) > 0 || n.Context.Conditions.LuaScript != "" {
+		if len(conditions) > 0 || n.Context.Conditions.LuaScript != "" || n.Context.Conditions.Expression != "" {
 			entry.Conditions = &sdk.WorkflowNodeConditions{
 				PlainConditions: conditions,
+				Expression:      n.Context.Conditions.Expression,
 				LuaScript:       n.Context.Conditions.LuaScript,
 			}
 		}
@@ -141,6 +148,9 @@ func craftNodeEntry(w sdk.Workflow, n sdk.Node) (NodeEntry, error) {
 			entry.OneAtATime = &n.Context.Mutex
 		}
 
+		entry.Approval = n.Context.Approval
+		entry.Concurrency = n.Context.Concurrency
+
 		if n.Context.HasDefaultPayload() {
 			enc := dump.NewDefaultEncoder(nil)
 			enc.ExtraFields.DetailedMap = false
@@ -206,7 +216,7 @@ func WorkflowSkipIfOnlyOneRepoWebhook(w sdk.Workflow, exportedWorkflow *Workflow
 	return nil
 }
 
-//NewWorkflow creates a new exportable workflow
+// NewWorkflow creates a new exportable workflow
 func NewWorkflow(w sdk.Workflow, opts ...WorkflowOptions) (Workflow, error) {
 	exportedWorkflow := Workflow{}
 	exportedWorkflow.Name = w.Name
@@ -229,6 +239,8 @@ func NewWorkflow(w sdk.Workflow, opts ...WorkflowOptions) (Workflow, error) {
 	}
 
 	exportedWorkflow.PurgeTags = w.PurgeTags
+	exportedWorkflow.RetentionPolicy = w.RetentionPolicy
+	exportedWorkflow.AutoCancelSuperseded = w.AutoCancelSuperseded
 
 	nodes := w.WorkflowData.Array()
 
@@ -242,8 +254,10 @@ func NewWorkflow(w sdk.Workflow, opts ...WorkflowOptions) (Workflow, error) {
 		exportedWorkflow.PipelineName = entry.PipelineName
 		exportedWorkflow.EnvironmentName = entry.EnvironmentName
 		exportedWorkflow.ProjectPlatformName = entry.ProjectPlatformName
+		exportedWorkflow.Approval = entry.Approval
+		exportedWorkflow.Concurrency = entry.Concurrency
 		exportedWorkflow.DependsOn = entry.DependsOn
-		if entry.Conditions != nil && (len(entry.Conditions.PlainConditions) > 0 || entry.Conditions.LuaScript != "") {
+		if entry.Conditions != nil && (len(entry.Conditions.PlainConditions) > 0 || entry.Conditions.LuaScript != "" || entry.Conditions.Expression != "") {
 			exportedWorkflow.When = entry.When
 			exportedWorkflow.Conditions = entry.Conditions
 		}
@@ -326,6 +340,8 @@ func (w Workflow) Entries() map[string]NodeEntry {
 		When:                w.When,
 		Payload:             w.Payload,
 		Parameters:          w.Parameters,
+		Approval:            w.Approval,
+		Concurrency:         w.Concurrency,
 	}
 	return map[string]NodeEntry{
 		w.PipelineName: singleEntry,
@@ -360,6 +376,12 @@ func (w Workflow) checkValidity() error {
 		if len(w.PipelineHooks) != 0 {
 			mError.Append(fmt.Errorf("Error: wrong usage: pipeline_hooks not allowed here"))
 		}
+		if w.Approval != nil {
+			mError.Append(fmt.Errorf("Error: wrong usage: approval not allowed here"))
+		}
+		if w.Concurrency != nil {
+			mError.Append(fmt.Errorf("Error: wrong usage: concurrency not allowed here"))
+		}
 	} else {
 		if len(w.Hooks) > 0 {
 			mError.Append(fmt.Errorf("Error: wrong usage: hooks not allowed here"))
@@ -431,6 +453,8 @@ func (w Workflow) GetWorkflow() (*sdk.Workflow, error) {
 		return nil, sdk.WrapError(err, "Unable to check dependencies")
 	}
 	wf.PurgeTags = w.PurgeTags
+	wf.RetentionPolicy = w.RetentionPolicy
+	wf.AutoCancelSuperseded = w.AutoCancelSuperseded
 	if len(w.Metadata) > 0 {
 		wf.Metadata = make(map[string]string, len(w.Metadata))
 		for k, v := range w.Metadata {
@@ -539,6 +563,20 @@ func (e *NodeEntry) getNode(name string, w *sdk.Workflow) (*sdk.Node, error) {
 		node.Context.Mutex = *e.OneAtATime
 	}
 
+	if e.Approval != nil {
+		if err := e.Approval.IsValid(); err != nil {
+			return nil, err
+		}
+		node.Context.Approval = e.Approval
+	}
+
+	if e.Concurrency != nil {
+		if err := e.Concurrency.IsValid(); err != nil {
+			return nil, err
+		}
+		node.Context.Concurrency = e.Concurrency
+	}
+
 	if e.OutgoingHookModelName != "" {
 		node.Type = sdk.NodeTypeOutGoingHook
 		config := sdk.WorkflowNodeHookConfig{}
@@ -47,6 +47,9 @@ func craftNotificationEntry(w sdk.Workflow, notif sdk.WorkflowNotification) ([]s
 	if entry.Settings.SendToAuthor != nil && *entry.Settings.SendToAuthor {
 		entry.Settings.SendToAuthor = nil
 	}
+	if entry.Settings.PRComment != nil && !*entry.Settings.PRComment {
+		entry.Settings.PRComment = nil
+	}
 	// Replace the default values by empty strings
 	if entry.Settings.OnSuccess == sdk.UserNotificationChange {
 		entry.Settings.OnSuccess = ""
@@ -78,7 +81,10 @@ func craftNotificationEntry(w sdk.Workflow, notif sdk.WorkflowNotification) ([]s
 		len(entry.Settings.Recipients) == 0 &&
 		entry.Settings.SendToAuthor == nil &&
 		entry.Settings.SendToGroups == nil &&
-		entry.Settings.Template == nil {
+		entry.Settings.Template == nil &&
+		entry.Settings.WebhookURL == "" &&
+		entry.Settings.StatusContext == "" &&
+		entry.Settings.PRComment == nil {
 		entry.Settings = nil
 	}
 
@@ -189,6 +189,42 @@ notify:
         Details : {{.cds.buildURL}}
         Triggered by : {{.cds.triggered_by.username}}
         Branch : {{.git.branch}}
+`,
+		}, {
+			name: "test one pipeline with a slack notif",
+			yaml: `name: test-notif-slack
+version: v1.0
+pipeline: test
+notify:
+- type: slack
+  settings:
+    on_success: always
+    webhook_url: 01234567890abcdef
+- type: teams
+  settings:
+    webhook_url: abcdef
+`,
+		}, {
+			name: "two pipelines with a vcs notif",
+			yaml: `name: test-notif-vcs
+version: v1.0
+workflow:
+  test:
+    pipeline: test
+  test_2:
+    depends_on:
+    - test
+    when:
+    - success
+    pipeline: test
+notifications:
+  test:
+  - type: vcs
+    settings:
+      status_context: CDS/build
+      pr_comment: true
+  test_2:
+  - type: vcs
 `,
 		}, {
 			name: "two pipelines with one notif",
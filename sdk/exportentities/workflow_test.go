@@ -913,6 +913,19 @@ pipeline_hooks:
     method: POST
 metadata:
   default_tags: git.branch,git.author
+`,
+		}, {
+			name: "simple pipeline with superseded runs cancelled",
+			yaml: `name: test5
+version: v1.0
+pipeline: build
+application: test1
+pipeline_hooks:
+- type: RepositoryWebHook
+  ref: "1541182444"
+metadata:
+  default_tags: git.branch,git.author
+auto_cancel_superseded: true
 `,
 		}, {
 			name: "pipeline with two hooks",
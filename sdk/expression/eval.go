@@ -0,0 +1,372 @@
+package expression
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+)
+
+type value interface {
+	String() string
+}
+
+type stringValue string
+type numberValue float64
+type boolValue bool
+type listValue []value
+type versionValue semver.Version
+type timeValue time.Time
+
+func (v stringValue) String() string  { return string(v) }
+func (v numberValue) String() string  { return strconv.FormatFloat(float64(v), 'f', -1, 64) }
+func (v boolValue) String() string    { return strconv.FormatBool(bool(v)) }
+func (v versionValue) String() string { return semver.Version(v).String() }
+func (v timeValue) String() string    { return time.Time(v).Format(time.RFC3339) }
+func (v listValue) String() string {
+	s := make([]string, len(v))
+	for i := range v {
+		s[i] = v[i].String()
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+// dateLayouts are the layouts accepted by the date function
+var dateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+func toNumber(v value) (numberValue, error) {
+	switch t := v.(type) {
+	case numberValue:
+		return t, nil
+	case boolValue:
+		if t {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(v.String()), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", v.String())
+	}
+	return numberValue(f), nil
+}
+
+func toVersion(v value) (versionValue, error) {
+	if t, ok := v.(versionValue); ok {
+		return t, nil
+	}
+	sv, err := semver.ParseTolerant(strings.TrimSpace(v.String()))
+	if err != nil {
+		return versionValue{}, fmt.Errorf("%q is not a semantic version", v.String())
+	}
+	return versionValue(sv), nil
+}
+
+func toTime(v value) (timeValue, error) {
+	if t, ok := v.(timeValue); ok {
+		return t, nil
+	}
+	s := strings.TrimSpace(v.String())
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return timeValue(t), nil
+		}
+	}
+	return timeValue{}, fmt.Errorf("%q is not a date", v.String())
+}
+
+// truthy returns the boolean value of v: only true booleans and "true" strings are true
+func truthy(v value) bool {
+	switch t := v.(type) {
+	case boolValue:
+		return bool(t)
+	case numberValue:
+		return t != 0
+	case listValue:
+		return len(t) > 0
+	}
+	return v.String() == "true"
+}
+
+// compare returns -1, 0 or 1 comparing a and b, converting them to the most specific common type
+func compare(a, b value) (int, error) {
+	switch {
+	case isTime(a) || isTime(b):
+		ta, err := toTime(a)
+		if err != nil {
+			return 0, err
+		}
+		tb, err := toTime(b)
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case time.Time(ta).Before(time.Time(tb)):
+			return -1, nil
+		case time.Time(ta).After(time.Time(tb)):
+			return 1, nil
+		}
+		return 0, nil
+
+	case isVersion(a) || isVersion(b):
+		va, err := toVersion(a)
+		if err != nil {
+			return 0, err
+		}
+		vb, err := toVersion(b)
+		if err != nil {
+			return 0, err
+		}
+		return semver.Version(va).Compare(semver.Version(vb)), nil
+
+	case isNumber(a) || isNumber(b):
+		na, err := toNumber(a)
+		if err != nil {
+			return 0, err
+		}
+		nb, err := toNumber(b)
+		if err != nil {
+			return 0, err
+		}
+		return compareNumbers(na, nb), nil
+	}
+
+	// Untyped values: try numbers, then versions, then fallback on strings
+	if na, err := toNumber(a); err == nil {
+		if nb, err := toNumber(b); err == nil {
+			return compareNumbers(na, nb), nil
+		}
+	}
+	if va, err := toVersion(a); err == nil {
+		if vb, err := toVersion(b); err == nil {
+			return semver.Version(va).Compare(semver.Version(vb)), nil
+		}
+	}
+	return strings.Compare(a.String(), b.String()), nil
+}
+
+func compareNumbers(a, b numberValue) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// equals compares a and b, strings are compared as is unless the other side is typed
+func equals(a, b value) (bool, error) {
+	_, sa := a.(stringValue)
+	_, sb := b.(stringValue)
+	if sa && sb {
+		return a.String() == b.String(), nil
+	}
+	if _, ok := a.(listValue); ok {
+		return a.String() == listOf(b).String(), nil
+	}
+	if _, ok := b.(boolValue); ok {
+		return truthy(a) == truthy(b), nil
+	}
+	if _, ok := a.(boolValue); ok {
+		return truthy(a) == truthy(b), nil
+	}
+	c, err := compare(a, b)
+	if err != nil {
+		return false, err
+	}
+	return c == 0, nil
+}
+
+// listOf converts v to a list, strings are split on commas
+func listOf(v value) listValue {
+	switch t := v.(type) {
+	case listValue:
+		return t
+	case stringValue:
+		if t == "" {
+			return listValue{}
+		}
+		parts := strings.Split(string(t), ",")
+		l := make(listValue, len(parts))
+		for i := range parts {
+			l[i] = stringValue(strings.TrimSpace(parts[i]))
+		}
+		return l
+	}
+	return listValue{v}
+}
+
+func contains(list, item value) (bool, error) {
+	if _, ok := list.(stringValue); ok {
+		if _, ok := item.(listValue); !ok {
+			return strings.Contains(list.String(), item.String()), nil
+		}
+	}
+	for _, v := range listOf(list) {
+		eq, err := equals(v, item)
+		if err != nil {
+			continue
+		}
+		if eq {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isTime(v value) bool    { _, ok := v.(timeValue); return ok }
+func isVersion(v value) bool { _, ok := v.(versionValue); return ok }
+func isNumber(v value) bool  { _, ok := v.(numberValue); return ok }
+
+func (n literalNode) eval(vars map[string]string) (value, error) {
+	return n.val, nil
+}
+
+func (n identNode) eval(vars map[string]string) (value, error) {
+	return stringValue(vars[n.name]), nil
+}
+
+func (n listNode) eval(vars map[string]string) (value, error) {
+	l := make(listValue, len(n.items))
+	for i := range n.items {
+		v, err := n.items[i].eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		l[i] = v
+	}
+	return l, nil
+}
+
+func (n notNode) eval(vars map[string]string) (value, error) {
+	v, err := n.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	return boolValue(!truthy(v)), nil
+}
+
+func (n callNode) eval(vars map[string]string) (value, error) {
+	args := make([]value, len(n.args))
+	for i := range n.args {
+		v, err := n.args[i].eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	v, err := n.fn.call(args, vars)
+	if err != nil {
+		return nil, newError(n.pos, fmt.Sprintf("%s: %v", n.name, err))
+	}
+	return v, nil
+}
+
+func (n binaryNode) eval(vars map[string]string) (value, error) {
+	left, err := n.left.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lazy evaluation of boolean operators
+	switch n.op {
+	case "&&":
+		if !truthy(left) {
+			return boolValue(false), nil
+		}
+		right, err := n.right.eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		return boolValue(truthy(right)), nil
+	case "||":
+		if truthy(left) {
+			return boolValue(true), nil
+		}
+		right, err := n.right.eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		return boolValue(truthy(right)), nil
+	}
+
+	right, err := n.right.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	var res bool
+	switch n.op {
+	case "==", "!=":
+		res, err = equals(left, right)
+		if n.op == "!=" {
+			res = !res
+		}
+	case "<", "<=", ">", ">=":
+		var c int
+		c, err = compare(left, right)
+		switch n.op {
+		case "<":
+			res = c < 0
+		case "<=":
+			res = c <= 0
+		case ">":
+			res = c > 0
+		case ">=":
+			res = c >= 0
+		}
+	case "in":
+		res, err = contains(right, left)
+	case "contains":
+		res, err = contains(left, right)
+	case "matches":
+		var r *regexp.Regexp
+		r, err = regexp.Compile(right.String())
+		if err == nil {
+			res = r.MatchString(left.String())
+		}
+	}
+	if err != nil {
+		return nil, newError(n.pos, fmt.Sprintf("unable to evaluate '%s': %v", n.op, err))
+	}
+	return boolValue(res), nil
+}
+
+type function struct {
+	arity int
+	// volatile functions depends on the context and can not be evaluated at parse time
+	volatile bool
+	call     func(args []value, vars map[string]string) (value, error)
+}
+
+var functions = map[string]function{
+	"number": {arity: 1, call: func(args []value, _ map[string]string) (value, error) {
+		return toNumber(args[0])
+	}},
+	"semver": {arity: 1, call: func(args []value, _ map[string]string) (value, error) {
+		return toVersion(args[0])
+	}},
+	"date": {arity: 1, call: func(args []value, _ map[string]string) (value, error) {
+		return toTime(args[0])
+	}},
+	"now": {arity: 0, volatile: true, call: func(_ []value, _ map[string]string) (value, error) {
+		return timeValue(time.Now()), nil
+	}},
+	"lower": {arity: 1, call: func(args []value, _ map[string]string) (value, error) {
+		return stringValue(strings.ToLower(args[0].String())), nil
+	}},
+	"upper": {arity: 1, call: func(args []value, _ map[string]string) (value, error) {
+		return stringValue(strings.ToUpper(args[0].String())), nil
+	}},
+	"var": {arity: 1, volatile: true, call: func(args []value, vars map[string]string) (value, error) {
+		return stringValue(vars[args[0].String()]), nil
+	}},
+	"defined": {arity: 1, volatile: true, call: func(args []value, vars map[string]string) (value, error) {
+		_, ok := vars[args[0].String()]
+		return boolValue(ok), nil
+	}},
+}
@@ -0,0 +1,75 @@
+// Package expression implements the condition language of workflow nodes.
+//
+// An expression compares variables and literals with typed operators, ie.
+//
+//	git.branch == "master" && semver(cds.version) >= semver("1.2.0") && payload.env in ["prod", "preprod"]
+//
+// Variables are referenced by their name, or with var("name") for names that
+// are not valid identifiers. Undefined variables are evaluated as empty strings.
+package expression
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Expression is a parsed condition expression
+type Expression struct {
+	raw  string
+	root node
+}
+
+// Parse parses and checks an expression
+func Parse(s string) (*Expression, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, newError(0, "empty expression")
+	}
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, newError(t.pos, fmt.Sprintf("unexpected %s", t))
+	}
+	return &Expression{raw: s, root: root}, nil
+}
+
+// Validate returns an error if the expression is invalid
+func Validate(s string) error {
+	_, err := Parse(s)
+	return err
+}
+
+// Eval parses and evaluates an expression against vars
+func Eval(s string, vars map[string]string) (bool, error) {
+	e, err := Parse(s)
+	if err != nil {
+		return false, err
+	}
+	return e.Eval(vars)
+}
+
+// String returns the expression as it was written
+func (e *Expression) String() string {
+	return e.raw
+}
+
+// Eval evaluates the expression against vars
+func (e *Expression) Eval(vars map[string]string) (bool, error) {
+	v, err := e.root.eval(vars)
+	if err != nil {
+		return false, err
+	}
+	return truthy(v), nil
+}
+
+// Quote returns s as a string literal
+func Quote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
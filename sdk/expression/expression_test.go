@@ -0,0 +1,113 @@
+package expression
+
+import (
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]string{
+		"git.branch":       "master",
+		"cds.version":      "12",
+		"app.version":      "1.10.2",
+		"payload.env":      "prod",
+		"payload.replicas": "3",
+		"build.date":       "2018-06-01",
+		"flag":             "true",
+		"cds.proj.my-var":  "foo",
+	}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: `git.branch == "master"`, want: true},
+		{expr: `git.branch != 'master'`, want: false},
+		{expr: `cds.version > 9`, want: true},
+		{expr: `cds.version > "9"`, want: true},
+		{expr: `cds.version == 12.0`, want: true},
+		{expr: `payload.replicas <= 2`, want: false},
+		{expr: `semver(app.version) >= semver("1.9.0")`, want: true},
+		{expr: `app.version > "1.9.0"`, want: true},
+		{expr: `semver(app.version) < "v1.10.3"`, want: true},
+		{expr: `date(build.date) < date("2018-06-02T00:00:00Z")`, want: true},
+		{expr: `date(build.date) > now()`, want: false},
+		{expr: `payload.env in ["prod", "preprod"]`, want: true},
+		{expr: `payload.env in []`, want: false},
+		{expr: `"prod" in "dev, prod"`, want: true},
+		{expr: `[1, 2, 3] contains payload.replicas`, want: true},
+		{expr: `git.branch contains "ast"`, want: true},
+		{expr: `git.branch matches "^mas.*"`, want: true},
+		{expr: `flag`, want: true},
+		{expr: `!flag || git.branch == "master"`, want: true},
+		{expr: `flag && !(git.branch == "master")`, want: false},
+		{expr: `flag == true`, want: true},
+		{expr: `unknown == ""`, want: true},
+		{expr: `defined(unknown)`, want: false},
+		{expr: `defined("git.branch") && var("cds.proj.my-var") == "foo"`, want: true},
+		{expr: `cds.proj.my-var == "foo"`, want: true},
+		{expr: `upper(git.branch) == "MASTER" && lower("A") == "a"`, want: true},
+		{expr: `false || true && false`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := Eval(tt.expr, vars)
+			if err != nil {
+				t.Fatalf("Eval() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvalError(t *testing.T) {
+	vars := map[string]string{"git.branch": "master"}
+	if _, err := Eval(`git.branch > 3`, vars); err == nil {
+		t.Error("expected an error comparing a string to a number")
+	}
+	if _, err := Eval(`semver(git.branch) > "1.0.0"`, vars); err == nil {
+		t.Error("expected an error parsing an invalid version")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: `a == "b" && (c || d)`},
+		{expr: ``, wantErr: "empty expression at position 1"},
+		{expr: `a == "b`, wantErr: "unterminated string at position 6"},
+		{expr: `a == `, wantErr: "unexpected end of expression at position 6"},
+		{expr: `(a == b`, wantErr: "expected ')' but found end of expression at position 8"},
+		{expr: `a == b c`, wantErr: "unexpected 'c' at position 8"},
+		{expr: `a == b == c`, wantErr: "unexpected '==', comparisons can not be chained at position 8"},
+		{expr: `a # b`, wantErr: "unexpected character '#' at position 3"},
+		{expr: `foo(a)`, wantErr: "unknown function foo at position 1"},
+		{expr: `semver(a, b)`, wantErr: "function semver expects 1 argument(s), got 2 at position 1"},
+		{expr: `semver("abc") > a`, wantErr: "semver: \"abc\" is not a semantic version at position 1"},
+		{expr: `a matches "(["`, wantErr: "invalid regular expression: error parsing regexp: missing closing ]: `[` at position 11"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			err := Validate(tt.expr)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	s := `a "quoted" \ value`
+	got, err := Eval(`var("v") == `+Quote(s), map[string]string{"v": s})
+	if err != nil || !got {
+		t.Errorf("Quote() = %s does not round trip: %v", Quote(s), err)
+	}
+}
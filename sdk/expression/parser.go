@@ -0,0 +1,279 @@
+package expression
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Error is returned when an expression can not be parsed or evaluated
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos+1)
+}
+
+func newError(pos int, msg string) *Error {
+	return &Error{Pos: pos, Msg: msg}
+}
+
+type node interface {
+	eval(vars map[string]string) (value, error)
+	position() int
+}
+
+type literalNode struct {
+	val value
+	pos int
+}
+
+type identNode struct {
+	name string
+	pos  int
+}
+
+type listNode struct {
+	items []node
+	pos   int
+}
+
+type callNode struct {
+	fn   function
+	name string
+	args []node
+	pos  int
+}
+
+type notNode struct {
+	operand node
+	pos     int
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+	pos         int
+}
+
+func (n literalNode) position() int { return n.pos }
+func (n identNode) position() int   { return n.pos }
+func (n listNode) position() int    { return n.pos }
+func (n callNode) position() int    { return n.pos }
+func (n notNode) position() int     { return n.pos }
+func (n binaryNode) position() int  { return n.pos }
+
+// comparison operators, including keywords
+var comparisons = map[string]bool{
+	"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+	"in": true, "contains": true, "matches": true,
+}
+
+type parser struct {
+	tokens []token
+	cur    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.cur]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.cur]
+	if t.kind != tokenEOF {
+		p.cur++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, value string) error {
+	t := p.next()
+	if t.kind != kind {
+		return newError(t.pos, fmt.Sprintf("expected '%s' but found %s", value, t))
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOperator && p.peek().value == "||" {
+		t := p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: t.value, left: left, right: right, pos: t.pos}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOperator && p.peek().value == "&&" {
+		t := p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: t.value, left: left, right: right, pos: t.pos}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if t := p.peek(); t.kind == tokenOperator && t.value == "!" {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand, pos: t.pos}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	if (t.kind != tokenOperator && t.kind != tokenIdent) || !comparisons[t.value] {
+		return left, nil
+	}
+	p.next()
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check regular expressions as soon as possible
+	if lit, ok := right.(literalNode); ok && t.value == "matches" {
+		if _, err := regexp.Compile(lit.val.String()); err != nil {
+			return nil, newError(lit.pos, fmt.Sprintf("invalid regular expression: %v", err))
+		}
+	}
+
+	if next := p.peek(); (next.kind == tokenOperator || next.kind == tokenIdent) && comparisons[next.value] {
+		return nil, newError(next.pos, fmt.Sprintf("unexpected %s, comparisons can not be chained", next))
+	}
+	return binaryNode{op: t.value, left: left, right: right, pos: t.pos}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return n, nil
+
+	case tokenString:
+		return literalNode{val: stringValue(t.value), pos: t.pos}, nil
+
+	case tokenNumber:
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, newError(t.pos, fmt.Sprintf("invalid number %s", t.value))
+		}
+		return literalNode{val: numberValue(f), pos: t.pos}, nil
+
+	case tokenLBracket:
+		list := listNode{pos: t.pos}
+		if p.peek().kind == tokenRBracket {
+			p.next()
+			return list, nil
+		}
+		for {
+			item, err := p.parsePrimary()
+			if err != nil {
+				return nil, err
+			}
+			list.items = append(list.items, item)
+			if p.peek().kind == tokenComma {
+				p.next()
+				continue
+			}
+			if err := p.expect(tokenRBracket, "]"); err != nil {
+				return nil, err
+			}
+			return list, nil
+		}
+
+	case tokenIdent:
+		switch t.value {
+		case "true":
+			return literalNode{val: boolValue(true), pos: t.pos}, nil
+		case "false":
+			return literalNode{val: boolValue(false), pos: t.pos}, nil
+		}
+		if comparisons[t.value] {
+			return nil, newError(t.pos, fmt.Sprintf("unexpected keyword %s", t))
+		}
+		if p.peek().kind != tokenLParen {
+			return identNode{name: t.value, pos: t.pos}, nil
+		}
+		return p.parseCall(t)
+	}
+
+	return nil, newError(t.pos, fmt.Sprintf("unexpected %s", t))
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	fn, ok := functions[name.value]
+	if !ok {
+		return nil, newError(name.pos, fmt.Sprintf("unknown function %s", name.value))
+	}
+	p.next() // (
+
+	call := callNode{fn: fn, name: name.value, pos: name.pos}
+	if p.peek().kind != tokenRParen {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if err := p.expect(tokenRParen, ")"); err != nil {
+		return nil, err
+	}
+
+	if len(call.args) != fn.arity {
+		return nil, newError(name.pos, fmt.Sprintf("function %s expects %d argument(s), got %d", name.value, fn.arity, len(call.args)))
+	}
+
+	// Evaluate calls on literals to report invalid values at parse time, ie. semver("foo")
+	allLiterals := true
+	for _, a := range call.args {
+		if _, ok := a.(literalNode); !ok {
+			allLiterals = false
+		}
+	}
+	if allLiterals && !fn.volatile {
+		v, err := call.eval(nil)
+		if err != nil {
+			return nil, err
+		}
+		return literalNode{val: v, pos: call.pos}, nil
+	}
+	return call, nil
+}
@@ -0,0 +1,98 @@
+package sdk
+
+// These are the gerrit stream-events types handled by the gerrit hook
+const (
+	GerritEventTypePatchsetCreated = "patchset-created"
+	GerritEventTypeCommentAdded    = "comment-added"
+	GerritEventTypeChangeMerged    = "change-merged"
+)
+
+// GerritEventTypes lists the gerrit stream-events types handled by the gerrit hook
+var GerritEventTypes = []string{
+	GerritEventTypePatchsetCreated,
+	GerritEventTypeCommentAdded,
+	GerritEventTypeChangeMerged,
+}
+
+// VCSGerritConfiguration represents the configuration used by the hooks service to listen the gerrit stream-events
+type VCSGerritConfiguration struct {
+	URL         string `json:"url"`
+	SSHUsername string `json:"ssh_username"`
+	SSHPort     int    `json:"ssh_port"`
+	SSHHostKey  string `json:"ssh_host_key"`
+	// Reviewer is the gerrit account used by CDS to vote, its comments are ignored by the hook
+	Reviewer string `json:"reviewer"`
+}
+
+// GerritEvent represents an event sent by the gerrit stream-events command
+// https://gerrit-review.googlesource.com/Documentation/cmd-stream-events.html
+type GerritEvent struct {
+	Type           string           `json:"type"`
+	Change         *GerritChange    `json:"change,omitempty"`
+	PatchSet       *GerritPatchSet  `json:"patchSet,omitempty"`
+	Uploader       *GerritAccount   `json:"uploader,omitempty"`
+	Author         *GerritAccount   `json:"author,omitempty"`
+	Submitter      *GerritAccount   `json:"submitter,omitempty"`
+	Approvals      []GerritApproval `json:"approvals,omitempty"`
+	Comment        string           `json:"comment,omitempty"`
+	NewRev         string           `json:"newRev,omitempty"`
+	RefUpdate      *GerritRefUpdate `json:"refUpdate,omitempty"`
+	ChangeKey      *GerritChangeKey `json:"changeKey,omitempty"`
+	Project        string           `json:"project,omitempty"`
+	RefName        string           `json:"refName,omitempty"`
+	EventCreatedOn int64            `json:"eventCreatedOn"`
+}
+
+// GerritChange represents a gerrit change in a stream event
+type GerritChange struct {
+	Project       string         `json:"project"`
+	Branch        string         `json:"branch"`
+	Topic         string         `json:"topic,omitempty"`
+	ID            string         `json:"id"`
+	Number        int64          `json:"number"`
+	Subject       string         `json:"subject"`
+	Owner         *GerritAccount `json:"owner,omitempty"`
+	URL           string         `json:"url"`
+	CommitMessage string         `json:"commitMessage"`
+	Status        string         `json:"status"`
+}
+
+// GerritPatchSet represents a patchset of a gerrit change in a stream event
+type GerritPatchSet struct {
+	Number    int64          `json:"number"`
+	Revision  string         `json:"revision"`
+	Parents   []string       `json:"parents"`
+	Ref       string         `json:"ref"`
+	Uploader  *GerritAccount `json:"uploader,omitempty"`
+	Author    *GerritAccount `json:"author,omitempty"`
+	CreatedOn int64          `json:"createdOn"`
+	Kind      string         `json:"kind"`
+}
+
+// GerritAccount represents a gerrit user in a stream event
+type GerritAccount struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+}
+
+// GerritApproval represents a vote on a label in a comment-added event
+type GerritApproval struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Value       string `json:"value"`
+	OldValue    string `json:"oldValue,omitempty"`
+}
+
+// GerritRefUpdate represents the update of a reference in a ref-updated event
+type GerritRefUpdate struct {
+	OldRev  string `json:"oldRev"`
+	NewRev  string `json:"newRev"`
+	RefName string `json:"refName"`
+	Project string `json:"project"`
+}
+
+// GerritChangeKey is the Change-Id of a change
+type GerritChangeKey struct {
+	ID string `json:"id"`
+}
@@ -31,11 +31,13 @@ var (
 	// Opencensus tags
 	TagHatchery     tag.Key
 	TagHatcheryName tag.Key
+	TagWorkerModel  tag.Key
 )
 
 func init() {
 	TagHatchery, _ = tag.NewKey("hatchery")
 	TagHatcheryName, _ = tag.NewKey("hatchery_name")
+	TagWorkerModel, _ = tag.NewKey("worker_model")
 }
 
 // WithTags returns a context with opencenstus tags
@@ -347,6 +349,13 @@ func Create(h Interface) error {
 				continue
 			}
 
+			//With the demand based provisioning, the workers are spawned by the provisioning ticker
+			if !h.Configuration().Provision.Disabled && h.Configuration().Provision.Demand.Enabled && !needsDedicatedWorker(j.Job.Action.Requirements) {
+				log.Debug("hatchery> job %d is let to the demand based provisioning", j.ID)
+				endTrace("demand provisioning")
+				continue
+			}
+
 			//We got a model, let's start a worker
 			workerRequest.model = *chosenModel
 
@@ -3,9 +3,13 @@ package hatchery
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync/atomic"
 	"time"
 
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -65,6 +69,11 @@ func provisioning(h Interface, models []sdk.Model) {
 		return
 	}
 
+	if h.Configuration().Provision.Demand.Enabled {
+		demandProvisioning(h, models)
+		return
+	}
+
 	for k := range models {
 		// for a shared.infra hatchery, all models are here (group shared.infra or not)
 		// but, a shared.infra hatchery can provision only a shared.infra model
@@ -76,19 +85,198 @@ func provisioning(h Interface, models []sdk.Model) {
 		if models[k].Type == h.ModelType() {
 			existing := h.WorkersStartedByModel(&models[k])
 			for i := existing; i < int(models[k].Provision); i++ {
-				go func(m sdk.Model) {
-					if name, errSpawn := h.SpawnWorker(context.Background(), SpawnArguments{Model: m, IsWorkflowJob: false, JobID: 0, Requirements: nil, LogInfo: "spawn for provision"}); errSpawn != nil {
-						log.Warning("provisioning> cannot spawn worker %s with model %s for provisioning: %s", name, m.Name, errSpawn)
-						var spawnError = sdk.SpawnErrorForm{
-							Error: fmt.Sprintf("hatchery %s cannot spawn worker %s for provisioning", h.Service().Name, m.Name),
-							Logs:  []byte(errSpawn.Error()),
-						}
-						if err := h.CDSClient().WorkerModelSpawnError(m.ID, spawnError); err != nil {
-							log.Error("provisioning> cannot client.WorkerModelSpawnError for worker %s with model %s for provisioning: %s", name, m.Name, errSpawn)
-						}
-					}
-				}(models[k])
+				go spawnForProvision(h, models[k])
 			}
 		}
 	}
 }
+
+func spawnForProvision(h Interface, m sdk.Model) {
+	if name, errSpawn := h.SpawnWorker(context.Background(), SpawnArguments{Model: m, IsWorkflowJob: false, JobID: 0, Requirements: nil, LogInfo: "spawn for provision"}); errSpawn != nil {
+		log.Warning("provisioning> cannot spawn worker %s with model %s for provisioning: %s", name, m.Name, errSpawn)
+		var spawnError = sdk.SpawnErrorForm{
+			Error: fmt.Sprintf("hatchery %s cannot spawn worker %s for provisioning", h.Service().Name, m.Name),
+			Logs:  []byte(errSpawn.Error()),
+		}
+		if err := h.CDSClient().WorkerModelSpawnError(m.ID, spawnError); err != nil {
+			log.Error("provisioning> cannot client.WorkerModelSpawnError for worker %s with model %s for provisioning: %s", name, m.Name, errSpawn)
+		}
+	}
+}
+
+// modelCapacity is the number of workers wanted and started for a worker model
+type modelCapacity struct {
+	model    sdk.Model
+	building int
+	demand   int
+	desired  int
+	actual   int
+}
+
+// demandProvisioning spawns workers for the jobs waiting in the queue, plus some warm workers for each worker model
+// used by the queue. The waiting workers above the demand are disabled once idle for more than Demand.IdleTTL.
+func demandProvisioning(h Interface, models []sdk.Model) {
+	jobs, err := h.CDSClient().QueueWorkflowNodeJobRun(sdk.StatusWaiting)
+	if err != nil {
+		log.Error("hatchery> demandProvisioning> cannot get queue: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	workers, err := h.CDSClient().WorkerList(ctx)
+	if err != nil {
+		log.Error("hatchery> demandProvisioning> cannot get workers: %v", err)
+		return
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Error("hatchery> demandProvisioning> cannot retrieve hostname: %v", err)
+		return
+	}
+
+	capacities := computeCapacities(h, models, jobs, workers, hostname)
+	for _, c := range capacities {
+		ctxModel, _ := tag.New(WithTags(context.Background(), h), tag.Upsert(TagWorkerModel, c.model.Name))
+		stats.Record(ctxModel, h.Stats().DesiredWorkers.M(int64(c.desired)), h.Stats().ActualWorkers.M(int64(c.actual)))
+	}
+
+	maxProv := h.Configuration().Provision.MaxConcurrentProvisioning
+	if maxProv < 1 {
+		maxProv = defaultMaxProvisioning
+	}
+	started := len(h.WorkersStarted())
+	var nbSpawn int
+	for _, c := range capacities {
+		for i := c.actual; i < c.desired; i++ {
+			if started >= h.Configuration().Provision.MaxWorker || nbSpawn >= maxProv {
+				log.Debug("hatchery> demandProvisioning> %s has reached its capacity (started: %d, spawned: %d)", h.Service().Name, started, nbSpawn)
+				break
+			}
+			started++
+			nbSpawn++
+			go spawnForProvision(h, c.model)
+		}
+	}
+
+	idleTTL := time.Duration(h.Configuration().Provision.Demand.IdleTTL) * time.Second
+	for _, w := range idleWorkersToDisable(h.Service().Name, capacities, workers, h.IdleWorkers(), time.Now(), idleTTL) {
+		log.Info("hatchery> demandProvisioning> disable idle worker %s", w.Name)
+		if err := h.CDSClient().WorkerDisable(ctx, w.ID); err != nil {
+			log.Warning("hatchery> demandProvisioning> cannot disable worker %s: %v", w.Name, err)
+		}
+	}
+}
+
+// needsDedicatedWorker returns true if the requirements can only be satisfied by a worker spawned for the job
+func needsDedicatedWorker(requirements []sdk.Requirement) bool {
+	for _, r := range requirements {
+		switch r.Type {
+		case sdk.ServiceRequirement, sdk.MemoryRequirement, sdk.VolumeRequirement:
+			return true
+		}
+	}
+	return false
+}
+
+// computeCapacities groups the waiting jobs by the first worker model able to run them.
+// The jobs with requirements needing a dedicated worker are let to the spawn for the job,
+// the booked jobs already have a worker starting for them.
+// For each model, desired is the number of building workers, plus the waiting jobs, plus the warm workers
+// if the model is used. It's never less than the provision of the model.
+func computeCapacities(h Interface, models []sdk.Model, jobs []sdk.WorkflowNodeJobRun, workers []sdk.Worker, hostname string) []*modelCapacity {
+	capacities := []*modelCapacity{}
+	byModel := map[int64]*modelCapacity{}
+	for k := range models {
+		// DO NOT provision if hatchery group is not the same as model
+		if models[k].GroupID != *h.Service().GroupID || models[k].Type != h.ModelType() {
+			continue
+		}
+		c := &modelCapacity{model: models[k], actual: h.WorkersStartedByModel(&models[k])}
+		capacities = append(capacities, c)
+		byModel[models[k].ID] = c
+	}
+
+	for _, w := range workers {
+		if w.HatcheryName != h.Service().Name || w.Status != sdk.StatusBuilding {
+			continue
+		}
+		if c, ok := byModel[w.ModelID]; ok {
+			c.building++
+		}
+	}
+
+	for _, j := range jobs {
+		if j.BookedBy.ID != 0 {
+			continue
+		}
+		if needsDedicatedWorker(j.Job.Action.Requirements) {
+			continue
+		}
+		req := workerStarterRequest{
+			id:            j.ID,
+			isWorkflowJob: true,
+			execGroups:    j.ExecGroups,
+			requirements:  j.Job.Action.Requirements,
+			hostname:      hostname,
+			timestamp:     time.Now().Unix(),
+		}
+		for _, c := range capacities {
+			if canRunJob(h, req, c.model) {
+				c.demand++
+				break
+			}
+		}
+	}
+
+	warm := h.Configuration().Provision.Demand.WarmWorkers
+	for _, c := range capacities {
+		c.desired = c.building + c.demand
+		if c.desired > 0 {
+			c.desired += warm
+		}
+		if c.desired < int(c.model.Provision) {
+			c.desired = int(c.model.Provision)
+		}
+	}
+	return capacities
+}
+
+// idleWorkersToDisable returns the waiting workers of the hatchery idle for more than idleTTL, up to the surplus
+// of their model. idleSince is updated with the waiting workers seen.
+func idleWorkersToDisable(hatcheryName string, capacities []*modelCapacity, workers []sdk.Worker, idleSince map[string]time.Time, now time.Time, idleTTL time.Duration) []sdk.Worker {
+	surplus := map[int64]int{}
+	for _, c := range capacities {
+		if c.actual > c.desired {
+			surplus[c.model.ID] = c.actual - c.desired
+		}
+	}
+
+	seen := map[string]bool{}
+	res := []sdk.Worker{}
+	for _, w := range workers {
+		if w.HatcheryName != hatcheryName || w.Status != sdk.StatusWaiting {
+			continue
+		}
+		seen[w.Name] = true
+		since, ok := idleSince[w.Name]
+		if !ok {
+			idleSince[w.Name] = now
+			continue
+		}
+		if now.Sub(since) < idleTTL || surplus[w.ModelID] <= 0 {
+			continue
+		}
+		surplus[w.ModelID]--
+		delete(idleSince, w.Name)
+		res = append(res, w)
+	}
+
+	for name := range idleSince {
+		if !seen[name] {
+			delete(idleSince, name)
+		}
+	}
+	return res
+}
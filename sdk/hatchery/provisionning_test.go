@@ -0,0 +1,51 @@
+package hatchery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_idleWorkersToDisable(t *testing.T) {
+	now := time.Now()
+	capacities := []*modelCapacity{
+		{model: sdk.Model{ID: 1}, desired: 1, actual: 3},
+		{model: sdk.Model{ID: 2}, desired: 2, actual: 2},
+	}
+	workers := []sdk.Worker{
+		{ID: "a", Name: "worker-a", ModelID: 1, HatcheryName: "my-hatchery", Status: sdk.StatusWaiting},
+		{ID: "b", Name: "worker-b", ModelID: 1, HatcheryName: "my-hatchery", Status: sdk.StatusWaiting},
+		{ID: "c", Name: "worker-c", ModelID: 1, HatcheryName: "my-hatchery", Status: sdk.StatusBuilding},
+		{ID: "d", Name: "worker-d", ModelID: 2, HatcheryName: "my-hatchery", Status: sdk.StatusWaiting},
+		{ID: "e", Name: "worker-e", ModelID: 1, HatcheryName: "other-hatchery", Status: sdk.StatusWaiting},
+		{ID: "f", Name: "worker-f", ModelID: 1, HatcheryName: "my-hatchery", Status: sdk.StatusWaiting},
+	}
+	idleSince := map[string]time.Time{
+		"worker-a": now.Add(-10 * time.Minute),
+		"worker-b": now.Add(-10 * time.Minute),
+		"worker-d": now.Add(-10 * time.Minute),
+		"worker-f": now.Add(-time.Minute),
+		"worker-z": now.Add(-10 * time.Minute),
+	}
+
+	res := idleWorkersToDisable("my-hatchery", capacities, workers, idleSince, now, 5*time.Minute)
+
+	// only the surplus of the model 1 is disabled, worker-f is not idle for long enough
+	if assert.Len(t, res, 2) {
+		assert.Equal(t, "a", res[0].ID)
+		assert.Equal(t, "b", res[1].ID)
+	}
+	// disabled and unknown workers are forgotten
+	assert.Len(t, idleSince, 2)
+	assert.Contains(t, idleSince, "worker-d")
+	assert.Contains(t, idleSince, "worker-f")
+
+	// a new waiting worker starts to be idle now
+	workers = append(workers, sdk.Worker{ID: "g", Name: "worker-g", ModelID: 1, HatcheryName: "my-hatchery", Status: sdk.StatusWaiting})
+	res = idleWorkersToDisable("my-hatchery", capacities, workers, idleSince, now, 5*time.Minute)
+	assert.Len(t, res, 0)
+	assert.Equal(t, now, idleSince["worker-g"])
+}
@@ -2,6 +2,7 @@ package hatchery
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/stats"
 
@@ -46,6 +47,11 @@ type CommonConfiguration struct {
 				ExtraValue string `toml:"extraValue" comment:"value for extraKey field. For many keys: valueaaa,valuebbb" json:"-"`
 			} `toml:"graylog" json:"graylog"`
 		} `toml:"workerLogsOptions" comment:"Worker Log Configuration" json:"workerLogsOptions"`
+		Demand struct {
+			Enabled     bool `toml:"enabled" default:"false" comment:"Provision workers from the jobs waiting in the queue instead of the provision of the worker models. Format:true or false" json:"enabled"`
+			WarmWorkers int  `toml:"warmWorkers" default:"1" comment:"Number of workers spawned ahead of demand for each worker model used by the queue" json:"warmWorkers"`
+			IdleTTL     int  `toml:"idleTTL" default:"300" comment:"Waiting workers above the demand are disabled after n seconds without job" json:"idleTTL"`
+		} `toml:"demand" comment:"Demand based provisioning" json:"demand"`
 	} `toml:"provision" json:"provision"`
 	LogOptions struct {
 		SpawnOptions struct {
@@ -76,6 +82,7 @@ type SpawnArguments struct {
 // ModelType returns type of hatchery
 // NeedRegistration return true if worker model need regsitration
 // ID returns hatchery id
+// IdleWorkers returns the date since the waiting workers of the hatchery have no job, used by the demand based provisioning
 type Interface interface {
 	Init() error
 	SpawnWorker(ctx context.Context, spawnArgs SpawnArguments) (string, error)
@@ -96,6 +103,7 @@ type Interface interface {
 	Stats() *Stats
 	PanicDumpDirectory() (string, error)
 	WorkerModelsEnabled() ([]sdk.Model, error)
+	IdleWorkers() map[string]time.Time
 }
 
 type Stats struct {
@@ -108,4 +116,6 @@ type Stats struct {
 	WaitingWorkers     *stats.Int64Measure
 	BuildingWorkers    *stats.Int64Measure
 	DisabledWorkers    *stats.Int64Measure
+	DesiredWorkers     *stats.Int64Measure
+	ActualWorkers      *stats.Int64Measure
 }
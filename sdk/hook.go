@@ -8,6 +8,7 @@ const (
 	GitPollerModelName            = "Git Repository Poller"
 	KafkaHookModelName            = "Kafka hook"
 	RabbitMQHookModelName         = "RabbitMQ hook"
+	GerritHookModelName           = "Gerrit hook"
 	WorkflowModelName             = "Workflow"
 	HookConfigProject             = "project"
 	HookConfigWorkflow            = "workflow"
@@ -30,6 +31,7 @@ const (
 	RabbitMQHookModelExchangeType = "exchange_type"
 	RabbitMQHookModelExchangeName = "exchange_name"
 	RabbitMQHookModelConsumerTag  = "consumer_tag"
+	GerritHookModelEventFilter    = "eventFilter"
 )
 
 // Here are the default hooks
@@ -42,6 +44,7 @@ var (
 		&KafkaHookModel,
 		&RabbitMQHookModel,
 		&WorkflowModel,
+		&GerritHookModel,
 	}
 
 	BuiltinOutgoingHookModels = []*WorkflowHookModel{
@@ -114,6 +117,21 @@ var (
 		},
 	}
 
+	GerritHookModel = WorkflowHookModel{
+		Author:     "CDS",
+		Type:       WorkflowHookModelBuiltin,
+		Identifier: "github.com/ovh/cds/hook/builtin/gerrit",
+		Name:       GerritHookModelName,
+		Icon:       "git",
+		DefaultConfig: WorkflowNodeHookConfig{
+			GerritHookModelEventFilter: {
+				Value:        GerritEventTypePatchsetCreated + ";" + GerritEventTypeChangeMerged,
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+		},
+	}
+
 	WebHookModel = WorkflowHookModel{
 		Author:     "CDS",
 		Type:       WorkflowHookModelBuiltin,
@@ -271,6 +289,8 @@ func GetDefaultHookModel(modelName string) WorkflowHookModel {
 		return GitPollerModel
 	case WorkflowModelName:
 		return WorkflowModel
+	case GerritHookModelName:
+		return GerritHookModel
 	}
 
 	return WebHookModel
@@ -25,6 +25,7 @@ type TaskExecution struct {
 	Kafka               *KafkaTaskExecution     `json:"kafka,omitempty" cli:"-"`
 	RabbitMQ            *RabbitMQTaskExecution  `json:"rabbitmq,omitempty" cli:"-"`
 	ScheduledTask       *ScheduledTaskExecution `json:"scheduled_task,omitempty" cli:"-"`
+	GerritEvent         *GerritEventExecution   `json:"gerrit,omitempty" cli:"-"`
 	Status              string                  `json:"status" cli:"status"`
 }
 
@@ -46,6 +47,11 @@ type RabbitMQTaskExecution struct {
 	Message []byte `json:"message"`
 }
 
+// GerritEventExecution contains specific data for a gerrit hook
+type GerritEventExecution struct {
+	Message []byte `json:"message"`
+}
+
 // ScheduledTaskExecution contains specific data for a scheduled task execution
 type ScheduledTaskExecution struct {
 	DateScheduledExecution string `json:"date_scheduled_execution"`
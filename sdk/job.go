@@ -1,5 +1,10 @@
 package sdk
 
+import (
+	"strings"
+	"time"
+)
+
 // This constant are the types of the kind of job of CDS: legacy and workflow
 const (
 	JobTypePipeline     = "pipeline_build_job"
@@ -14,4 +19,208 @@ type Job struct {
 	LastModified     int64                  `json:"last_modified"`
 	Action           Action                 `json:"action"`
 	Warnings         []PipelineBuildWarning `json:"warnings"`
+	RetryPolicy      *JobRetryPolicy        `json:"retry_policy,omitempty"`
+	Matrix           *JobMatrix             `json:"matrix,omitempty"`
+}
+
+const maxRetryBackoffDoubling = 10
+
+// JobRetryPolicy describes when and how a job run is automatically retried
+type JobRetryPolicy struct {
+	MaxAttempts int      `json:"max_attempts"`
+	Statuses    []string `json:"statuses,omitempty"`
+	ExitCodes   []int64  `json:"exit_codes,omitempty"`
+	Backoff     int64    `json:"backoff,omitempty"`
+}
+
+// IsValid checks the retry policy
+func (p JobRetryPolicy) IsValid() error {
+	if p.MaxAttempts < 1 {
+		return WrapError(ErrWrongRequest, "retry policy max attempts must be greater than 0")
+	}
+	if p.Backoff < 0 {
+		return WrapError(ErrWrongRequest, "retry policy backoff must be positive")
+	}
+	for _, s := range p.Statuses {
+		switch s {
+		case StatusFail.String(), StatusStopped.String():
+		default:
+			return WrapError(ErrWrongRequest, "retry policy does not support status %s", s)
+		}
+	}
+	return nil
+}
+
+// ShouldRetry returns true if the given attempt of a job run, ended with given status
+// and exit code, has to be retried. Attempts are numbered from 1.
+func (p JobRetryPolicy) ShouldRetry(attempt int, status string, exitCode int64) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+
+	statuses := p.Statuses
+	if len(statuses) == 0 {
+		statuses = []string{StatusFail.String()}
+	}
+	if !IsInArray(status, statuses) {
+		return false
+	}
+
+	if len(p.ExitCodes) == 0 {
+		return true
+	}
+	for _, c := range p.ExitCodes {
+		if c == exitCode {
+			return true
+		}
+	}
+	return false
+}
+
+// BackoffDuration returns the delay to wait after the given failed attempt, doubled at each attempt
+func (p JobRetryPolicy) BackoffDuration(attempt int) time.Duration {
+	if p.Backoff <= 0 || attempt < 1 {
+		return 0
+	}
+	if attempt > maxRetryBackoffDoubling {
+		attempt = maxRetryBackoffDoubling
+	}
+	return time.Duration(p.Backoff) * time.Second * time.Duration(1<<uint(attempt-1))
+}
+
+// MaxJobMatrixCombinations is the maximum number of job runs that a job matrix can produce
+const MaxJobMatrixCombinations = 256
+
+// JobMatrix describes how a job is fanned out over a set of parameter values.
+// Each combination of axis values produces its own job run.
+type JobMatrix struct {
+	Axes    []JobMatrixAxis     `json:"axes"`
+	Include []map[string]string `json:"include,omitempty"`
+	Exclude []map[string]string `json:"exclude,omitempty"`
+}
+
+// JobMatrixAxis is a named dimension of a job matrix
+type JobMatrixAxis struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// IsValid checks the job matrix
+func (m JobMatrix) IsValid() error {
+	names := make(map[string]struct{}, len(m.Axes))
+	for _, a := range m.Axes {
+		if !NamePatternRegex.MatchString(a.Name) {
+			return WrapError(ErrWrongRequest, "invalid matrix axis name %s", a.Name)
+		}
+		if _, has := names[a.Name]; has {
+			return WrapError(ErrWrongRequest, "duplicated matrix axis %s", a.Name)
+		}
+		if len(a.Values) == 0 {
+			return WrapError(ErrWrongRequest, "matrix axis %s has no value", a.Name)
+		}
+		names[a.Name] = struct{}{}
+	}
+	for _, filters := range [][]map[string]string{m.Include, m.Exclude} {
+		for _, f := range filters {
+			for k := range f {
+				if _, has := names[k]; !has {
+					return WrapError(ErrWrongRequest, "unknown matrix axis %s", k)
+				}
+			}
+		}
+	}
+
+	// The combinations are counted before being computed, so that a huge matrix is never materialized
+	n := 1
+	for _, a := range m.Axes {
+		n *= len(a.Values)
+		if n > MaxJobMatrixCombinations {
+			return WrapError(ErrWrongRequest, "matrix axes produce more than %d combinations", MaxJobMatrixCombinations)
+		}
+	}
+	if len(m.Include) > MaxJobMatrixCombinations {
+		return WrapError(ErrWrongRequest, "matrix includes %d combinations, maximum is %d", len(m.Include), MaxJobMatrixCombinations)
+	}
+
+	n = len(m.Combinations())
+	if n == 0 {
+		return WrapError(ErrWrongRequest, "matrix does not produce any combination")
+	}
+	if n > MaxJobMatrixCombinations {
+		return WrapError(ErrWrongRequest, "matrix produces %d combinations, maximum is %d", n, MaxJobMatrixCombinations)
+	}
+	return nil
+}
+
+// Combinations returns all the axis values combinations of the matrix, excluded
+// combinations are removed and included ones are added at the end.
+func (m JobMatrix) Combinations() []map[string]string {
+	var res []map[string]string
+	if len(m.Axes) > 0 {
+		res = []map[string]string{{}}
+		for _, a := range m.Axes {
+			next := make([]map[string]string, 0, len(res)*len(a.Values))
+			for _, c := range res {
+				for _, v := range a.Values {
+					nc := make(map[string]string, len(c)+1)
+					for k, cv := range c {
+						nc[k] = cv
+					}
+					nc[a.Name] = v
+					next = append(next, nc)
+				}
+			}
+			res = next
+		}
+	}
+
+	filtered := res[:0]
+	for _, c := range res {
+		var excluded bool
+		for _, e := range m.Exclude {
+			if matchMatrixCombination(c, e) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			filtered = append(filtered, c)
+		}
+	}
+	res = filtered
+
+	for _, i := range m.Include {
+		var found bool
+		for _, c := range res {
+			if len(c) == len(i) && matchMatrixCombination(c, i) {
+				found = true
+				break
+			}
+		}
+		if !found && len(i) > 0 {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// CombinationName returns a human readable name of a matrix combination, following the axes order
+func (m JobMatrix) CombinationName(c map[string]string) string {
+	values := make([]string, 0, len(c))
+	for _, a := range m.Axes {
+		if v, has := c[a.Name]; has {
+			values = append(values, a.Name+":"+v)
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+// matchMatrixCombination returns true if all the values of filter are in the combination
+func matchMatrixCombination(c, filter map[string]string) bool {
+	for k, v := range filter {
+		if c[k] != v {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,150 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJobRetryPolicyShouldRetry(t *testing.T) {
+	type args struct {
+		attempt  int
+		status   string
+		exitCode int64
+	}
+	tests := []struct {
+		name   string
+		policy JobRetryPolicy
+		args   args
+		want   bool
+	}{
+		{
+			name:   "failed job is retried by default",
+			policy: JobRetryPolicy{MaxAttempts: 3},
+			args:   args{attempt: 1, status: StatusFail.String(), exitCode: 1},
+			want:   true,
+		},
+		{
+			name:   "last attempt is not retried",
+			policy: JobRetryPolicy{MaxAttempts: 3},
+			args:   args{attempt: 3, status: StatusFail.String(), exitCode: 1},
+			want:   false,
+		},
+		{
+			name:   "stopped job is not retried by default",
+			policy: JobRetryPolicy{MaxAttempts: 3},
+			args:   args{attempt: 1, status: StatusStopped.String()},
+			want:   false,
+		},
+		{
+			name:   "stopped job is retried if configured",
+			policy: JobRetryPolicy{MaxAttempts: 3, Statuses: []string{StatusStopped.String()}},
+			args:   args{attempt: 1, status: StatusStopped.String()},
+			want:   true,
+		},
+		{
+			name:   "matching exit code is retried",
+			policy: JobRetryPolicy{MaxAttempts: 3, ExitCodes: []int64{137}},
+			args:   args{attempt: 2, status: StatusFail.String(), exitCode: 137},
+			want:   true,
+		},
+		{
+			name:   "other exit code is not retried",
+			policy: JobRetryPolicy{MaxAttempts: 3, ExitCodes: []int64{137}},
+			args:   args{attempt: 1, status: StatusFail.String(), exitCode: 1},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.ShouldRetry(tt.args.attempt, tt.args.status, tt.args.exitCode); got != tt.want {
+				t.Errorf("ShouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJobRetryPolicyBackoffDuration(t *testing.T) {
+	p := JobRetryPolicy{MaxAttempts: 20, Backoff: 10}
+	if got := p.BackoffDuration(1); got != 10*time.Second {
+		t.Errorf("BackoffDuration(1) = %v, want 10s", got)
+	}
+	if got := p.BackoffDuration(3); got != 40*time.Second {
+		t.Errorf("BackoffDuration(3) = %v, want 40s", got)
+	}
+	if got := p.BackoffDuration(15); got != p.BackoffDuration(maxRetryBackoffDoubling) {
+		t.Errorf("BackoffDuration(15) = %v, want %v", got, p.BackoffDuration(maxRetryBackoffDoubling))
+	}
+}
+
+func TestJobMatrixCombinations(t *testing.T) {
+	m := JobMatrix{
+		Axes: []JobMatrixAxis{
+			{Name: "go", Values: []string{"1.10", "1.11"}},
+			{Name: "os", Values: []string{"linux", "windows"}},
+		},
+		Exclude: []map[string]string{{"go": "1.10", "os": "windows"}},
+		Include: []map[string]string{{"go": "1.12", "os": "linux"}, {"go": "1.11", "os": "linux"}},
+	}
+	if err := m.IsValid(); err != nil {
+		t.Fatalf("IsValid() = %v", err)
+	}
+
+	got := m.Combinations()
+	want := []string{"go:1.10, os:linux", "go:1.11, os:linux", "go:1.11, os:windows", "go:1.12, os:linux"}
+	if len(got) != len(want) {
+		t.Fatalf("Combinations() returns %d combinations, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if name := m.CombinationName(got[i]); name != want[i] {
+			t.Errorf("Combinations()[%d] = %s, want %s", i, name, want[i])
+		}
+	}
+}
+
+func TestJobMatrixIsValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix JobMatrix
+	}{
+		{
+			name:   "no values",
+			matrix: JobMatrix{Axes: []JobMatrixAxis{{Name: "go"}}},
+		},
+		{
+			name:   "duplicated axis",
+			matrix: JobMatrix{Axes: []JobMatrixAxis{{Name: "go", Values: []string{"1.10"}}, {Name: "go", Values: []string{"1.11"}}}},
+		},
+		{
+			name: "unknown axis",
+			matrix: JobMatrix{
+				Axes:    []JobMatrixAxis{{Name: "go", Values: []string{"1.10"}}},
+				Exclude: []map[string]string{{"os": "linux"}},
+			},
+		},
+		{
+			name: "too many combinations",
+			matrix: JobMatrix{
+				Axes: []JobMatrixAxis{
+					{Name: "a", Values: make([]string, 1000)},
+					{Name: "b", Values: make([]string, 1000)},
+					{Name: "c", Values: make([]string, 1000)},
+				},
+				Exclude: []map[string]string{{"a": ""}},
+			},
+		},
+		{
+			name: "everything excluded",
+			matrix: JobMatrix{
+				Axes:    []JobMatrixAxis{{Name: "go", Values: []string{"1.10"}}},
+				Exclude: []map[string]string{{"go": "1.10"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.matrix.IsValid(); err == nil {
+				t.Errorf("IsValid() should return an error")
+			}
+		})
+	}
+}
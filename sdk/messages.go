@@ -73,11 +73,21 @@ var (
 	MsgWorkflowNodeStop                    = &Message{"MsgWorkflowNodeStop", trad{FR: "Le pipeline a été arrété par %s", EN: "The pipeline has been stopped by %s"}, nil}
 	MsgWorkflowNodeMutex                   = &Message{"MsgWorkflowNodeMutex", trad{FR: "Le pipeline %s est mis en attente tant qu'il est en cours sur un autre run", EN: "The pipeline %s is waiting while it's running on another run"}, nil}
 	MsgWorkflowNodeMutexRelease            = &Message{"MsgWorkflowNodeMutexRelease", trad{FR: "Lancement du pipeline %s", EN: "Triggering pipeline %s"}, nil}
+	MsgWorkflowNodeWaitingApproval         = &Message{"MsgWorkflowNodeWaitingApproval", trad{FR: "Le pipeline %s est en attente de %d approbation(s) des groupes %s", EN: "The pipeline %s is waiting for %d approval(s) from groups %s"}, nil}
+	MsgWorkflowNodeApproved                = &Message{"MsgWorkflowNodeApproved", trad{FR: "Le pipeline a été approuvé par %s: %s", EN: "The pipeline has been approved by %s: %s"}, nil}
+	MsgWorkflowNodeRejected                = &Message{"MsgWorkflowNodeRejected", trad{FR: "Le pipeline a été rejeté par %s: %s", EN: "The pipeline has been rejected by %s: %s"}, nil}
+	MsgWorkflowNodeConcurrencyQueued       = &Message{"MsgWorkflowNodeConcurrencyQueued", trad{FR: "Le pipeline %s est mis en attente dans le groupe de concurrence %s", EN: "The pipeline %s is queued in the concurrency group %s"}, nil}
+	MsgWorkflowNodeConcurrencyRelease      = &Message{"MsgWorkflowNodeConcurrencyRelease", trad{FR: "Lancement du pipeline %s libéré par le groupe de concurrence %s", EN: "Triggering pipeline %s released by the concurrency group %s"}, nil}
+	MsgWorkflowNodeConcurrencyCancelled    = &Message{"MsgWorkflowNodeConcurrencyCancelled", trad{FR: "Le pipeline %s a été annulé par le workflow %s #%d dans le groupe de concurrence %s", EN: "The pipeline %s has been cancelled by workflow %s #%d in the concurrency group %s"}, nil}
+	MsgWorkflowRunSuperseded               = &Message{"MsgWorkflowRunSuperseded", trad{FR: "Le workflow a été arrêté car remplacé par l'exécution #%d sur la branche %s", EN: "The workflow has been stopped because it was superseded by run #%d on branch %s"}, nil}
 	MsgWorkflowImportedUpdated             = &Message{"MsgWorkflowImportedUpdated", trad{FR: "Le workflow %s a été mis à jour", EN: "Workflow %s has been updated"}, nil}
 	MsgWorkflowImportedInserted            = &Message{"MsgWorkflowImportedInserted", trad{FR: "Le workflow %s a été créé", EN: "Workflow %s has been created"}, nil}
 	MsgSpawnInfoHatcheryCannotStartJob     = &Message{"MsgSpawnInfoHatcheryCannotStart", trad{FR: "Aucune hatchery n'a pu démarrer de worker respectant vos pré-requis de job, merci de les vérifier.", EN: "No hatchery can spawn a worker corresponding your job's requirements. Please check your job's requirements."}, nil}
 	MsgWorkflowRunBranchDeleted            = &Message{"MsgWorkflowRunBranchDeleted", trad{FR: "La branche %s  a été supprimée", EN: "Branch %s has been deleted"}, nil}
 	MsgSpawnInfoDeprecatedModel            = &Message{"MsgSpawnInfoDeprecatedModel", trad{FR: "Attention vous utilisez un worker model (%s) déprécié", EN: "Pay attention you are using a deprecated worker model (%s)"}, nil}
+	MsgSpawnInfoJobTimeout                 = &Message{"MsgSpawnInfoJobTimeout", trad{FR: "Le job a dépassé son délai d'exécution de %s et a été mis en échec", EN: "Job has exceeded its timeout of %s and has been failed"}, nil}
+	MsgSpawnInfoWorkerDisappeared          = &Message{"MsgSpawnInfoWorkerDisappeared", trad{FR: "Le worker exécutant ce job a disparu après %d tentatives, le job a été mis en échec", EN: "The worker running this job has disappeared after %d attempts, the job has been failed"}, nil}
+	MsgSpawnInfoJobRetry                   = &Message{"MsgSpawnInfoJobRetry", trad{FR: "La tentative %d/%d du job a échoué (%s), nouvelle tentative dans %s", EN: "Job attempt %d/%d has failed (%s), retrying in %s"}, nil}
 )
 
 // Messages contains all sdk Messages
@@ -137,9 +147,19 @@ var Messages = map[string]*Message{
 	MsgWorkflowImportedInserted.ID:            MsgWorkflowImportedInserted,
 	MsgWorkflowNodeMutex.ID:                   MsgWorkflowNodeMutex,
 	MsgWorkflowNodeMutexRelease.ID:            MsgWorkflowNodeMutexRelease,
+	MsgWorkflowNodeWaitingApproval.ID:         MsgWorkflowNodeWaitingApproval,
+	MsgWorkflowNodeApproved.ID:                MsgWorkflowNodeApproved,
+	MsgWorkflowNodeRejected.ID:                MsgWorkflowNodeRejected,
+	MsgWorkflowNodeConcurrencyQueued.ID:       MsgWorkflowNodeConcurrencyQueued,
+	MsgWorkflowNodeConcurrencyRelease.ID:      MsgWorkflowNodeConcurrencyRelease,
+	MsgWorkflowNodeConcurrencyCancelled.ID:    MsgWorkflowNodeConcurrencyCancelled,
+	MsgWorkflowRunSuperseded.ID:               MsgWorkflowRunSuperseded,
 	MsgSpawnInfoHatcheryCannotStartJob.ID:     MsgSpawnInfoHatcheryCannotStartJob,
 	MsgWorkflowRunBranchDeleted.ID:            MsgWorkflowRunBranchDeleted,
 	MsgSpawnInfoDeprecatedModel.ID:            MsgSpawnInfoDeprecatedModel,
+	MsgSpawnInfoJobTimeout.ID:                 MsgSpawnInfoJobTimeout,
+	MsgSpawnInfoWorkerDisappeared.ID:          MsgSpawnInfoWorkerDisappeared,
+	MsgSpawnInfoJobRetry.ID:                   MsgSpawnInfoJobRetry,
 }
 
 //Message represent a struc format translated messages
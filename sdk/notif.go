@@ -2,8 +2,12 @@ package sdk
 
 //const
 const (
-	EmailUserNotification  = "email"
-	JabberUserNotification = "jabber"
+	EmailUserNotification      = "email"
+	JabberUserNotification     = "jabber"
+	SlackUserNotification      = "slack"
+	MattermostUserNotification = "mattermost"
+	TeamsUserNotification      = "teams"
+	VCSUserNotification        = "vcs"
 )
 
 //const
@@ -24,13 +28,25 @@ type UserNotification struct {
 
 // UserNotificationSettings are jabber or email settings
 type UserNotificationSettings struct {
-	OnSuccess    string                    `json:"on_success,omitempty" yaml:"on_success,omitempty"`         // default is "onChange", empty means onChange
-	OnFailure    string                    `json:"on_failure,omitempty" yaml:"on_failure,omitempty"`         // default is "always", empty means always
-	OnStart      *bool                     `json:"on_start,omitempty" yaml:"on_start,omitempty"`             // default is false, nil is false
-	SendToGroups *bool                     `json:"send_to_groups,omitempty" yaml:"send_to_groups,omitempty"` // default is false, nil is false
-	SendToAuthor *bool                     `json:"send_to_author,omitempty" yaml:"send_to_author,omitempty"` // default is true, nil is true
-	Recipients   []string                  `json:"recipients,omitempty" yaml:"recipients,omitempty"`
-	Template     *UserNotificationTemplate `json:"template,omitempty" yaml:"template,omitempty"`
+	OnSuccess     string                    `json:"on_success,omitempty" yaml:"on_success,omitempty"`         // default is "onChange", empty means onChange
+	OnFailure     string                    `json:"on_failure,omitempty" yaml:"on_failure,omitempty"`         // default is "always", empty means always
+	OnStart       *bool                     `json:"on_start,omitempty" yaml:"on_start,omitempty"`             // default is false, nil is false
+	SendToGroups  *bool                     `json:"send_to_groups,omitempty" yaml:"send_to_groups,omitempty"` // default is false, nil is false
+	SendToAuthor  *bool                     `json:"send_to_author,omitempty" yaml:"send_to_author,omitempty"` // default is true, nil is true
+	Recipients    []string                  `json:"recipients,omitempty" yaml:"recipients,omitempty"`
+	Template      *UserNotificationTemplate `json:"template,omitempty" yaml:"template,omitempty"`
+	WebhookURL    string                    `json:"webhook_url,omitempty" yaml:"webhook_url,omitempty"`       // incoming webhook of chat notifications, it is a secret
+	StatusContext string                    `json:"status_context,omitempty" yaml:"status_context,omitempty"` // context of the commit statuses of vcs notifications, default is CDS/<project>-<workflow>-<node>
+	PRComment     *bool                     `json:"pr_comment,omitempty" yaml:"pr_comment,omitempty"`         // comment the pull request with a summary of the node run, default is false, nil is false
+}
+
+// IsChatUserNotification returns true for the notification types posting on an incoming webhook
+func IsChatUserNotification(t string) bool {
+	switch t {
+	case SlackUserNotification, MattermostUserNotification, TeamsUserNotification:
+		return true
+	}
+	return false
 }
 
 // UserNotificationTemplate is the notification content
@@ -65,8 +81,32 @@ Branch : {{.git.branch | default "n/a"}}`,
 		Body:    `{{.cds.buildURL}}`,
 	}
 
+	// UserNotificationTemplateSlack is also used by Mattermost which understands the same markdown
+	UserNotificationTemplateSlack = UserNotificationTemplate{
+		Subject: "{{.cds.project}}/{{.cds.workflow}}#{{.cds.version}} {{.cds.status}}",
+		Body: `<{{.cds.buildURL}}|{{.cds.project}}/{{.cds.workflow}}#{{.cds.version}}> *{{.cds.node}}* is *{{.cds.status}}*
+Branch: {{.git.branch | default "n/a"}} - Triggered by: {{.cds.triggered_by.username}}
+Failed jobs: {{.cds.failed_jobs | default "none"}}
+{{.cds.commits}}`,
+	}
+
+	UserNotificationTemplateTeams = UserNotificationTemplate{
+		Subject: "{{.cds.project}}/{{.cds.workflow}}#{{.cds.version}} {{.cds.status}}",
+		Body: `[{{.cds.project}}/{{.cds.workflow}}#{{.cds.version}}]({{.cds.buildURL}}) **{{.cds.node}}** is **{{.cds.status}}**
+
+Branch: {{.git.branch | default "n/a"}} - Triggered by: {{.cds.triggered_by.username}}
+
+Failed jobs: {{.cds.failed_jobs | default "none"}}
+
+{{.cds.commits}}`,
+	}
+
 	UserNotificationTemplateMap = map[string]UserNotificationTemplate{
-		EmailUserNotification:  UserNotificationTemplateEmail,
-		JabberUserNotification: UserNotificationTemplateJabber,
+		EmailUserNotification:      UserNotificationTemplateEmail,
+		JabberUserNotification:     UserNotificationTemplateJabber,
+		SlackUserNotification:      UserNotificationTemplateSlack,
+		MattermostUserNotification: UserNotificationTemplateSlack,
+		TeamsUserNotification:      UserNotificationTemplateTeams,
+		VCSUserNotification:        {},
 	}
 )
@@ -177,7 +177,8 @@ func ParametersFromPlatform(ppf PlatformConfig) map[string]string {
 func VariablesToParameters(prefix string, variables []Variable) []Parameter {
 	res := make([]Parameter, 0, len(variables))
 	for _, t := range variables {
-		if NeedPlaceholder(t.Type) {
+		// Secrets and references to vault secrets are only sent to the worker with the job secrets
+		if NeedPlaceholder(t.Type) || t.Type == VaultVariable {
 			continue
 		}
 		if prefix != "" {
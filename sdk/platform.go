@@ -1,9 +1,12 @@
 package sdk
 
+import "time"
+
 // This is the buitin platform model
 const (
 	KafkaPlatformModel    = "Kafka"
 	RabbitMQPlatformModel = "RabbitMQ"
+	WebhookPlatformModel  = "Webhook"
 )
 
 // Here are the default plateform models
@@ -11,6 +14,7 @@ var (
 	BuiltinPlatformModels = []*PlatformModel{
 		&KafkaPlatform,
 		&RabbitMQPlatform,
+		&WebhookPlatform,
 	}
 	// KafkaPlatform represent a kafka platform
 	KafkaPlatform = PlatformModel{
@@ -31,6 +35,7 @@ var (
 		},
 		Disabled: false,
 		Hook:     true,
+		Event:    true,
 	}
 	// RabbitMQPlatform represent a kafka platform
 	RabbitMQPlatform = PlatformModel{
@@ -51,6 +56,24 @@ var (
 		},
 		Disabled: false,
 		Hook:     true,
+		Event:    true,
+	}
+	// WebhookPlatform represent an HTTP endpoint receiving events
+	WebhookPlatform = PlatformModel{
+		Name:       WebhookPlatformModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/platform/builtin/webhook",
+		Icon:       "",
+		DefaultConfig: PlatformConfig{
+			"url": PlatformConfigValue{
+				Type: PlatformConfigTypeString,
+			},
+			"secret": PlatformConfigValue{
+				Type: PlatformConfigTypePassword,
+			},
+		},
+		Disabled: false,
+		Event:    true,
 	}
 )
 
@@ -127,6 +150,7 @@ type PlatformModel struct {
 	BlockStorage            bool                      `json:"block_storage" db:"block_storage" yaml:"block_storage" cli:"block_storage supported"`
 	Deployment              bool                      `json:"deployment" db:"deployment" yaml:"deployment" cli:"deployment_supported"`
 	Compute                 bool                      `json:"compute" db:"compute" yaml:"compute" cli:"compute_supported"`
+	Event                   bool                      `json:"event" db:"event" yaml:"event" cli:"event_supported"`
 	Public                  bool                      `json:"public,omitempty" db:"public" yaml:"public,omitempty"`
 }
 
@@ -186,3 +210,32 @@ func (config *PlatformConfig) HideSecrets() {
 		}
 	}
 }
+
+// ProjectEventExport sends the events of a project to one of its platforms.
+// EventTypes filters the exported events, ie. EventRunWorkflow, an empty list exports all the events.
+// Topic is the Kafka topic or the RabbitMQ exchange, it is not used by webhooks.
+type ProjectEventExport struct {
+	ID                int64      `json:"id" db:"id" cli:"id,key"`
+	ProjectID         int64      `json:"project_id" db:"project_id" cli:"-"`
+	ProjectPlatformID int64      `json:"project_platform_id" db:"project_platform_id" cli:"-"`
+	PlatformName      string     `json:"platform_name" db:"-" cli:"platform"`
+	EventTypes        []string   `json:"event_types" db:"-" cli:"event_types"`
+	Topic             string     `json:"topic" db:"topic" cli:"topic"`
+	Enabled           bool       `json:"enabled" db:"enabled" cli:"enabled"`
+	LastDelivery      *time.Time `json:"last_delivery,omitempty" db:"last_delivery" cli:"last_delivery"`
+	LastStatus        string     `json:"last_status" db:"last_status" cli:"last_status"`
+	LastError         string     `json:"last_error,omitempty" db:"last_error" cli:"last_error"`
+	Delivered         int64      `json:"delivered" db:"delivered" cli:"delivered"`
+	Failed            int64      `json:"failed" db:"failed" cli:"failed"`
+}
+
+// IsValid checks the export against the model of its platform
+func (e ProjectEventExport) IsValid(pf ProjectPlatform) error {
+	if !pf.Model.Event {
+		return NewErrorFrom(ErrWrongRequest, "platform %s does not support events", pf.Name)
+	}
+	if e.Topic == "" && pf.Model.Name != WebhookPlatformModel {
+		return NewErrorFrom(ErrWrongRequest, "a topic is required to export events on %s", pf.Name)
+	}
+	return nil
+}
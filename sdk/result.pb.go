@@ -27,6 +27,7 @@ type Result struct {
 	Reason     string                     `protobuf:"bytes,5,opt,name=reason" json:"reason,omitempty"`
 	RemoteTime *google_protobuf.Timestamp `protobuf:"bytes,6,opt,name=remoteTime" json:"remoteTime,omitempty"`
 	Duration   string                     `protobuf:"bytes,7,opt,name=duration" json:"duration,omitempty"`
+	ExitCode   int64                      `protobuf:"varint,8,opt,name=exitCode" json:"exitCode,omitempty"`
 }
 
 func (m *Result) Reset()                    { *m = Result{} }
@@ -83,6 +84,13 @@ func (m *Result) GetDuration() string {
 	return ""
 }
 
+func (m *Result) GetExitCode() int64 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Result)(nil), "github.com.ovh.cds.sdk.Result")
 }
@@ -90,20 +98,20 @@ func init() {
 func init() { proto.RegisterFile("result.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 228 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x4c, 0x8e, 0x31, 0x4f, 0xc3, 0x30,
-	0x10, 0x85, 0xe5, 0x84, 0xa6, 0x60, 0x10, 0x83, 0x87, 0xca, 0xca, 0x42, 0xc4, 0x94, 0xc9, 0x95,
-	0x60, 0x63, 0x44, 0x2c, 0xac, 0x51, 0x27, 0xb6, 0xa4, 0x3e, 0x52, 0xab, 0x71, 0xaf, 0xf2, 0x9d,
-	0xfb, 0x4b, 0xf9, 0x41, 0x28, 0x76, 0x83, 0x18, 0xbf, 0xd3, 0xfb, 0xde, 0x3d, 0xf9, 0x10, 0x80,
-	0xe2, 0xc4, 0xe6, 0x1c, 0x90, 0x51, 0x6d, 0x46, 0xc7, 0x87, 0x38, 0x98, 0x3d, 0x7a, 0x83, 0x97,
-	0x83, 0xd9, 0x5b, 0x32, 0x64, 0x8f, 0xf5, 0xd3, 0x88, 0x38, 0x4e, 0xb0, 0x4d, 0xa9, 0x21, 0x7e,
-	0x6f, 0xd9, 0x79, 0x20, 0xee, 0xfd, 0x39, 0x8b, 0xcf, 0x3f, 0x42, 0x56, 0x5d, 0x6a, 0x52, 0x8f,
-	0xb2, 0x70, 0x56, 0x8b, 0x46, 0xb4, 0x65, 0x57, 0x38, 0xab, 0xb4, 0x5c, 0x0f, 0xd1, 0x4d, 0xf6,
-	0xf3, 0x43, 0x17, 0xe9, 0xb8, 0xa0, 0xda, 0xc8, 0x8a, 0xb8, 0xe7, 0x48, 0xba, 0x6c, 0x44, 0x7b,
-	0xd7, 0x5d, 0x69, 0x36, 0x2e, 0x10, 0xc8, 0xe1, 0x49, 0xdf, 0x64, 0xe3, 0x8a, 0xb3, 0x11, 0xa0,
-	0x27, 0x3c, 0xe9, 0x55, 0x36, 0x32, 0xa9, 0x37, 0x29, 0x03, 0x78, 0x64, 0xd8, 0x39, 0x0f, 0xba,
-	0x6a, 0x44, 0x7b, 0xff, 0x52, 0x9b, 0x3c, 0xda, 0x2c, 0xa3, 0xcd, 0x6e, 0x19, 0xdd, 0xfd, 0x4b,
-	0xab, 0x5a, 0xde, 0xda, 0x18, 0x7a, 0x9e, 0xdf, 0xad, 0x53, 0xeb, 0x1f, 0xbf, 0xaf, 0xbe, 0x4a,
-	0xb2, 0xc7, 0xa1, 0x4a, 0x15, 0xaf, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x6e, 0xb8, 0xaf, 0xc7,
-	0x2d, 0x01, 0x00, 0x00,
+	// 237 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x8e, 0x31, 0x4f, 0xc3, 0x30,
+	0x10, 0x85, 0x95, 0x84, 0xa6, 0xc5, 0x20, 0x06, 0x0f, 0x95, 0x95, 0x85, 0x88, 0x29, 0x93, 0x2b,
+	0xc1, 0xc6, 0x08, 0x2c, 0xac, 0x51, 0x27, 0xb6, 0xa4, 0x3e, 0x52, 0xab, 0x71, 0xaf, 0xf2, 0x9d,
+	0x2b, 0x7e, 0x36, 0x3f, 0x01, 0xc5, 0x6e, 0x2a, 0xc6, 0xcf, 0x7e, 0xdf, 0xbb, 0x27, 0xee, 0x3d,
+	0x50, 0x18, 0x59, 0x9f, 0x3c, 0x32, 0xca, 0xf5, 0x60, 0x79, 0x1f, 0x7a, 0xbd, 0x43, 0xa7, 0xf1,
+	0xbc, 0xd7, 0x3b, 0x43, 0x9a, 0xcc, 0xa1, 0x7a, 0x1c, 0x10, 0x87, 0x11, 0x36, 0x31, 0xd5, 0x87,
+	0xef, 0x0d, 0x5b, 0x07, 0xc4, 0x9d, 0x3b, 0x25, 0xf1, 0xe9, 0x37, 0x13, 0x65, 0x1b, 0x9b, 0xe4,
+	0x83, 0xc8, 0xad, 0x51, 0x59, 0x9d, 0x35, 0x45, 0x9b, 0x5b, 0x23, 0x95, 0x58, 0xf6, 0xc1, 0x8e,
+	0xe6, 0xf3, 0x43, 0xe5, 0xf1, 0x71, 0x46, 0xb9, 0x16, 0x25, 0x71, 0xc7, 0x81, 0x54, 0x51, 0x67,
+	0xcd, 0x6d, 0x7b, 0xa1, 0xc9, 0x38, 0x83, 0x27, 0x8b, 0x47, 0x75, 0x93, 0x8c, 0x0b, 0x4e, 0x86,
+	0x87, 0x8e, 0xf0, 0xa8, 0x16, 0xc9, 0x48, 0x24, 0x5f, 0x85, 0xf0, 0xe0, 0x90, 0x61, 0x6b, 0x1d,
+	0xa8, 0xb2, 0xce, 0x9a, 0xbb, 0xe7, 0x4a, 0xa7, 0xd1, 0x7a, 0x1e, 0xad, 0xb7, 0xf3, 0xe8, 0xf6,
+	0x5f, 0x5a, 0x56, 0x62, 0x65, 0x82, 0xef, 0x78, 0x3a, 0xb7, 0x8c, 0xad, 0x57, 0x9e, 0xfe, 0xe0,
+	0xc7, 0xf2, 0x3b, 0x1a, 0x50, 0xab, 0x38, 0xe5, 0xca, 0x6f, 0x8b, 0xaf, 0x82, 0xcc, 0xa1, 0x2f,
+	0x63, 0xfd, 0xcb, 0xdf, 0x00, 0xfb, 0xe7, 0x4f, 0xb2, 0x49, 0x01, 0x00, 0x00,
 }
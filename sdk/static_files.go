@@ -12,7 +12,7 @@ type StaticFiles struct {
 	ID           int64     `json:"id" db:"id" cli:"id"`
 	Name         string    `json:"name" db:"name" cli:"name"`
 	NodeRunID    int64     `json:"workflow_node_run_id" db:"workflow_node_run_id"`
-	NodeJobRunID int64     `json:"workflow_node_run_job_id,omitempty" db:"-"`
+	NodeJobRunID int64     `json:"workflow_node_run_job_id,omitempty" db:"workflow_node_run_job_id"`
 	EntryPoint   string    `json:"entrypoint" db:"entrypoint"`
 	PublicURL    string    `json:"public_url" db:"public_url" cli:"public_url"`
 	Created      time.Time `json:"created" db:"created" cli:"created"`
@@ -58,6 +58,41 @@ type UserLoginRequest struct {
 	Password string `json:"password"`
 }
 
+// UserOIDCRequest is the redirection to the OpenID Connect provider for an authorization code login
+type UserOIDCRequest struct {
+	URL   string `json:"url"`
+	State string `json:"state"`
+}
+
+// UserOIDCLoginRequest login request with an OpenID Connect authorization code
+type UserOIDCLoginRequest struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+// UserOIDCDeviceAuthorization is a pending OpenID Connect device authorization
+type UserOIDCDeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval,omitempty"`
+}
+
+// UserOIDCDeviceLoginRequest login request with an OpenID Connect device code
+type UserOIDCDeviceLoginRequest struct {
+	DeviceCode string `json:"device_code"`
+}
+
+// UserOIDCLink links a user to an OpenID Connect identity, identified by the issuer and the subject of its ID tokens
+type UserOIDCLink struct {
+	ID      int64  `json:"id" db:"id"`
+	UserID  int64  `json:"user_id" db:"user_id"`
+	Issuer  string `json:"issuer" db:"issuer"`
+	Subject string `json:"subject" db:"subject"`
+}
+
 // UserAPIResponse  response from rest API
 type UserAPIResponse struct {
 	User     User   `json:"user"`
@@ -1,6 +1,9 @@
 package sdk
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Variable represent a variable for a project or pipeline
 type Variable struct {
@@ -35,6 +38,7 @@ const (
 	BooleanVariable    = "boolean"
 	NumberVariable     = "number"
 	RepositoryVariable = "repository"
+	VaultVariable      = "vault"
 )
 
 var (
@@ -46,6 +50,7 @@ var (
 		KeyVariable,
 		BooleanVariable,
 		NumberVariable,
+		VaultVariable,
 	}
 )
 
@@ -59,6 +64,32 @@ func NeedPlaceholder(t string) bool {
 	}
 }
 
+// VaultReferenceDefaultKey is the key read in a vault secret when a vault variable does not specify one
+const VaultReferenceDefaultKey = "data"
+
+// ParseVaultReference returns the path and the key of the secret referenced by a vault variable.
+// The value of a vault variable is formatted as path#key, for example secret/data/myapp#password.
+func ParseVaultReference(ref string) (string, string, error) {
+	path, key := ref, VaultReferenceDefaultKey
+	if i := strings.LastIndex(ref, "#"); i != -1 {
+		path, key = ref[:i], ref[i+1:]
+	}
+	path = strings.Trim(strings.TrimSpace(path), "/")
+	if path == "" || key == "" {
+		return "", "", NewErrorFrom(ErrInvalidVaultReference, "invalid vault reference %q", ref)
+	}
+	return path, key, nil
+}
+
+// CheckVaultVariable checks the reference of a vault variable, other variables are ignored
+func CheckVaultVariable(v Variable) error {
+	if v.Type != VaultVariable {
+		return nil
+	}
+	_, _, err := ParseVaultReference(v.Value)
+	return err
+}
+
 // VariableFind return a variable given its name if it exists in array
 func VariableFind(vars []Variable, s string) *Variable {
 	for _, v := range vars {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 )
 
@@ -84,8 +85,12 @@ func GetDefaultBranch(branches []VCSBranch) VCSBranch {
 	return VCSBranch{}
 }
 
-// VCSCommitStatusDescription return a node formated status description
+// VCSCommitStatusDescription return a node formated status description, the status context
+// of a vcs notification is always prefixed by CDS/ to be recognized as a CDS status
 func VCSCommitStatusDescription(projKey, workflowName string, evt EventRunWorkflowNode) string {
+	if evt.StatusContext != "" {
+		return "CDS/" + strings.TrimPrefix(evt.StatusContext, "CDS/")
+	}
 	key := fmt.Sprintf("%s-%s-%s",
 		projKey,
 		workflowName,
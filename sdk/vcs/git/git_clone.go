@@ -19,6 +19,8 @@ type CloneOpts struct {
 	Quiet                   bool
 	CheckoutCommit          string
 	NoStrictHostKeyChecking bool
+	// FetchRef is fetched before the checkout of the commit, it is used for the commits which are not on a branch like gerrit changes
+	FetchRef string
 }
 
 // Clone make a git clone
@@ -89,7 +91,6 @@ func prepareGitCloneCommands(repo string, path string, opts *CloneOpts) (string,
 			cmd:  "git",
 			args: []string{"reset", "--hard", opts.CheckoutCommit},
 		}
-		userLogCommand += "\n\rExecuting: git " + strings.Join(resetCmd.args, " ")
 		//Locate the git reset cmd to the right directory
 		if path == "" {
 			t := strings.Split(repo, "/")
@@ -98,6 +99,17 @@ func prepareGitCloneCommands(repo string, path string, opts *CloneOpts) (string,
 			resetCmd.dir = path
 		}
 
+		if opts.FetchRef != "" {
+			fetchCmd := cmd{
+				cmd:  "git",
+				args: []string{"fetch", "origin", opts.FetchRef},
+				dir:  resetCmd.dir,
+			}
+			userLogCommand += "\n\rExecuting: git " + strings.Join(fetchCmd.args, " ")
+			allCmd = append(allCmd, fetchCmd)
+		}
+
+		userLogCommand += "\n\rExecuting: git " + strings.Join(resetCmd.args, " ")
 		allCmd = append(allCmd, resetCmd)
 	}
 
@@ -38,6 +38,8 @@ type Workflow struct {
 	Usage                   *Usage                      `json:"usage,omitempty" db:"-" cli:"-"`
 	HistoryLength           int64                       `json:"history_length" db:"history_length" cli:"-"`
 	PurgeTags               []string                    `json:"purge_tags,omitempty" db:"-" cli:"-"`
+	RetentionPolicy         *WorkflowRetentionPolicy    `json:"retention_policy,omitempty" db:"-" cli:"-"`
+	AutoCancelSuperseded    bool                        `json:"auto_cancel_superseded" db:"-" cli:"-"`
 	Notifications           []WorkflowNotification      `json:"notifications,omitempty" db:"-" cli:"-"`
 	FromRepository          string                      `json:"from_repository,omitempty" db:"from_repository" cli:"from"`
 	DerivedFromWorkflowID   int64                       `json:"derived_from_workflow_id,omitempty" db:"derived_from_workflow_id" cli:"-"`
@@ -689,6 +691,8 @@ func (n Node) retroMigrate() WorkflowNode {
 			DefaultPipelineParameters: n.Context.DefaultPipelineParameters,
 			DefaultPayload:            n.Context.DefaultPayload,
 			Mutex:                     n.Context.Mutex,
+			Approval:                  n.Context.Approval,
+			Concurrency:               n.Context.Concurrency,
 			Conditions:                n.Context.Conditions,
 		},
 		PipelineID:    n.Context.PipelineID,
@@ -809,6 +813,8 @@ func (n WorkflowNode) migrate(withID bool) Node {
 			DefaultPayload:            n.Context.DefaultPayload,
 			DefaultPipelineParameters: n.Context.DefaultPipelineParameters,
 			Mutex:                     n.Context.Mutex,
+			Approval:                  n.Context.Approval,
+			Concurrency:               n.Context.Concurrency,
 		},
 		Hooks:    make([]NodeHook, 0, len(n.Hooks)),
 		Triggers: make([]NodeTrigger, 0, len(n.Triggers)+len(n.Forks)+len(n.OutgoingHooks)),
@@ -1466,9 +1472,10 @@ type WorkflowNodeOutgoingHookTrigger struct {
 	WorkflowDestNode           WorkflowNode `json:"workflow_dest_node" db:"-"`
 }
 
-//WorkflowNodeConditions is either an array of WorkflowNodeCondition or a lua script
+//WorkflowNodeConditions is either an array of WorkflowNodeCondition, a condition expression or a lua script
 type WorkflowNodeConditions struct {
 	PlainConditions []WorkflowNodeCondition `json:"plain,omitempty" yaml:"check,omitempty"`
+	Expression      string                  `json:"expression,omitempty" yaml:"expression,omitempty"`
 	LuaScript       string                  `json:"lua_script,omitempty" yaml:"script,omitempty"`
 }
 
@@ -1481,18 +1488,20 @@ type WorkflowNodeCondition struct {
 
 //WorkflowNodeContext represents a context attached on a node
 type WorkflowNodeContext struct {
-	ID                        int64                  `json:"id" db:"id"`
-	WorkflowNodeID            int64                  `json:"workflow_node_id" db:"workflow_node_id"`
-	ApplicationID             int64                  `json:"application_id" db:"application_id"`
-	Application               *Application           `json:"application,omitempty" db:"-"`
-	Environment               *Environment           `json:"environment,omitempty" db:"-"`
-	EnvironmentID             int64                  `json:"environment_id" db:"environment_id"`
-	ProjectPlatform           *ProjectPlatform       `json:"project_platform" db:"-"`
-	ProjectPlatformID         int64                  `json:"project_platform_id" db:"project_platform_id"`
-	DefaultPayload            interface{}            `json:"default_payload,omitempty" db:"-"`
-	DefaultPipelineParameters []Parameter            `json:"default_pipeline_parameters,omitempty" db:"-"`
-	Conditions                WorkflowNodeConditions `json:"conditions,omitempty" db:"-"`
-	Mutex                     bool                   `json:"mutex"`
+	ID                        int64                    `json:"id" db:"id"`
+	WorkflowNodeID            int64                    `json:"workflow_node_id" db:"workflow_node_id"`
+	ApplicationID             int64                    `json:"application_id" db:"application_id"`
+	Application               *Application             `json:"application,omitempty" db:"-"`
+	Environment               *Environment             `json:"environment,omitempty" db:"-"`
+	EnvironmentID             int64                    `json:"environment_id" db:"environment_id"`
+	ProjectPlatform           *ProjectPlatform         `json:"project_platform" db:"-"`
+	ProjectPlatformID         int64                    `json:"project_platform_id" db:"project_platform_id"`
+	DefaultPayload            interface{}              `json:"default_payload,omitempty" db:"-"`
+	DefaultPipelineParameters []Parameter              `json:"default_pipeline_parameters,omitempty" db:"-"`
+	Conditions                WorkflowNodeConditions   `json:"conditions,omitempty" db:"-"`
+	Mutex                     bool                     `json:"mutex"`
+	Approval                  *WorkflowNodeApproval    `json:"approval,omitempty" db:"-"`
+	Concurrency               *WorkflowNodeConcurrency `json:"concurrency,omitempty" db:"-"`
 }
 
 // HasDefaultPayload returns true if the node has a default payload
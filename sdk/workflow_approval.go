@@ -0,0 +1,92 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WorkflowNodeApproval is a gate on a workflow node: the node run waits until
+// RequiredApprovals members of the given groups approve it, or one of them rejects it
+type WorkflowNodeApproval struct {
+	Groups            []string `json:"groups" yaml:"groups"`
+	RequiredApprovals int64    `json:"required_approvals" yaml:"required_approvals,omitempty"`
+}
+
+// IsValid checks the approval gate values
+func (a WorkflowNodeApproval) IsValid() error {
+	if len(a.Groups) == 0 {
+		return NewError(ErrWorkflowInvalid, fmt.Errorf("Invalid approval gate: at least one group is mandatory"))
+	}
+	for _, g := range a.Groups {
+		if strings.TrimSpace(g) == "" {
+			return NewError(ErrWorkflowInvalid, fmt.Errorf("Invalid approval gate: group name is mandatory"))
+		}
+	}
+	if a.RequiredApprovals < 0 {
+		return NewError(ErrWorkflowInvalid, fmt.Errorf("Invalid approval gate: required approvals must be positive"))
+	}
+	return nil
+}
+
+// Required returns the number of approvals needed to open the gate, at least one
+func (a WorkflowNodeApproval) Required() int64 {
+	if a.RequiredApprovals < 1 {
+		return 1
+	}
+	return a.RequiredApprovals
+}
+
+// IsApprover returns true if the user is a member of one of the approval groups
+func (a WorkflowNodeApproval) IsApprover(u User) bool {
+	for _, g := range u.Groups {
+		for _, name := range a.Groups {
+			if g.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WorkflowNodeRunApproval is an approval or a rejection given on a node run waiting for approval
+type WorkflowNodeRunApproval struct {
+	Username string    `json:"username"`
+	Fullname string    `json:"fullname"`
+	Approved bool      `json:"approved"`
+	Comment  string    `json:"comment,omitempty"`
+	Date     time.Time `json:"date"`
+}
+
+// WorkflowNodeRunApprovalRequest is the body sent to approve or reject a node run
+type WorkflowNodeRunApprovalRequest struct {
+	Approved bool   `json:"approved"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// HasApproved returns true if the user already gave its approval or rejection on the node run
+func (nr WorkflowNodeRun) HasApproved(username string) bool {
+	for _, a := range nr.Approvals {
+		if a.Username == username {
+			return true
+		}
+	}
+	return false
+}
+
+// ApprovalStatus computes the status of a node run gate from its approvals:
+// StatusStopped if one approver rejected it, StatusWaiting if it has been approved enough
+// to be executed, StatusWaitingApproval otherwise
+func (nr WorkflowNodeRun) ApprovalStatus(a WorkflowNodeApproval) Status {
+	var nb int64
+	for _, ap := range nr.Approvals {
+		if !ap.Approved {
+			return StatusStopped
+		}
+		nb++
+	}
+	if nb >= a.Required() {
+		return StatusWaiting
+	}
+	return StatusWaitingApproval
+}
@@ -0,0 +1,40 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowNodeRunApprovalStatus(t *testing.T) {
+	a := WorkflowNodeApproval{Groups: []string{"ops"}, RequiredApprovals: 2}
+	nr := WorkflowNodeRun{}
+	assert.Equal(t, StatusWaitingApproval, nr.ApprovalStatus(a))
+
+	nr.Approvals = append(nr.Approvals, WorkflowNodeRunApproval{Username: "alice", Approved: true})
+	assert.Equal(t, StatusWaitingApproval, nr.ApprovalStatus(a))
+	assert.True(t, nr.HasApproved("alice"))
+	assert.False(t, nr.HasApproved("bob"))
+
+	nr.Approvals = append(nr.Approvals, WorkflowNodeRunApproval{Username: "bob", Approved: true})
+	assert.Equal(t, StatusWaiting, nr.ApprovalStatus(a))
+
+	// one rejection stops the node run
+	nr.Approvals = append(nr.Approvals, WorkflowNodeRunApproval{Username: "carol", Approved: false})
+	assert.Equal(t, StatusStopped, nr.ApprovalStatus(a))
+
+	// at least one approval is required
+	nr.Approvals = []WorkflowNodeRunApproval{{Username: "alice", Approved: true}}
+	assert.Equal(t, StatusWaiting, nr.ApprovalStatus(WorkflowNodeApproval{Groups: []string{"ops"}}))
+}
+
+func TestWorkflowNodeApprovalIsApprover(t *testing.T) {
+	a := WorkflowNodeApproval{Groups: []string{"ops", "release-managers"}}
+	assert.True(t, a.IsApprover(User{Groups: []Group{{Name: "dev"}, {Name: "ops"}}}))
+	assert.False(t, a.IsApprover(User{Groups: []Group{{Name: "dev"}}}))
+	assert.False(t, a.IsApprover(User{Admin: true}))
+
+	assert.NoError(t, a.IsValid())
+	assert.Error(t, WorkflowNodeApproval{}.IsValid())
+	assert.Error(t, WorkflowNodeApproval{Groups: []string{"ops"}, RequiredApprovals: -1}.IsValid())
+}
@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Concurrency policies of a concurrency group
+const (
+	// ConcurrencyPolicyQueue queues the runs until the group has a free slot
+	ConcurrencyPolicyQueue = "queue"
+	// ConcurrencyPolicyCancelPending cancels the older pending runs of the group when a new run arrives
+	ConcurrencyPolicyCancelPending = "cancel-pending"
+	// ConcurrencyPolicyCancelInProgress cancels the older pending and in progress runs of the group
+	// on the same branch when a run of a newer commit arrives
+	ConcurrencyPolicyCancelInProgress = "cancel-in-progress"
+)
+
+var concurrencyGroupPattern = regexp.MustCompile("^[a-zA-Z0-9._-]{1,256}$")
+
+// WorkflowNodeConcurrency puts a workflow node in a named concurrency group shared by all the workflows of a project.
+// At most Limit runs of the nodes of the group are executed at the same time.
+type WorkflowNodeConcurrency struct {
+	Group  string `json:"group" yaml:"group"`
+	Limit  int64  `json:"limit,omitempty" yaml:"limit,omitempty"`
+	Policy string `json:"policy,omitempty" yaml:"policy,omitempty"`
+}
+
+// IsValid checks the concurrency group values
+func (c WorkflowNodeConcurrency) IsValid() error {
+	if !concurrencyGroupPattern.MatchString(c.Group) {
+		return NewError(ErrWorkflowInvalid, fmt.Errorf("Invalid concurrency group %q: allowed pattern is %s", c.Group, concurrencyGroupPattern.String()))
+	}
+	if c.Limit < 0 {
+		return NewError(ErrWorkflowInvalid, fmt.Errorf("Invalid concurrency group %s: limit must be positive", c.Group))
+	}
+	switch c.Policy {
+	case "", ConcurrencyPolicyQueue, ConcurrencyPolicyCancelPending, ConcurrencyPolicyCancelInProgress:
+	default:
+		return NewError(ErrWorkflowInvalid, fmt.Errorf("Invalid concurrency group %s: unknown policy %s", c.Group, c.Policy))
+	}
+	return nil
+}
+
+// MaxRunning returns the number of runs of the group executed at the same time, at least one
+func (c WorkflowNodeConcurrency) MaxRunning() int64 {
+	if c.Limit < 1 {
+		return 1
+	}
+	return c.Limit
+}
@@ -0,0 +1,22 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowNodeConcurrencyIsValid(t *testing.T) {
+	assert.NoError(t, WorkflowNodeConcurrency{Group: "staging-env"}.IsValid())
+	assert.NoError(t, WorkflowNodeConcurrency{Group: "prod.eu_1", Limit: 2, Policy: ConcurrencyPolicyCancelInProgress}.IsValid())
+
+	assert.Error(t, WorkflowNodeConcurrency{}.IsValid())
+	assert.Error(t, WorkflowNodeConcurrency{Group: "staging env"}.IsValid())
+	assert.Error(t, WorkflowNodeConcurrency{Group: "staging", Limit: -1}.IsValid())
+	assert.Error(t, WorkflowNodeConcurrency{Group: "staging", Policy: "cancel-all"}.IsValid())
+}
+
+func TestWorkflowNodeConcurrencyMaxRunning(t *testing.T) {
+	assert.Equal(t, int64(1), WorkflowNodeConcurrency{Group: "staging"}.MaxRunning())
+	assert.Equal(t, int64(3), WorkflowNodeConcurrency{Group: "staging", Limit: 3}.MaxRunning())
+}
@@ -2,9 +2,9 @@ package sdk
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 
+	"github.com/ovh/cds/sdk/expression"
 	"github.com/ovh/cds/sdk/interpolate"
 )
 
@@ -32,52 +32,87 @@ var (
 	}
 )
 
-//WorkflowCheckConditions checks conditions given a list of parameters
-func WorkflowCheckConditions(conditions []WorkflowNodeCondition, params []Parameter) (bool, error) {
-	mapParams := ParametersToMap(params)
-	for k, v := range mapParams {
-		var err error
-		mapParams[k], err = interpolate.Do(v, mapParams)
-		if err != nil {
-			return false, fmt.Errorf("Unable to interpolate %s (%v)", v, err)
+// WorkflowConditionsExpressionOperators maps plain condition operators to expression operators
+var WorkflowConditionsExpressionOperators = map[string]string{
+	WorkflowConditionsOperatorEquals:             "==",
+	WorkflowConditionsOperatorNotEquals:          "!=",
+	WorkflowConditionsOperatorLessThan:           "<",
+	WorkflowConditionsOperatorLessOrEqualThan:    "<=",
+	WorkflowConditionsOperatorGreaterThan:        ">",
+	WorkflowConditionsOperatorGreaterOrEqualThan: ">=",
+	WorkflowConditionsOperatorRegex:              "matches",
+}
+
+// IsValid checks operators of plain conditions and the syntax of the condition expression
+func (c WorkflowNodeConditions) IsValid() error {
+	for _, cond := range c.PlainConditions {
+		if _, ok := WorkflowConditionsOperators[cond.Operator]; !ok {
+			return NewErrorFrom(ErrWorkflowConditionBadOperator, "unknown operator %s on variable %s", cond.Operator, cond.Variable)
 		}
 	}
+	if c.Expression != "" {
+		if err := expression.Validate(c.Expression); err != nil {
+			return NewErrorFrom(ErrWorkflowConditionBadExpression, "%v", err)
+		}
+	}
+	return nil
+}
 
-	var conditionsOK = true
+// WorkflowConditionsToExpression converts plain conditions to the equivalent condition expression.
+// Values are quoted, so they have to be interpolated before.
+func WorkflowConditionsToExpression(conditions []WorkflowNodeCondition) string {
+	var exprs []string
 	for _, cond := range conditions {
+		op, ok := WorkflowConditionsExpressionOperators[cond.Operator]
+		if !ok {
+			continue
+		}
+		exprs = append(exprs, fmt.Sprintf("var(%s) %s %s", expression.Quote(cond.Variable), op, expression.Quote(cond.Value)))
+	}
+	return strings.Join(exprs, " && ")
+}
+
+//WorkflowCheckConditions checks conditions given a list of parameters
+func WorkflowCheckConditions(conditions []WorkflowNodeCondition, params []Parameter) (bool, error) {
+	mapParams, err := interpolateConditionsParameters(params)
+	if err != nil {
+		return false, err
+	}
+
+	interpolated := make([]WorkflowNodeCondition, len(conditions))
+	for i, cond := range conditions {
 		var err error
 		cond.Value, err = interpolate.Do(cond.Value, mapParams)
 		if err != nil {
 			return false, fmt.Errorf("Unable to interpolate %s (%v)", cond.Value, err)
 		}
+		interpolated[i] = cond
+	}
 
-		switch cond.Operator {
-		case WorkflowConditionsOperatorEquals:
-			conditionsOK = conditionsOK && cond.Value == mapParams[cond.Variable]
-
-		case WorkflowConditionsOperatorNotEquals:
-			conditionsOK = conditionsOK && cond.Value != mapParams[cond.Variable]
-
-		case WorkflowConditionsOperatorLessThan:
-			conditionsOK = conditionsOK && strings.Compare(mapParams[cond.Variable], cond.Value) < 0
-
-		case WorkflowConditionsOperatorLessOrEqualThan:
-			conditionsOK = conditionsOK && strings.Compare(mapParams[cond.Variable], cond.Value) <= 0
-
-		case WorkflowConditionsOperatorGreaterThan:
-			conditionsOK = conditionsOK && strings.Compare(mapParams[cond.Variable], cond.Value) > 0
+	expr := WorkflowConditionsToExpression(interpolated)
+	if expr == "" {
+		return true, nil
+	}
+	return expression.Eval(expr, mapParams)
+}
 
-		case WorkflowConditionsOperatorGreaterOrEqualThan:
-			conditionsOK = conditionsOK && strings.Compare(mapParams[cond.Variable], cond.Value) >= 0
+// WorkflowCheckConditionsExpression evaluates a condition expression given a list of parameters
+func WorkflowCheckConditionsExpression(expr string, params []Parameter) (bool, error) {
+	mapParams, err := interpolateConditionsParameters(params)
+	if err != nil {
+		return false, err
+	}
+	return expression.Eval(expr, mapParams)
+}
 
-		case WorkflowConditionsOperatorRegex:
-			match, err := regexp.MatchString(cond.Value, mapParams[cond.Variable])
-			if err != nil {
-				return false, fmt.Errorf("Unable to match string with regex %s (%v)", cond.Value, err)
-			}
-			conditionsOK = conditionsOK && match
+func interpolateConditionsParameters(params []Parameter) (map[string]string, error) {
+	mapParams := ParametersToMap(params)
+	for k, v := range mapParams {
+		var err error
+		mapParams[k], err = interpolate.Do(v, mapParams)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to interpolate %s (%v)", v, err)
 		}
 	}
-
-	return conditionsOK, nil
+	return mapParams, nil
 }
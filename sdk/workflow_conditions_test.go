@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowCheckConditions(t *testing.T) {
+	params := []Parameter{
+		{Name: "git.branch", Type: StringParameter, Value: "master"},
+		{Name: "cds.version", Type: StringParameter, Value: "10"},
+		{Name: "app.version", Type: StringParameter, Value: "1.10.0"},
+		{Name: "cds.pip.branch", Type: StringParameter, Value: "{{.git.branch}}"},
+	}
+	tests := []struct {
+		name       string
+		conditions []WorkflowNodeCondition
+		want       bool
+	}{
+		{name: "no conditions", want: true},
+		{name: "equals", conditions: []WorkflowNodeCondition{{Variable: "git.branch", Operator: WorkflowConditionsOperatorEquals, Value: "master"}}, want: true},
+		{name: "interpolated value", conditions: []WorkflowNodeCondition{{Variable: "cds.pip.branch", Operator: WorkflowConditionsOperatorEquals, Value: "{{.git.branch}}"}}, want: true},
+		{name: "numeric comparison", conditions: []WorkflowNodeCondition{{Variable: "cds.version", Operator: WorkflowConditionsOperatorGreaterThan, Value: "9"}}, want: true},
+		{name: "semver comparison", conditions: []WorkflowNodeCondition{{Variable: "app.version", Operator: WorkflowConditionsOperatorGreaterOrEqualThan, Value: "1.9.0"}}, want: true},
+		{name: "regex", conditions: []WorkflowNodeCondition{{Variable: "git.branch", Operator: WorkflowConditionsOperatorRegex, Value: "^feat/.*"}}, want: false},
+		{name: "all conditions must match", conditions: []WorkflowNodeCondition{
+			{Variable: "git.branch", Operator: WorkflowConditionsOperatorNotEquals, Value: "master"},
+			{Variable: "cds.version", Operator: WorkflowConditionsOperatorLessThan, Value: "11"},
+		}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WorkflowCheckConditions(tt.conditions, params)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWorkflowNodeConditionsIsValid(t *testing.T) {
+	assert.NoError(t, WorkflowNodeConditions{Expression: `git.branch == "master" && payload.env in ["prod"]`}.IsValid())
+	assert.Error(t, WorkflowNodeConditions{PlainConditions: []WorkflowNodeCondition{{Variable: "a", Operator: "like"}}}.IsValid())
+
+	err := WorkflowNodeConditions{Expression: `git.branch == `}.IsValid()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "position 15")
+}
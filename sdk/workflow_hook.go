@@ -5,6 +5,7 @@ const (
 	GitlabIcon    = "Gitlab"
 	GitHubIcon    = "Github"
 	BitbucketIcon = "Bitbucket"
+	GiteaIcon     = "Git"
 )
 
 // FilterHooksConfig filter all hooks configuration and remove some configuration key
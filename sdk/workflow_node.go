@@ -42,20 +42,22 @@ type NodeHook struct {
 
 // NodeContext represents a node linked to a pipeline
 type NodeContext struct {
-	ID                        int64                  `json:"id" db:"id"`
-	NodeID                    int64                  `json:"node_id" db:"node_id"`
-	PipelineID                int64                  `json:"pipeline_id" db:"pipeline_id"`
-	PipelineName              string                 `json:"-" db:"-"`
-	ApplicationID             int64                  `json:"application_id" db:"application_id"`
-	ApplicationName           string                 `json:"-" db:"-"`
-	EnvironmentID             int64                  `json:"environment_id" db:"environment_id"`
-	EnvironmentName           string                 `json:"-" db:"-"`
-	ProjectPlatformID         int64                  `json:"project_platform_id" db:"project_platform_id"`
-	ProjectPlatformName       string                 `json:"-" db:"-"`
-	DefaultPayload            interface{}            `json:"default_payload,omitempty" db:"-"`
-	DefaultPipelineParameters []Parameter            `json:"default_pipeline_parameters" db:"-"`
-	Conditions                WorkflowNodeConditions `json:"conditions" db:"-"`
-	Mutex                     bool                   `json:"mutex" db:"mutex"`
+	ID                        int64                    `json:"id" db:"id"`
+	NodeID                    int64                    `json:"node_id" db:"node_id"`
+	PipelineID                int64                    `json:"pipeline_id" db:"pipeline_id"`
+	PipelineName              string                   `json:"-" db:"-"`
+	ApplicationID             int64                    `json:"application_id" db:"application_id"`
+	ApplicationName           string                   `json:"-" db:"-"`
+	EnvironmentID             int64                    `json:"environment_id" db:"environment_id"`
+	EnvironmentName           string                   `json:"-" db:"-"`
+	ProjectPlatformID         int64                    `json:"project_platform_id" db:"project_platform_id"`
+	ProjectPlatformName       string                   `json:"-" db:"-"`
+	DefaultPayload            interface{}              `json:"default_payload,omitempty" db:"-"`
+	DefaultPipelineParameters []Parameter              `json:"default_pipeline_parameters" db:"-"`
+	Conditions                WorkflowNodeConditions   `json:"conditions" db:"-"`
+	Mutex                     bool                     `json:"mutex" db:"mutex"`
+	Approval                  *WorkflowNodeApproval    `json:"approval,omitempty" db:"-"`
+	Concurrency               *WorkflowNodeConcurrency `json:"concurrency,omitempty" db:"-"`
 }
 
 //AddTrigger adds a trigger to the destination node from the node found by its name
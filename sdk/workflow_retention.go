@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WorkflowRetentionPolicy contains the retention rules of the runs of a workflow.
+// A run is kept if it matches a keep rule, otherwise it's deleted if it matches a delete rule.
+// The history length of the workflow is not used when a retention policy is set.
+type WorkflowRetentionPolicy struct {
+	// Runs older than MaxAge days are deleted
+	MaxAge int64 `json:"max_age,omitempty" yaml:"max_age,omitempty"`
+	// Only the last KeepLastPerBranch runs of each branch are kept
+	KeepLastPerBranch int64 `json:"keep_last_per_branch,omitempty" yaml:"keep_last_per_branch,omitempty"`
+	// Runs with one of these tags are always kept, a tag is "name" or "name=value"
+	KeepTags []string `json:"keep_tags,omitempty" yaml:"keep_tags,omitempty"`
+	// Runs which deployed successfully on one of these environments are always kept
+	KeepEnvironments []string `json:"keep_environments,omitempty" yaml:"keep_environments,omitempty"`
+	// Runs of the branches deleted on the repository are deleted
+	DeleteDeletedBranches bool `json:"delete_deleted_branches,omitempty" yaml:"delete_deleted_branches,omitempty"`
+	// Artifacts of the runs older than ArtifactMaxAge days are deleted, even if the runs are kept
+	ArtifactMaxAge int64 `json:"artifact_max_age,omitempty" yaml:"artifact_max_age,omitempty"`
+}
+
+// IsValid checks the retention policy values
+func (p WorkflowRetentionPolicy) IsValid() error {
+	if p.MaxAge < 0 || p.KeepLastPerBranch < 0 || p.ArtifactMaxAge < 0 {
+		return NewError(ErrWorkflowInvalid, fmt.Errorf("Invalid retention policy: values must be positive"))
+	}
+	for _, t := range p.KeepTags {
+		if strings.TrimSpace(strings.SplitN(t, "=", 2)[0]) == "" {
+			return NewError(ErrWorkflowInvalid, fmt.Errorf("Invalid retention policy: invalid tag %q", t))
+		}
+	}
+	for _, e := range p.KeepEnvironments {
+		if strings.TrimSpace(e) == "" {
+			return NewError(ErrWorkflowInvalid, fmt.Errorf("Invalid retention policy: environment name is mandatory"))
+		}
+	}
+	return nil
+}
+
+// MatchTags returns true if one of the given tags is kept by the retention policy
+func (p WorkflowRetentionPolicy) MatchTags(tags []WorkflowRunTag) bool {
+	for _, k := range p.KeepTags {
+		kv := strings.SplitN(k, "=", 2)
+		for _, t := range tags {
+			if t.Tag != kv[0] {
+				continue
+			}
+			if len(kv) == 1 || t.Value == kv[1] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -253,6 +253,8 @@ type WorkflowNodeRun struct {
 	HookExecutionTimeStamp int64                                `json:"hook_execution_timestamp,omitempty"`
 	HookExecutionID        string                               `json:"execution_id,omitempty"`
 	Callback               *WorkflowNodeOutgoingHookRunCallback `json:"callback,omitempty"`
+	Approvals              []WorkflowNodeRunApproval            `json:"approvals,omitempty"`
+	ConcurrencyGroup       string                               `json:"concurrency_group,omitempty"`
 }
 
 // WorkflowNodeOutgoingHookRunCallback is the callback coming from hooks uservice avec an outgoing hook execution
@@ -356,6 +358,21 @@ func (w WorkflowNodeRunArtifact) Equal(c WorkflowNodeRunArtifact) bool {
 		w.MD5sum == c.MD5sum
 }
 
+// ArtifactUploadChunkSize is the size of the chunks sent by workers to upload an artifact through the API
+const ArtifactUploadChunkSize = 10 * 1024 * 1024
+
+// WorkflowNodeRunArtifactUpload is the state of a chunked upload of an artifact.
+// Offset is the number of bytes received, the next chunk must start at this offset.
+// With objectstores supporting temporary urls, the next chunk is sent to TempURL.
+type WorkflowNodeRunArtifactUpload struct {
+	ID           string                  `json:"id"`
+	NodeJobRunID int64                   `json:"workflow_node_run_job_id"`
+	Artifact     WorkflowNodeRunArtifact `json:"artifact"`
+	Offset       int64                   `json:"offset"`
+	Chunks       []int64                 `json:"chunks,omitempty"`
+	TempURL      string                  `json:"temp_url,omitempty"`
+}
+
 //WorkflowNodeJobRun represents an job to be run
 // /!\ DONT FORGET TO REGENERATE EASYJSON FILES /!\
 //easyjson:json
@@ -2140,6 +2140,29 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk1(in *jlexer.Lexer, out *ExecutedJo
 			out.WorkerName = string(in.String())
 		case "worker_id":
 			out.WorkerID = string(in.String())
+		case "previous_attempts":
+			if in.IsNull() {
+				in.Skip()
+				out.PreviousAttempts = nil
+			} else {
+				in.Delim('[')
+				if out.PreviousAttempts == nil {
+					if !in.IsDelim(']') {
+						out.PreviousAttempts = make([]ExecutedJobAttempt, 0, 1)
+					} else {
+						out.PreviousAttempts = []ExecutedJobAttempt{}
+					}
+				} else {
+					out.PreviousAttempts = (out.PreviousAttempts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v69 ExecutedJobAttempt
+					easyjsonD7860c2dDecodeGithubComOvhCdsSdk17(in, &v69)
+					out.PreviousAttempts = append(out.PreviousAttempts, v69)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		case "pipeline_action_id":
 			out.PipelineActionID = int64(in.Int64())
 		case "pipeline_stage_id":
@@ -2153,26 +2176,501 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk1(in *jlexer.Lexer, out *ExecutedJo
 		case "warnings":
 			if in.IsNull() {
 				in.Skip()
-				out.Warnings = nil
+				out.Warnings = nil
+			} else {
+				in.Delim('[')
+				if out.Warnings == nil {
+					if !in.IsDelim(']') {
+						out.Warnings = make([]PipelineBuildWarning, 0, 1)
+					} else {
+						out.Warnings = []PipelineBuildWarning{}
+					}
+				} else {
+					out.Warnings = (out.Warnings)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v55 PipelineBuildWarning
+					easyjsonD7860c2dDecodeGithubComOvhCdsSdk15(in, &v55)
+					out.Warnings = append(out.Warnings, v55)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "retry_policy":
+			if in.IsNull() {
+				in.Skip()
+				out.RetryPolicy = nil
+			} else {
+				if out.RetryPolicy == nil {
+					out.RetryPolicy = new(JobRetryPolicy)
+				}
+				easyjsonD7860c2dDecodeGithubComOvhCdsSdk16(in, &*out.RetryPolicy)
+			}
+		case "matrix":
+			if in.IsNull() {
+				in.Skip()
+				out.Matrix = nil
+			} else {
+				if out.Matrix == nil {
+					out.Matrix = new(JobMatrix)
+				}
+				easyjsonD7860c2dDecodeGithubComOvhCdsSdk18(in, &*out.Matrix)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD7860c2dEncodeGithubComOvhCdsSdk1(out *jwriter.Writer, in ExecutedJob) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"step_status\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.StepStatus == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v56, v57 := range in.StepStatus {
+				if v56 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD7860c2dEncodeGithubComOvhCdsSdk13(out, v57)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"reason\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Reason))
+	}
+	{
+		const prefix string = ",\"worker_name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.WorkerName))
+	}
+	{
+		const prefix string = ",\"worker_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.WorkerID))
+	}
+	if len(in.PreviousAttempts) != 0 {
+		const prefix string = ",\"previous_attempts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.RawByte('[')
+		for v70, v71 := range in.PreviousAttempts {
+			if v70 > 0 {
+				out.RawByte(',')
+			}
+			easyjsonD7860c2dEncodeGithubComOvhCdsSdk17(out, v71)
+		}
+		out.RawByte(']')
+	}
+	{
+		const prefix string = ",\"pipeline_action_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.PipelineActionID))
+	}
+	{
+		const prefix string = ",\"pipeline_stage_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.PipelineStageID))
+	}
+	{
+		const prefix string = ",\"enabled\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Enabled))
+	}
+	{
+		const prefix string = ",\"last_modified\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.LastModified))
+	}
+	{
+		const prefix string = ",\"action\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out, in.Action)
+	}
+	{
+		const prefix string = ",\"warnings\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Warnings == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v58, v59 := range in.Warnings {
+				if v58 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD7860c2dEncodeGithubComOvhCdsSdk15(out, v59)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.RetryPolicy != nil {
+		const prefix string = ",\"retry_policy\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjsonD7860c2dEncodeGithubComOvhCdsSdk16(out, *in.RetryPolicy)
+	}
+	if in.Matrix != nil {
+		const prefix string = ",\"matrix\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjsonD7860c2dEncodeGithubComOvhCdsSdk18(out, *in.Matrix)
+	}
+	out.RawByte('}')
+}
+func easyjsonD7860c2dDecodeGithubComOvhCdsSdk17(in *jlexer.Lexer, out *ExecutedJobAttempt) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "attempt":
+			out.Attempt = int(in.Int())
+		case "status":
+			out.Status = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		case "exit_code":
+			out.ExitCode = int64(in.Int64())
+		case "worker_name":
+			out.WorkerName = string(in.String())
+		case "start":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Start).UnmarshalJSON(data))
+			}
+		case "done":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Done).UnmarshalJSON(data))
+			}
+		case "step_status":
+			if in.IsNull() {
+				in.Skip()
+				out.StepStatus = nil
+			} else {
+				in.Delim('[')
+				if out.StepStatus == nil {
+					if !in.IsDelim(']') {
+						out.StepStatus = make([]StepStatus, 0, 1)
+					} else {
+						out.StepStatus = []StepStatus{}
+					}
+				} else {
+					out.StepStatus = (out.StepStatus)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v78 StepStatus
+					easyjsonD7860c2dDecodeGithubComOvhCdsSdk13(in, &v78)
+					out.StepStatus = append(out.StepStatus, v78)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "spawninfos":
+			if in.IsNull() {
+				in.Skip()
+				out.SpawnInfos = nil
+			} else {
+				in.Delim('[')
+				if out.SpawnInfos == nil {
+					if !in.IsDelim(']') {
+						out.SpawnInfos = make([]SpawnInfo, 0, 1)
+					} else {
+						out.SpawnInfos = []SpawnInfo{}
+					}
+				} else {
+					out.SpawnInfos = (out.SpawnInfos)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v79 SpawnInfo
+					easyjsonD7860c2dDecodeGithubComOvhCdsSdk4(in, &v79)
+					out.SpawnInfos = append(out.SpawnInfos, v79)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD7860c2dEncodeGithubComOvhCdsSdk17(out *jwriter.Writer, in ExecutedJobAttempt) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"attempt\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Attempt))
+	}
+	{
+		const prefix string = ",\"status\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Status))
+	}
+	if in.Reason != "" {
+		const prefix string = ",\"reason\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Reason))
+	}
+	if in.ExitCode != 0 {
+		const prefix string = ",\"exit_code\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.ExitCode))
+	}
+	if in.WorkerName != "" {
+		const prefix string = ",\"worker_name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.WorkerName))
+	}
+	{
+		const prefix string = ",\"start\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Start).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"done\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Done).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"step_status\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.StepStatus == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v80, v81 := range in.StepStatus {
+				if v80 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD7860c2dEncodeGithubComOvhCdsSdk13(out, v81)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"spawninfos\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.SpawnInfos == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v82, v83 := range in.SpawnInfos {
+				if v82 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD7860c2dEncodeGithubComOvhCdsSdk4(out, v83)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+func easyjsonD7860c2dDecodeGithubComOvhCdsSdk16(in *jlexer.Lexer, out *JobRetryPolicy) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "max_attempts":
+			out.MaxAttempts = int(in.Int())
+		case "statuses":
+			if in.IsNull() {
+				in.Skip()
+				out.Statuses = nil
 			} else {
 				in.Delim('[')
-				if out.Warnings == nil {
+				if out.Statuses == nil {
 					if !in.IsDelim(']') {
-						out.Warnings = make([]PipelineBuildWarning, 0, 1)
+						out.Statuses = make([]string, 0, 4)
 					} else {
-						out.Warnings = []PipelineBuildWarning{}
+						out.Statuses = []string{}
 					}
 				} else {
-					out.Warnings = (out.Warnings)[:0]
+					out.Statuses = (out.Statuses)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v55 PipelineBuildWarning
-					easyjsonD7860c2dDecodeGithubComOvhCdsSdk15(in, &v55)
-					out.Warnings = append(out.Warnings, v55)
+					var v72 string
+					v72 = string(in.String())
+					out.Statuses = append(out.Statuses, v72)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "exit_codes":
+			if in.IsNull() {
+				in.Skip()
+				out.ExitCodes = nil
+			} else {
+				in.Delim('[')
+				if out.ExitCodes == nil {
+					if !in.IsDelim(']') {
+						out.ExitCodes = make([]int64, 0, 8)
+					} else {
+						out.ExitCodes = []int64{}
+					}
+				} else {
+					out.ExitCodes = (out.ExitCodes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v73 int64
+					v73 = int64(in.Int64())
+					out.ExitCodes = append(out.ExitCodes, v73)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "backoff":
+			out.Backoff = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -2183,128 +2681,378 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk1(in *jlexer.Lexer, out *ExecutedJo
 		in.Consumed()
 	}
 }
-func easyjsonD7860c2dEncodeGithubComOvhCdsSdk1(out *jwriter.Writer, in ExecutedJob) {
+func easyjsonD7860c2dEncodeGithubComOvhCdsSdk16(out *jwriter.Writer, in JobRetryPolicy) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"step_status\":"
+		const prefix string = ",\"max_attempts\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		if in.StepStatus == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v56, v57 := range in.StepStatus {
-				if v56 > 0 {
-					out.RawByte(',')
-				}
-				easyjsonD7860c2dEncodeGithubComOvhCdsSdk13(out, v57)
-			}
-			out.RawByte(']')
-		}
+		out.Int(int(in.MaxAttempts))
 	}
-	{
-		const prefix string = ",\"reason\":"
+	if len(in.Statuses) != 0 {
+		const prefix string = ",\"statuses\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Reason))
+		out.RawByte('[')
+		for v74, v75 := range in.Statuses {
+			if v74 > 0 {
+				out.RawByte(',')
+			}
+			out.String(string(v75))
+		}
+		out.RawByte(']')
 	}
-	{
-		const prefix string = ",\"worker_name\":"
+	if len(in.ExitCodes) != 0 {
+		const prefix string = ",\"exit_codes\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.WorkerName))
+		out.RawByte('[')
+		for v76, v77 := range in.ExitCodes {
+			if v76 > 0 {
+				out.RawByte(',')
+			}
+			out.Int64(int64(v77))
+		}
+		out.RawByte(']')
 	}
-	{
-		const prefix string = ",\"worker_id\":"
+	if in.Backoff != 0 {
+		const prefix string = ",\"backoff\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.WorkerID))
+		out.Int64(int64(in.Backoff))
 	}
-	{
-		const prefix string = ",\"pipeline_action_id\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
+	out.RawByte('}')
+}
+func easyjsonD7860c2dDecodeGithubComOvhCdsSdk18(in *jlexer.Lexer, out *JobMatrix) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
 		}
-		out.Int64(int64(in.PipelineActionID))
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "axes":
+			if in.IsNull() {
+				in.Skip()
+				out.Axes = nil
+			} else {
+				in.Delim('[')
+				if out.Axes == nil {
+					if !in.IsDelim(']') {
+						out.Axes = make([]JobMatrixAxis, 0, 1)
+					} else {
+						out.Axes = []JobMatrixAxis{}
+					}
+				} else {
+					out.Axes = (out.Axes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v84 JobMatrixAxis
+					easyjsonD7860c2dDecodeGithubComOvhCdsSdk19(in, &v84)
+					out.Axes = append(out.Axes, v84)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "include":
+			if in.IsNull() {
+				in.Skip()
+				out.Include = nil
+			} else {
+				in.Delim('[')
+				if out.Include == nil {
+					if !in.IsDelim(']') {
+						out.Include = make([]map[string]string, 0, 1)
+					} else {
+						out.Include = []map[string]string{}
+					}
+				} else {
+					out.Include = (out.Include)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v85 map[string]string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						in.Delim('{')
+						if !in.IsDelim('}') {
+							v85 = make(map[string]string)
+						} else {
+							v85 = nil
+						}
+						for !in.IsDelim('}') {
+							key := string(in.String())
+							in.WantColon()
+							var v86 string
+							v86 = string(in.String())
+							(v85)[key] = v86
+							in.WantComma()
+						}
+						in.Delim('}')
+					}
+					out.Include = append(out.Include, v85)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "exclude":
+			if in.IsNull() {
+				in.Skip()
+				out.Exclude = nil
+			} else {
+				in.Delim('[')
+				if out.Exclude == nil {
+					if !in.IsDelim(']') {
+						out.Exclude = make([]map[string]string, 0, 1)
+					} else {
+						out.Exclude = []map[string]string{}
+					}
+				} else {
+					out.Exclude = (out.Exclude)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v87 map[string]string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						in.Delim('{')
+						if !in.IsDelim('}') {
+							v87 = make(map[string]string)
+						} else {
+							v87 = nil
+						}
+						for !in.IsDelim('}') {
+							key := string(in.String())
+							in.WantColon()
+							var v88 string
+							v88 = string(in.String())
+							(v87)[key] = v88
+							in.WantComma()
+						}
+						in.Delim('}')
+					}
+					out.Exclude = append(out.Exclude, v87)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
 	}
+}
+func easyjsonD7860c2dEncodeGithubComOvhCdsSdk18(out *jwriter.Writer, in JobMatrix) {
+	out.RawByte('{')
+	first := true
+	_ = first
 	{
-		const prefix string = ",\"pipeline_stage_id\":"
+		const prefix string = ",\"axes\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int64(int64(in.PipelineStageID))
+		if in.Axes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v89, v90 := range in.Axes {
+				if v89 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD7860c2dEncodeGithubComOvhCdsSdk19(out, v90)
+			}
+			out.RawByte(']')
+		}
 	}
-	{
-		const prefix string = ",\"enabled\":"
+	if len(in.Include) != 0 {
+		const prefix string = ",\"include\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Bool(bool(in.Enabled))
+		out.RawByte('[')
+		for v91, v92 := range in.Include {
+			if v91 > 0 {
+				out.RawByte(',')
+			}
+			if v92 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+				out.RawString(`null`)
+			} else {
+				out.RawByte('{')
+				v92First := true
+				for v92Name, v92Value := range v92 {
+					if v92First {
+						v92First = false
+					} else {
+						out.RawByte(',')
+					}
+					out.String(string(v92Name))
+					out.RawByte(':')
+					out.String(string(v92Value))
+				}
+				out.RawByte('}')
+			}
+		}
+		out.RawByte(']')
 	}
-	{
-		const prefix string = ",\"last_modified\":"
+	if len(in.Exclude) != 0 {
+		const prefix string = ",\"exclude\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int64(int64(in.LastModified))
+		out.RawByte('[')
+		for v93, v94 := range in.Exclude {
+			if v93 > 0 {
+				out.RawByte(',')
+			}
+			if v94 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+				out.RawString(`null`)
+			} else {
+				out.RawByte('{')
+				v94First := true
+				for v94Name, v94Value := range v94 {
+					if v94First {
+						v94First = false
+					} else {
+						out.RawByte(',')
+					}
+					out.String(string(v94Name))
+					out.RawByte(':')
+					out.String(string(v94Value))
+				}
+				out.RawByte('}')
+			}
+		}
+		out.RawByte(']')
+	}
+	out.RawByte('}')
+}
+func easyjsonD7860c2dDecodeGithubComOvhCdsSdk19(in *jlexer.Lexer, out *JobMatrixAxis) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "values":
+			if in.IsNull() {
+				in.Skip()
+				out.Values = nil
+			} else {
+				in.Delim('[')
+				if out.Values == nil {
+					if !in.IsDelim(']') {
+						out.Values = make([]string, 0, 4)
+					} else {
+						out.Values = []string{}
+					}
+				} else {
+					out.Values = (out.Values)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v95 string
+					v95 = string(in.String())
+					out.Values = append(out.Values, v95)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
 	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD7860c2dEncodeGithubComOvhCdsSdk19(out *jwriter.Writer, in JobMatrixAxis) {
+	out.RawByte('{')
+	first := true
+	_ = first
 	{
-		const prefix string = ",\"action\":"
+		const prefix string = ",\"name\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out, in.Action)
+		out.String(string(in.Name))
 	}
 	{
-		const prefix string = ",\"warnings\":"
+		const prefix string = ",\"values\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		if in.Warnings == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Values == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v58, v59 := range in.Warnings {
-				if v58 > 0 {
+			for v96, v97 := range in.Values {
+				if v96 > 0 {
 					out.RawByte(',')
 				}
-				easyjsonD7860c2dEncodeGithubComOvhCdsSdk15(out, v59)
+				out.String(string(v97))
 			}
 			out.RawByte(']')
 		}
@@ -2478,6 +3226,8 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk14(in *jlexer.Lexer, out *Action) {
 			out.Optional = bool(in.Bool())
 		case "always_executed":
 			out.AlwaysExecuted = bool(in.Bool())
+		case "timeout":
+			out.Timeout = int64(in.Int64())
 		case "last_modified":
 			out.LastModified = int64(in.Int64())
 		default:
@@ -2647,6 +3397,16 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out *jwriter.Writer, in Action)
 		}
 		out.Bool(bool(in.AlwaysExecuted))
 	}
+	if in.Timeout != 0 {
+		const prefix string = ",\"timeout\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Timeout))
+	}
 	{
 		const prefix string = ",\"last_modified\":"
 		if first {
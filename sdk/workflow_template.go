@@ -0,0 +1,211 @@
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"text/template"
+)
+
+// Types of the workflow template parameters
+const (
+	WorkflowTemplateParameterTypeString  = "string"
+	WorkflowTemplateParameterTypeBoolean = "boolean"
+	WorkflowTemplateParameterTypeNumber  = "number"
+)
+
+// WorkflowTemplateLeftDelim and WorkflowTemplateRightDelim are the delimiters of the template actions,
+// so that CDS variables like {{.cds.version}} are kept as is in the generated files
+const (
+	WorkflowTemplateLeftDelim  = "[["
+	WorkflowTemplateRightDelim = "]]"
+)
+
+var workflowTemplateParameterPattern = regexp.MustCompile("^[a-zA-Z0-9_]+$")
+
+// WorkflowTemplate is a workflow owned by a group, with its pipelines, applications and environments,
+// rendered with the parameters of each instance
+type WorkflowTemplate struct {
+	ID           int64                       `json:"id" db:"id" yaml:"-"`
+	GroupID      int64                       `json:"group_id" db:"group_id" yaml:"-"`
+	Name         string                      `json:"name" db:"name" yaml:"name" cli:"name,key"`
+	Description  string                      `json:"description" db:"description" yaml:"description,omitempty" cli:"description"`
+	Version      int64                       `json:"version" db:"version" yaml:"-" cli:"version"`
+	Parameters   []WorkflowTemplateParameter `json:"parameters" db:"-" yaml:"parameters,omitempty"`
+	Value        string                      `json:"value" db:"value" yaml:"workflow"`
+	Pipelines    []PipelineTemplate          `json:"pipelines" db:"-" yaml:"pipelines,omitempty"`
+	Applications []ApplicationTemplate       `json:"applications" db:"-" yaml:"applications,omitempty"`
+	Environments []EnvironmentTemplate       `json:"environments" db:"-" yaml:"environments,omitempty"`
+	Group        *Group                      `json:"group,omitempty" db:"-" yaml:"-"`
+	GroupName    string                      `json:"group_name,omitempty" db:"-" yaml:"group" cli:"group"`
+}
+
+// WorkflowTemplateParameter is a typed parameter of a workflow template
+type WorkflowTemplateParameter struct {
+	Key      string `json:"key" yaml:"key"`
+	Type     string `json:"type" yaml:"type"`
+	Required bool   `json:"required" yaml:"required,omitempty"`
+}
+
+// PipelineTemplate is the template of a pipeline YAML file
+type PipelineTemplate struct {
+	Value string `json:"value" yaml:"value"`
+}
+
+// ApplicationTemplate is the template of an application YAML file
+type ApplicationTemplate struct {
+	Value string `json:"value" yaml:"value"`
+}
+
+// EnvironmentTemplate is the template of an environment YAML file
+type EnvironmentTemplate struct {
+	Value string `json:"value" yaml:"value"`
+}
+
+// IsValid checks the name, the parameters and the syntax of the templates
+func (w WorkflowTemplate) IsValid() error {
+	if !NamePatternRegex.MatchString(w.Name) {
+		return NewError(ErrWrongRequest, fmt.Errorf("Invalid workflow template name. It should match %s", NamePattern))
+	}
+	if w.GroupID == 0 {
+		return NewError(ErrWrongRequest, fmt.Errorf("Invalid workflow template %s: group is required", w.Name))
+	}
+
+	keys := make(map[string]struct{}, len(w.Parameters))
+	for _, p := range w.Parameters {
+		if !workflowTemplateParameterPattern.MatchString(p.Key) {
+			return NewError(ErrWrongRequest, fmt.Errorf("Invalid workflow template parameter %q: allowed pattern is %s", p.Key, workflowTemplateParameterPattern.String()))
+		}
+		if _, has := keys[p.Key]; has {
+			return NewError(ErrWrongRequest, fmt.Errorf("Invalid workflow template parameter %s: duplicate key", p.Key))
+		}
+		keys[p.Key] = struct{}{}
+		switch p.Type {
+		case WorkflowTemplateParameterTypeString, WorkflowTemplateParameterTypeBoolean, WorkflowTemplateParameterTypeNumber:
+		default:
+			return NewError(ErrWrongRequest, fmt.Errorf("Invalid workflow template parameter %s: unknown type %s", p.Key, p.Type))
+		}
+	}
+
+	if w.Value == "" {
+		return NewError(ErrWrongRequest, fmt.Errorf("Invalid workflow template %s: workflow is required", w.Name))
+	}
+	values := []string{w.Value}
+	for _, p := range w.Pipelines {
+		values = append(values, p.Value)
+	}
+	for _, a := range w.Applications {
+		values = append(values, a.Value)
+	}
+	for _, e := range w.Environments {
+		values = append(values, e.Value)
+	}
+	for _, v := range values {
+		if _, err := ParseWorkflowTemplateValue(v); err != nil {
+			return NewError(ErrWrongRequest, fmt.Errorf("Invalid workflow template %s: %v", w.Name, err))
+		}
+	}
+	return nil
+}
+
+// ParseWorkflowTemplateValue parses one of the files of a workflow template
+func ParseWorkflowTemplateValue(v string) (*template.Template, error) {
+	return template.New("").Delims(WorkflowTemplateLeftDelim, WorkflowTemplateRightDelim).Option("missingkey=error").Parse(v)
+}
+
+// CheckParameters checks the values of a request against the parameters of the template
+// and returns them converted to their type
+func (w WorkflowTemplate) CheckParameters(r WorkflowTemplateRequest) (map[string]interface{}, error) {
+	params := make(map[string]interface{}, len(w.Parameters))
+	for _, p := range w.Parameters {
+		v, has := r.Parameters[p.Key]
+		if !has || v == "" {
+			if p.Required {
+				return nil, NewError(ErrWrongRequest, fmt.Errorf("Parameter %s is required", p.Key))
+			}
+		}
+
+		switch p.Type {
+		case WorkflowTemplateParameterTypeBoolean:
+			b := false
+			if v != "" {
+				var err error
+				b, err = strconv.ParseBool(v)
+				if err != nil {
+					return nil, NewError(ErrWrongRequest, fmt.Errorf("Parameter %s must be a boolean", p.Key))
+				}
+			}
+			params[p.Key] = b
+		case WorkflowTemplateParameterTypeNumber:
+			var n float64
+			if v != "" {
+				var err error
+				n, err = strconv.ParseFloat(v, 64)
+				if err != nil {
+					return nil, NewError(ErrWrongRequest, fmt.Errorf("Parameter %s must be a number", p.Key))
+				}
+			}
+			params[p.Key] = n
+		default:
+			params[p.Key] = v
+		}
+	}
+
+	for k := range r.Parameters {
+		if _, has := params[k]; !has {
+			return nil, NewError(ErrWrongRequest, fmt.Errorf("Unknown parameter %s", k))
+		}
+	}
+	return params, nil
+}
+
+// WorkflowTemplateRequest is the request to generate a workflow from a template in a project
+type WorkflowTemplateRequest struct {
+	ProjectKey   string            `json:"project_key"`
+	WorkflowName string            `json:"workflow_name"`
+	Parameters   map[string]string `json:"parameters"`
+}
+
+// WorkflowTemplateResult contains the generated files, by file name as expected by the workflow push:
+// <workflow>.yml, <pipeline>.pip.yml, <application>.app.yml and <environment>.env.yml
+type WorkflowTemplateResult struct {
+	Files map[string]string `json:"files"`
+}
+
+// WorkflowTemplateInstance is a workflow generated from a template, with the version of the template used
+type WorkflowTemplateInstance struct {
+	ID                      int64                   `json:"id" db:"id" cli:"id,key"`
+	WorkflowTemplateID      int64                   `json:"workflow_template_id" db:"workflow_template_id"`
+	ProjectID               int64                   `json:"project_id" db:"project_id"`
+	WorkflowID              int64                   `json:"workflow_id" db:"workflow_id"`
+	WorkflowTemplateVersion int64                   `json:"workflow_template_version" db:"workflow_template_version" cli:"version"`
+	Request                 WorkflowTemplateRequest `json:"request" db:"-"`
+	ProjectKey              string                  `json:"project_key" db:"-" cli:"project"`
+	WorkflowName            string                  `json:"workflow_name" db:"-" cli:"workflow"`
+}
+
+// IsOutdated returns true if the instance was generated with an older version of the template
+func (i WorkflowTemplateInstance) IsOutdated(w WorkflowTemplate) bool {
+	return i.WorkflowTemplateVersion < w.Version
+}
+
+// WorkflowTemplateBulk is the request to apply a template on some of its instances, all if empty
+type WorkflowTemplateBulk struct {
+	InstanceIDs []int64 `json:"instance_ids"`
+}
+
+// WorkflowTemplateInstanceDiff is the diff between a workflow and the files generated by the last version of its template.
+// VersionDiff is the diff between the files generated by the version of the instance and by the last version.
+type WorkflowTemplateInstanceDiff struct {
+	Instance    WorkflowTemplateInstance `json:"instance"`
+	Diff        string                   `json:"diff"`
+	VersionDiff string                   `json:"version_diff,omitempty"`
+	Error       string                   `json:"error,omitempty"`
+}
+
+// WorkflowTemplateBulkResult is the result of the apply of a template on one of its instances
+type WorkflowTemplateBulkResult struct {
+	Instance WorkflowTemplateInstance `json:"instance"`
+	Messages []string                 `json:"messages,omitempty"`
+	Error    string                   `json:"error,omitempty"`
+}
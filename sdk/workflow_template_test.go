@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowTemplateIsValid(t *testing.T) {
+	wt := WorkflowTemplate{
+		Name:    "go-service",
+		GroupID: 1,
+		Parameters: []WorkflowTemplateParameter{
+			{Key: "repo", Type: WorkflowTemplateParameterTypeString},
+		},
+		Value: "name: [[ .name ]]",
+	}
+	assert.NoError(t, wt.IsValid())
+
+	invalid := wt
+	invalid.GroupID = 0
+	assert.Error(t, invalid.IsValid())
+
+	invalid = wt
+	invalid.Parameters = []WorkflowTemplateParameter{{Key: "my-repo", Type: WorkflowTemplateParameterTypeString}}
+	assert.Error(t, invalid.IsValid())
+
+	invalid = wt
+	invalid.Parameters = []WorkflowTemplateParameter{{Key: "repo", Type: "list"}}
+	assert.Error(t, invalid.IsValid())
+
+	invalid = wt
+	invalid.Parameters = []WorkflowTemplateParameter{{Key: "repo", Type: WorkflowTemplateParameterTypeString}, {Key: "repo", Type: WorkflowTemplateParameterTypeNumber}}
+	assert.Error(t, invalid.IsValid())
+
+	invalid = wt
+	invalid.Pipelines = []PipelineTemplate{{Value: "name: [[ .name "}}
+	assert.Error(t, invalid.IsValid())
+}
+
+func TestWorkflowTemplateCheckParameters(t *testing.T) {
+	wt := WorkflowTemplate{
+		Parameters: []WorkflowTemplateParameter{
+			{Key: "repo", Type: WorkflowTemplateParameterTypeString, Required: true},
+			{Key: "deploy", Type: WorkflowTemplateParameterTypeBoolean},
+			{Key: "replicas", Type: WorkflowTemplateParameterTypeNumber},
+		},
+	}
+
+	params, err := wt.CheckParameters(WorkflowTemplateRequest{Parameters: map[string]string{"repo": "my-org/my-service", "deploy": "true", "replicas": "3"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"repo": "my-org/my-service", "deploy": true, "replicas": float64(3)}, params)
+
+	params, err = wt.CheckParameters(WorkflowTemplateRequest{Parameters: map[string]string{"repo": "my-org/my-service"}})
+	assert.NoError(t, err)
+	assert.Equal(t, false, params["deploy"])
+
+	_, err = wt.CheckParameters(WorkflowTemplateRequest{})
+	assert.Error(t, err, "repo is required")
+
+	_, err = wt.CheckParameters(WorkflowTemplateRequest{Parameters: map[string]string{"repo": "r", "deploy": "maybe"}})
+	assert.Error(t, err)
+
+	_, err = wt.CheckParameters(WorkflowTemplateRequest{Parameters: map[string]string{"repo": "r", "replicas": "three"}})
+	assert.Error(t, err)
+
+	_, err = wt.CheckParameters(WorkflowTemplateRequest{Parameters: map[string]string{"repo": "r", "branch": "master"}})
+	assert.Error(t, err)
+}
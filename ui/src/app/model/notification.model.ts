@@ -1,7 +1,8 @@
 import {Environment} from './environment.model';
 import {Pipeline} from './pipeline.model';
 
-export const notificationTypes = ['jabber', 'email'];
+export const notificationTypes = ['jabber', 'email', 'slack', 'mattermost', 'teams', 'vcs'];
+export const chatNotificationTypes = ['slack', 'mattermost', 'teams'];
 export const notificationOnSuccess = ['always', 'change', 'never'];
 export const notificationOnFailure = ['always', 'change', 'never'];
 
@@ -27,6 +28,9 @@ export class UserNotificationSettings {
     send_to_author: boolean;
     recipients: Array<string>;
     template: UserNotificationTemplate;
+    webhook_url: string;
+    status_context: string;
+    pr_comment: boolean;
 
     constructor() {
         this.on_success = notificationOnSuccess[1];
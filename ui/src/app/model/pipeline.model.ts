@@ -21,6 +21,7 @@ export class PipelineStatus {
     static SKIPPED = 'Skipped';
     static NEVER_BUILT = 'Never Built';
     static STOPPED = 'Stopped';
+    static WAITING_APPROVAL = 'WaitingApproval';
 
     static neverRun(status: string) {
       if (status === this.SKIPPED || status === this.NEVER_BUILT || status === this.SKIPPED || status === this.DISABLED) {
@@ -31,7 +32,7 @@ export class PipelineStatus {
     }
 
     static isActive(status: string) {
-      if (status === this.WAITING || status === this.BUILDING) {
+      if (status === this.WAITING || status === this.BUILDING || status === this.WAITING_APPROVAL) {
         return true;
       }
 
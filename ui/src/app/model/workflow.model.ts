@@ -33,6 +33,7 @@ export class Workflow {
     usage: Usage;
     history_length: number;
     purge_tags: Array<string>;
+    auto_cancel_superseded: boolean;
     notifications: Array<WorkflowNotification>;
     from_repository: string;
     favorite: boolean;
@@ -632,6 +633,19 @@ export class WNodeContext {
     default_pipeline_parameters: Array<Parameter>;
     conditions: WorkflowNodeConditions;
     mutex: boolean;
+    approval: WorkflowNodeApproval;
+    concurrency: WorkflowNodeConcurrency;
+}
+
+export class WorkflowNodeApproval {
+    groups: Array<string>;
+    required_approvals: number;
+}
+
+export class WorkflowNodeConcurrency {
+    group: string;
+    limit: number;
+    policy: string;
 }
 
 export class WNodeOutgoingHook {
@@ -94,6 +94,8 @@ export class WorkflowNodeRun {
     hook_execution_timestamp: number;
     execution_id: string;
     callback: WorkflowNodeOutgoingHookRunCallback;
+    approvals: Array<WorkflowNodeRunApproval>;
+    concurrency_group: string;
 
 
     static fromEventRunWorkflowNode(e: Event): WorkflowNodeRun {
@@ -310,6 +312,14 @@ export class WorkflowNodeRunManual {
     user: User;
 }
 
+export class WorkflowNodeRunApproval {
+    username: string;
+    fullname: string;
+    approved: boolean;
+    comment: string;
+    date: string;
+}
+
 export class WorkflowNodeRunVulnerabilityReport {
     id: number;
     application_id: number;
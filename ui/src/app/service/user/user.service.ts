@@ -48,6 +48,37 @@ export class UserService {
         }));
     }
 
+    /**
+     * Get the authentication mode of the API: local, ldap or oidc
+     * @returns {Observable<string>}
+     */
+    getAuthMode(): Observable<string> {
+        return this._http.get<any>('/auth/mode').pipe(map(res => res.auth_mode));
+    }
+
+    /**
+     * Get the URL of the OpenID Connect provider where the user has to login
+     * @returns {Observable<any>}
+     */
+    getOIDCLogin(): Observable<any> {
+        return this._http.get<any>('/login/oidc');
+    }
+
+    /**
+     * LogIn user to API with the authorization code returned by the OpenID Connect provider
+     * @param code Authorization code
+     * @param state State returned by the OpenID Connect provider
+     * @returns {Observable<User>}
+     */
+    loginOIDC(code: string, state: string): Observable<User> {
+        return this._http.post<any>('/login/oidc/callback', {code: code, state: state}, {observe: 'response'}).pipe(map(res => {
+            let u = res.body.user;
+            u.token = res.body.token;
+            this._authStore.addUser(u, true);
+            return u;
+        }));
+    }
+
     resetPassword(user: User, href: string) {
         let request = {
             user: user,
@@ -121,6 +121,22 @@ export class WorkflowRunService {
             map (() => true));
     }
 
+    /**
+     * Approve or reject a workflow node run waiting for approval
+     * @param {string} key Project unique key
+     * @param {string} workflowName Workflow name
+     * @param {number} number Number of the workflow run
+     * @param {number} id of the node run to approve
+     * @param {boolean} approved false to reject the node run
+     * @param {string} comment Comment recorded with the approval
+     * @returns {Observable<WorkflowNodeRun>}
+     */
+    approveNodeRun(key: string, workflowName: string, num: number, id: number, approved: boolean,
+                   comment: string): Observable<WorkflowNodeRun> {
+        return this._http.post<WorkflowNodeRun>('/project/' + key + '/workflows/' + workflowName + '/runs/' + num +
+            '/nodes/' + id + '/approval', {approved: approved, comment: comment});
+    }
+
     /**
      * Get workflow tags
      * @param {string} key Project unique key
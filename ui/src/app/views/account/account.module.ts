@@ -2,6 +2,7 @@ import {NgModule} from '@angular/core';
 import {SharedModule} from '../../shared/shared.module';
 import {accountRouting} from './account.routing';
 import {LoginComponent} from './login/login.component';
+import {OIDCCallbackComponent} from './oidc/oidc.component';
 import {PasswordComponent} from './password/password.component';
 import {SignUpComponent} from './signup/signup.component';
 import {VerifyComponent} from './verify/verify.component';
@@ -9,6 +10,7 @@ import {VerifyComponent} from './verify/verify.component';
 @NgModule({
     declarations: [
         LoginComponent,
+        OIDCCallbackComponent,
         PasswordComponent,
         SignUpComponent,
         VerifyComponent,
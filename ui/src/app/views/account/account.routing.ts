@@ -1,6 +1,7 @@
 import {ModuleWithProviders} from '@angular/core';
 import {RouterModule, Routes} from '@angular/router';
 import {LoginComponent} from './login/login.component';
+import {OIDCCallbackComponent} from './oidc/oidc.component';
 import {PasswordComponent} from './password/password.component';
 import {SignUpComponent} from './signup/signup.component';
 import {VerifyComponent} from './verify/verify.component';
@@ -17,7 +18,8 @@ const routes: Routes = [
             },
             { path: 'password', component: PasswordComponent, data: { title: 'Reset Password' }},
             { path: 'signup', component: SignUpComponent, data: { title: 'Signup' }},
-            { path: 'verify/:username/:token', component: VerifyComponent }
+            { path: 'verify/:username/:token', component: VerifyComponent },
+            { path: 'oidc/callback', component: OIDCCallbackComponent, data: { title: 'CDS • Login' }}
         ]
     }
 ];
@@ -56,6 +56,7 @@ describe('CDS: LoginComponent', () => {
         // Start detecting change in model
         fixture.detectChanges();
         tick(50);
+        http.expectOne('http://localhost:8081/auth/mode').flush({'auth_mode': 'local'});
 
         // Simulate user typing
         let inputUsername = compiled.querySelector('input[name="username"]');
@@ -4,6 +4,7 @@ import {User} from '../../../model/user.model';
 import {AuthentificationStore} from '../../../service/auth/authentification.store';
 import {UserService} from '../../../service/user/user.service';
 import {AccountComponent} from '../account.component';
+import {OIDCCallbackComponent} from '../oidc/oidc.component';
 
 @Component({
     selector: 'app-account-login',
@@ -14,6 +15,7 @@ export class LoginComponent extends AccountComponent {
 
     user: User;
     redirect: string;
+    authMode: string;
 
     constructor(private _userService: UserService, private _router: Router,
         _authStore: AuthentificationStore, private _route: ActivatedRoute) {
@@ -23,6 +25,20 @@ export class LoginComponent extends AccountComponent {
         this._route.queryParams.subscribe(queryParams => {
            this.redirect = queryParams.redirect;
         });
+
+        this._userService.getAuthMode().subscribe(mode => {
+            this.authMode = mode;
+        });
+    }
+
+    signInOIDC() {
+        this._userService.getOIDCLogin().subscribe(res => {
+            sessionStorage.setItem(OIDCCallbackComponent.stateKey, res.state);
+            if (this.redirect) {
+                sessionStorage.setItem(OIDCCallbackComponent.redirectKey, this.redirect);
+            }
+            window.location.href = res.url;
+        });
     }
 
     signIn() {
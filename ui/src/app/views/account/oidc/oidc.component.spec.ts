@@ -0,0 +1,70 @@
+/* tslint:disable:no-unused-variable */
+
+import {TestBed, tick, fakeAsync} from '@angular/core/testing';
+import {APP_BASE_HREF} from '@angular/common';
+import {RouterTestingModule} from '@angular/router/testing';
+import {OIDCCallbackComponent} from './oidc.component';
+
+import {UserService} from '../../../service/user/user.service';
+import {AuthentificationStore} from '../../../service/auth/authentification.store';
+import {AppModule} from '../../../app.module';
+import {Router, ActivatedRoute} from '@angular/router';
+import {AccountModule} from '../account.module';
+
+import {HttpClientTestingModule, HttpTestingController} from '@angular/common/http/testing';
+
+describe('CDS: OIDCCallbackComponent', () => {
+
+    beforeEach(() => {
+        TestBed.configureTestingModule({
+            declarations: [],
+            providers: [
+                { provide: APP_BASE_HREF, useValue: '/' },
+                UserService,
+                AuthentificationStore,
+                { provide: Router, useClass: MockRouter},
+                { provide: ActivatedRoute, useValue: { snapshot: { queryParams: {code: 'code', state: 'state'}}} },
+            ],
+            imports : [
+                AppModule,
+                RouterTestingModule.withRoutes([]),
+                AccountModule,
+                HttpClientTestingModule
+            ]
+        });
+    });
+
+    it('should login with the authorization code', fakeAsync(() => {
+        const http = TestBed.get(HttpTestingController);
+        sessionStorage.setItem(OIDCCallbackComponent.stateKey, 'state');
+
+        let fixture = TestBed.createComponent(OIDCCallbackComponent);
+        fixture.detectChanges();
+        tick(50);
+
+        const req = http.expectOne('http://localhost:8081/login/oidc/callback');
+        expect(req.request.body.code).toBe('code');
+        expect(req.request.body.state).toBe('state');
+        req.flush({'user': {'username': 'foo'}, 'token': 'session'});
+
+        expect(fixture.componentInstance.showErrorMessage).toBeFalsy();
+        http.verify();
+    }));
+
+    it('should refuse a state not started from this browser', fakeAsync(() => {
+        const http = TestBed.get(HttpTestingController);
+        sessionStorage.setItem(OIDCCallbackComponent.stateKey, 'another state');
+
+        let fixture = TestBed.createComponent(OIDCCallbackComponent);
+        fixture.detectChanges();
+        tick(50);
+
+        expect(fixture.componentInstance.showErrorMessage).toBeTruthy();
+        http.verify();
+    }));
+});
+
+export class MockRouter {
+    public navigate() {
+    }
+}
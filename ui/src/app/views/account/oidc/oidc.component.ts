@@ -0,0 +1,51 @@
+import {Component, OnInit} from '@angular/core';
+import {ActivatedRoute, Params, Router} from '@angular/router';
+import {AuthentificationStore} from '../../../service/auth/authentification.store';
+import {UserService} from '../../../service/user/user.service';
+import {AccountComponent} from '../account.component';
+
+@Component({
+    selector: 'app-account-oidc-callback',
+    templateUrl: './oidc.html',
+    styleUrls: ['./oidc.scss']
+})
+export class OIDCCallbackComponent extends AccountComponent implements OnInit {
+
+    static stateKey = 'CDS-OIDC-STATE';
+    static redirectKey = 'CDS-OIDC-REDIRECT';
+
+    showErrorMessage = false;
+
+    constructor(private _userService: UserService, private _router: Router,
+        private _activatedRoute: ActivatedRoute, _authStore: AuthentificationStore) {
+        super(_authStore);
+    }
+
+    ngOnInit(): void {
+        let params: Params = this._activatedRoute.snapshot.queryParams;
+        let state = sessionStorage.getItem(OIDCCallbackComponent.stateKey);
+        let redirect = sessionStorage.getItem(OIDCCallbackComponent.redirectKey);
+        sessionStorage.removeItem(OIDCCallbackComponent.stateKey);
+        sessionStorage.removeItem(OIDCCallbackComponent.redirectKey);
+
+        // The state must be the one of the login started from this browser
+        if (!params['code'] || !state || params['state'] !== state) {
+            this.showErrorMessage = true;
+            return;
+        }
+
+        this._userService.loginOIDC(params['code'], params['state']).subscribe(() => {
+            if (redirect) {
+                this._router.navigateByUrl(decodeURIComponent(redirect));
+            } else {
+                this._router.navigate(['home']);
+            }
+        }, () => {
+            this.showErrorMessage = true;
+        });
+    }
+
+    navigateToLogin() {
+        this._router.navigate(['/account/login']);
+    }
+}
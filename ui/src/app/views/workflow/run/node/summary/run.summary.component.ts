@@ -29,6 +29,7 @@ export class WorkflowNodeRunSummaryComponent implements OnInit {
     pipelineStatusEnum = PipelineStatus;
 
     loading = false;
+    approvalComment: string;
 
     constructor(private _router: Router, private _wrService: WorkflowRunService, private _toast: ToastService,
                 private _translate: TranslateService) {
@@ -55,6 +56,19 @@ export class WorkflowNodeRunSummaryComponent implements OnInit {
         });
     }
 
+    approve(approved: boolean): void {
+        this.loading = true;
+        this._wrService.approveNodeRun(this.project.key, this.workflow.name, this.nodeRun.num, this.nodeRun.id,
+            approved, this.approvalComment)
+            .pipe(
+                first(),
+                finalize(() => this.loading = false)
+            ).subscribe(() => {
+            this.approvalComment = '';
+            this._toast.success('', this._translate.instant(approved ? 'pipeline_approved' : 'pipeline_rejected'));
+        });
+    }
+
     runNew(): void {
         let request = new WorkflowRunRequest();
         request.from_nodes = [this.nodeRun.workflow_node_id];
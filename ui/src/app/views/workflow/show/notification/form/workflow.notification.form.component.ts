@@ -2,7 +2,12 @@ import {Component, EventEmitter, Input, Output} from '@angular/core';
 import { NotificationService } from 'app/service/notification/notification.service';
 import {cloneDeep} from 'lodash';
 import { finalize, first } from 'rxjs/operators';
-import {notificationOnFailure, notificationOnSuccess, notificationTypes} from '../../../../../model/notification.model';
+import {
+    chatNotificationTypes,
+    notificationOnFailure,
+    notificationOnSuccess,
+    notificationTypes
+} from '../../../../../model/notification.model';
 import {Project} from '../../../../../model/project.model';
 import {WNode, WNodeType, Workflow, WorkflowNotification} from '../../../../../model/workflow.model';
 
@@ -81,6 +86,10 @@ export class WorkflowNotificationFormComponent {
         this.notification.source_node_ref = this.notification.source_node_ref.map(id => id.toString());
     }
 
+    isChatNotification(): boolean {
+        return this.notification && chatNotificationTypes.indexOf(this.notification.type) !== -1;
+    }
+
     deleteNotification(): void {
         this.deleteNotificationEvent.emit(this.notification);
     }